@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// benchResult holds the measurements of one conversion run.
+type benchResult struct {
+	bytes     int64
+	rows      int
+	parseTime time.Duration
+	totalTime time.Duration
+}
+
+// benchParse runs a parse-only pass: the converter scans every row with a
+// no-op yield, so the measured time excludes all SQLite work.
+func benchParse(ctx context.Context, inputPath, driverName string) (int, time.Duration, error) {
+	inputFile, err := os.Open(inputPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open input: %w", err)
+	}
+	defer inputFile.Close()
+
+	config := &common.ConversionConfig{InputPath: inputPath}
+	start := time.Now()
+	converter, err := converters.Open(driverName, inputFile, config)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to initialize converter: %w", err)
+	}
+	if c, ok := converter.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	rows := 0
+	for _, tableName := range converter.GetTableNames() {
+		err := converter.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+			if rowErr != nil {
+				return nil // Count clean rows only; errors don't stop the pass
+			}
+			rows++
+			return nil
+		})
+		if err != nil {
+			return rows, time.Since(start), fmt.Errorf("parse pass failed for table %s: %w", tableName, err)
+		}
+	}
+	return rows, time.Since(start), nil
+}
+
+// benchConvert runs the full conversion into a throwaway database file.
+func benchConvert(inputPath, driverName string) (*converters.ImportReport, time.Duration, error) {
+	tmpFile, err := os.CreateTemp("", "mksqlite_bench_*.db")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create scratch database: %w", err)
+	}
+	outputPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(outputPath)
+
+	report := &converters.ImportReport{}
+	start := time.Now()
+	err = FileToSQLite(inputPath, outputPath, driverName, nil, &converters.ImportOptions{Report: report})
+	return report, time.Since(start), err
+}
+
+func printBenchResult(r benchResult) {
+	insertTime := r.totalTime - r.parseTime
+	if insertTime < 0 {
+		insertTime = 0
+	}
+	fmt.Printf("Input size:   %.2f MB\n", float64(r.bytes)/(1024*1024))
+	fmt.Printf("Rows:         %d\n", r.rows)
+	fmt.Printf("Parse time:   %v (scan only)\n", r.parseTime.Round(time.Millisecond))
+	fmt.Printf("Total time:   %v\n", r.totalTime.Round(time.Millisecond))
+	fmt.Printf("Insert+commit: %v (total minus parse)\n", insertTime.Round(time.Millisecond))
+	secs := r.totalTime.Seconds()
+	if secs > 0 {
+		fmt.Printf("Throughput:   %.0f rows/sec, %.2f MB/sec\n", float64(r.rows)/secs, float64(r.bytes)/(1024*1024)/secs)
+	}
+}
+
+func newBenchFlagSet() (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite bench [flags] <input.file>")
+		fs.PrintDefaults()
+	}
+	cpuProfile := fs.String("cpuprofile", "", "write a CPU profile of the conversion to this file")
+	memProfile := fs.String("memprofile", "", "write a heap profile taken after the conversion to this file")
+	return fs, cpuProfile, memProfile
+}
+
+func runBench(ctx context.Context, args []string) {
+	fs, cpuProfile, memProfile := newBenchFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	inputPath := positional[0]
+
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		fmt.Printf("Error: failed to stat input: %v\n", err)
+		os.Exit(exitInput)
+	}
+	driverName, err := getDriverName(inputPath, info.IsDir())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitInput)
+	}
+
+	fmt.Printf("Benchmarking %s (driver: %s)\n\n", inputPath, driverName)
+
+	rows, parseTime, err := benchParse(ctx, inputPath, driverName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitInput)
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			fmt.Printf("Error: failed to create CPU profile: %v\n", err)
+			os.Exit(exitInput)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			fmt.Printf("Error: failed to start CPU profile: %v\n", err)
+			os.Exit(exitInput)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	report, totalTime, err := benchConvert(inputPath, driverName)
+	if err != nil {
+		fmt.Printf("Error: conversion failed: %v\n", err)
+		os.Exit(exitInput)
+	}
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			fmt.Printf("Error: failed to create heap profile: %v\n", err)
+			os.Exit(exitInput)
+		}
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			fmt.Printf("Error: failed to write heap profile: %v\n", err)
+			os.Exit(exitInput)
+		}
+		f.Close()
+	}
+
+	// The parse pass and the conversion count rows independently; prefer
+	// the conversion's number when they disagree (limits, dedupe, ...)
+	if report.Rows > 0 {
+		rows = report.Rows
+	}
+	printBenchResult(benchResult{
+		bytes:     info.Size(),
+		rows:      rows,
+		parseTime: parseTime,
+		totalTime: totalTime,
+	})
+}
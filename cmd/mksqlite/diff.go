@@ -0,0 +1,291 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tablePrimaryKey returns the primary key columns of a table in key order,
+// or nil when the table has no declared primary key.
+func tablePrimaryKey(db *sql.DB, qualifier, name string) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf(`PRAGMA %s.table_info("%s")`, qualifier, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	byOrder := make(map[int]string)
+	for rows.Next() {
+		var cid int
+		var colName, colType string
+		var notNull, pk int
+		var dflt interface{}
+		if err := rows.Scan(&cid, &colName, &colType, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		if pk > 0 {
+			byOrder[pk] = colName
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	key := make([]string, 0, len(byOrder))
+	for i := 1; i <= len(byOrder); i++ {
+		key = append(key, byOrder[i])
+	}
+	return key, nil
+}
+
+// diffCounts holds the per-table result of a diff.
+type diffCounts struct {
+	added, removed, changed int64
+	keyed                   bool
+}
+
+// quoteColumns wraps each column name in double quotes.
+func quoteColumns(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf(`"%s"`, name)
+	}
+	return quoted
+}
+
+// diffTable writes the added, removed, and changed rows of one table into
+// the same-named delta table on main, tagging each row in changeColumn. The
+// delta lands in memory when no output database was asked for, so the
+// returned counts are all the caller needs. Keyed tables match rows on the
+// primary key; keyless tables fall back to whole-row comparison, where a
+// change shows up as one removed and one added row.
+func diffTable(db *sql.DB, name string, colNames, colTypes, key []string, changeColumn string) (*diffCounts, error) {
+	var defs []string
+	for i, col := range colNames {
+		def := fmt.Sprintf(`"%s"`, col)
+		if colTypes[i] != "" {
+			def += " " + colTypes[i]
+		}
+		defs = append(defs, def)
+	}
+	defs = append(defs, fmt.Sprintf(`"%s" TEXT`, changeColumn))
+	createSQL := fmt.Sprintf(`CREATE TABLE main."%s" (%s)`, name, strings.Join(defs, ", "))
+	if _, err := db.Exec(createSQL); err != nil {
+		return nil, fmt.Errorf("failed to create delta table %s: %w", name, err)
+	}
+
+	counts := &diffCounts{keyed: len(key) > 0}
+	colList := strings.Join(quoteColumns(colNames), ", ")
+
+	record := func(dst *int64, insertSQL string) error {
+		res, err := db.Exec(insertSQL)
+		if err != nil {
+			return fmt.Errorf("failed to diff table %s: %w", name, err)
+		}
+		*dst, err = res.RowsAffected()
+		return err
+	}
+
+	if !counts.keyed {
+		// No primary key: whole rows are the identity, EXCEPT finds the
+		// rows unique to each side
+		added := fmt.Sprintf(`INSERT INTO main."%s" SELECT *, 'added' FROM (SELECT %s FROM n."%s" EXCEPT SELECT %s FROM o."%s")`,
+			name, colList, name, colList, name)
+		if err := record(&counts.added, added); err != nil {
+			return nil, err
+		}
+		removed := fmt.Sprintf(`INSERT INTO main."%s" SELECT *, 'removed' FROM (SELECT %s FROM o."%s" EXCEPT SELECT %s FROM n."%s")`,
+			name, colList, name, colList, name)
+		if err := record(&counts.removed, removed); err != nil {
+			return nil, err
+		}
+		return counts, nil
+	}
+
+	// Null-safe key match between the two sides
+	var keyMatch []string
+	for _, col := range key {
+		keyMatch = append(keyMatch, fmt.Sprintf(`od."%s" IS nw."%s"`, col, col))
+	}
+	matchExpr := strings.Join(keyMatch, " AND ")
+
+	nwCols := make([]string, len(colNames))
+	for i, col := range colNames {
+		nwCols[i] = fmt.Sprintf(`nw."%s"`, col)
+	}
+	odCols := make([]string, len(colNames))
+	for i, col := range colNames {
+		odCols[i] = fmt.Sprintf(`od."%s"`, col)
+	}
+
+	added := fmt.Sprintf(`INSERT INTO main."%s" SELECT %s, 'added' FROM n."%s" AS nw
+		WHERE NOT EXISTS (SELECT 1 FROM o."%s" AS od WHERE %s)`,
+		name, strings.Join(nwCols, ", "), name, name, matchExpr)
+	if err := record(&counts.added, added); err != nil {
+		return nil, err
+	}
+
+	removed := fmt.Sprintf(`INSERT INTO main."%s" SELECT %s, 'removed' FROM o."%s" AS od
+		WHERE NOT EXISTS (SELECT 1 FROM n."%s" AS nw WHERE %s)`,
+		name, strings.Join(odCols, ", "), name, name, matchExpr)
+	if err := record(&counts.removed, removed); err != nil {
+		return nil, err
+	}
+
+	// Changed rows carry the new values; only non-key columns can differ
+	isKey := make(map[string]bool, len(key))
+	for _, col := range key {
+		isKey[col] = true
+	}
+	var same []string
+	for _, col := range colNames {
+		if !isKey[col] {
+			same = append(same, fmt.Sprintf(`od."%s" IS nw."%s"`, col, col))
+		}
+	}
+	if len(same) == 0 {
+		return counts, nil // Key-only table: rows can't change in place
+	}
+	changed := fmt.Sprintf(`INSERT INTO main."%s" SELECT %s, 'changed' FROM n."%s" AS nw
+		JOIN o."%s" AS od ON %s WHERE NOT (%s)`,
+		name, strings.Join(nwCols, ", "), name, name, matchExpr, strings.Join(same, " AND "))
+	if err := record(&counts.changed, changed); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// diffDatabases compares same-named tables of two produced databases and
+// prints what changed. With deltaPath set, the added/removed/changed rows
+// are kept in a delta database there; otherwise they are staged in memory
+// just for counting.
+func diffDatabases(oldPath, newPath, deltaPath, changeColumn string) error {
+	for _, path := range []string{oldPath, newPath} {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("failed to stat database: %w", err)
+		}
+	}
+
+	mainPath := ":memory:"
+	if deltaPath != "" {
+		if _, err := os.Stat(deltaPath); err == nil {
+			return fmt.Errorf("delta output %s already exists", deltaPath)
+		}
+		mainPath = deltaPath
+	}
+
+	db, err := sql.Open("sqlite", mainPath)
+	if err != nil {
+		return fmt.Errorf("failed to open delta database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`ATTACH DATABASE ? AS o`, oldPath); err != nil {
+		return fmt.Errorf("failed to attach %s: %w", oldPath, err)
+	}
+	if _, err := db.Exec(`ATTACH DATABASE ? AS n`, newPath); err != nil {
+		return fmt.Errorf("failed to attach %s: %w", newPath, err)
+	}
+
+	oldTables, err := listUserTables(db, "o")
+	if err != nil {
+		return fmt.Errorf("%s: %w", oldPath, err)
+	}
+	newTables, err := listUserTables(db, "n")
+	if err != nil {
+		return fmt.Errorf("%s: %w", newPath, err)
+	}
+	inOld := make(map[string]bool, len(oldTables))
+	for _, name := range oldTables {
+		inOld[name] = true
+	}
+	inNew := make(map[string]bool, len(newTables))
+	for _, name := range newTables {
+		inNew[name] = true
+	}
+
+	for _, name := range oldTables {
+		if !inNew[name] {
+			var count int64
+			if err := db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM o."%s"`, name)).Scan(&count); err != nil {
+				return fmt.Errorf("failed to count rows in %s: %w", name, err)
+			}
+			fmt.Printf("%s: only in %s (%d rows)\n", name, oldPath, count)
+		}
+	}
+
+	for _, name := range newTables {
+		if !inOld[name] {
+			var count int64
+			if err := db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM n."%s"`, name)).Scan(&count); err != nil {
+				return fmt.Errorf("failed to count rows in %s: %w", name, err)
+			}
+			fmt.Printf("%s: only in %s (%d rows)\n", name, newPath, count)
+			continue
+		}
+
+		oldCols, _, err := tableColumns(db, "o", name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", oldPath, err)
+		}
+		newCols, newTypes, err := tableColumns(db, "n", name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", newPath, err)
+		}
+		if strings.Join(oldCols, "\x1f") != strings.Join(newCols, "\x1f") {
+			fmt.Printf("%s: schema differs (%s vs %s), skipping row diff\n",
+				name, strings.Join(oldCols, ","), strings.Join(newCols, ","))
+			continue
+		}
+		for _, col := range newCols {
+			if col == changeColumn {
+				return fmt.Errorf("table %s already has a %q column; pick another --change-column", name, changeColumn)
+			}
+		}
+
+		key, err := tablePrimaryKey(db, "n", name)
+		if err != nil {
+			return err
+		}
+		counts, err := diffTable(db, name, newCols, newTypes, key, changeColumn)
+		if err != nil {
+			return err
+		}
+		if counts.keyed {
+			fmt.Printf("%s: %d added, %d removed, %d changed\n", name, counts.added, counts.removed, counts.changed)
+		} else {
+			fmt.Printf("%s: %d added, %d removed (no primary key, full-row comparison)\n", name, counts.added, counts.removed)
+		}
+	}
+
+	return nil
+}
+
+func newDiffFlagSet() (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite diff [flags] <old.db> <new.db>")
+		fs.PrintDefaults()
+	}
+	output := fs.String("output", "", "write the added/removed/changed rows to this delta database")
+	changeColumn := fs.String("change-column", "_change", "name of the delta column recording added, removed, or changed")
+	return fs, output, changeColumn
+}
+
+func runDiff(args []string) {
+	fs, output, changeColumn := newDiffFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 2 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+
+	if err := diffDatabases(positional[0], positional[1], *output, *changeColumn); err != nil {
+		fmt.Printf("Error diffing databases: %v\n", err)
+		os.Exit(exitInput)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	appconfig "github.com/darianmavgo/mksqlite/config"
+	"github.com/darianmavgo/mksqlite/converters"
+)
+
+// defaultHookTimeout bounds webhook requests and hook commands when the
+// hooks block doesn't set its own timeout.
+const defaultHookTimeout = 10 * time.Second
+
+// hookPayload is the JSON body delivered to completion hooks: POSTed to
+// URL hooks and piped to the stdin of command hooks.
+type hookPayload struct {
+	Event      string                   `json:"event"` // "success" or "failure"
+	Input      string                   `json:"input"`
+	Output     string                   `json:"output"`
+	Error      string                   `json:"error,omitempty"`
+	Report     *converters.ImportReport `json:"report,omitempty"`
+	FinishedAt string                   `json:"finished_at"`
+}
+
+// runHooks fires the configured completion hooks for the conversion
+// outcome. Hook failures are reported but never change the exit code:
+// a conversion that succeeded stays a success even if Slack is down.
+func runHooks(hooks *appconfig.HooksBlock, convErr error, report *converters.ImportReport, inputPath, outputPath string) {
+	if hooks == nil {
+		return
+	}
+
+	payload := hookPayload{
+		Event:      "success",
+		Input:      inputPath,
+		Output:     outputPath,
+		Report:     report,
+		FinishedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	hookURL, hookCmd := hooks.OnSuccessURL, hooks.OnSuccessCmd
+	if convErr != nil {
+		payload.Event = "failure"
+		payload.Error = convErr.Error()
+		hookURL, hookCmd = hooks.OnFailureURL, hooks.OnFailureCmd
+	}
+	if hookURL == "" && hookCmd == "" {
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode hook payload: %v\n", err)
+		return
+	}
+
+	timeout := defaultHookTimeout
+	if hooks.Timeout != "" {
+		timeout, _ = time.ParseDuration(hooks.Timeout) // validated on load
+	}
+
+	if hookURL != "" {
+		if err := postHook(hookURL, body, timeout); err != nil {
+			fmt.Printf("Warning: %s hook failed: %v\n", payload.Event, err)
+		}
+	}
+	if hookCmd != "" {
+		if err := execHook(hookCmd, payload.Event, body, timeout); err != nil {
+			fmt.Printf("Warning: %s hook command failed: %v\n", payload.Event, err)
+		}
+	}
+}
+
+// postHook POSTs the payload to a webhook URL.
+func postHook(url string, body []byte, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// execHook runs a hook command through the shell with the payload on
+// stdin and the outcome in MKSQLITE_EVENT.
+func execHook(command, event string, body []byte, timeout time.Duration) error {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "MKSQLITE_EVENT="+event)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		<-done
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
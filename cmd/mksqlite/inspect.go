@@ -0,0 +1,204 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// inspectColumn describes one column of an inspected table, including the
+// original header text recorded in _mksqlite_meta when available.
+type inspectColumn struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	RawName string `json:"raw_name,omitempty"`
+}
+
+// inspectTable summarizes one table of an inspected database.
+type inspectTable struct {
+	Name    string          `json:"name"`
+	Rows    int64           `json:"rows"`
+	Columns []inspectColumn `json:"columns"`
+}
+
+// inspectInfo is everything the inspect command reports about a database.
+type inspectInfo struct {
+	Path        string         `json:"path"`
+	Source      string         `json:"source,omitempty"`
+	Converter   string         `json:"converter,omitempty"`
+	ConvertedAt string         `json:"converted_at,omitempty"`
+	Tables      []inspectTable `json:"tables"`
+}
+
+// internalTable reports whether a table is one of mksqlite's own bookkeeping
+// tables rather than imported data.
+func internalTable(name string) bool {
+	return strings.HasPrefix(name, "_mksqlite_") || strings.HasPrefix(name, "_rejects_")
+}
+
+// inspectDatabase collects tables, row counts, column types, and the
+// _mksqlite_meta provenance from an existing output database.
+func inspectDatabase(path string) (*inspectInfo, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to stat database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type='table' ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tableNames []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tableNames = append(tableNames, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	info := &inspectInfo{Path: path}
+
+	// Raw header names and provenance live in the _mksqlite_meta manifest
+	rawNames := make(map[string]string) // "table.column" -> raw header
+	hasMeta := false
+	for _, name := range tableNames {
+		if name == "_mksqlite_meta" {
+			hasMeta = true
+		}
+	}
+	if hasMeta {
+		metaRows, err := db.Query(`SELECT table_name, column_name, raw_name, source_file, converter, converted_at
+			FROM _mksqlite_meta ORDER BY rowid`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read _mksqlite_meta: %w", err)
+		}
+		for metaRows.Next() {
+			var tableName, columnName, rawName, sourceFile, converter, convertedAt string
+			if err := metaRows.Scan(&tableName, &columnName, &rawName, &sourceFile, &converter, &convertedAt); err != nil {
+				metaRows.Close()
+				return nil, err
+			}
+			if rawName != "" {
+				rawNames[tableName+"."+columnName] = rawName
+			}
+			// The last manifest rows win, matching re-imports
+			info.Source = sourceFile
+			info.Converter = converter
+			info.ConvertedAt = convertedAt
+		}
+		metaRows.Close()
+		if err := metaRows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range tableNames {
+		if internalTable(name) {
+			continue
+		}
+		table := inspectTable{Name: name}
+
+		if err := db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, name)).Scan(&table.Rows); err != nil {
+			return nil, fmt.Errorf("failed to count rows in %s: %w", name, err)
+		}
+
+		colRows, err := db.Query(fmt.Sprintf(`PRAGMA table_info("%s")`, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", name, err)
+		}
+		for colRows.Next() {
+			var cid int
+			var colName, colType string
+			var notNull, pk int
+			var dflt interface{}
+			if err := colRows.Scan(&cid, &colName, &colType, &notNull, &dflt, &pk); err != nil {
+				colRows.Close()
+				return nil, err
+			}
+			table.Columns = append(table.Columns, inspectColumn{
+				Name:    colName,
+				Type:    colType,
+				RawName: rawNames[name+"."+colName],
+			})
+		}
+		colRows.Close()
+		if err := colRows.Err(); err != nil {
+			return nil, err
+		}
+
+		info.Tables = append(info.Tables, table)
+	}
+
+	return info, nil
+}
+
+// printInspectInfo renders the collected info as human-readable text.
+func printInspectInfo(info *inspectInfo) {
+	fmt.Printf("%s: %d tables\n", info.Path, len(info.Tables))
+	if info.Source != "" {
+		fmt.Printf("source: %s (%s), converted at %s\n", info.Source, info.Converter, info.ConvertedAt)
+	}
+	for _, table := range info.Tables {
+		fmt.Printf("\n%s (%d rows)\n", table.Name, table.Rows)
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		for _, col := range table.Columns {
+			raw := ""
+			if col.RawName != "" && col.RawName != col.Name {
+				raw = col.RawName
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", col.Name, col.Type, raw)
+		}
+		w.Flush()
+	}
+}
+
+func newInspectFlagSet() (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite inspect [flags] <database.db>")
+		fs.PrintDefaults()
+	}
+	asJSON := fs.Bool("json", false, "print the summary as JSON")
+	return fs, asJSON
+}
+
+func runInspect(args []string) {
+	fs, asJSON := newInspectFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+
+	info, err := inspectDatabase(positional[0])
+	if err != nil {
+		fmt.Printf("Error inspecting database: %v\n", err)
+		os.Exit(exitInput)
+	}
+
+	if *asJSON {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			fmt.Printf("Error encoding summary: %v\n", err)
+			os.Exit(exitInput)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	printInspectInfo(info)
+}
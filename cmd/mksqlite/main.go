@@ -1,56 +1,329 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"hash"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
+
+	appconfig "github.com/darianmavgo/mksqlite/config"
 	"github.com/darianmavgo/mksqlite/converters"
 	_ "github.com/darianmavgo/mksqlite/converters/all"
 	"github.com/darianmavgo/mksqlite/converters/common"
 )
 
+// Exit codes, so scripts can tell a partial database from no database at all.
+const (
+	exitSuccess = 0 // conversion completed without logged errors
+	exitUsage   = 1 // bad flags or arguments
+	exitInput   = 2 // the input could not be read or converted
+	exitPartial = 3 // import stopped early (timeout or interrupt); partial database written
+	exitErrors  = 4 // conversion completed but rows were logged or quarantined as errors
+)
+
+// exitCodeFor maps a conversion error onto the script-facing exit codes.
+// Interrupts and timeouts commit what was imported so far, so they get their
+// own code; everything else means the input could not be converted.
+func exitCodeFor(err error) int {
+	if errors.Is(err, converters.ErrInterrupted) || errors.Is(err, converters.ErrScanTimeout) {
+		return exitPartial
+	}
+	return exitInput
+}
+
 func getDriverName(path string, isDir bool) (string, error) {
 	if isDir {
 		return "filesystem", nil
 	}
+	// Well-known browser export filenames take priority over the
+	// generic html/json converters.
+	base := strings.ToLower(filepath.Base(path))
+	switch base {
+	case "bookmarks", "bookmarks.html", "bookmarks.json", "browserhistory.json", "history.json":
+		return "browser", nil
+	}
+
 	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".csv":
-		return "csv", nil
-	case ".xlsx", ".xls":
-		return "excel", nil
-	case ".zip":
-		return "zip", nil
-	case ".html", ".htm":
-		return "html", nil
-	case ".json":
-		return "json", nil
-	case ".md":
-		return "markdown", nil
-	case ".txt":
-		return "txt", nil
+	if name, ok := converters.ForExtension(ext); ok {
+		return name, nil
+	}
+	// No extension or an unknown one (downloads named "data",
+	// "export.tmp"): sniff the leading bytes instead.
+	if name, ok := sniffFile(path); ok {
+		return name, nil
 	}
 	return "", fmt.Errorf("unsupported file type: %s", ext)
 }
 
-// FileToSQLite converts a file to SQLite using the appropriate converter
-func FileToSQLite(inputPath, outputPath string, config *common.ConversionConfig, opts *converters.ImportOptions) error {
-	info, err := os.Stat(inputPath)
+// sniffFile reads the first bytes of path and asks the registry's content
+// sniffer for a driver. Errors just mean "couldn't sniff".
+func sniffFile(path string) (string, bool) {
+	f, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("failed to stat input path: %w", err)
+		return "", false
 	}
+	defer f.Close()
 
-	driverName, err := getDriverName(inputPath, info.IsDir())
+	header := make([]byte, converters.SniffLen)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", false
+	}
+	return converters.Sniff(header[:n])
+}
+
+// countingReader tracks how many bytes the converter consumed, for the
+// conversion report.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeReport emits the conversion summary as JSON to the given path, or to
+// stderr when path is "-".
+func writeReport(path string, report *converters.ImportReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err := os.Stderr.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// isURL reports whether the input path is a remote http(s) source.
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// normalizeSheetURL rewrites Google Sheets edit/view links to their CSV export
+// endpoint so published spreadsheets can be fetched directly.
+func normalizeSheetURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.Contains(u.Host, "docs.google.com") || !strings.Contains(u.Path, "/spreadsheets/") {
+		return rawURL
+	}
+	if strings.Contains(u.Path, "/export") {
+		return rawURL
+	}
+	// .../spreadsheets/d/<id>/edit... -> .../spreadsheets/d/<id>/export?format=csv
+	if idx := strings.Index(u.Path, "/edit"); idx != -1 {
+		u.Path = u.Path[:idx] + "/export"
+		u.RawQuery = "format=csv"
+		return u.String()
+	}
+	return rawURL
+}
+
+// driverForRemote picks a converter driver for a fetched URL based on the
+// response content type, falling back to the URL path extension.
+func driverForRemote(fetchURL, contentType string) string {
+	if name, ok := converters.ForMIMEType(contentType); ok {
+		return name
+	}
+	if u, err := url.Parse(fetchURL); err == nil {
+		if name, err := getDriverName(u.Path, false); err == nil {
+			return name
+		}
+	}
+	return "csv"
+}
+
+// URLToSQLite fetches a remote source and imports it into outputPath.
+// Unlike FileToSQLite it does not truncate the output, so repeated refreshes
+// upsert into the existing database.
+func URLToSQLite(fetchURL, outputPath string, config *common.ConversionConfig, opts *converters.ImportOptions) error {
+	fetchURL = normalizeSheetURL(fetchURL)
+
+	resp, err := http.Get(fetchURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", fetchURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: status %s", fetchURL, resp.Status)
+	}
+
+	driverName := driverForRemote(fetchURL, resp.Header.Get("Content-Type"))
+
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+	config.InputPath = fetchURL
+
+	if opts == nil {
+		opts = &converters.ImportOptions{}
+	}
+	opts.SourcePath = fetchURL
+	opts.DriverName = driverName
+
+	// Resume from the last good byte on mid-stream failures
+	retrying := newRetryReader(fetchURL, resp.Body)
+	defer retrying.Close()
+
+	var body io.Reader = retrying
+	if maxBandwidth > 0 {
+		body = &throttledReader{r: body, limit: maxBandwidth}
+	}
+	if opts.Report != nil {
+		counter := &countingReader{r: body}
+		body = counter
+		defer func() { opts.Report.BytesRead = counter.n }()
+	}
+
+	// Verify the stream hash while converting; a sidecar <url>.sha256 is
+	// used when no expectation was given explicitly
+	if opts.SourceSHA256 == "" {
+		opts.SourceSHA256 = fetchSidecarSHA256(fetchURL)
+	}
+	var hasher hash.Hash
+	if opts.SourceSHA256 != "" {
+		hasher = sha256.New()
+		body = io.TeeReader(body, hasher)
+	}
+
+	converter, err := converters.Open(driverName, body, config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize converter: %w", err)
+	}
+	if c, ok := converter.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Open without truncating so refreshes see the existing tables
+	outputFile, err := os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE, 0644)
 	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer outputFile.Close()
+
+	if err := converters.ImportToSQLite(converter, outputFile, opts); err != nil {
 		return err
 	}
 
+	// Drain whatever the converter didn't read so the digest covers the
+	// whole response, then compare
+	if hasher != nil {
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			return fmt.Errorf("failed to drain input for checksum: %w", err)
+		}
+		got := hex.EncodeToString(hasher.Sum(nil))
+		if !strings.EqualFold(got, opts.SourceSHA256) {
+			return fmt.Errorf("sha256 mismatch for %s: got %s, want %s (input truncated or tampered with?)", fetchURL, got, opts.SourceSHA256)
+		}
+	}
+	return nil
+}
+
+// fetchSidecarSHA256 tries <url>.sha256 and returns the first hex token, or
+// "" when the sidecar doesn't exist or doesn't parse.
+func fetchSidecarSHA256(fetchURL string) string {
+	resp, err := http.Get(fetchURL + ".sha256")
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return ""
+	}
+	if digest, err := hex.DecodeString(fields[0]); err != nil || len(digest) != sha256.Size {
+		return ""
+	}
+	return fields[0]
+}
+
+// verifyFileSHA256 hashes a local input and compares it to the expectation.
+func verifyFileSHA256(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open input: %w", err)
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return fmt.Errorf("failed to hash input: %w", err)
+	}
+	got := hex.EncodeToString(hasher.Sum(nil))
+	if !strings.EqualFold(got, expected) {
+		return fmt.Errorf("sha256 mismatch for %s: got %s, want %s (input truncated or tampered with?)", path, got, expected)
+	}
+	return nil
+}
+
+// refreshLoop re-fetches a remote source on the given interval until the
+// context is cancelled.
+func refreshLoop(ctx context.Context, fetchURL, outputPath string, interval time.Duration, config *common.ConversionConfig, opts *converters.ImportOptions) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := URLToSQLite(fetchURL, outputPath, config, opts); err != nil {
+			fmt.Printf("Refresh error: %v\n", err)
+		} else {
+			fmt.Printf("Refreshed %s at %s\n", outputPath, time.Now().Format(time.RFC3339))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// FileToSQLite converts a file to SQLite using the appropriate converter.
+// If driverName is empty, the driver is chosen from the input path.
+func FileToSQLite(inputPath, outputPath, driverName string, config *common.ConversionConfig, opts *converters.ImportOptions) error {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat input path: %w", err)
+	}
+
+	if driverName == "" {
+		driverName, err = getDriverName(inputPath, info.IsDir())
+		if err != nil {
+			return err
+		}
+	}
+
 	inputFile, err := os.Open(inputPath)
 	if err != nil {
 		return fmt.Errorf("failed to open input: %w", err)
@@ -62,6 +335,17 @@ func FileToSQLite(inputPath, outputPath string, config *common.ConversionConfig,
 	}
 	config.InputPath = inputPath
 
+	if opts == nil {
+		opts = &converters.ImportOptions{}
+	}
+	opts.SourcePath = inputPath
+	opts.DriverName = driverName
+	if opts.Report != nil && !info.IsDir() {
+		// The file path keeps its fast paths (ReaderAt), so count the
+		// whole input rather than wrapping the reader.
+		opts.Report.BytesRead = info.Size()
+	}
+
 	converter, err := converters.Open(driverName, inputFile, config)
 	if err != nil {
 		return fmt.Errorf("failed to initialize converter: %w", err)
@@ -88,6 +372,58 @@ func FileToSQLite(inputPath, outputPath string, config *common.ConversionConfig,
 	return converters.ImportToSQLite(converter, outputFile, opts)
 }
 
+// FileToSQLiteParts converts a file into numbered part databases derived
+// from outputPath (out.db becomes out_001.db, out_002.db, ...), rolling over
+// at the thresholds in opts. It returns the part paths written.
+func FileToSQLiteParts(inputPath, outputPath, driverName string, config *common.ConversionConfig, opts *converters.ImportOptions) ([]string, error) {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat input path: %w", err)
+	}
+
+	if driverName == "" {
+		driverName, err = getDriverName(inputPath, info.IsDir())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputFile, err := os.Open(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	defer inputFile.Close()
+
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+	config.InputPath = inputPath
+
+	opts.SourcePath = inputPath
+	opts.DriverName = driverName
+	if opts.Report != nil && !info.IsDir() {
+		opts.Report.BytesRead = info.Size()
+	}
+
+	converter, err := converters.Open(driverName, inputFile, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize converter: %w", err)
+	}
+
+	// Clean up converter resources if it implements io.Closer
+	if c, ok := converter.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	// Ensure output directory exists
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return converters.ImportToSQLiteParts(converter, outputPath, opts)
+}
+
 // exportToSQL exports a file as SQL statements to writer
 func exportToSQL(ctx context.Context, inputPath string, writer io.Writer, config *common.ConversionConfig) error {
 	info, err := os.Stat(inputPath)
@@ -126,91 +462,821 @@ func exportToSQL(ctx context.Context, inputPath string, writer io.Writer, config
 		return fmt.Errorf("converter for %s does not support SQL export", driverName)
 	}
 
-	return streamConv.ConvertToSQL(ctx, writer)
+	return converters.DumpToSQL(ctx, streamConv, writer, &converters.DumpOptions{
+		Header:     true,
+		SourcePath: inputPath,
+	})
 }
 
-func main() {
-	// Setup global context for cancellation
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer cancel()
+// usage prints the top-level command summary.
+func usage() {
+	fmt.Println("Usage: mksqlite <command> [flags] <args>")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  convert        Convert a file, directory, or URL to a SQLite database")
+	fmt.Println("  dump-sql       Export a file as SQL statements")
+	fmt.Println("  inspect        Summarize a produced database (tables, counts, provenance)")
+	fmt.Println("  query          Run SQL against a produced database (table/csv/json output)")
+	fmt.Println("  sink           Append NDJSON messages from stdin to a table, checkpointing offsets")
+	fmt.Println("  merge          Combine produced databases into one (attach and union tables)")
+	fmt.Println("  diff           Compare two produced databases (added/removed/changed rows)")
+	fmt.Println("  export-config  Write a config file (format picked from the extension)")
+	fmt.Println("  bench          Time a conversion and report throughput (optional pprof capture)")
+	fmt.Println("  completion     Generate a shell completion script (bash)")
+	fmt.Println("  help           Show this help, or a command's flags with help <command>")
+	fmt.Println()
+	fmt.Println("Running mksqlite with a file or URL as the first argument is shorthand")
+	fmt.Println("for \"mksqlite convert\".")
+}
 
-	args := os.Args[1:]
-	logMode := false
-
-	// Filter out --log flag
-	// Parse flags manually for simplicity
-	var cleanArgs []string
-	var resumePath string
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch {
-		case arg == "--log":
-			logMode = true
-		case arg == "--resume-path":
-			if i+1 < len(args) {
-				resumePath = args[i+1]
-				i++ // Skip value
-			} else {
-				fmt.Println("Error: --resume-path requires a value")
-				os.Exit(1)
+// parseFlags parses fs over args, collecting positional arguments and
+// re-parsing so flags may appear after them, as the old single-command
+// parser allowed. Exits on bad flags; -h prints the command's help.
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for len(args) > 0 {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				os.Exit(exitSuccess)
 			}
-		default:
-			cleanArgs = append(cleanArgs, arg)
+			os.Exit(exitUsage)
 		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
+	return positional
+}
 
-	if len(cleanArgs) < 1 {
-		fmt.Println("Usage:")
-		fmt.Println("  mksqlite [--log] [--resume-path <path>] <input_file> [output_db] # Convert to SQLite database")
-		fmt.Println("  mksqlite --sql <input_file> [output_file]                          # Export as SQL statements")
-		os.Exit(1)
+// convertFlags holds every flag of the convert command.
+type convertFlags struct {
+	log, takeout, mediaMetadata, followSymlinks, preserveNames bool
+	dedupe, explodeArrays, lazyQuotes, trimBOM, recoverRows    bool
+	rejectRows, noHeader, extractKV, keepUnparsed, advHeader   bool
+	rawViews, lineage, strict, deterministic, rawFallback      bool
+	strictTables, withoutRowid, formats, extractArticle        bool
+	fetchSitemaps, noQuoting                                   bool
+	quoteChar, escapeStyle                                     string
+	primaryKeyList                                             string
+	linePattern, recordSep, zipPassword, resumePath            string
+	protoDesc, protoMessage, hashAlgo, whereExpr, columnsList  string
+	dedupeKey, reportPath, configPath, profileName, meltIDs    string
+	locale                                                     string
+	commentPrefix, delimiterStr, delimiterRegex, query, engine string
+	sha256Hex, maxBandwidthStr, blobFieldsList                 string
+	encryptKeyEnv                                              string
+	splitRowsStr, splitSizeStr, wideStrategy                   string
+	inlineContent                                              int64
+	limit, maxErrors, flattenDepth, schemaSample, skipLines    int
+	jobs, crawlPages                                           int
+	sampleRate, errorRate                                      float64
+	refreshInterval                                            time.Duration
+}
+
+func newConvertFlagSet() (*flag.FlagSet, *convertFlags) {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite convert [flags] <input_file|url> [output_db]")
+		fs.PrintDefaults()
 	}
+	c := &convertFlags{}
+	fs.BoolVar(&c.log, "log", false, "log bad rows to _mksqlite_errors instead of aborting")
+	fs.BoolVar(&c.takeout, "takeout", false, "treat the input as a Google Takeout archive")
+	fs.BoolVar(&c.mediaMetadata, "media-metadata", false, "extract EXIF/ID3 metadata (for filesystem)")
+	fs.BoolVar(&c.followSymlinks, "follow-symlinks", false, "descend into symlinked directories (for filesystem)")
+	fs.BoolVar(&c.preserveNames, "preserve-names", false, "quote identifiers to keep original header names")
+	fs.BoolVar(&c.dedupe, "dedupe", false, "drop rows identical to an already imported row")
+	fs.BoolVar(&c.explodeArrays, "explode-arrays", false, "turn nested arrays of objects into child tables (for json)")
+	fs.BoolVar(&c.lazyQuotes, "lazy-quotes", false, "tolerate bare quotes inside CSV fields")
+	fs.BoolVar(&c.trimBOM, "trim-bom", false, "strip a leading UTF-8 byte order mark (for csv)")
+	fs.BoolVar(&c.recoverRows, "recover", false, "skip unparseable CSV lines and resync at the next newline")
+	fs.BoolVar(&c.rejectRows, "rejects", false, "quarantine bad rows verbatim in _rejects_<table> tables")
+	fs.BoolVar(&c.noHeader, "no-header", false, "treat the first row as data; columns are named cl0..clN (for csv)")
+	fs.BoolVar(&c.extractKV, "extract-kv", false, "extract <dl> lists and label/value grids as key/value tables (for html)")
+	fs.BoolVar(&c.extractArticle, "article", false, "extract the main article content (title, byline, published, text) into an articles table (for html)")
+	fs.BoolVar(&c.fetchSitemaps, "fetch-sitemaps", false, "fetch the child sitemaps of a sitemap index and import their URLs (for sitemap)")
+	fs.BoolVar(&c.keepUnparsed, "keep-unparsed", false, "route lines that don't match --line-pattern to an \"unparsed\" table")
+	fs.BoolVar(&c.advHeader, "advanced-header", false, "scan the first rows to locate the real header row")
+	fs.BoolVar(&c.rawViews, "raw-views", false, "create <table>_raw views aliasing columns back to the original header names")
+	fs.BoolVar(&c.rawFallback, "raw-fallback", false, "add <col>_raw TEXT companions that keep values failing numeric coercion")
+	fs.BoolVar(&c.strictTables, "strict-tables", false, "create STRICT tables (SQLite 3.37+); values that don't fit their declared type fail the insert")
+	fs.BoolVar(&c.withoutRowid, "without-rowid", false, "create WITHOUT ROWID tables clustered by the primary key (requires --primary-key)")
+	fs.BoolVar(&c.formats, "formats", false, "record per-column number/date format strings in a _mksqlite_formats table")
+	fs.StringVar(&c.primaryKeyList, "primary-key", "", "comma-separated columns declared as the PRIMARY KEY of every table")
+	fs.BoolVar(&c.lineage, "lineage", false, "add _source_file, _source_line, and _imported_at columns to every table")
+	fs.BoolVar(&c.strict, "strict", false, "abort on rows that don't match the schema instead of padding or truncating (with --log/--rejects, divert them instead)")
+	fs.BoolVar(&c.deterministic, "deterministic", false, "produce byte-identical output for identical input (epoch timestamps, canonical page layout)")
+	fs.StringVar(&c.engine, "engine", "", "population engine: default, or the experimental in-memory \"fast\"")
+	fs.StringVar(&c.sha256Hex, "sha256", "", "expected SHA-256 hex digest of the input; the conversion fails on mismatch")
+	fs.StringVar(&c.encryptKeyEnv, "encrypt-key-env", "", "unsupported: the bundled SQLite driver cannot encrypt output; fails instead of writing plaintext (see README)")
+	fs.StringVar(&c.maxBandwidthStr, "max-bandwidth", "", "cap remote read throughput, e.g. 5MB/s (for URL inputs)")
+	fs.StringVar(&c.linePattern, "line-pattern", "", "regex with named groups; each match becomes a row (for txt)")
+	fs.StringVar(&c.recordSep, "record-sep", "", "line that ends a multi-line record; \"blank\" splits on empty lines (for txt)")
+	fs.StringVar(&c.zipPassword, "zip-password", "", "password for encrypted archive members (for zip)")
+	fs.StringVar(&c.resumePath, "resume-path", "", "path to resume processing from (for filesystem)")
+	fs.StringVar(&c.protoDesc, "proto-desc", "", "path to a compiled FileDescriptorSet (for protobuf)")
+	fs.StringVar(&c.protoMessage, "proto-message", "", "fully qualified message name (for protobuf)")
+	fs.StringVar(&c.hashAlgo, "hash", "", "content hash column algorithm: sha256 or md5 (for filesystem)")
+	fs.Int64Var(&c.inlineContent, "inline-content", 0, "store file or member contents up to this many bytes as a BLOB content column (for filesystem, zip)")
+	fs.StringVar(&c.blobFieldsList, "blob-fields", "", "comma-separated field names whose base64 text is decoded into BLOB values (for json)")
+	fs.StringVar(&c.splitRowsStr, "split-rows", "", "roll the output over to out_001.db, out_002.db, ... after this many rows (e.g. 10_000_000)")
+	fs.StringVar(&c.splitSizeStr, "split-size", "", "roll the output over to a new part after this size, e.g. 1GB")
+	fs.StringVar(&c.wideStrategy, "wide-strategy", "", "layout for tables wider than SQLite's column limit: \"partition\" (rowid-joined <table>_partN tables) or \"eav\" (row_id, column, value)")
+	fs.StringVar(&c.whereExpr, "where", "", "only import rows matching this filter expression")
+	fs.StringVar(&c.columnsList, "columns", "", "comma-separated list of columns to keep")
+	fs.StringVar(&c.dedupeKey, "dedupe-key", "", "comma-separated columns that define a duplicate (implies --dedupe)")
+	fs.StringVar(&c.meltIDs, "melt", "", "unpivot tables into a long layout: the comma-separated id columns stay, the rest become variable/value rows")
+	fs.StringVar(&c.locale, "locale", "", "locale for number and date parsing, e.g. de_DE reads \"1.234,56\" as 1234.56 and dates as DD.MM.YYYY")
+	fs.StringVar(&c.reportPath, "report", "", "write a JSON conversion summary to this path, or \"-\" for stderr")
+	fs.StringVar(&c.query, "query", "", "SQL to run against the output database once the conversion completes")
+	fs.StringVar(&c.configPath, "config", "", "config file with conversion defaults (HCL, YAML, or JSON)")
+	fs.StringVar(&c.profileName, "profile", "", "named profile in --config to apply")
+	fs.StringVar(&c.commentPrefix, "comment", "", "lines starting with this character are skipped (for csv)")
+	fs.StringVar(&c.delimiterStr, "delimiter", "", "field delimiter, may be multi-character (for csv)")
+	fs.StringVar(&c.delimiterRegex, "delimiter-regex", "", "regular expression that splits each line into fields (for csv)")
+	fs.StringVar(&c.quoteChar, "quote", "", "field quote character, e.g. ' for single-quoted exports (for csv)")
+	fs.StringVar(&c.escapeStyle, "escape-style", "", "quote escaping inside quoted fields: doubled (default) or backslash (for csv)")
+	fs.BoolVar(&c.noQuoting, "no-quoting", false, "treat quote characters as literal text; fields split on the delimiter only (for csv)")
+	fs.IntVar(&c.limit, "limit", 0, "import at most this many rows per table")
+	fs.IntVar(&c.maxErrors, "max-errors", 0, "abort after this many bad rows")
+	fs.IntVar(&c.flattenDepth, "flatten-depth", 0, "flatten nested JSON objects into underscored columns up to this depth")
+	fs.IntVar(&c.schemaSample, "schema-sample", 0, "JSON records pre-scanned to union keys (-1 scans everything)")
+	fs.IntVar(&c.skipLines, "skip-lines", 0, "leading lines discarded before parsing (for csv)")
+	fs.IntVar(&c.jobs, "jobs", 0, "convert the files of a directory input in parallel with this many workers, merging the results")
+	fs.IntVar(&c.crawlPages, "crawl", 0, "follow rel=next pagination links, importing up to this many pages in total (for html)")
+	fs.Float64Var(&c.sampleRate, "sample", 0, "import a random sample of rows at this rate (0 < rate < 1)")
+	fs.Float64Var(&c.errorRate, "error-rate", 0, "abort when the bad-row rate exceeds this (0 < rate < 1)")
+	fs.DurationVar(&c.refreshInterval, "refresh", 0, "re-fetch a URL input on this interval (e.g. 15m)")
+	return fs, c
+}
 
-	if cleanArgs[0] == "--sql" {
-		if len(cleanArgs) < 2 {
-			fmt.Println("Usage: mksqlite --sql <input_file> [output_file]")
-			os.Exit(1)
+// validate rejects flag values the old parser used to refuse inline.
+func (c *convertFlags) validate() error {
+	// Fail loudly rather than quietly writing a plaintext database: the
+	// bundled modernc.org/sqlite driver has no SQLCipher/SEE support.
+	if c.encryptKeyEnv != "" {
+		return fmt.Errorf("--encrypt-key-env is not supported: the bundled SQLite driver cannot produce encrypted databases; convert first and re-key with the sqlcipher shell, or write to an encrypted filesystem (see README \"Scope\")")
+	}
+	if c.limit < 0 {
+		return fmt.Errorf("--limit requires a positive row count")
+	}
+	if c.maxErrors < 0 {
+		return fmt.Errorf("--max-errors requires a positive error count")
+	}
+	if c.skipLines < 0 {
+		return fmt.Errorf("--skip-lines requires a non-negative line count")
+	}
+	if c.flattenDepth < 0 {
+		return fmt.Errorf("--flatten-depth requires a non-negative depth")
+	}
+	if c.schemaSample < -1 {
+		return fmt.Errorf("--schema-sample requires a record count (-1 scans everything)")
+	}
+	if c.sampleRate != 0 && (c.sampleRate <= 0 || c.sampleRate >= 1) {
+		return fmt.Errorf("--sample requires a rate between 0 and 1 (exclusive)")
+	}
+	if c.errorRate != 0 && (c.errorRate <= 0 || c.errorRate >= 1) {
+		return fmt.Errorf("--error-rate requires a rate between 0 and 1 (exclusive)")
+	}
+	if c.profileName != "" && c.configPath == "" {
+		return fmt.Errorf("--profile requires --config")
+	}
+	switch c.engine {
+	case "", "default", "fast":
+	default:
+		return fmt.Errorf("--engine must be default or fast")
+	}
+	switch c.escapeStyle {
+	case "", "doubled", "backslash":
+	default:
+		return fmt.Errorf("--escape-style must be doubled or backslash")
+	}
+	switch c.wideStrategy {
+	case "", converters.WidePartition, converters.WideEAV:
+	default:
+		return fmt.Errorf("--wide-strategy must be %s or %s", converters.WidePartition, converters.WideEAV)
+	}
+	if c.locale != "" {
+		if _, err := common.ParseLocale(c.locale); err != nil {
+			return fmt.Errorf("--locale: %v", err)
+		}
+	}
+	if c.withoutRowid && c.primaryKeyList == "" {
+		return fmt.Errorf("--without-rowid requires --primary-key")
+	}
+	if c.sha256Hex != "" {
+		if digest, err := hex.DecodeString(c.sha256Hex); err != nil || len(digest) != sha256.Size {
+			return fmt.Errorf("--sha256 requires a %d-character hex digest", sha256.Size*2)
+		}
+	}
+	if c.maxBandwidthStr != "" {
+		if _, err := parseBandwidth(c.maxBandwidthStr); err != nil {
+			return fmt.Errorf("--max-bandwidth: %v", err)
+		}
+	}
+	if c.inlineContent < 0 {
+		return fmt.Errorf("--inline-content requires a non-negative byte count")
+	}
+	if c.jobs < 0 {
+		return fmt.Errorf("--jobs requires a positive worker count")
+	}
+	if c.crawlPages < 0 {
+		return fmt.Errorf("--crawl requires a positive page count")
+	}
+	if c.splitRowsStr != "" {
+		if _, err := parseSplitRows(c.splitRowsStr); err != nil {
+			return fmt.Errorf("--split-rows: %v", err)
 		}
-		inputPath := cleanArgs[1]
+	}
+	if c.splitSizeStr != "" {
+		if _, err := parseSize(c.splitSizeStr); err != nil {
+			return fmt.Errorf("--split-size: %v", err)
+		}
+	}
+	return nil
+}
 
-		var writer io.Writer
-		if len(cleanArgs) >= 3 {
-			outputPath := cleanArgs[2]
-			f, err := os.Create(outputPath)
-			if err != nil {
-				fmt.Printf("Error creating output file: %v\n", err)
-				os.Exit(1)
-			}
-			defer f.Close()
-			writer = f
-		} else {
-			writer = os.Stdout
+// parseSplitRows parses a row count, allowing underscore digit separators
+// as in 10_000_000.
+func parseSplitRows(s string) (int64, error) {
+	n, err := strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(s), "_", ""), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid row count %q (want e.g. 10_000_000)", s)
+	}
+	return n, nil
+}
+
+// parseSize parses a byte size with an optional KB/MB/GB suffix.
+func parseSize(s string) (int64, error) {
+	v := strings.TrimSpace(s)
+	unit := int64(1)
+	upper := strings.ToUpper(v)
+	switch {
+	case strings.HasSuffix(upper, "GB"):
+		unit = 1024 * 1024 * 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "MB"):
+		unit = 1024 * 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "KB"):
+		unit = 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "B"):
+		v = v[:len(v)-1]
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 1GB)", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+func runConvert(ctx context.Context, args []string) {
+	fs, c := newConvertFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) < 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if err := c.validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inputPath := positional[0]
+	var outputPath string
+	if len(positional) >= 2 {
+		outputPath = positional[1]
+	} else if isURL(inputPath) {
+		outputPath = "output.db"
+	} else {
+		outputPath = inputPath + ".db"
+	}
+
+	config := &common.ConversionConfig{
+		ResumePath:              c.resumePath,
+		ProtoDescriptor:         c.protoDesc,
+		ProtoMessage:            c.protoMessage,
+		MediaMetadata:           c.mediaMetadata,
+		HashAlgorithm:           c.hashAlgo,
+		FollowSymlinks:          c.followSymlinks,
+		PreserveNames:           c.preserveNames,
+		AdvancedHeaderDetection: c.advHeader,
+		FlattenDepth:            c.flattenDepth,
+		ExplodeArrays:           c.explodeArrays,
+		SchemaSample:            c.schemaSample,
+		DelimiterString:         c.delimiterStr,
+		DelimiterRegex:          c.delimiterRegex,
+		LazyQuotes:              c.lazyQuotes,
+		CommentPrefix:           c.commentPrefix,
+		SkipLines:               c.skipLines,
+		TrimBOM:                 c.trimBOM,
+		Recover:                 c.recoverRows,
+		NoHeader:                c.noHeader,
+		QuoteChar:               c.quoteChar,
+		EscapeStyle:             c.escapeStyle,
+		NoQuoting:               c.noQuoting,
+		ExtractKeyValue:         c.extractKV,
+		ExtractArticle:          c.extractArticle,
+		LinePattern:             c.linePattern,
+		KeepUnparsed:            c.keepUnparsed,
+		RecordSeparator:         c.recordSep,
+		ZipPassword:             c.zipPassword,
+		InlineContent:           c.inlineContent,
+		Locale:                  c.locale,
+		CrawlPages:              c.crawlPages,
+		FetchSitemaps:           c.fetchSitemaps,
+	}
+	if c.blobFieldsList != "" {
+		config.BlobFields = strings.Split(c.blobFieldsList, ",")
+	}
+
+	// Headerless files can take their column names from --columns
+	if c.noHeader && c.columnsList != "" {
+		config.ColumnNames = strings.Split(c.columnsList, ",")
+	}
+
+	// A config file (optionally a named profile in it) provides defaults;
+	// explicit flags keep precedence
+	var transforms map[string]*common.Transform
+	var foreignKeys map[string][]common.ForeignKey
+	var postSQL []string
+	var appID, userVersion int64
+	var hooks *appconfig.HooksBlock
+	lineage := c.lineage
+	if c.configPath != "" {
+		appCfg, err := appconfig.LoadProfile(c.configPath, c.profileName)
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		appCfg.ApplyConverterConfig(config)
+		converters.BatchSize = appCfg.BatchSize
+		lineage = lineage || appCfg.Lineage
+		appID, userVersion = appCfg.ApplicationID, appCfg.UserVersion
+		hooks = appCfg.Hooks
+		transforms, err = appCfg.BuildTransforms()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(exitUsage)
 		}
+		foreignKeys, err = appCfg.BuildRelations()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		postSQL, err = appCfg.ReadPostSQL()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
 
-		err := exportToSQL(ctx, inputPath, writer, nil)
+	// Recovered lines are only useful if they land somewhere: the
+	// quarantine table with --rejects, _mksqlite_errors otherwise
+	logMode := c.log
+	if c.recoverRows && !c.rejectRows {
+		logMode = true
+	}
+
+	driverName := ""
+	if c.takeout {
+		driverName = "takeout"
+	}
+
+	var where *common.Predicate
+	if c.whereExpr != "" {
+		var err error
+		where, err = common.ParsePredicate(c.whereExpr)
 		if err != nil {
-			fmt.Printf("Error exporting SQL: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("Error: invalid --where expression: %v\n", err)
+			os.Exit(exitUsage)
 		}
-	} else {
-		inputPath := cleanArgs[0]
-		var outputPath string
-		if len(cleanArgs) >= 2 {
-			outputPath = cleanArgs[1]
-		} else {
-			outputPath = inputPath + ".db"
+	}
+
+	var columns map[string][]string
+	if c.columnsList != "" {
+		columns = map[string][]string{"": strings.Split(c.columnsList, ",")}
+	}
+
+	dedupe := c.dedupe
+	var dedupeColumns map[string][]string
+	if c.dedupeKey != "" {
+		dedupeColumns = map[string][]string{"": strings.Split(c.dedupeKey, ",")}
+		dedupe = true
+	}
+
+	var melt []string
+	if c.meltIDs != "" {
+		melt = strings.Split(c.meltIDs, ",")
+	}
+
+	var primaryKey map[string][]string
+	if c.primaryKeyList != "" {
+		primaryKey = map[string][]string{"": strings.Split(c.primaryKeyList, ",")}
+	}
+
+	// One-shot conversions always collect a summary so the exit code can
+	// reflect logged errors; it is only written out with --report.
+	var report *converters.ImportReport
+	if c.refreshInterval == 0 {
+		report = &converters.ImportReport{}
+	}
+	emitReport := func() {
+		if report == nil || c.reportPath == "" {
+			return
+		}
+		if err := writeReport(c.reportPath, report); err != nil {
+			fmt.Printf("Error writing report: %v\n", err)
 		}
+	}
 
-		config := &common.ConversionConfig{
-			ResumePath: resumePath,
+	if c.jobs > 0 {
+		if isURL(inputPath) {
+			fmt.Println("Error: --jobs requires a local directory input")
+			os.Exit(exitUsage)
+		}
+		info, err := os.Stat(inputPath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitInput)
+		}
+		if !info.IsDir() {
+			fmt.Println("Error: --jobs requires a directory input")
+			os.Exit(exitUsage)
+		}
+		// Each worker converts with the plain import path; options that
+		// rewrite rows or the output layout don't combine with the pool
+		if dedupe || where != nil || columns != nil || len(transforms) > 0 || len(foreignKeys) > 0 || len(postSQL) > 0 ||
+			lineage || logMode || c.rejectRows || c.strict || c.engine == "fast" || c.limit != 0 || c.sampleRate != 0 ||
+			c.takeout || c.sha256Hex != "" || c.reportPath != "" || c.splitRowsStr != "" || c.splitSizeStr != "" {
+			fmt.Println("Error: --jobs cannot be combined with dedupe, filtering, transforms, relations, lineage, error tolerance, split output, --takeout, --sha256, or --report")
+			os.Exit(exitUsage)
+		}
+		opts := &converters.ImportOptions{ApplicationID: appID, UserVersion: userVersion, Deterministic: c.deterministic}
+		converted, failed, rows, err := convertDirParallel(inputPath, outputPath, c.jobs, config, opts)
+		runHooks(hooks, err, nil, inputPath, outputPath)
+		if err != nil {
+			fmt.Printf("Error converting directory: %v\n", err)
+			os.Exit(exitCodeFor(err))
 		}
+		fmt.Printf("Successfully converted %d file(s) (%d rows) to %s\n", converted, rows, outputPath)
+		runPostQuery(c.query, outputPath)
+		if failed > 0 {
+			fmt.Printf("%d file(s) failed to convert\n", failed)
+			os.Exit(exitPartial)
+		}
+		return
+	}
 
-		err := FileToSQLite(inputPath, outputPath, config, &converters.ImportOptions{LogErrors: logMode})
+	if c.splitRowsStr != "" || c.splitSizeStr != "" {
+		if isURL(inputPath) {
+			fmt.Println("Error: --split-rows/--split-size require a local input")
+			os.Exit(exitUsage)
+		}
+		// The split importer is a plain insert path; options that rewrite
+		// or drop rows along the way don't combine with it
+		if dedupe || where != nil || columns != nil || len(transforms) > 0 || len(foreignKeys) > 0 || len(postSQL) > 0 ||
+			lineage || logMode || c.rejectRows || c.strict || c.engine == "fast" || c.limit != 0 || c.sampleRate != 0 {
+			fmt.Println("Error: --split-rows/--split-size cannot be combined with dedupe, filtering, transforms, relations, lineage, error tolerance, or the fast engine")
+			os.Exit(exitUsage)
+		}
+		var splitRows, splitSize int64
+		if c.splitRowsStr != "" {
+			splitRows, _ = parseSplitRows(c.splitRowsStr) // validated already
+		}
+		if c.splitSizeStr != "" {
+			splitSize, _ = parseSize(c.splitSizeStr)
+		}
+		if c.sha256Hex != "" {
+			if err := verifyFileSHA256(inputPath, c.sha256Hex); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(exitInput)
+			}
+		}
+		parts, err := FileToSQLiteParts(inputPath, outputPath, driverName, config,
+			&converters.ImportOptions{Report: report, SplitRows: splitRows, SplitSize: splitSize,
+				ApplicationID: appID, UserVersion: userVersion})
+		emitReport()
+		runHooks(hooks, err, report, inputPath, outputPath)
 		if err != nil {
 			fmt.Printf("Error converting file: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCodeFor(err))
 		}
+		fmt.Printf("Successfully converted %s to %d part(s): %s\n", inputPath, len(parts), strings.Join(parts, ", "))
+		return
+	}
 
+	if isURL(inputPath) {
+		if c.maxBandwidthStr != "" {
+			maxBandwidth, _ = parseBandwidth(c.maxBandwidthStr) // validated already
+		}
+		opts := &converters.ImportOptions{LogErrors: logMode, RejectRows: c.rejectRows, Strict: c.strict, MaxErrors: c.maxErrors, ErrorRate: c.errorRate,
+			Report: report, Upsert: true, Where: where, Columns: columns, Transforms: transforms, PostSQL: postSQL, RawNameViews: c.rawViews, Lineage: lineage, Engine: c.engine, SourceSHA256: c.sha256Hex,
+			Dedupe: dedupe, DedupeColumns: dedupeColumns, Limit: c.limit, SampleRate: c.sampleRate, Deterministic: c.deterministic, WideStrategy: c.wideStrategy, Melt: melt, Locale: c.locale, RawFallback: c.rawFallback, StrictTables: c.strictTables,
+			PrimaryKey: primaryKey, WithoutRowid: c.withoutRowid, ForeignKeys: foreignKeys, Formats: c.formats,
+			ApplicationID: appID, UserVersion: userVersion}
+		if c.refreshInterval > 0 {
+			fmt.Printf("Refreshing %s every %s (Ctrl-C to stop)\n", inputPath, c.refreshInterval)
+			refreshLoop(ctx, inputPath, outputPath, c.refreshInterval, config, opts)
+			return
+		}
+		err := URLToSQLite(inputPath, outputPath, config, opts)
+		emitReport()
+		runHooks(hooks, err, report, inputPath, outputPath)
+		if err != nil {
+			fmt.Printf("Error converting URL: %v\n", err)
+			os.Exit(exitCodeFor(err))
+		}
 		fmt.Printf("Successfully converted %s to %s\n", inputPath, outputPath)
+		runPostQuery(c.query, outputPath)
+		if report.Errors > 0 {
+			os.Exit(exitErrors)
+		}
+		return
+	}
+
+	if c.refreshInterval > 0 {
+		fmt.Println("Error: --refresh requires a URL input")
+		os.Exit(exitUsage)
+	}
+
+	// Local inputs are hashed up front; remote streams verify inline
+	if c.sha256Hex != "" {
+		if err := verifyFileSHA256(inputPath, c.sha256Hex); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitInput)
+		}
+	}
+
+	err := FileToSQLite(inputPath, outputPath, driverName, config, &converters.ImportOptions{LogErrors: logMode, RejectRows: c.rejectRows, Strict: c.strict, MaxErrors: c.maxErrors, ErrorRate: c.errorRate,
+		Report: report, Where: where, Columns: columns, Transforms: transforms, PostSQL: postSQL, RawNameViews: c.rawViews, Lineage: lineage, Engine: c.engine, SourceSHA256: c.sha256Hex,
+		Dedupe: dedupe, DedupeColumns: dedupeColumns, Limit: c.limit, SampleRate: c.sampleRate, Deterministic: c.deterministic, WideStrategy: c.wideStrategy, Melt: melt, Locale: c.locale, RawFallback: c.rawFallback, StrictTables: c.strictTables,
+		PrimaryKey: primaryKey, WithoutRowid: c.withoutRowid, ForeignKeys: foreignKeys, Formats: c.formats,
+		ApplicationID: appID, UserVersion: userVersion})
+	emitReport()
+	runHooks(hooks, err, report, inputPath, outputPath)
+	if err != nil {
+		fmt.Printf("Error converting file: %v\n", err)
+		os.Exit(exitCodeFor(err))
+	}
+
+	fmt.Printf("Successfully converted %s to %s\n", inputPath, outputPath)
+	runPostQuery(c.query, outputPath)
+	if report.Errors > 0 {
+		os.Exit(exitErrors)
+	}
+}
+
+// runPostQuery runs the convert command's --query against the finished
+// database, printing the results as a table.
+func runPostQuery(query, outputPath string) {
+	if query == "" {
+		return
+	}
+	if err := queryDatabase(outputPath, query, "table", os.Stdout); err != nil {
+		fmt.Printf("Error running query: %v\n", err)
+		os.Exit(exitInput)
+	}
+}
+
+func newDumpSQLFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("dump-sql", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite dump-sql <input_file> [output_file]")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runDumpSQL(ctx context.Context, args []string) {
+	fs := newDumpSQLFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) < 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	inputPath := positional[0]
+
+	var writer io.Writer
+	if len(positional) >= 2 {
+		outputPath := positional[1]
+		f, err := os.Create(outputPath)
+		if err != nil {
+			fmt.Printf("Error creating output file: %v\n", err)
+			os.Exit(exitInput)
+		}
+		defer f.Close()
+		writer = f
+
+		// SQL dumps are highly compressible; a .gz or .zst output path
+		// wraps the file in the matching compressor
+		switch {
+		case strings.HasSuffix(outputPath, ".gz"):
+			gz := gzip.NewWriter(f)
+			defer gz.Close()
+			writer = gz
+		case strings.HasSuffix(outputPath, ".zst"):
+			zw, err := zstd.NewWriter(f)
+			if err != nil {
+				fmt.Printf("Error creating zstd writer: %v\n", err)
+				os.Exit(exitInput)
+			}
+			defer zw.Close()
+			writer = zw
+		}
+	} else {
+		writer = os.Stdout
+	}
+
+	if err := exportToSQL(ctx, inputPath, writer, nil); err != nil {
+		fmt.Printf("Error exporting SQL: %v\n", err)
+		os.Exit(exitInput)
+	}
+}
+
+func newExportConfigFlagSet() (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet("export-config", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite export-config [flags] <output_file>")
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "", "existing config file to re-export instead of the defaults")
+	profileName := fs.String("profile", "", "profile in --config to flatten into the export")
+	return fs, configPath, profileName
+}
+
+func runExportConfig(args []string) {
+	fs, configPath, profileName := newExportConfigFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *profileName != "" && *configPath == "" {
+		fmt.Println("Error: --profile requires --config")
+		os.Exit(exitUsage)
+	}
+
+	cfg := appconfig.DefaultConfig()
+	if *configPath != "" {
+		var err error
+		cfg, err = appconfig.LoadProfile(*configPath, *profileName)
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+	if err := appconfig.Export(positional[0], cfg); err != nil {
+		fmt.Printf("Error exporting config: %v\n", err)
+		os.Exit(exitInput)
+	}
+	fmt.Printf("Wrote config to %s\n", positional[0])
+}
+
+// flagNames lists a flag set's flags as --name completion words.
+func flagNames(fs *flag.FlagSet) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "--"+f.Name)
+	})
+	return names
+}
+
+func runCompletion(args []string) {
+	if len(args) != 1 || args[0] != "bash" {
+		fmt.Println("Usage: mksqlite completion bash")
+		os.Exit(exitUsage)
+	}
+
+	convertFS, _ := newConvertFlagSet()
+	inspectFS, _ := newInspectFlagSet()
+	queryFS, _ := newQueryFlagSet()
+	exportFS, _, _ := newExportConfigFlagSet()
+	fmt.Printf(`_mksqlite() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=( $(compgen -W "convert dump-sql inspect query sink merge diff export-config bench completion help" -- "$cur") )
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+        convert)
+            COMPREPLY=( $(compgen -f -W "%s" -- "$cur") )
+            ;;
+        inspect)
+            COMPREPLY=( $(compgen -f -W "%s" -- "$cur") )
+            ;;
+        query)
+            COMPREPLY=( $(compgen -f -W "%s" -- "$cur") )
+            ;;
+        export-config)
+            COMPREPLY=( $(compgen -f -W "%s" -- "$cur") )
+            ;;
+        completion)
+            COMPREPLY=( $(compgen -W "bash" -- "$cur") )
+            ;;
+        *)
+            COMPREPLY=( $(compgen -f -- "$cur") )
+            ;;
+    esac
+}
+complete -F _mksqlite mksqlite
+`, strings.Join(flagNames(convertFS), " "), strings.Join(flagNames(inspectFS), " "), strings.Join(flagNames(queryFS), " "), strings.Join(flagNames(exportFS), " "))
+}
+
+// runHelp shows the top-level usage, or a command's flags.
+func runHelp(args []string) {
+	if len(args) == 0 {
+		usage()
+		return
+	}
+	switch args[0] {
+	case "convert":
+		fs, _ := newConvertFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "dump-sql":
+		fs := newDumpSQLFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "inspect":
+		fs, _ := newInspectFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "query":
+		fs, _ := newQueryFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "sink":
+		fs, _ := newSinkFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "merge":
+		fs, _ := newMergeFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "diff":
+		fs, _, _ := newDiffFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "export-config":
+		fs, _, _ := newExportConfigFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "bench":
+		fs, _, _ := newBenchFlagSet()
+		fs.SetOutput(os.Stdout)
+		fs.Usage()
+	case "completion":
+		fmt.Println("Usage: mksqlite completion bash")
+	default:
+		fmt.Printf("Unknown command %q\n", args[0])
+		usage()
+		os.Exit(exitUsage)
+	}
+}
+
+func main() {
+	// Setup global context for cancellation
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	args := os.Args[1:]
+	if len(args) < 1 {
+		usage()
+		os.Exit(exitUsage)
+	}
+
+	switch args[0] {
+	case "convert":
+		runConvert(ctx, args[1:])
+	case "dump-sql":
+		runDumpSQL(ctx, args[1:])
+	case "--sql": // legacy spelling of dump-sql
+		runDumpSQL(ctx, args[1:])
+	case "inspect":
+		runInspect(args[1:])
+	case "query":
+		runQuery(args[1:])
+	case "sink":
+		runSink(ctx, args[1:])
+	case "merge":
+		runMerge(args[1:])
+	case "diff":
+		runDiff(args[1:])
+	case "export-config":
+		runExportConfig(args[1:])
+	case "bench":
+		runBench(ctx, args[1:])
+	case "completion":
+		runCompletion(args[1:])
+	case "help", "-h", "--help":
+		runHelp(args[1:])
+	default:
+		// Legacy invocation: the first argument is the input itself
+		runConvert(ctx, args)
 	}
 }
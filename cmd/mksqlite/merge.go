@@ -0,0 +1,188 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tableColumns reads the column names and declared types of a table through
+// the given schema qualifier ("main" or an attached alias).
+func tableColumns(db *sql.DB, qualifier, name string) ([]string, []string, error) {
+	rows, err := db.Query(fmt.Sprintf(`PRAGMA %s.table_info("%s")`, qualifier, name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read columns of %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var names, types []string
+	for rows.Next() {
+		var cid int
+		var colName, colType string
+		var notNull, pk int
+		var dflt interface{}
+		if err := rows.Scan(&cid, &colName, &colType, &notNull, &dflt, &pk); err != nil {
+			return nil, nil, err
+		}
+		names = append(names, colName)
+		types = append(types, colType)
+	}
+	return names, types, rows.Err()
+}
+
+// schemaKey flattens a column list into a comparable string, so two tables
+// stack only when their names and types line up exactly.
+func schemaKey(names, types []string) string {
+	var b strings.Builder
+	for i, name := range names {
+		fmt.Fprintf(&b, "%s:%s\x1f", name, strings.ToUpper(types[i]))
+	}
+	return b.String()
+}
+
+// listUserTables returns the non-internal tables of the given schema
+// qualifier, skipping SQLite's own bookkeeping tables.
+func listUserTables(db *sql.DB, qualifier string) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf(
+		`SELECT name FROM %s.sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%%' ORDER BY name`, qualifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if internalTable(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// mergeDatabases attaches each input in turn and copies its tables into
+// outputPath. Tables with the same name and schema stack into one table,
+// with sourceColumn recording which input each row came from; a name clash
+// with a different schema lands in a numbered table (tb0_2, tb0_3, ...)
+// instead. labels are the values written to sourceColumn, one per input, so
+// rows merged out of temp databases can still point at the original source.
+func mergeDatabases(inputs, labels []string, outputPath, sourceColumn string) error {
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("output %s already exists", outputPath)
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// Target table name -> schema key of what's already merged there
+	mergedSchemas := make(map[string]string)
+
+	for inputIdx, input := range inputs {
+		if _, err := os.Stat(input); err != nil {
+			return fmt.Errorf("failed to stat input database: %w", err)
+		}
+		if _, err := db.Exec(`ATTACH DATABASE ? AS src`, input); err != nil {
+			return fmt.Errorf("failed to attach %s: %w", input, err)
+		}
+
+		tables, err := listUserTables(db, "src")
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+
+		for _, name := range tables {
+			colNames, colTypes, err := tableColumns(db, "src", name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", input, err)
+			}
+			for _, col := range colNames {
+				if col == sourceColumn {
+					return fmt.Errorf("table %s in %s already has a %q column; pick another --source-column", name, input, sourceColumn)
+				}
+			}
+			key := schemaKey(colNames, colTypes)
+
+			// Same name and schema stack; a different schema gets the next
+			// free numbered name
+			target := name
+			for n := 2; ; n++ {
+				existing, exists := mergedSchemas[target]
+				if !exists || existing == key {
+					break
+				}
+				target = fmt.Sprintf("%s_%d", name, n)
+			}
+
+			if _, exists := mergedSchemas[target]; !exists {
+				var defs []string
+				for i, col := range colNames {
+					def := fmt.Sprintf(`"%s"`, col)
+					if colTypes[i] != "" {
+						def += " " + colTypes[i]
+					}
+					defs = append(defs, def)
+				}
+				defs = append(defs, fmt.Sprintf(`"%s" TEXT`, sourceColumn))
+				createSQL := fmt.Sprintf(`CREATE TABLE main."%s" (%s)`, target, strings.Join(defs, ", "))
+				if _, err := db.Exec(createSQL); err != nil {
+					return fmt.Errorf("failed to create table %s: %w", target, err)
+				}
+				mergedSchemas[target] = key
+			}
+
+			quoted := make([]string, len(colNames))
+			for i, col := range colNames {
+				quoted[i] = fmt.Sprintf(`"%s"`, col)
+			}
+			colList := strings.Join(quoted, ", ")
+			insertSQL := fmt.Sprintf(`INSERT INTO main."%s" (%s, "%s") SELECT %s, ? FROM src."%s"`,
+				target, colList, sourceColumn, colList, name)
+			if _, err := db.Exec(insertSQL, labels[inputIdx]); err != nil {
+				return fmt.Errorf("failed to copy table %s from %s: %w", name, input, err)
+			}
+		}
+
+		if _, err := db.Exec(`DETACH DATABASE src`); err != nil {
+			return fmt.Errorf("failed to detach %s: %w", input, err)
+		}
+	}
+
+	return nil
+}
+
+func newMergeFlagSet() (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite merge [flags] <input.db> <input.db> [...] <output.db>")
+		fs.PrintDefaults()
+	}
+	sourceColumn := fs.String("source-column", "_source_db", "name of the column recording which input each row came from")
+	return fs, sourceColumn
+}
+
+func runMerge(args []string) {
+	fs, sourceColumn := newMergeFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) < 3 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+
+	inputs := positional[:len(positional)-1]
+	outputPath := positional[len(positional)-1]
+	if err := mergeDatabases(inputs, inputs, outputPath, *sourceColumn); err != nil {
+		fmt.Printf("Error merging databases: %v\n", err)
+		os.Exit(exitInput)
+	}
+	fmt.Printf("Merged %d databases into %s\n", len(inputs), outputPath)
+}
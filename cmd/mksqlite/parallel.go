@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// collectConvertibleFiles walks dir and returns the files a converter driver
+// claims, in walk order. Files with unrecognized extensions are skipped and
+// counted so the caller can report them.
+func collectConvertibleFiles(dir string) (files []string, skipped int, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, err := getDriverName(path, false); err != nil {
+			skipped++
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, skipped, err
+}
+
+// convertDirParallel converts every recognizable file under dir with a pool
+// of jobs workers, each into its own temp database, then merges them into
+// outputPath with a _source_db column pointing back at the original files.
+// Progress is printed as files finish. It returns how many files converted,
+// how many failed, and the total rows merged.
+func convertDirParallel(dir, outputPath string, jobs int, config *common.ConversionConfig, opts *converters.ImportOptions) (converted, failed int, rows int, err error) {
+	files, skipped, err := collectConvertibleFiles(dir)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	if len(files) == 0 {
+		return 0, 0, 0, fmt.Errorf("no convertible files under %s (%d skipped)", dir, skipped)
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipping %d file(s) with no matching converter\n", skipped)
+	}
+	if jobs > len(files) {
+		jobs = len(files)
+	}
+
+	tempDir, err := os.MkdirTemp("", "mksqlite-jobs-*")
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	type result struct {
+		db   string
+		rows int
+		err  error
+	}
+	results := make([]result, len(files))
+
+	var mu sync.Mutex
+	done := 0
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				path := files[i]
+				tempDB := filepath.Join(tempDir, fmt.Sprintf("part_%04d.db", i))
+
+				// Per-file copies: FileToSQLite mutates both as it runs
+				cfg := *config
+				fileOpts := *opts
+				fileOpts.Report = &converters.ImportReport{}
+
+				err := FileToSQLite(path, tempDB, "", &cfg, &fileOpts)
+				results[i] = result{db: tempDB, rows: fileOpts.Report.Rows, err: err}
+
+				mu.Lock()
+				done++
+				if err != nil {
+					fmt.Printf("[%d/%d] %s: %v\n", done, len(files), path, err)
+				} else {
+					fmt.Printf("[%d/%d] %s: %d rows\n", done, len(files), path, fileOpts.Report.Rows)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range files {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	// Merge the successful parts in walk order so the output is stable
+	var parts, labels []string
+	for i, res := range results {
+		if res.err != nil {
+			failed++
+			continue
+		}
+		parts = append(parts, res.db)
+		labels = append(labels, files[i])
+		rows += res.rows
+		converted++
+	}
+	if converted == 0 {
+		return 0, failed, 0, fmt.Errorf("all %d file(s) failed to convert", failed)
+	}
+
+	// convert overwrites its output, matching the single-file path
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return converted, failed, rows, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.Remove(outputPath); err != nil && !os.IsNotExist(err) {
+		return converted, failed, rows, fmt.Errorf("failed to replace output: %w", err)
+	}
+	if err := mergeDatabases(parts, labels, outputPath, "_source_db"); err != nil {
+		return converted, failed, rows, err
+	}
+	return converted, failed, rows, nil
+}
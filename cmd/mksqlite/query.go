@@ -0,0 +1,159 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// queryDatabase executes one SQL statement against a produced database and
+// writes the results in the requested format: table, csv, or json.
+func queryDatabase(path, query, format string, writer io.Writer) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to stat database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	var results [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		// The sqlite driver hands text back as []byte
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		results = append(results, values)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	switch format {
+	case "table":
+		return writeQueryTable(writer, columns, results)
+	case "csv":
+		return writeQueryCSV(writer, columns, results)
+	case "json":
+		return writeQueryJSON(writer, columns, results)
+	default:
+		return fmt.Errorf("unknown format %q (want table, csv, or json)", format)
+	}
+}
+
+// cellString renders one result value for the table and csv formats.
+func cellString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func writeQueryTable(writer io.Writer, columns []string, results [][]interface{}) error {
+	w := tabwriter.NewWriter(writer, 2, 4, 2, ' ', 0)
+	for i, col := range columns {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, col)
+	}
+	fmt.Fprintln(w)
+	for _, row := range results {
+		for i, v := range row {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, cellString(v))
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+func writeQueryCSV(writer io.Writer, columns []string, results [][]interface{}) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for _, row := range results {
+		for i, v := range row {
+			record[i] = cellString(v)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeQueryJSON(writer io.Writer, columns []string, results [][]interface{}) error {
+	objects := make([]map[string]interface{}, 0, len(results))
+	for _, row := range results {
+		object := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			object[col] = row[i]
+		}
+		objects = append(objects, object)
+	}
+	data, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = writer.Write(data)
+	return err
+}
+
+func newQueryFlagSet() (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite query [flags] <database.db> \"SELECT ...\"")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "table", "output format: table, csv, or json")
+	return fs, format
+}
+
+func runQuery(args []string) {
+	fs, format := newQueryFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 2 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+
+	if err := queryDatabase(positional[0], positional[1], *format, os.Stdout); err != nil {
+		fmt.Printf("Error running query: %v\n", err)
+		os.Exit(exitInput)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryMaxAttempts bounds how often a broken remote stream is re-opened
+// before the conversion gives up.
+const retryMaxAttempts = 5
+
+// retryReader resumes a remote stream after mid-read failures by re-issuing
+// the GET with a Range header from the last good byte offset, so flaky
+// connections don't kill long URL conversions. Servers that ignore ranges
+// can't be resumed safely and fail immediately.
+type retryReader struct {
+	url     string
+	body    io.ReadCloser
+	offset  int64
+	retries int
+}
+
+func newRetryReader(url string, body io.ReadCloser) *retryReader {
+	return &retryReader{url: url, body: body}
+}
+
+func (r *retryReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if err == nil || errors.Is(err, io.EOF) {
+			return n, err
+		}
+		if rerr := r.resume(); rerr != nil {
+			return n, fmt.Errorf("stream failed at byte %d: %w (resume failed: %v)", r.offset, err, rerr)
+		}
+		if n > 0 {
+			// Deliver the good bytes now; the reconnected body serves the
+			// next call
+			return n, nil
+		}
+	}
+}
+
+// resume re-opens the stream at the current offset with linear backoff.
+func (r *retryReader) resume() error {
+	var lastErr error
+	for r.retries < retryMaxAttempts {
+		r.retries++
+		time.Sleep(time.Duration(r.retries) * 250 * time.Millisecond)
+
+		req, err := http.NewRequest(http.MethodGet, r.url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+			r.body.Close()
+			r.body = resp.Body
+			return nil
+		case http.StatusRequestedRangeNotSatisfiable:
+			// The source is exactly offset bytes long: serve a clean EOF
+			resp.Body.Close()
+			r.body.Close()
+			r.body = io.NopCloser(strings.NewReader(""))
+			return nil
+		default:
+			resp.Body.Close()
+			return fmt.Errorf("server does not support range resume (status %s)", resp.Status)
+		}
+	}
+	return fmt.Errorf("gave up after %d attempts: %w", retryMaxAttempts, lastErr)
+}
+
+func (r *retryReader) Close() error {
+	return r.body.Close()
+}
+
+// maxBandwidth caps remote read throughput in bytes per second; zero means
+// unlimited. Set from --max-bandwidth before a URL conversion starts.
+var maxBandwidth int64
+
+// parseBandwidth parses values like "5MB/s", "500KB", or a plain byte count
+// into bytes per second.
+func parseBandwidth(s string) (int64, error) {
+	v := strings.TrimSuffix(strings.TrimSpace(s), "/s")
+	unit := int64(1)
+	upper := strings.ToUpper(v)
+	switch {
+	case strings.HasSuffix(upper, "GB"):
+		unit = 1024 * 1024 * 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "MB"):
+		unit = 1024 * 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "KB"):
+		unit = 1024
+		v = v[:len(v)-2]
+	case strings.HasSuffix(upper, "B"):
+		v = v[:len(v)-1]
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q (want e.g. 5MB/s)", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// throttledReader caps the average read rate of a remote stream, sleeping
+// between reads once the byte budget for the elapsed time is spent.
+type throttledReader struct {
+	r     io.Reader
+	limit int64 // bytes per second
+	start time.Time
+	read  int64
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+	// Cap single reads so sleeps stay short on large buffers
+	if int64(len(p)) > t.limit {
+		p = p[:t.limit]
+	}
+	n, err := t.r.Read(p)
+	t.read += int64(n)
+	ahead := time.Duration(float64(t.read)/float64(t.limit)*float64(time.Second)) - time.Since(t.start)
+	if ahead > 0 {
+		time.Sleep(ahead)
+	}
+	return n, err
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+)
+
+// sinkFlags holds every flag of the sink command.
+type sinkFlags struct {
+	tableName       string
+	columnsList     string
+	checkpointField string
+	batchSize       int
+	flushInterval   time.Duration
+	printCheckpoint bool
+}
+
+func newSinkFlagSet() (*flag.FlagSet, *sinkFlags) {
+	fs := flag.NewFlagSet("sink", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mksqlite sink [flags] <output.db>")
+		fmt.Fprintln(fs.Output(), "Appends newline-delimited JSON messages from stdin to a table, committing")
+		fmt.Fprintln(fs.Output(), "in batches; pipe a queue consumer (kcat, nats sub, aws sqs ...) into it.")
+		fs.PrintDefaults()
+	}
+	c := &sinkFlags{}
+	fs.StringVar(&c.tableName, "table", "", "target table, default \"tb0\"")
+	fs.StringVar(&c.columnsList, "columns", "", "comma-separated column names; empty derives them from the first message's keys")
+	fs.StringVar(&c.checkpointField, "checkpoint-field", "", "message field holding the source position (e.g. a Kafka partition:offset), checkpointed atomically with each commit")
+	fs.IntVar(&c.batchSize, "batch", 0, "rows per commit (0 uses the importer's batch size)")
+	fs.DurationVar(&c.flushInterval, "flush", 0, "commit pending rows at least this often, e.g. 2s")
+	fs.BoolVar(&c.printCheckpoint, "print-checkpoint", false, "print the last committed position and exit, for seeking the upstream consumer on restart")
+	return fs, c
+}
+
+// messageKeys returns the sorted top-level keys of a JSON object message,
+// used to derive the table columns when --columns wasn't given.
+func messageKeys(msg []byte) ([]string, error) {
+	var rec map[string]interface{}
+	if err := json.Unmarshal(msg, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode first message: %w", err)
+	}
+	if len(rec) == 0 {
+		return nil, fmt.Errorf("first message has no keys to derive columns from; pass --columns")
+	}
+	keys := make([]string, 0, len(rec))
+	for k := range rec {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// messageCheckpoint extracts the named field of a JSON message as the source
+// position string, or "" when the field is absent.
+func messageCheckpoint(msg []byte, field string) string {
+	var rec map[string]interface{}
+	if err := json.Unmarshal(msg, &rec); err != nil {
+		return ""
+	}
+	v, ok := rec[field]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// runSink consumes NDJSON from stdin into outputPath until the stream ends
+// or the context is cancelled, so any queue CLI can pipe into it. The sink
+// commits rows and the checkpoint together, and --print-checkpoint exposes
+// the resume position to the wrapper script that restarts the consumer.
+func runSink(ctx context.Context, args []string) {
+	fs, c := newSinkFlagSet()
+	positional := parseFlags(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	outputPath := positional[0]
+
+	if c.printCheckpoint {
+		position, err := converters.SinkCheckpoint(outputPath, c.tableName)
+		if err != nil {
+			fmt.Printf("Error reading checkpoint: %v\n", err)
+			os.Exit(exitInput)
+		}
+		fmt.Println(position)
+		return
+	}
+
+	var headers []string
+	if c.columnsList != "" {
+		for _, col := range strings.Split(c.columnsList, ",") {
+			headers = append(headers, strings.TrimSpace(col))
+		}
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var sink *converters.Sink
+	var rows int64
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			// Interrupt: commit what arrived and report a partial run
+			if sink != nil {
+				sink.Close()
+			}
+			fmt.Printf("Interrupted after %d rows; checkpoint committed to %s\n", rows, outputPath)
+			os.Exit(exitPartial)
+		default:
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		// The sink opens lazily so column derivation can see the first message
+		if sink == nil {
+			if headers == nil {
+				derived, err := messageKeys(line)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(exitInput)
+				}
+				headers = derived
+			}
+			s, err := converters.OpenSink(outputPath, &converters.SinkOptions{
+				TableName:     c.tableName,
+				Headers:       headers,
+				BatchSize:     c.batchSize,
+				FlushInterval: c.flushInterval,
+			})
+			if err != nil {
+				fmt.Printf("Error opening sink: %v\n", err)
+				os.Exit(exitInput)
+			}
+			sink = s
+		}
+
+		checkpoint := ""
+		if c.checkpointField != "" {
+			checkpoint = messageCheckpoint(line, c.checkpointField)
+		}
+		if err := sink.AppendJSON(line, checkpoint); err != nil {
+			sink.Close()
+			fmt.Printf("Error appending message: %v\n", err)
+			os.Exit(exitInput)
+		}
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		if sink != nil {
+			sink.Close()
+		}
+		fmt.Printf("Error reading stdin: %v\n", err)
+		os.Exit(exitInput)
+	}
+
+	if sink != nil {
+		if err := sink.Close(); err != nil {
+			fmt.Printf("Error committing final batch: %v\n", err)
+			os.Exit(exitInput)
+		}
+	}
+	fmt.Printf("Appended %d rows to %s\n", rows, outputPath)
+}
@@ -1,18 +1,308 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
 )
 
-// Config represents the application configuration.
+// Config represents the application configuration. Alongside HCL it can be
+// read from and written to YAML and JSON, where the repeated transform,
+// relation, and profile blocks become the "transforms", "relations", and
+// "profiles" lists.
 type Config struct {
-	BatchSize int `hcl:"batch_size,optional"`
+	BatchSize int      `hcl:"batch_size,optional" yaml:"batch_size,omitempty" json:"batch_size,omitempty"`
+	Includes  []string `hcl:"include,optional" yaml:"include,omitempty" json:"include,omitempty"`
+	// Lineage adds _source_file, _source_line, and _imported_at columns to
+	// every imported table.
+	Lineage bool `hcl:"lineage,optional" yaml:"lineage,omitempty" json:"lineage,omitempty"`
+	// ApplicationID and UserVersion are written into the output database
+	// header (PRAGMA application_id / user_version); application_id
+	// defaults to mksqlite's magic and user_version is free for schema
+	// revision tracking.
+	ApplicationID int64 `hcl:"application_id,optional" yaml:"application_id,omitempty" json:"application_id,omitempty"`
+	UserVersion   int64 `hcl:"user_version,optional" yaml:"user_version,omitempty" json:"user_version,omitempty"`
+	// PostSQL lists SQL script files run after a successful import,
+	// resolved relative to the config file that names them.
+	PostSQL    []string         `hcl:"post_sql,optional" yaml:"post_sql,omitempty" json:"post_sql,omitempty"`
+	CSV        *CSVBlock        `hcl:"csv,block" yaml:"csv,omitempty" json:"csv,omitempty"`
+	Excel      *ExcelBlock      `hcl:"excel,block" yaml:"excel,omitempty" json:"excel,omitempty"`
+	Hooks      *HooksBlock      `hcl:"hooks,block" yaml:"hooks,omitempty" json:"hooks,omitempty"`
+	Transforms []TransformBlock `hcl:"transform,block" yaml:"transforms,omitempty" json:"transforms,omitempty"`
+	Relations  []RelationBlock  `hcl:"relation,block" yaml:"relations,omitempty" json:"relations,omitempty"`
+	Profiles   []ProfileBlock   `hcl:"profile,block" yaml:"profiles,omitempty" json:"profiles,omitempty"`
+}
+
+// ProfileBlock is a named conversion recipe with the same body as the
+// top-level config. A profile selected with --profile is layered over the
+// top-level values, e.g.
+//
+//	profile "takeout" {
+//	  batch_size = 5000
+//	}
+type ProfileBlock struct {
+	Name          string           `hcl:"name,label" yaml:"name" json:"name"`
+	BatchSize     int              `hcl:"batch_size,optional" yaml:"batch_size,omitempty" json:"batch_size,omitempty"`
+	Lineage       bool             `hcl:"lineage,optional" yaml:"lineage,omitempty" json:"lineage,omitempty"`
+	ApplicationID int64            `hcl:"application_id,optional" yaml:"application_id,omitempty" json:"application_id,omitempty"`
+	UserVersion   int64            `hcl:"user_version,optional" yaml:"user_version,omitempty" json:"user_version,omitempty"`
+	PostSQL       []string         `hcl:"post_sql,optional" yaml:"post_sql,omitempty" json:"post_sql,omitempty"`
+	CSV           *CSVBlock        `hcl:"csv,block" yaml:"csv,omitempty" json:"csv,omitempty"`
+	Excel         *ExcelBlock      `hcl:"excel,block" yaml:"excel,omitempty" json:"excel,omitempty"`
+	Hooks         *HooksBlock      `hcl:"hooks,block" yaml:"hooks,omitempty" json:"hooks,omitempty"`
+	Transforms    []TransformBlock `hcl:"transform,block" yaml:"transforms,omitempty" json:"transforms,omitempty"`
+	Relations     []RelationBlock  `hcl:"relation,block" yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// CSVBlock holds the csv converter's options, e.g.
+//
+//	csv {
+//	  delimiter = ";"
+//	  comment   = "#"
+//	}
+type CSVBlock struct {
+	Delimiter   string   `hcl:"delimiter,optional" yaml:"delimiter,omitempty" json:"delimiter,omitempty"`
+	Quote       string   `hcl:"quote,optional" yaml:"quote,omitempty" json:"quote,omitempty"`
+	EscapeStyle string   `hcl:"escape_style,optional" yaml:"escape_style,omitempty" json:"escape_style,omitempty"`
+	NoQuoting   bool     `hcl:"no_quoting,optional" yaml:"no_quoting,omitempty" json:"no_quoting,omitempty"`
+	LazyQuotes  bool     `hcl:"lazy_quotes,optional" yaml:"lazy_quotes,omitempty" json:"lazy_quotes,omitempty"`
+	Comment     string   `hcl:"comment,optional" yaml:"comment,omitempty" json:"comment,omitempty"`
+	SkipLines   int      `hcl:"skip_lines,optional" yaml:"skip_lines,omitempty" json:"skip_lines,omitempty"`
+	TrimBOM     bool     `hcl:"trim_bom,optional" yaml:"trim_bom,omitempty" json:"trim_bom,omitempty"`
+	NoHeader    bool     `hcl:"no_header,optional" yaml:"no_header,omitempty" json:"no_header,omitempty"`
+	Columns     []string `hcl:"columns,optional" yaml:"columns,omitempty" json:"columns,omitempty"`
+	Recover     bool     `hcl:"recover,optional" yaml:"recover,omitempty" json:"recover,omitempty"`
+}
+
+// ExcelBlock holds the excel converter's options, e.g.
+//
+//	excel {
+//	  sheets = ["Data"]
+//	}
+type ExcelBlock struct {
+	Sheets      []string `hcl:"sheets,optional" yaml:"sheets,omitempty" json:"sheets,omitempty"`
+	RawFormulas bool     `hcl:"raw_formulas,optional" yaml:"raw_formulas,omitempty" json:"raw_formulas,omitempty"`
+	CellLinks   bool     `hcl:"cell_links,optional" yaml:"cell_links,omitempty" json:"cell_links,omitempty"`
+	CellNotes   bool     `hcl:"cell_notes,optional" yaml:"cell_notes,omitempty" json:"cell_notes,omitempty"`
+	Dates       string   `hcl:"dates,optional" yaml:"dates,omitempty" json:"dates,omitempty"`
+}
+
+// HooksBlock declares completion notifications so scheduled conversions
+// can ping Slack or kick downstream jobs without wrapper scripts, e.g.
+//
+//	hooks {
+//	  on_success_url = "https://hooks.example.com/converted"
+//	  on_failure_cmd = "./alert.sh"
+//	}
+//
+// URL hooks POST the conversion report as JSON; command hooks run through
+// the shell with the same JSON on stdin and the outcome in MKSQLITE_EVENT.
+type HooksBlock struct {
+	OnSuccessURL string `hcl:"on_success_url,optional" yaml:"on_success_url,omitempty" json:"on_success_url,omitempty"`
+	OnFailureURL string `hcl:"on_failure_url,optional" yaml:"on_failure_url,omitempty" json:"on_failure_url,omitempty"`
+	OnSuccessCmd string `hcl:"on_success_cmd,optional" yaml:"on_success_cmd,omitempty" json:"on_success_cmd,omitempty"`
+	OnFailureCmd string `hcl:"on_failure_cmd,optional" yaml:"on_failure_cmd,omitempty" json:"on_failure_cmd,omitempty"`
+	Timeout      string `hcl:"timeout,optional" yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// TransformBlock declares a per-column transform pipeline, e.g.
+//
+//	transform "tb0.price" {
+//	  expr = "trim | replace(',', '')"
+//	}
+type TransformBlock struct {
+	Target string `hcl:"target,label" yaml:"target" json:"target"`
+	Expr   string `hcl:"expr" yaml:"expr" json:"expr"`
+}
+
+// BuildTransforms compiles the declared transform blocks into the
+// "table.column" map consumed by the import engine.
+func (c *Config) BuildTransforms() (map[string]*common.Transform, error) {
+	if len(c.Transforms) == 0 {
+		return nil, nil
+	}
+	transforms := make(map[string]*common.Transform, len(c.Transforms))
+	for _, block := range c.Transforms {
+		t, err := common.ParseTransform(block.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform for %s: %w", block.Target, err)
+		}
+		transforms[block.Target] = t
+	}
+	return transforms, nil
+}
+
+// RelationBlock declares a foreign key between imported tables, e.g.
+//
+//	relation "orders.customer_id" {
+//	  references = "customers.id"
+//	}
+type RelationBlock struct {
+	Source     string `hcl:"source,label" yaml:"source" json:"source"`
+	References string `hcl:"references" yaml:"references" json:"references"`
+}
+
+// BuildRelations compiles the declared relation blocks into the per-table
+// foreign key map consumed by the import engine.
+func (c *Config) BuildRelations() (map[string][]common.ForeignKey, error) {
+	if len(c.Relations) == 0 {
+		return nil, nil
+	}
+	relations := make(map[string][]common.ForeignKey, len(c.Relations))
+	for _, block := range c.Relations {
+		table, column, ok := strings.Cut(block.Source, ".")
+		if !ok || table == "" || column == "" {
+			return nil, fmt.Errorf("invalid relation source %q (want table.column)", block.Source)
+		}
+		refTable, refColumn, ok := strings.Cut(block.References, ".")
+		if !ok || refTable == "" || refColumn == "" {
+			return nil, fmt.Errorf("invalid relation references %q for %s (want table.column)", block.References, block.Source)
+		}
+		relations[table] = append(relations[table], common.ForeignKey{Column: column, RefTable: refTable, RefColumn: refColumn})
+	}
+	return relations, nil
+}
+
+// ApplyConverterConfig copies the per-driver blocks onto the config handed to
+// the converters. Values already present on cfg (e.g. from command-line
+// flags) are kept.
+func (c *Config) ApplyConverterConfig(cfg *common.ConversionConfig) {
+	if c.CSV != nil {
+		if c.CSV.Delimiter != "" && cfg.DelimiterString == "" {
+			cfg.DelimiterString = c.CSV.Delimiter
+		}
+		if c.CSV.Quote != "" && cfg.QuoteChar == "" {
+			cfg.QuoteChar = c.CSV.Quote
+		}
+		if c.CSV.EscapeStyle != "" && cfg.EscapeStyle == "" {
+			cfg.EscapeStyle = c.CSV.EscapeStyle
+		}
+		if c.CSV.NoQuoting {
+			cfg.NoQuoting = true
+		}
+		if c.CSV.LazyQuotes {
+			cfg.LazyQuotes = true
+		}
+		if c.CSV.Comment != "" && cfg.CommentPrefix == "" {
+			cfg.CommentPrefix = c.CSV.Comment
+		}
+		if c.CSV.SkipLines > 0 && cfg.SkipLines == 0 {
+			cfg.SkipLines = c.CSV.SkipLines
+		}
+		if c.CSV.TrimBOM {
+			cfg.TrimBOM = true
+		}
+		if c.CSV.NoHeader {
+			cfg.NoHeader = true
+		}
+		if len(c.CSV.Columns) > 0 && len(cfg.ColumnNames) == 0 {
+			cfg.ColumnNames = c.CSV.Columns
+		}
+		if c.CSV.Recover {
+			cfg.Recover = true
+		}
+	}
+	if c.Excel != nil {
+		if len(c.Excel.Sheets) > 0 && len(cfg.Sheets) == 0 {
+			cfg.Sheets = c.Excel.Sheets
+		}
+		if c.Excel.RawFormulas {
+			cfg.RawFormulas = true
+		}
+		if c.Excel.CellLinks {
+			cfg.CellLinks = true
+		}
+		if c.Excel.CellNotes {
+			cfg.CellNotes = true
+		}
+		if c.Excel.Dates != "" && cfg.Dates == "" {
+			cfg.Dates = c.Excel.Dates
+		}
+	}
+}
+
+// validate rejects values the converters would choke on later.
+func (c *Config) validate() error {
+	if c.BatchSize < 1 {
+		return fmt.Errorf("batch_size must be at least 1, got %d", c.BatchSize)
+	}
+	if c.ApplicationID < 0 || c.ApplicationID > 0xFFFFFFFF {
+		return fmt.Errorf("application_id must be a 32-bit value, got %d", c.ApplicationID)
+	}
+	if c.UserVersion < 0 || c.UserVersion > 0xFFFFFFFF {
+		return fmt.Errorf("user_version must be a 32-bit value, got %d", c.UserVersion)
+	}
+	if c.CSV != nil {
+		if c.CSV.SkipLines < 0 {
+			return fmt.Errorf("csv skip_lines must be non-negative, got %d", c.CSV.SkipLines)
+		}
+		if len(c.CSV.Comment) > 1 {
+			return fmt.Errorf("csv comment must be a single character, got %q", c.CSV.Comment)
+		}
+	}
+	if c.Excel != nil {
+		switch c.Excel.Dates {
+		case "", "text", "unix":
+		default:
+			return fmt.Errorf("excel dates must be \"text\" or \"unix\", got %q", c.Excel.Dates)
+		}
+	}
+	if c.Hooks != nil && c.Hooks.Timeout != "" {
+		if _, err := time.ParseDuration(c.Hooks.Timeout); err != nil {
+			return fmt.Errorf("invalid hooks timeout %q: %w", c.Hooks.Timeout, err)
+		}
+	}
+	return nil
+}
+
+// applyEnv layers MKSQLITE_* environment variable overrides on top of the
+// file values, so CI jobs can tweak a checked-in config without editing it.
+func (c *Config) applyEnv() error {
+	if v := os.Getenv("MKSQLITE_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid MKSQLITE_BATCH_SIZE %q: %w", v, err)
+		}
+		c.BatchSize = n
+	}
+	if v := os.Getenv("MKSQLITE_CSV_DELIMITER"); v != "" {
+		c.ensureCSV().Delimiter = v
+	}
+	if v := os.Getenv("MKSQLITE_CSV_COMMENT"); v != "" {
+		c.ensureCSV().Comment = v
+	}
+	if v := os.Getenv("MKSQLITE_EXCEL_SHEETS"); v != "" {
+		c.ensureExcel().Sheets = strings.Split(v, ",")
+	}
+	return nil
+}
+
+func (c *Config) ensureCSV() *CSVBlock {
+	if c.CSV == nil {
+		c.CSV = &CSVBlock{}
+	}
+	return c.CSV
+}
+
+func (c *Config) ensureExcel() *ExcelBlock {
+	if c.Excel == nil {
+		c.Excel = &ExcelBlock{}
+	}
+	return c.Excel
 }
 
 // DefaultConfig returns the default configuration.
@@ -22,35 +312,281 @@ func DefaultConfig() *Config {
 	}
 }
 
-// Load reads the configuration from the given HCL file.
+// Load reads the configuration from the given HCL file. Unknown keys and
+// blocks are rejected, included files are merged in, and MKSQLITE_*
+// environment variables override the file's values.
 func Load(path string) (*Config, error) {
+	return LoadProfile(path, "")
+}
+
+// LoadProfile reads the configuration from the given HCL file and, when
+// profile is non-empty, layers the named profile block over the top-level
+// values. Included files are merged first, so the including file and its
+// profiles win.
+func LoadProfile(path, profile string) (*Config, error) {
+	// -1 marks batch_size as unset so includes can't be clobbered by the
+	// zero value, while an explicit 0 still fails validation.
+	cfg := &Config{BatchSize: -1}
+	if err := loadInto(cfg, path, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	if profile != "" {
+		p := cfg.findProfile(profile)
+		if p == nil {
+			return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+		}
+		mergeConfig(cfg, p.asConfig())
+	}
+
+	if cfg.BatchSize == -1 {
+		cfg.BatchSize = DefaultConfig().BatchSize
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// loadInto parses one config file and merges it into dst, recursing into its
+// include list first so the including file's own values override.
+func loadInto(dst *Config, path string, seen map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	if seen[abs] {
+		return fmt.Errorf("config include cycle at %s", path)
+	}
+	seen[abs] = true
+
+	cfg, err := decodeFile(path)
+	if err != nil {
+		return err
+	}
+
+	for _, include := range cfg.Includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		if err := loadInto(dst, include, seen); err != nil {
+			return err
+		}
+	}
+
+	// Hook script paths are relative to the file that named them
+	resolvePostSQL(cfg.PostSQL, path)
+	for i := range cfg.Profiles {
+		resolvePostSQL(cfg.Profiles[i].PostSQL, path)
+	}
+
+	mergeConfig(dst, cfg)
+	return nil
+}
+
+func resolvePostSQL(scripts []string, configPath string) {
+	for i, script := range scripts {
+		if !filepath.IsAbs(script) {
+			scripts[i] = filepath.Join(filepath.Dir(configPath), script)
+		}
+	}
+}
+
+// ReadPostSQL reads the configured post-import hook scripts and returns
+// their contents in order.
+func (c *Config) ReadPostSQL() ([]string, error) {
+	if len(c.PostSQL) == 0 {
+		return nil, nil
+	}
+	scripts := make([]string, 0, len(c.PostSQL))
+	for _, path := range c.PostSQL {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read post_sql script: %w", err)
+		}
+		scripts = append(scripts, string(data))
+	}
+	return scripts, nil
+}
+
+// decodeFile parses a single config file, picking the format from the file
+// extension: .yaml/.yml and .json decode directly, everything else is HCL.
+// Unknown keys are rejected in every format.
+func decodeFile(path string) (*Config, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	parser := hclparse.NewParser()
-	file, diags := parser.ParseHCL(content, path)
-	if diags.HasErrors() {
-		return nil, fmt.Errorf("failed to parse config file: %s", diags.Error())
+	// -1 marks batch_size as unset; see LoadProfile
+	cfg := &Config{BatchSize: -1}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+	default:
+		parser := hclparse.NewParser()
+		file, diags := parser.ParseHCL(content, path)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse config file: %s", diags.Error())
+		}
+		diags = gohcl.DecodeBody(file.Body, nil, cfg)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to decode config: %s", diags.Error())
+		}
 	}
+	return cfg, nil
+}
 
-	cfg := DefaultConfig()
-	diags = gohcl.DecodeBody(file.Body, nil, cfg)
-	if diags.HasErrors() {
-		return nil, fmt.Errorf("failed to decode config: %s", diags.Error())
+func (c *Config) findProfile(name string) *ProfileBlock {
+	for i := range c.Profiles {
+		if c.Profiles[i].Name == name {
+			return &c.Profiles[i]
+		}
 	}
+	return nil
+}
 
-	return cfg, nil
+// asConfig lifts a profile block into a mergeable config overlay.
+func (p *ProfileBlock) asConfig() *Config {
+	batchSize := p.BatchSize
+	if batchSize == 0 {
+		batchSize = -1 // unset
+	}
+	return &Config{BatchSize: batchSize, Lineage: p.Lineage, ApplicationID: p.ApplicationID, UserVersion: p.UserVersion,
+		PostSQL: p.PostSQL, CSV: p.CSV, Excel: p.Excel, Hooks: p.Hooks, Transforms: p.Transforms, Relations: p.Relations}
+}
+
+// mergeConfig layers src over dst; set values in src win, transform,
+// relation, and profile blocks accumulate.
+func mergeConfig(dst, src *Config) {
+	if src.BatchSize != -1 {
+		dst.BatchSize = src.BatchSize
+	}
+	dst.Lineage = dst.Lineage || src.Lineage
+	if src.ApplicationID != 0 {
+		dst.ApplicationID = src.ApplicationID
+	}
+	if src.UserVersion != 0 {
+		dst.UserVersion = src.UserVersion
+	}
+	if src.CSV != nil {
+		if dst.CSV == nil {
+			dst.CSV = &CSVBlock{}
+		}
+		mergeCSV(dst.CSV, src.CSV)
+	}
+	if src.Excel != nil {
+		if len(src.Excel.Sheets) > 0 {
+			dst.ensureExcel().Sheets = src.Excel.Sheets
+		}
+		if src.Excel.RawFormulas {
+			dst.ensureExcel().RawFormulas = true
+		}
+		if src.Excel.CellLinks {
+			dst.ensureExcel().CellLinks = true
+		}
+		if src.Excel.CellNotes {
+			dst.ensureExcel().CellNotes = true
+		}
+		if src.Excel.Dates != "" {
+			dst.ensureExcel().Dates = src.Excel.Dates
+		}
+	}
+	if src.Hooks != nil {
+		if dst.Hooks == nil {
+			dst.Hooks = &HooksBlock{}
+		}
+		mergeHooks(dst.Hooks, src.Hooks)
+	}
+	dst.PostSQL = append(dst.PostSQL, src.PostSQL...)
+	dst.Transforms = append(dst.Transforms, src.Transforms...)
+	dst.Relations = append(dst.Relations, src.Relations...)
+	dst.Profiles = append(dst.Profiles, src.Profiles...)
 }
 
-// Export writes the configuration to the specified file in HCL format.
+func mergeHooks(dst, src *HooksBlock) {
+	if src.OnSuccessURL != "" {
+		dst.OnSuccessURL = src.OnSuccessURL
+	}
+	if src.OnFailureURL != "" {
+		dst.OnFailureURL = src.OnFailureURL
+	}
+	if src.OnSuccessCmd != "" {
+		dst.OnSuccessCmd = src.OnSuccessCmd
+	}
+	if src.OnFailureCmd != "" {
+		dst.OnFailureCmd = src.OnFailureCmd
+	}
+	if src.Timeout != "" {
+		dst.Timeout = src.Timeout
+	}
+}
+
+func mergeCSV(dst, src *CSVBlock) {
+	if src.Delimiter != "" {
+		dst.Delimiter = src.Delimiter
+	}
+	if src.Quote != "" {
+		dst.Quote = src.Quote
+	}
+	if src.EscapeStyle != "" {
+		dst.EscapeStyle = src.EscapeStyle
+	}
+	if src.Comment != "" {
+		dst.Comment = src.Comment
+	}
+	if src.SkipLines > 0 {
+		dst.SkipLines = src.SkipLines
+	}
+	if len(src.Columns) > 0 {
+		dst.Columns = src.Columns
+	}
+	dst.NoQuoting = dst.NoQuoting || src.NoQuoting
+	dst.LazyQuotes = dst.LazyQuotes || src.LazyQuotes
+	dst.TrimBOM = dst.TrimBOM || src.TrimBOM
+	dst.NoHeader = dst.NoHeader || src.NoHeader
+	dst.Recover = dst.Recover || src.Recover
+}
+
+// Export writes the configuration to the specified file, picking the format
+// from the file extension like Load does.
 func Export(path string, cfg *Config) error {
-	f := hclwrite.NewEmptyFile()
-	root := f.Body()
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+	case ".json":
+		data, err = json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		data = append(data, '\n')
+	default:
+		f := hclwrite.NewEmptyFile()
+		root := f.Body()
 
-	// Add comments and values
-	root.SetAttributeValue("batch_size", cty.NumberIntVal(int64(cfg.BatchSize)))
+		// Add comments and values
+		root.SetAttributeValue("batch_size", cty.NumberIntVal(int64(cfg.BatchSize)))
+		data = f.Bytes()
+	}
 
 	file, err := os.Create(path)
 	if err != nil {
@@ -58,7 +594,7 @@ func Export(path string, cfg *Config) error {
 	}
 	defer file.Close()
 
-	_, err = file.Write(f.Bytes())
+	_, err = file.Write(data)
 	if err != nil {
 		return fmt.Errorf("failed to write config to file: %w", err)
 	}
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
 )
 
 func TestExportAndLoad(t *testing.T) {
@@ -56,3 +59,378 @@ func TestLoadDefaults(t *testing.T) {
 		t.Errorf("expected default BatchSize 1000, got %d", loadedCfg.BatchSize)
 	}
 }
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.hcl")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadDriverBlocks(t *testing.T) {
+	configPath := writeConfig(t, `
+batch_size = 200
+
+csv {
+  delimiter = ";"
+  comment   = "#"
+}
+
+excel {
+  sheets = ["Data"]
+}
+`)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.BatchSize != 200 {
+		t.Errorf("expected BatchSize 200, got %d", cfg.BatchSize)
+	}
+	if cfg.CSV == nil || cfg.CSV.Delimiter != ";" || cfg.CSV.Comment != "#" {
+		t.Errorf("unexpected csv block: %+v", cfg.CSV)
+	}
+	if cfg.Excel == nil || len(cfg.Excel.Sheets) != 1 || cfg.Excel.Sheets[0] != "Data" {
+		t.Errorf("unexpected excel block: %+v", cfg.Excel)
+	}
+
+	convCfg := &common.ConversionConfig{}
+	cfg.ApplyConverterConfig(convCfg)
+	if convCfg.DelimiterString != ";" {
+		t.Errorf("expected delimiter ; applied, got %q", convCfg.DelimiterString)
+	}
+	if convCfg.CommentPrefix != "#" {
+		t.Errorf("expected comment # applied, got %q", convCfg.CommentPrefix)
+	}
+	if len(convCfg.Sheets) != 1 || convCfg.Sheets[0] != "Data" {
+		t.Errorf("expected sheets applied, got %v", convCfg.Sheets)
+	}
+}
+
+func TestLoadHooks(t *testing.T) {
+	configPath := writeConfig(t, `
+batch_size = 100
+
+hooks {
+  on_success_url = "https://hooks.example.com/done"
+  on_failure_cmd = "./alert.sh"
+  timeout        = "5s"
+}
+`)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Hooks == nil || cfg.Hooks.OnSuccessURL != "https://hooks.example.com/done" {
+		t.Errorf("unexpected hooks block: %+v", cfg.Hooks)
+	}
+	if cfg.Hooks.OnFailureCmd != "./alert.sh" || cfg.Hooks.Timeout != "5s" {
+		t.Errorf("unexpected hooks block: %+v", cfg.Hooks)
+	}
+
+	configPath = writeConfig(t, "batch_size = 100\nhooks {\n  timeout = \"soon\"\n}\n")
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected timeout validation error, got %v", err)
+	}
+}
+
+func TestLoadUnknownKeyRejected(t *testing.T) {
+	configPath := writeConfig(t, "batch_sizes = 200\n")
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for unknown key")
+	}
+
+	configPath = writeConfig(t, "csv {\n  delimiters = \";\"\n}\n")
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for unknown key inside a driver block")
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	configPath := writeConfig(t, "batch_size = 0\n")
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "batch_size") {
+		t.Errorf("expected batch_size validation error, got %v", err)
+	}
+
+	configPath = writeConfig(t, "csv {\n  comment = \"//\"\n}\n")
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "comment") {
+		t.Errorf("expected comment validation error, got %v", err)
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	configPath := writeConfig(t, "batch_size = 200\n")
+
+	t.Setenv("MKSQLITE_BATCH_SIZE", "250")
+	t.Setenv("MKSQLITE_CSV_DELIMITER", "|")
+	t.Setenv("MKSQLITE_EXCEL_SHEETS", "Data,Summary")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.BatchSize != 250 {
+		t.Errorf("expected BatchSize 250 from environment, got %d", cfg.BatchSize)
+	}
+	if cfg.CSV == nil || cfg.CSV.Delimiter != "|" {
+		t.Errorf("expected delimiter | from environment, got %+v", cfg.CSV)
+	}
+	if cfg.Excel == nil || len(cfg.Excel.Sheets) != 2 || cfg.Excel.Sheets[1] != "Summary" {
+		t.Errorf("expected two sheets from environment, got %+v", cfg.Excel)
+	}
+
+	t.Setenv("MKSQLITE_BATCH_SIZE", "lots")
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for a non-numeric MKSQLITE_BATCH_SIZE")
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	configPath := writeConfig(t, `
+batch_size = 200
+
+csv {
+  delimiter = ";"
+}
+
+profile "takeout" {
+  batch_size = 5000
+
+  csv {
+    comment = "#"
+  }
+}
+`)
+
+	// Without a profile the top-level values apply
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.BatchSize != 200 {
+		t.Errorf("expected BatchSize 200, got %d", cfg.BatchSize)
+	}
+
+	// The profile layers over the top level without discarding it
+	cfg, err = LoadProfile(configPath, "takeout")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	if cfg.BatchSize != 5000 {
+		t.Errorf("expected BatchSize 5000 from profile, got %d", cfg.BatchSize)
+	}
+	if cfg.CSV == nil || cfg.CSV.Delimiter != ";" || cfg.CSV.Comment != "#" {
+		t.Errorf("unexpected merged csv block: %+v", cfg.CSV)
+	}
+
+	if _, err := LoadProfile(configPath, "nosuch"); err == nil || !strings.Contains(err.Error(), "nosuch") {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestLoadIncludes(t *testing.T) {
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.hcl")
+	if err := os.WriteFile(basePath, []byte("batch_size = 500\n\ncsv {\n  delimiter = \";\"\n}\n"), 0644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+	mainPath := filepath.Join(tempDir, "main.hcl")
+	if err := os.WriteFile(mainPath, []byte("include = [\"base.hcl\"]\n\ncsv {\n  comment = \"#\"\n}\n"), 0644); err != nil {
+		t.Fatalf("failed to write main config: %v", err)
+	}
+
+	cfg, err := Load(mainPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.BatchSize != 500 {
+		t.Errorf("expected BatchSize 500 from include, got %d", cfg.BatchSize)
+	}
+	if cfg.CSV == nil || cfg.CSV.Delimiter != ";" || cfg.CSV.Comment != "#" {
+		t.Errorf("unexpected merged csv block: %+v", cfg.CSV)
+	}
+}
+
+func TestLoadIncludeCycle(t *testing.T) {
+	tempDir := t.TempDir()
+	aPath := filepath.Join(tempDir, "a.hcl")
+	bPath := filepath.Join(tempDir, "b.hcl")
+	if err := os.WriteFile(aPath, []byte("include = [\"b.hcl\"]\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(bPath, []byte("include = [\"a.hcl\"]\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := Load(aPath); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected include cycle error, got %v", err)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+batch_size: 200
+csv:
+  delimiter: ";"
+profiles:
+  - name: takeout
+    batch_size: 5000
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadProfile(configPath, "takeout")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	if cfg.BatchSize != 5000 {
+		t.Errorf("expected BatchSize 5000 from profile, got %d", cfg.BatchSize)
+	}
+	if cfg.CSV == nil || cfg.CSV.Delimiter != ";" {
+		t.Errorf("unexpected csv block: %+v", cfg.CSV)
+	}
+
+	// Unknown keys are rejected in YAML too
+	if err := os.WriteFile(configPath, []byte("batch_sizes: 200\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for unknown key in YAML config")
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"batch_size": 300, "excel": {"sheets": ["Data"]}}`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.BatchSize != 300 {
+		t.Errorf("expected BatchSize 300, got %d", cfg.BatchSize)
+	}
+	if cfg.Excel == nil || len(cfg.Excel.Sheets) != 1 || cfg.Excel.Sheets[0] != "Data" {
+		t.Errorf("unexpected excel block: %+v", cfg.Excel)
+	}
+
+	if err := os.WriteFile(configPath, []byte(`{"batch_sizes": 300}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("expected error for unknown key in JSON config")
+	}
+}
+
+func TestExportFormats(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.BatchSize = 400
+	cfg.CSV = &CSVBlock{Delimiter: ";"}
+
+	for _, name := range []string{"config.yaml", "config.json"} {
+		configPath := filepath.Join(tempDir, name)
+		if err := Export(configPath, cfg); err != nil {
+			t.Fatalf("Export to %s failed: %v", name, err)
+		}
+		loaded, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Load of exported %s failed: %v", name, err)
+		}
+		if loaded.BatchSize != 400 {
+			t.Errorf("%s: expected BatchSize 400, got %d", name, loaded.BatchSize)
+		}
+		if loaded.CSV == nil || loaded.CSV.Delimiter != ";" {
+			t.Errorf("%s: unexpected csv block: %+v", name, loaded.CSV)
+		}
+	}
+}
+
+func TestLoadTransforms(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config_test_transform")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	configPath := filepath.Join(tempDir, "transform.hcl")
+	content := `
+transform "tb0.price" {
+  expr = "trim + replace(',', '')"
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	transforms, err := cfg.BuildTransforms()
+	if err != nil {
+		t.Fatalf("BuildTransforms failed: %v", err)
+	}
+	tr, ok := transforms["tb0.price"]
+	if !ok {
+		t.Fatalf("expected transform for tb0.price, got %v", transforms)
+	}
+	if got := tr.Apply(" 1,234 "); got != "1234" {
+		t.Errorf("expected transformed value 1234, got %q", got)
+	}
+
+	cfg.Transforms[0].Expr = "nosuch"
+	if _, err := cfg.BuildTransforms(); err == nil {
+		t.Error("expected error for unknown transform")
+	}
+}
+
+func TestLoadRelations(t *testing.T) {
+	configPath := writeConfig(t, `
+batch_size = 100
+
+relation "orders.customer_id" {
+  references = "customers.id"
+}
+
+relation "orders.product_id" {
+  references = "products.id"
+}
+`)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	relations, err := cfg.BuildRelations()
+	if err != nil {
+		t.Fatalf("BuildRelations failed: %v", err)
+	}
+	fks, ok := relations["orders"]
+	if !ok || len(fks) != 2 {
+		t.Fatalf("expected 2 foreign keys for orders, got %v", relations)
+	}
+	if fks[0].Column != "customer_id" || fks[0].RefTable != "customers" || fks[0].RefColumn != "id" {
+		t.Errorf("unexpected foreign key: %+v", fks[0])
+	}
+
+	cfg.Relations[0].References = "customers"
+	if _, err := cfg.BuildRelations(); err == nil {
+		t.Error("expected error for references without a column")
+	}
+	cfg.Relations[0].Source = "customer_id"
+	if _, err := cfg.BuildRelations(); err == nil {
+		t.Error("expected error for source without a table")
+	}
+}
@@ -0,0 +1,403 @@
+package converters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// AdapterTB is the table name used by the in-process adapters below,
+// matching the tb0 convention of the file-based converters.
+const AdapterTB = "tb0"
+
+// structsProvider adapts a slice of Go structs to the RowProvider interface.
+type structsProvider struct {
+	rawHeaders []string
+	headers    []string
+	colTypes   []string
+	fields     []int // Field indices into the struct, parallel to headers
+	rows       reflect.Value
+}
+
+var _ common.RowProvider = (*structsProvider)(nil)
+var _ common.RawHeaderProvider = (*structsProvider)(nil)
+
+// FromStructs adapts a slice of structs (or pointers to structs) to a
+// RowProvider, so embedding applications can hand arbitrary Go data to
+// ImportToSQLite without writing a converter. Exported fields become
+// columns named after the lowercased field name, or the `db:"name"` tag
+// when present; `db:"-"` skips a field. Column types are derived from the
+// Go field types (integers and bools map to INTEGER, floats to REAL,
+// []byte to BLOB, everything else to TEXT).
+func FromStructs[T any](rows []T) (common.RowProvider, error) {
+	elem := reflect.TypeOf((*T)(nil)).Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("converters: FromStructs wants a slice of structs, got []%s", elem.Kind())
+	}
+
+	p := &structsProvider{rows: reflect.ValueOf(rows)}
+	for i := 0; i < elem.NumField(); i++ {
+		f := elem.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("db"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		p.rawHeaders = append(p.rawHeaders, name)
+		p.fields = append(p.fields, i)
+		p.colTypes = append(p.colTypes, columnTypeFor(f.Type))
+	}
+	if len(p.fields) == 0 {
+		return nil, fmt.Errorf("converters: FromStructs: %s has no usable exported fields", elem)
+	}
+	p.headers = common.GenColumnNames(p.rawHeaders)
+	return p, nil
+}
+
+// columnTypeFor maps a Go type to the SQLite column type it stores as.
+func columnTypeFor(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB"
+		}
+	}
+	return "TEXT"
+}
+
+// bindValue converts a struct field value to something the SQLite driver
+// can bind: sized integers widen to int64, bools become 0/1, time.Time
+// formats as RFC 3339, nil pointers stay NULL and anything else falls
+// back to its string form.
+func bindValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return int64(1)
+		}
+		return int64(0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.IsNil() {
+				return nil
+			}
+			return v.Bytes()
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// GetTableNames implements RowProvider
+func (p *structsProvider) GetTableNames() []string {
+	return []string{AdapterTB}
+}
+
+// GetHeaders implements RowProvider
+func (p *structsProvider) GetHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.headers
+}
+
+// GetRawHeaders implements RawHeaderProvider
+func (p *structsProvider) GetRawHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.rawHeaders
+}
+
+// GetColumnTypes implements RowProvider
+func (p *structsProvider) GetColumnTypes(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.colTypes
+}
+
+// ScanRows implements RowProvider
+func (p *structsProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != AdapterTB {
+		return nil
+	}
+	for i := 0; i < p.rows.Len(); i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		item := p.rows.Index(i)
+		for item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				return fmt.Errorf("converters: FromStructs: nil element at index %d", i)
+			}
+			item = item.Elem()
+		}
+		row := make([]interface{}, len(p.fields))
+		for j, fieldIdx := range p.fields {
+			row[j] = bindValue(item.Field(fieldIdx))
+		}
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqlRowsProvider adapts a database/sql result set to the RowProvider
+// interface.
+type sqlRowsProvider struct {
+	rows       *sql.Rows
+	rawHeaders []string
+	headers    []string
+	colTypes   []string
+	consumed   bool
+}
+
+var _ common.RowProvider = (*sqlRowsProvider)(nil)
+var _ common.RawHeaderProvider = (*sqlRowsProvider)(nil)
+var _ io.Closer = (*sqlRowsProvider)(nil)
+
+// FromSQLRows adapts a *sql.Rows result set to a RowProvider, so a query
+// against any database/sql source (Postgres, MySQL, ...) can be
+// snapshotted into a local SQLite file. Column names come from the result
+// set and column types are mapped from the driver's declared database
+// types to SQLite affinities. The provider consumes and closes rows, so
+// ScanRows can run only once.
+func FromSQLRows(rows *sql.Rows) (common.RowProvider, error) {
+	if rows == nil {
+		return nil, fmt.Errorf("converters: FromSQLRows: nil rows")
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("converters: FromSQLRows: %w", err)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("converters: FromSQLRows: result set has no columns")
+	}
+	p := &sqlRowsProvider{
+		rows:       rows,
+		rawHeaders: cols,
+		headers:    common.GenColumnNames(cols),
+	}
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("converters: FromSQLRows: %w", err)
+	}
+	p.colTypes = make([]string, len(colTypes))
+	for i, ct := range colTypes {
+		p.colTypes[i] = sqliteAffinity(ct.DatabaseTypeName())
+	}
+	return p, nil
+}
+
+// sqliteAffinity maps a driver-reported database type name to the SQLite
+// column type it should land as, following SQLite's own affinity rules
+// (substring matching on the declared type).
+func sqliteAffinity(dbType string) string {
+	t := strings.ToUpper(dbType)
+	switch {
+	case strings.Contains(t, "INT"), strings.Contains(t, "BOOL"),
+		strings.Contains(t, "SERIAL"), strings.Contains(t, "BIT"):
+		return "INTEGER"
+	case strings.Contains(t, "REAL"), strings.Contains(t, "FLOA"),
+		strings.Contains(t, "DOUB"), strings.Contains(t, "NUMERIC"),
+		strings.Contains(t, "DECIMAL"), strings.Contains(t, "MONEY"):
+		return "REAL"
+	case strings.Contains(t, "BLOB"), strings.Contains(t, "BINARY"),
+		strings.Contains(t, "BYTEA"):
+		return "BLOB"
+	}
+	return "TEXT"
+}
+
+// GetTableNames implements RowProvider
+func (p *sqlRowsProvider) GetTableNames() []string {
+	return []string{AdapterTB}
+}
+
+// GetHeaders implements RowProvider
+func (p *sqlRowsProvider) GetHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.headers
+}
+
+// GetRawHeaders implements RawHeaderProvider
+func (p *sqlRowsProvider) GetRawHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.rawHeaders
+}
+
+// GetColumnTypes implements RowProvider
+func (p *sqlRowsProvider) GetColumnTypes(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.colTypes
+}
+
+// ScanRows implements RowProvider
+func (p *sqlRowsProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != AdapterTB {
+		return nil
+	}
+	if p.consumed {
+		return fmt.Errorf("converters: FromSQLRows: result set already consumed")
+	}
+	p.consumed = true
+	defer p.rows.Close()
+
+	for p.rows.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		values := make([]interface{}, len(p.headers))
+		ptrs := make([]interface{}, len(values))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := p.rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		if err := yield(values, nil); err != nil {
+			return err
+		}
+	}
+	return p.rows.Err()
+}
+
+// Close implements io.Closer so the engine releases the result set even
+// when the import stops before draining it.
+func (p *sqlRowsProvider) Close() error {
+	return p.rows.Close()
+}
+
+// channelProvider adapts a channel of rows to the RowProvider interface.
+type channelProvider struct {
+	ch         <-chan []interface{}
+	rawHeaders []string
+	headers    []string
+}
+
+var _ common.RowProvider = (*channelProvider)(nil)
+var _ common.RawHeaderProvider = (*channelProvider)(nil)
+
+// FromChannel adapts a channel of rows to a RowProvider, so a producer
+// goroutine can stream data of unknown length straight into the import
+// engine. Headers name the columns, in row order; all columns are
+// declared TEXT, but values keep whatever type the producer sends
+// (SQLite stores them dynamically). ScanRows drains the channel until it
+// is closed, so the producer must close ch when done.
+func FromChannel(ch <-chan []interface{}, headers []string) (common.RowProvider, error) {
+	if ch == nil {
+		return nil, fmt.Errorf("converters: FromChannel: nil channel")
+	}
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("converters: FromChannel: headers are required")
+	}
+	return &channelProvider{
+		ch:         ch,
+		rawHeaders: headers,
+		headers:    common.GenColumnNames(headers),
+	}, nil
+}
+
+// GetTableNames implements RowProvider
+func (p *channelProvider) GetTableNames() []string {
+	return []string{AdapterTB}
+}
+
+// GetHeaders implements RowProvider
+func (p *channelProvider) GetHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.headers
+}
+
+// GetRawHeaders implements RawHeaderProvider
+func (p *channelProvider) GetRawHeaders(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	return p.rawHeaders
+}
+
+// GetColumnTypes implements RowProvider
+func (p *channelProvider) GetColumnTypes(tableName string) []string {
+	if tableName != AdapterTB {
+		return nil
+	}
+	colTypes := make([]string, len(p.headers))
+	for i := range colTypes {
+		colTypes[i] = "TEXT"
+	}
+	return colTypes
+}
+
+// ScanRows implements RowProvider
+func (p *channelProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != AdapterTB {
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case row, ok := <-p.ch:
+			if !ok {
+				return nil
+			}
+			if err := yield(row, nil); err != nil {
+				return err
+			}
+		}
+	}
+}
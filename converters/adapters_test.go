@@ -0,0 +1,201 @@
+package converters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromStructs(t *testing.T) {
+	type record struct {
+		ID      int
+		Name    string
+		Score   float64
+		Blob    []byte
+		Alias   string `db:"nickname"`
+		Skipped string `db:"-"`
+		hidden  int
+	}
+
+	provider, err := FromStructs([]record{
+		{ID: 1, Name: "ada", Score: 9.5, Blob: []byte{0x01}, Alias: "al", Skipped: "no"},
+		{ID: 2, Name: "bob", Score: 7.25, Alias: "bo", Skipped: "no"},
+	})
+	if err != nil {
+		t.Fatalf("FromStructs failed: %v", err)
+	}
+
+	headers := provider.GetHeaders(AdapterTB)
+	want := []string{"id", "name", "score", "blob", "nickname"}
+	if len(headers) != len(want) {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+	for i, h := range want {
+		if headers[i] != h {
+			t.Errorf("header %d: got %s, want %s", i, headers[i], h)
+		}
+	}
+	colTypes := provider.GetColumnTypes(AdapterTB)
+	wantTypes := []string{"INTEGER", "TEXT", "REAL", "BLOB", "TEXT"}
+	for i, ct := range wantTypes {
+		if colTypes[i] != ct {
+			t.Errorf("column type %d: got %s, want %s", i, colTypes[i], ct)
+		}
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "structs.db")
+	f, err := os.Create(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	if err := ImportToSQLite(provider, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var id int64
+	var name string
+	var score float64
+	err = db.QueryRow(`SELECT id, name, score FROM tb0 WHERE nickname = 'al'`).Scan(&id, &name, &score)
+	if err != nil {
+		t.Fatalf("Failed to read row back: %v", err)
+	}
+	if id != 1 || name != "ada" || score != 9.5 {
+		t.Errorf("unexpected row: %d %s %f", id, name, score)
+	}
+}
+
+func TestFromStructsRejectsNonStructs(t *testing.T) {
+	if _, err := FromStructs([]int{1, 2, 3}); err == nil {
+		t.Error("expected error for non-struct element type")
+	}
+}
+
+func TestFromSQLRows(t *testing.T) {
+	srcPath := filepath.Join(t.TempDir(), "source.db")
+	src, err := sql.Open("sqlite", srcPath)
+	if err != nil {
+		t.Fatalf("Failed to open source database: %v", err)
+	}
+	defer src.Close()
+	_, err = src.Exec(`CREATE TABLE people (id INTEGER, name TEXT, height REAL);
+		INSERT INTO people VALUES (1, 'ada', 1.63), (2, 'bob', 1.8)`)
+	if err != nil {
+		t.Fatalf("Failed to seed source database: %v", err)
+	}
+
+	rows, err := src.Query(`SELECT id, name, height FROM people ORDER BY id`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	provider, err := FromSQLRows(rows)
+	if err != nil {
+		t.Fatalf("FromSQLRows failed: %v", err)
+	}
+
+	colTypes := provider.GetColumnTypes(AdapterTB)
+	wantTypes := []string{"INTEGER", "TEXT", "REAL"}
+	for i, ct := range wantTypes {
+		if colTypes[i] != ct {
+			t.Errorf("column type %d: got %s, want %s", i, colTypes[i], ct)
+		}
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "snapshot.db")
+	f, err := os.Create(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	if err := ImportToSQLite(provider, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var name string
+	var height float64
+	if err := db.QueryRow(`SELECT name, height FROM tb0 WHERE id = 2`).Scan(&name, &height); err != nil {
+		t.Fatalf("Failed to read row back: %v", err)
+	}
+	if name != "bob" || height != 1.8 {
+		t.Errorf("unexpected row: %s %f", name, height)
+	}
+
+	// The result set is gone after the import; a second scan must refuse
+	err = provider.ScanRows(context.Background(), AdapterTB, func([]interface{}, error) error { return nil })
+	if err == nil {
+		t.Error("expected error scanning an already-consumed result set")
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	ch := make(chan []interface{})
+	go func() {
+		defer close(ch)
+		for i := 0; i < 5; i++ {
+			ch <- []interface{}{i, fmt.Sprintf("row %d", i)}
+		}
+	}()
+
+	provider, err := FromChannel(ch, []string{"n", "label"})
+	if err != nil {
+		t.Fatalf("FromChannel failed: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "channel.db")
+	f, err := os.Create(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	if err := ImportToSQLite(provider, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM tb0`).Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("expected 5 rows, got %d", count)
+	}
+	var label string
+	if err := db.QueryRow(`SELECT label FROM tb0 WHERE n = 3`).Scan(&label); err != nil {
+		t.Fatalf("Failed to read row back: %v", err)
+	}
+	if label != "row 3" {
+		t.Errorf("unexpected label: %s", label)
+	}
+}
+
+func TestFromChannelCancellation(t *testing.T) {
+	ch := make(chan []interface{})
+	provider, err := FromChannel(ch, []string{"n"})
+	if err != nil {
+		t.Fatalf("FromChannel failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = provider.ScanRows(ctx, AdapterTB, func([]interface{}, error) error { return nil })
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
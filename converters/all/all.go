@@ -2,12 +2,31 @@ package all
 
 import (
 	// Import all the converters so they register themselves
+	_ "github.com/darianmavgo/mksqlite/converters/api"
+	_ "github.com/darianmavgo/mksqlite/converters/avro"
+	_ "github.com/darianmavgo/mksqlite/converters/browser"
 	_ "github.com/darianmavgo/mksqlite/converters/csv"
+	_ "github.com/darianmavgo/mksqlite/converters/dbf"
+	_ "github.com/darianmavgo/mksqlite/converters/docx"
+	_ "github.com/darianmavgo/mksqlite/converters/email"
 	_ "github.com/darianmavgo/mksqlite/converters/excel"
 	_ "github.com/darianmavgo/mksqlite/converters/filesystem"
+	_ "github.com/darianmavgo/mksqlite/converters/geojson"
+	_ "github.com/darianmavgo/mksqlite/converters/gpx"
 	_ "github.com/darianmavgo/mksqlite/converters/html"
+	_ "github.com/darianmavgo/mksqlite/converters/ics"
 	_ "github.com/darianmavgo/mksqlite/converters/json"
+	_ "github.com/darianmavgo/mksqlite/converters/kml"
 	_ "github.com/darianmavgo/mksqlite/converters/markdown"
+	_ "github.com/darianmavgo/mksqlite/converters/orc"
+	_ "github.com/darianmavgo/mksqlite/converters/protobuf"
+	_ "github.com/darianmavgo/mksqlite/converters/rar"
+	_ "github.com/darianmavgo/mksqlite/converters/sevenzip"
+	_ "github.com/darianmavgo/mksqlite/converters/sitemap"
+	_ "github.com/darianmavgo/mksqlite/converters/sqldump"
+	_ "github.com/darianmavgo/mksqlite/converters/sqlite"
+	_ "github.com/darianmavgo/mksqlite/converters/takeout"
 	_ "github.com/darianmavgo/mksqlite/converters/txt"
+	_ "github.com/darianmavgo/mksqlite/converters/vcard"
 	_ "github.com/darianmavgo/mksqlite/converters/zip"
 )
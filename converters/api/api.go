@@ -0,0 +1,481 @@
+// Package api ingests paginated JSON HTTP APIs into a table, replacing the
+// throwaway scripts people write to land an API into SQLite. The driver is
+// configured entirely through ConversionConfig: InputPath carries the URL
+// template ({page} and {cursor} placeholders expand per request, or the
+// page/cursor is appended as a query parameter), APIPagination selects the
+// strategy ("page", "cursor" or a Link response header), APIRecordsPath
+// points at the record array inside each response and APIAuthHeader is
+// added to every request.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	APITB = "tb0"
+
+	// DefaultPageParam is the query parameter used for the page number or
+	// cursor when the URL template has no placeholder.
+	DefaultPageParam = "page"
+)
+
+func init() {
+	converters.Register("api", &apiDriver{}, converters.Capabilities{Streaming: true})
+}
+
+type apiDriver struct{}
+
+func (d *apiDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	if config == nil || config.InputPath == "" {
+		return nil, fmt.Errorf("api converter requires the URL in InputPath")
+	}
+	return NewAPIConverterWithConfig(config.InputPath, config)
+}
+
+// APIConverter streams pages of a JSON API into one table. Columns are the
+// union of the keys seen in the first page's records, sorted; nested
+// objects and arrays are stored as JSON text.
+type APIConverter struct {
+	urlTemplate string
+	client      *http.Client
+	pagination  string // "page", "cursor", "link" or "" for a single page
+	pageParam   string
+	cursorPath  string
+	recordsPath string
+	authName    string
+	authValue   string
+	maxPages    int
+
+	rowsPerInsert int
+
+	headers  []string
+	colTypes []string
+
+	// Pagination state, advanced by fetchPage
+	page      int
+	cursor    string
+	nextURL   string
+	fetched   int
+	exhausted bool
+
+	// First page, fetched at construction to derive the schema
+	firstPage []map[string]interface{}
+}
+
+// Ensure APIConverter implements RowProvider
+var _ common.RowProvider = (*APIConverter)(nil)
+
+// Ensure APIConverter implements StreamConverter
+var _ common.StreamConverter = (*APIConverter)(nil)
+
+// NewAPIConverter creates a converter fetching a single page from url.
+func NewAPIConverter(url string) (*APIConverter, error) {
+	return NewAPIConverterWithConfig(url, nil)
+}
+
+// NewAPIConverterWithConfig creates a converter for the given URL template
+// with the api options of config applied. The first page is fetched
+// immediately so the schema is known before the import begins.
+func NewAPIConverterWithConfig(rawURL string, config *common.ConversionConfig) (*APIConverter, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+
+	c := &APIConverter{
+		urlTemplate: rawURL,
+		client:      &http.Client{Timeout: 30 * time.Second},
+		pageParam:   DefaultPageParam,
+		page:        1,
+	}
+	if config != nil {
+		switch config.APIPagination {
+		case "", "page", "cursor", "link":
+			c.pagination = config.APIPagination
+		default:
+			return nil, fmt.Errorf("unsupported pagination strategy: %s (want page, cursor or link)", config.APIPagination)
+		}
+		if config.APIPageParam != "" {
+			c.pageParam = config.APIPageParam
+		}
+		c.cursorPath = config.APICursorPath
+		c.recordsPath = config.APIRecordsPath
+		c.maxPages = config.APIMaxPages
+		if config.APIAuthHeader != "" {
+			name, value, ok := strings.Cut(config.APIAuthHeader, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth header %q (want \"Name: value\")", config.APIAuthHeader)
+			}
+			c.authName = strings.TrimSpace(name)
+			c.authValue = strings.TrimSpace(value)
+		}
+		if config.ScanTimeout != "" {
+			if d, err := time.ParseDuration(config.ScanTimeout); err == nil {
+				c.client.Timeout = d
+			}
+		}
+		c.rowsPerInsert = config.RowsPerInsert
+	}
+
+	// Fetch the first page up front to derive headers and column types
+	records, err := c.fetchPage(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	c.firstPage = records
+
+	keys := make(map[string]bool)
+	for _, rec := range records {
+		for k := range rec {
+			keys[k] = true
+		}
+	}
+	for k := range keys {
+		c.headers = append(c.headers, k)
+	}
+	sort.Strings(c.headers)
+	c.colTypes = inferTypes(c.headers, records)
+
+	return c, nil
+}
+
+// inferTypes picks a column type per header from the first page's values.
+func inferTypes(headers []string, records []map[string]interface{}) []string {
+	colTypes := make([]string, len(headers))
+	for i, h := range headers {
+		colType := ""
+		for _, rec := range records {
+			v, ok := rec[h]
+			if !ok || v == nil {
+				continue
+			}
+			var t string
+			switch cellValue(v).(type) {
+			case int64:
+				t = "INTEGER"
+			case float64:
+				t = "REAL"
+			default:
+				t = "TEXT"
+			}
+			switch {
+			case colType == "" || colType == t:
+				colType = t
+			case (colType == "INTEGER" && t == "REAL") || (colType == "REAL" && t == "INTEGER"):
+				colType = "REAL"
+			default:
+				colType = "TEXT"
+			}
+		}
+		if colType == "" {
+			colType = "TEXT"
+		}
+		colTypes[i] = colType
+	}
+	return colTypes
+}
+
+// cellValue converts a decoded JSON value to something the SQLite driver
+// can bind: numbers keep integer/real distinction, bools become 0/1 and
+// nested structures are stored as JSON text.
+func cellValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return t
+	case bool:
+		if t {
+			return int64(1)
+		}
+		return int64(0)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	default:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprintf("%v", t)
+		}
+		return string(data)
+	}
+}
+
+// requestURL builds the URL of the next request from the template and the
+// current pagination state.
+func (c *APIConverter) requestURL() (string, error) {
+	if c.pagination == "link" {
+		if c.fetched == 0 {
+			return c.urlTemplate, nil
+		}
+		return c.nextURL, nil
+	}
+
+	u := c.urlTemplate
+	replaced := false
+	if strings.Contains(u, "{page}") {
+		u = strings.ReplaceAll(u, "{page}", strconv.Itoa(c.page))
+		replaced = true
+	}
+	if strings.Contains(u, "{cursor}") {
+		u = strings.ReplaceAll(u, "{cursor}", url.QueryEscape(c.cursor))
+		replaced = true
+	}
+	if replaced || c.pagination == "" {
+		return u, nil
+	}
+
+	// No placeholder: carry the page number or cursor as a query parameter
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL: %w", err)
+	}
+	q := parsed.Query()
+	switch c.pagination {
+	case "page":
+		q.Set(c.pageParam, strconv.Itoa(c.page))
+	case "cursor":
+		if c.cursor != "" {
+			q.Set(c.pageParam, c.cursor)
+		}
+	}
+	parsed.RawQuery = q.Encode()
+	return parsed.String(), nil
+}
+
+// lookupPath descends a dot-separated path of object keys.
+func lookupPath(v interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		v, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// parseLinkNext extracts the rel="next" target from a Link header, or ""
+// when there is no next page.
+func parseLinkNext(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range segments[1:] {
+			param = strings.TrimSpace(param)
+			if param == `rel="next"` || param == "rel=next" {
+				return strings.Trim(target, "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// fetchPage requests the next page, advances the pagination state and
+// returns the page's records. It returns nil once the API is exhausted.
+func (c *APIConverter) fetchPage(ctx context.Context) ([]map[string]interface{}, error) {
+	if c.exhausted {
+		return nil, nil
+	}
+	if c.maxPages > 0 && c.fetched >= c.maxPages {
+		c.exhausted = true
+		return nil, nil
+	}
+
+	reqURL, err := c.requestURL()
+	if err != nil {
+		return nil, err
+	}
+	if reqURL == "" {
+		c.exhausted = true
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authName != "" {
+		req.Header.Set(c.authName, c.authValue)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API returned %s for %s", resp.Status, reqURL)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	raw, ok := lookupPath(body, c.recordsPath)
+	if !ok {
+		return nil, fmt.Errorf("records path %q not found in response", c.recordsPath)
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("records path %q is not an array", c.recordsPath)
+	}
+	records := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		rec, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record is not a JSON object: %v", item)
+		}
+		records = append(records, rec)
+	}
+
+	c.fetched++
+	switch c.pagination {
+	case "":
+		c.exhausted = true
+	case "page":
+		c.page++
+		if len(records) == 0 {
+			c.exhausted = true
+		}
+	case "cursor":
+		c.cursor = ""
+		if next, ok := lookupPath(body, c.cursorPath); ok && next != nil {
+			c.cursor = fmt.Sprintf("%v", next)
+		}
+		if c.cursor == "" || len(records) == 0 {
+			c.exhausted = true
+		}
+	case "link":
+		c.nextURL = parseLinkNext(resp.Header.Get("Link"))
+		if c.nextURL == "" || len(records) == 0 {
+			c.exhausted = true
+		}
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records, nil
+}
+
+// GetTableNames implements RowProvider
+func (c *APIConverter) GetTableNames() []string {
+	return []string{APITB}
+}
+
+// GetHeaders implements RowProvider
+func (c *APIConverter) GetHeaders(tableName string) []string {
+	if tableName != APITB {
+		return nil
+	}
+	return common.GenColumnNames(c.headers)
+}
+
+// GetRawHeaders implements RawHeaderProvider
+func (c *APIConverter) GetRawHeaders(tableName string) []string {
+	if tableName != APITB {
+		return nil
+	}
+	return c.headers
+}
+
+// GetColumnTypes implements RowProvider
+func (c *APIConverter) GetColumnTypes(tableName string) []string {
+	if tableName != APITB {
+		return nil
+	}
+	return c.colTypes
+}
+
+// row aligns a record to the first page's headers; keys that only appear
+// in later pages are dropped.
+func (c *APIConverter) row(rec map[string]interface{}) []interface{} {
+	row := make([]interface{}, len(c.headers))
+	for i, h := range c.headers {
+		if v, ok := rec[h]; ok {
+			row[i] = cellValue(v)
+		}
+	}
+	return row
+}
+
+// ScanRows implements RowProvider
+func (c *APIConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != APITB {
+		return nil
+	}
+
+	records := c.firstPage
+	c.firstPage = nil
+	for records != nil {
+		for _, rec := range records {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := yield(c.row(rec), nil); err != nil {
+				return err
+			}
+		}
+		var err error
+		records, err = c.fetchPage(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertToSQL implements StreamConverter for API sources
+func (c *APIConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	headers := c.GetHeaders(APITB)
+	colTypes := c.GetColumnTypes(APITB)
+	sqlWriter := common.NewSQLWriter(writer, c.rowsPerInsert)
+	if err := sqlWriter.BeginTable(APITB, headers, colTypes); err != nil {
+		return err
+	}
+	err := c.ScanRows(ctx, APITB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		return sqlWriter.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return sqlWriter.EndTable()
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// collect runs ScanRows and returns every yielded row.
+func collect(t *testing.T, c *APIConverter) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := c.ScanRows(context.Background(), APITB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestPagePagination(t *testing.T) {
+	pages := map[string]string{
+		"1": `[{"id": 1, "name": "ada"}, {"id": 2, "name": "bob"}]`,
+		"2": `[{"id": 3, "name": "cay"}]`,
+		"3": `[]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer sesame" {
+			t.Errorf("missing auth header, got %q", got)
+		}
+		fmt.Fprint(w, pages[r.URL.Query().Get("page")])
+	}))
+	defer srv.Close()
+
+	c, err := NewAPIConverterWithConfig(srv.URL, &common.ConversionConfig{
+		APIPagination: "page",
+		APIAuthHeader: "Authorization: Bearer sesame",
+	})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	headers := c.GetHeaders(APITB)
+	if len(headers) != 2 || headers[0] != "id" || headers[1] != "name" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+	colTypes := c.GetColumnTypes(APITB)
+	if colTypes[0] != "INTEGER" || colTypes[1] != "TEXT" {
+		t.Fatalf("unexpected column types: %v", colTypes)
+	}
+
+	rows := collect(t, c)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[2][0] != int64(3) || rows[2][1] != "cay" {
+		t.Errorf("unexpected last row: %v", rows[2])
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("after") {
+		case "":
+			fmt.Fprint(w, `{"data": {"items": [{"n": 1}]}, "meta": {"next": "abc"}}`)
+		case "abc":
+			fmt.Fprint(w, `{"data": {"items": [{"n": 2}]}, "meta": {"next": null}}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewAPIConverterWithConfig(srv.URL, &common.ConversionConfig{
+		APIPagination:  "cursor",
+		APIPageParam:   "after",
+		APICursorPath:  "meta.next",
+		APIRecordsPath: "data.items",
+	})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := collect(t, c)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[1][0] != int64(2) {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+}
+
+func TestLinkPagination(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/first":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/second>; rel="next"`, srv.URL))
+			fmt.Fprint(w, `[{"v": "a"}]`)
+		case "/second":
+			fmt.Fprint(w, `[{"v": "b"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewAPIConverterWithConfig(srv.URL+"/first", &common.ConversionConfig{
+		APIPagination: "link",
+	})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := collect(t, c)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "a" || rows[1][0] != "b" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+}
+
+func TestMaxPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"n": 1}]`) // never-ending API
+	}))
+	defer srv.Close()
+
+	c, err := NewAPIConverterWithConfig(srv.URL, &common.ConversionConfig{
+		APIPagination: "page",
+		APIMaxPages:   3,
+	})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	if rows := collect(t, c); len(rows) != 3 {
+		t.Errorf("expected 3 rows, got %d", len(rows))
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	if _, err := NewAPIConverter(srv.URL); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
+
+func TestNestedValuesStoredAsJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "tags": ["x", "y"], "ok": true}]`)
+	}))
+	defer srv.Close()
+
+	c, err := NewAPIConverter(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	rows := collect(t, c)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	// Headers are sorted: id, ok, tags
+	if rows[0][1] != int64(1) {
+		t.Errorf("expected bool stored as 1, got %v", rows[0][1])
+	}
+	if rows[0][2] != `["x","y"]` {
+		t.Errorf("expected array stored as JSON text, got %v", rows[0][2])
+	}
+}
@@ -0,0 +1,214 @@
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	hamba "github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	AvroTB = "tb0"
+)
+
+func init() {
+	converters.Register("avro", &avroDriver{}, converters.Capabilities{Extensions: []string{".avro"}, MIMETypes: []string{"application/avro"}})
+}
+
+type avroDriver struct{}
+
+func (d *avroDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewAvroConverterWithConfig(source, config)
+}
+
+// AvroConverter converts Avro object container files (.avro) to a SQLite
+// table, deriving column names and types from the embedded schema and
+// streaming the records.
+type AvroConverter struct {
+	decoder  *ocf.Decoder
+	fields   []*hamba.Field // nil when the schema root is not a record
+	headers  []string
+	colTypes []string
+
+	Config common.ConversionConfig
+}
+
+// Ensure AvroConverter implements RowProvider
+var _ common.RowProvider = (*AvroConverter)(nil)
+
+// NewAvroConverter creates a new AvroConverter from an io.Reader.
+func NewAvroConverter(r io.Reader) (*AvroConverter, error) {
+	return NewAvroConverterWithConfig(r, nil)
+}
+
+// NewAvroConverterWithConfig creates a new AvroConverter from an io.Reader with optional config.
+func NewAvroConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*AvroConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{
+			TableName: AvroTB,
+		}
+	}
+	if config.TableName == "" {
+		config.TableName = AvroTB
+	}
+
+	decoder, err := ocf.NewDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open avro container: %w", err)
+	}
+
+	c := &AvroConverter{
+		decoder: decoder,
+		Config:  *config,
+	}
+
+	if record, ok := decoder.Schema().(*hamba.RecordSchema); ok {
+		c.fields = record.Fields()
+		for _, field := range c.fields {
+			c.headers = append(c.headers, field.Name())
+			c.colTypes = append(c.colTypes, sqliteTypeFor(field.Type()))
+		}
+	} else {
+		// Non-record root (e.g. a file of plain strings or longs)
+		c.headers = []string{"value"}
+		c.colTypes = []string{sqliteTypeFor(decoder.Schema())}
+	}
+
+	return c, nil
+}
+
+// sqliteTypeFor maps an Avro schema to a SQLite column type. Unions resolve to
+// the first non-null member; complex types are JSON-encoded TEXT.
+func sqliteTypeFor(schema hamba.Schema) string {
+	switch schema.Type() {
+	case hamba.Int, hamba.Long, hamba.Boolean:
+		return "INTEGER"
+	case hamba.Float, hamba.Double:
+		return "REAL"
+	case hamba.Bytes, hamba.Fixed:
+		return "BLOB"
+	case hamba.String, hamba.Enum:
+		return "TEXT"
+	case hamba.Union:
+		union := schema.(*hamba.UnionSchema)
+		for _, member := range union.Types() {
+			if member.Type() != hamba.Null {
+				return sqliteTypeFor(member)
+			}
+		}
+		return "TEXT"
+	default:
+		return "TEXT"
+	}
+}
+
+// convertValue flattens one decoded Avro value for SQLite insertion.
+func convertValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil, string, int, int32, int64, float32, float64, bool, []byte:
+		return val
+	case map[string]interface{}:
+		// Union values decode as a single-entry map keyed by branch name
+		if len(val) == 1 {
+			for key, inner := range val {
+				if key != "" && !isComplex(inner) {
+					return convertValue(inner)
+				}
+			}
+		}
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(encoded)
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(encoded)
+	}
+}
+
+// isComplex reports whether a decoded value needs JSON encoding.
+func isComplex(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// GetTableNames implements RowProvider
+func (c *AvroConverter) GetTableNames() []string {
+	return []string{c.Config.TableName}
+}
+
+// GetHeaders implements RowProvider
+func (c *AvroConverter) GetHeaders(tableName string) []string {
+	if tableName == c.Config.TableName {
+		return common.GenColumnNames(c.headers)
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *AvroConverter) GetColumnTypes(tableName string) []string {
+	if tableName == c.Config.TableName {
+		return c.colTypes
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider by streaming records from the container file.
+func (c *AvroConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	if c.decoder == nil {
+		return fmt.Errorf("avro decoder is not initialized")
+	}
+
+	for c.decoder.HasNext() {
+		if c.fields == nil {
+			var v interface{}
+			if err := c.decoder.Decode(&v); err != nil {
+				if yieldErr := yield(nil, fmt.Errorf("failed to decode avro value: %w", err)); yieldErr != nil {
+					return yieldErr
+				}
+				continue
+			}
+			if err := yield([]interface{}{convertValue(v)}, nil); err != nil {
+				return err
+			}
+		} else {
+			var m map[string]interface{}
+			if err := c.decoder.Decode(&m); err != nil {
+				if yieldErr := yield(nil, fmt.Errorf("failed to decode avro record: %w", err)); yieldErr != nil {
+					return yieldErr
+				}
+				continue
+			}
+			values := make([]interface{}, len(c.fields))
+			for i, field := range c.fields {
+				values[i] = convertValue(m[field.Name()])
+			}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return c.decoder.Error()
+}
@@ -0,0 +1,84 @@
+package avro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hamba/avro/v2/ocf"
+)
+
+const testSchema = `{
+  "type": "record",
+  "name": "person",
+  "fields": [
+    {"name": "name", "type": "string"},
+    {"name": "age", "type": "long"},
+    {"name": "score", "type": "double"},
+    {"name": "nickname", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+func TestAvroConverter(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(testSchema, &buf)
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	records := []map[string]interface{}{
+		{"name": "alice", "age": int64(30), "score": 1.5, "nickname": map[string]interface{}{"string": "al"}},
+		{"name": "bob", "age": int64(40), "score": 2.5, "nickname": nil},
+	}
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			t.Fatalf("failed to encode record: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("failed to close encoder: %v", err)
+	}
+
+	conv, err := NewAvroConverter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	headers := conv.GetHeaders(AvroTB)
+	if len(headers) != 4 || headers[0] != "name" || headers[1] != "age" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	colTypes := conv.GetColumnTypes(AvroTB)
+	expected := []string{"TEXT", "INTEGER", "REAL", "TEXT"}
+	for i, want := range expected {
+		if colTypes[i] != want {
+			t.Errorf("column %d type = %s, want %s", i, colTypes[i], want)
+		}
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), AvroTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "alice" || rows[0][1] != int64(30) {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+	if rows[0][3] != "al" {
+		t.Errorf("union value not unwrapped: %v", rows[0][3])
+	}
+	if rows[1][3] != nil {
+		t.Errorf("null union should be nil: %v", rows[1][3])
+	}
+}
@@ -0,0 +1,366 @@
+package browser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	BookmarksTB = "bookmarks"
+	VisitsTB    = "visits"
+)
+
+func init() {
+	converters.Register("browser", &browserDriver{}, converters.Capabilities{})
+}
+
+type browserDriver struct{}
+
+func (d *browserDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewBrowserConverterWithConfig(source, config)
+}
+
+// bookmarkRow holds one exported bookmark.
+type bookmarkRow struct {
+	Title     string
+	URL       string
+	Folder    string
+	DateAdded string
+}
+
+// visitRow holds one history visit.
+type visitRow struct {
+	Title     string
+	URL       string
+	VisitTime string
+}
+
+// BrowserConverter converts Chrome/Firefox bookmark exports (Netscape HTML or
+// JSON) and Takeout history JSON to "bookmarks" and "visits" tables.
+// Timestamps are normalized from WebKit/Unix epochs to ISO-8601.
+type BrowserConverter struct {
+	bookmarks []bookmarkRow
+	visits    []visitRow
+
+	Config common.ConversionConfig
+}
+
+// Ensure BrowserConverter implements RowProvider
+var _ common.RowProvider = (*BrowserConverter)(nil)
+
+// NewBrowserConverter creates a new BrowserConverter from an io.Reader.
+func NewBrowserConverter(r io.Reader) (*BrowserConverter, error) {
+	return NewBrowserConverterWithConfig(r, nil)
+}
+
+// NewBrowserConverterWithConfig creates a new BrowserConverter from an io.Reader with optional config.
+// The export format is detected from the content.
+func NewBrowserConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*BrowserConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	c := &BrowserConverter{Config: *config}
+
+	br := bufio.NewReaderSize(r, 65536)
+	peek, _ := br.Peek(64)
+	trimmed := bytes.TrimLeft(peek, " \t\r\n")
+
+	if len(trimmed) > 0 && trimmed[0] == '<' {
+		if err := c.parseNetscapeHTML(br); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if err := c.parseJSON(br); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// webkitEpochOffsetSec is the number of seconds between the WebKit epoch
+// (1601-01-01) and the Unix epoch (1970-01-01).
+const webkitEpochOffsetSec = 11644473600
+
+// webkitToISO converts a WebKit microsecond timestamp to ISO-8601.
+// time.Duration cannot represent the full span since 1601, so the
+// conversion goes through the Unix epoch.
+func webkitToISO(usec int64) string {
+	if usec <= 0 {
+		return ""
+	}
+	sec := usec/1e6 - webkitEpochOffsetSec
+	nsec := (usec % 1e6) * 1000
+	return time.Unix(sec, nsec).UTC().Format(time.RFC3339)
+}
+
+// unixToISO converts a Unix timestamp to ISO-8601, accepting seconds,
+// milliseconds or microseconds based on magnitude.
+func unixToISO(v int64) string {
+	if v <= 0 {
+		return ""
+	}
+	switch {
+	case v > 1e15: // Microseconds
+		return time.UnixMicro(v).UTC().Format(time.RFC3339)
+	case v > 1e12: // Milliseconds
+		return time.UnixMilli(v).UTC().Format(time.RFC3339)
+	default: // Seconds
+		return time.Unix(v, 0).UTC().Format(time.RFC3339)
+	}
+}
+
+var (
+	anchorRe   = regexp.MustCompile(`(?is)<a\s+([^>]*)>(.*?)</a>`)
+	hrefRe     = regexp.MustCompile(`(?i)href="([^"]*)"`)
+	addDateRe  = regexp.MustCompile(`(?i)add_date="([^"]*)"`)
+	folderRe   = regexp.MustCompile(`(?is)<h3[^>]*>(.*?)</h3>`)
+	tagStripRe = regexp.MustCompile(`<[^>]+>`)
+)
+
+// parseNetscapeHTML parses the Netscape bookmark file format used by the
+// "export bookmarks" feature of both Chrome and Firefox.
+func (c *BrowserConverter) parseNetscapeHTML(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read bookmarks HTML: %w", err)
+	}
+
+	// Track folder context by scanning sequentially for H3 (folder) and A (bookmark) tags.
+	content := string(data)
+	var folders []string
+	pos := 0
+	for pos < len(content) {
+		h3 := folderRe.FindStringSubmatchIndex(content[pos:])
+		a := anchorRe.FindStringSubmatchIndex(content[pos:])
+		dlEnd := strings.Index(strings.ToLower(content[pos:]), "</dl>")
+
+		next := -1
+		kind := ""
+		if h3 != nil {
+			next = h3[0]
+			kind = "h3"
+		}
+		if a != nil && (next == -1 || a[0] < next) {
+			next = a[0]
+			kind = "a"
+		}
+		if dlEnd != -1 && (next == -1 || dlEnd < next) {
+			next = dlEnd
+			kind = "dl"
+		}
+		if next == -1 {
+			break
+		}
+
+		switch kind {
+		case "h3":
+			folders = append(folders, strings.TrimSpace(tagStripRe.ReplaceAllString(content[pos+h3[2]:pos+h3[3]], "")))
+			pos += h3[1]
+		case "dl":
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+			pos += dlEnd + len("</dl>")
+		case "a":
+			attrs := content[pos+a[2] : pos+a[3]]
+			title := strings.TrimSpace(tagStripRe.ReplaceAllString(content[pos+a[4]:pos+a[5]], ""))
+			row := bookmarkRow{
+				Title:  title,
+				Folder: strings.Join(folders, "/"),
+			}
+			if m := hrefRe.FindStringSubmatch(attrs); m != nil {
+				row.URL = m[1]
+			}
+			if m := addDateRe.FindStringSubmatch(attrs); m != nil {
+				if v, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+					row.DateAdded = unixToISO(v)
+				}
+			}
+			c.bookmarks = append(c.bookmarks, row)
+			pos += a[1]
+		}
+	}
+	return nil
+}
+
+// chromeBookmarkNode mirrors the Chrome Bookmarks JSON structure.
+type chromeBookmarkNode struct {
+	Children  []chromeBookmarkNode `json:"children"`
+	DateAdded string               `json:"date_added"`
+	Name      string               `json:"name"`
+	Type      string               `json:"type"`
+	URL       string               `json:"url"`
+	// Firefox bookmark backup fields
+	URI       string `json:"uri"`
+	Title     string `json:"title"`
+	DateMozMs int64  `json:"dateAdded"`
+}
+
+// takeoutHistory mirrors the Takeout "BrowserHistory.json" structure.
+type takeoutHistory struct {
+	BrowserHistory []struct {
+		Title    string `json:"title"`
+		URL      string `json:"url"`
+		TimeUsec int64  `json:"time_usec"`
+	} `json:"Browser History"`
+}
+
+// parseJSON detects and parses Chrome bookmarks JSON, Firefox bookmark backup
+// JSON, or Takeout browser history JSON.
+func (c *BrowserConverter) parseJSON(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read browser JSON: %w", err)
+	}
+
+	// Takeout history
+	var history takeoutHistory
+	if err := json.Unmarshal(data, &history); err == nil && len(history.BrowserHistory) > 0 {
+		for _, h := range history.BrowserHistory {
+			c.visits = append(c.visits, visitRow{
+				Title:     h.Title,
+				URL:       h.URL,
+				VisitTime: unixToISO(h.TimeUsec),
+			})
+		}
+		return nil
+	}
+
+	// Chrome Bookmarks file
+	var chrome struct {
+		Roots map[string]chromeBookmarkNode `json:"roots"`
+	}
+	if err := json.Unmarshal(data, &chrome); err == nil && len(chrome.Roots) > 0 {
+		for rootName, node := range chrome.Roots {
+			c.walkBookmarkNode(node, rootName)
+		}
+		return nil
+	}
+
+	// Firefox bookmark backup (single root node)
+	var moz chromeBookmarkNode
+	if err := json.Unmarshal(data, &moz); err == nil && (len(moz.Children) > 0 || moz.URI != "") {
+		c.walkBookmarkNode(moz, "")
+		return nil
+	}
+
+	return fmt.Errorf("unrecognized browser export format")
+}
+
+// walkBookmarkNode recursively collects bookmark rows from Chrome/Firefox JSON trees.
+func (c *BrowserConverter) walkBookmarkNode(node chromeBookmarkNode, folder string) {
+	title := node.Name
+	if title == "" {
+		title = node.Title
+	}
+
+	url := node.URL
+	if url == "" {
+		url = node.URI
+	}
+
+	if url != "" {
+		row := bookmarkRow{
+			Title:  title,
+			URL:    url,
+			Folder: folder,
+		}
+		if node.DateAdded != "" {
+			if v, err := strconv.ParseInt(node.DateAdded, 10, 64); err == nil {
+				row.DateAdded = webkitToISO(v)
+			}
+		} else if node.DateMozMs > 0 {
+			row.DateAdded = unixToISO(node.DateMozMs)
+		}
+		c.bookmarks = append(c.bookmarks, row)
+		return
+	}
+
+	childFolder := folder
+	if title != "" {
+		if childFolder != "" {
+			childFolder += "/"
+		}
+		childFolder += title
+	}
+	for _, child := range node.Children {
+		c.walkBookmarkNode(child, childFolder)
+	}
+}
+
+// GetTableNames implements RowProvider
+func (c *BrowserConverter) GetTableNames() []string {
+	return []string{BookmarksTB, VisitsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *BrowserConverter) GetHeaders(tableName string) []string {
+	switch tableName {
+	case BookmarksTB:
+		if len(c.bookmarks) == 0 {
+			return nil
+		}
+		return []string{"title", "url", "folder", "date_added"}
+	case VisitsTB:
+		if len(c.visits) == 0 {
+			return nil
+		}
+		return []string{"title", "url", "visit_time"}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *BrowserConverter) GetColumnTypes(tableName string) []string {
+	switch tableName {
+	case BookmarksTB:
+		return []string{"TEXT", "TEXT", "TEXT", "TEXT"}
+	case VisitsTB:
+		return []string{"TEXT", "TEXT", "TEXT"}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *BrowserConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	switch tableName {
+	case BookmarksTB:
+		for _, b := range c.bookmarks {
+			values := []interface{}{b.Title, b.URL, b.Folder, b.DateAdded}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	case VisitsTB:
+		for _, v := range c.visits {
+			values := []interface{}{v.Title, v.URL, v.VisitTime}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	return nil
+}
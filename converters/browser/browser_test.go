@@ -0,0 +1,107 @@
+package browser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func collectRows(t *testing.T, c *BrowserConverter, table string) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := c.ScanRows(context.Background(), table, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestBrowserConverter_NetscapeHTML(t *testing.T) {
+	html := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3 ADD_DATE="1700000000">Work</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com/" ADD_DATE="1700000100">Example</A>
+    </DL><p>
+    <DT><A HREF="https://golang.org/" ADD_DATE="1700000200">Go</A>
+</DL><p>
+`
+	conv, err := NewBrowserConverter(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := collectRows(t, conv, BookmarksTB)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(rows))
+	}
+	if rows[0][0] != "Example" || rows[0][1] != "https://example.com/" {
+		t.Errorf("unexpected first bookmark: %v", rows[0])
+	}
+	if rows[0][2] != "Work" {
+		t.Errorf("unexpected folder: %v", rows[0][2])
+	}
+	if !strings.HasPrefix(rows[0][3].(string), "2023-11-") {
+		t.Errorf("unexpected date_added: %v", rows[0][3])
+	}
+	if rows[1][2] != "" {
+		t.Errorf("second bookmark should be outside folder, got folder %v", rows[1][2])
+	}
+}
+
+func TestBrowserConverter_ChromeJSON(t *testing.T) {
+	jsonData := `{
+  "roots": {
+    "bookmark_bar": {
+      "children": [
+        {"date_added": "13345000000000000", "name": "Example", "type": "url", "url": "https://example.com/"}
+      ],
+      "name": "Bookmarks bar",
+      "type": "folder"
+    }
+  },
+  "version": 1
+}`
+	conv, err := NewBrowserConverter(strings.NewReader(jsonData))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := collectRows(t, conv, BookmarksTB)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(rows))
+	}
+	if rows[0][2] != "bookmark_bar/Bookmarks bar" {
+		t.Errorf("unexpected folder: %v", rows[0][2])
+	}
+	// 13345000000000000 usec after 1601 lands in 2023
+	if !strings.HasPrefix(rows[0][3].(string), "2023-") {
+		t.Errorf("unexpected webkit date conversion: %v", rows[0][3])
+	}
+}
+
+func TestBrowserConverter_TakeoutHistory(t *testing.T) {
+	jsonData := `{
+  "Browser History": [
+    {"title": "Example", "url": "https://example.com/", "time_usec": 1700000000000000}
+  ]
+}`
+	conv, err := NewBrowserConverter(strings.NewReader(jsonData))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := collectRows(t, conv, VisitsTB)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 visit, got %d", len(rows))
+	}
+	if !strings.HasPrefix(rows[0][2].(string), "2023-11-") {
+		t.Errorf("unexpected visit_time: %v", rows[0][2])
+	}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ArchiveEntry holds the per-member metadata shared by the archive listing
+// converters (rar, 7z). The columns mirror the zip converter's base
+// file_list schema so mixed archive collections index uniformly.
+type ArchiveEntry struct {
+	Name             string
+	Comment          string
+	Modified         time.Time
+	UncompressedSize uint64
+	CompressedSize   uint64
+	CRC32            uint32
+	IsDir            bool
+}
+
+// ArchiveListHeaders returns the raw file_list column names.
+func ArchiveListHeaders() []string {
+	return []string{
+		"name",
+		"comment",
+		"modified",
+		"uncompressed_size",
+		"compressed_size",
+		"crc32",
+		"is_dir",
+	}
+}
+
+// ArchiveListColumnTypes returns the column types matching ArchiveListHeaders.
+func ArchiveListColumnTypes() []string {
+	return []string{"TEXT", "TEXT", "TEXT", "INTEGER", "INTEGER", "INTEGER", "INTEGER"}
+}
+
+// ListValues renders the entry as a ScanRows row.
+func (f ArchiveEntry) ListValues() []interface{} {
+	isDir := "false"
+	if f.IsDir {
+		isDir = "true"
+	}
+	return []interface{}{
+		f.Name,
+		f.Comment,
+		f.Modified.Format(time.RFC3339),
+		f.UncompressedSize,
+		f.CompressedSize,
+		f.CRC32,
+		isDir,
+	}
+}
+
+// ListStrings renders the entry as string values for SQL output.
+func (f ArchiveEntry) ListStrings() []string {
+	isDir := "false"
+	if f.IsDir {
+		isDir = "true"
+	}
+	return []string{
+		f.Name,
+		f.Comment,
+		f.Modified.Format(time.RFC3339),
+		fmt.Sprintf("%d", f.UncompressedSize),
+		fmt.Sprintf("%d", f.CompressedSize),
+		fmt.Sprintf("%d", f.CRC32),
+		isDir,
+	}
+}
+
+// WriteArchiveList streams a file_list table for the given entries.
+func WriteArchiveList(ctx context.Context, writer io.Writer, files []ArchiveEntry, rowsPerInsert int) error {
+	sqlWriter := NewSQLWriter(writer, rowsPerInsert)
+	if err := sqlWriter.BeginTable("file_list", GenColumnNames(ArchiveListHeaders()), ArchiveListColumnTypes()); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := sqlWriter.WriteStringRow(f.ListStrings()); err != nil {
+			return err
+		}
+		// Check cancel
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+
+	return sqlWriter.EndTable()
+}
@@ -7,12 +7,56 @@ import (
 // ConversionConfig stores configuration options for the conversion process.
 type ConversionConfig struct {
 	Delimiter               rune   // Delimiter used for CSV/text parsing
+	DelimiterString         string // Multi-character delimiter (e.g. "||"); selects a plain line splitter without quote handling
+	DelimiterRegex          string // Regular expression that splits each line into fields (for csv)
 	TableName               string // Name of the table
 	AdvancedHeaderDetection bool
-	Verbose                 bool   // Enable detailed logging
-	InputPath               string // Path to the input file or directory
-	ResumePath              string // Path to resume processing from (for filesystem)
-	ScanTimeout             string // Duration string (e.g. "20s") for timeout
+	Verbose                 bool     // Enable detailed logging
+	InputPath               string   // Path to the input file or directory
+	ResumePath              string   // Path to resume processing from (for filesystem)
+	ScanTimeout             string   // Duration string (e.g. "20s") for timeout
+	ProtoDescriptor         string   // Path to a compiled FileDescriptorSet (for protobuf)
+	ProtoMessage            string   // Fully qualified message name (for protobuf)
+	MediaMetadata           bool     // Extract EXIF/ID3 metadata (for filesystem)
+	HashAlgorithm           string   // "sha256" or "md5" content hash column (for filesystem)
+	FollowSymlinks          bool     // Descend into symlinked directories (for filesystem)
+	RowsPerInsert           int      // Rows per multi-row INSERT in SQL output (0 = DefaultRowsPerInsert)
+	PreserveNames           bool     // Quote identifiers to keep original header names instead of mangling them
+	FlattenDepth            int      // Flatten nested JSON objects into underscored columns up to this depth (0 = off)
+	ExplodeArrays           bool     // Turn nested arrays of objects into child tables with a parent_id FK (for json)
+	SchemaSample            int      // JSON records pre-scanned to union keys (0 = first record only, -1 = all)
+	LazyQuotes              bool     // Tolerate bare quotes inside CSV fields
+	CommentPrefix           string   // Lines starting with this character are skipped (for csv)
+	SkipLines               int      // Leading lines discarded before parsing (for csv)
+	TrimBOM                 bool     // Strip a leading UTF-8 byte order mark (for csv)
+	Recover                 bool     // Report unparseable CSV lines as row errors and resync at the next newline
+	NoHeader                bool     // Treat the first row as data; columns are named cl0..clN (for csv)
+	ColumnNames             []string // Explicit column names when NoHeader is set
+	QuoteChar               string   // Field quote character, default double quote; e.g. "'" for single-quoted exports (for csv)
+	EscapeStyle             string   // Quote escaping inside quoted fields: "doubled" (default) or "backslash" (for csv)
+	NoQuoting               bool     // Treat quote characters as literal text; fields split on the delimiter only (for csv)
+	ExtractKeyValue         bool     // Extract <dl> lists and label/value grids as key/value tables (for html)
+	ExtractArticle          bool     // Extract the main article content into an articles table: title, byline, published, text (for html)
+	LinePattern             string   // Regex with named groups; each matching line becomes a row with one column per group (for txt)
+	KeepUnparsed            bool     // Route lines that don't match LinePattern to an "unparsed" table
+	RecordSeparator         string   // Line that ends a multi-line record; "blank" splits on empty lines (for txt)
+	ZipPassword             string   // Password for reading member contents of encrypted archives (for zip)
+	Sheets                  []string // Sheet names to import, empty means all (for excel)
+	RawFormulas             bool     // Emit formula text instead of cached results for formula cells (for excel)
+	CellLinks               bool     // Add <col>_link columns with per-cell hyperlink targets (for excel)
+	CellNotes               bool     // Add <col>_note columns with per-cell comments (for excel)
+	Dates                   string   // Convert date cells: "text" for ISO-8601 strings, "unix" for epoch-second integers (for excel)
+	InlineContent           int64    // Store file or member contents up to this many bytes as a BLOB content column (for filesystem, zip)
+	BlobFields              []string // Field names whose base64 text is decoded into BLOB values (for json)
+	Locale                  string   // Locale tag like "de_DE" driving number and date parsing of text values (for csv)
+	APIPagination           string   // Pagination strategy: "page", "cursor" or "link"; empty fetches a single page (for api)
+	APIPageParam            string   // Query parameter carrying the page number or cursor, default "page" (for api)
+	APICursorPath           string   // Dot path to the next-page cursor in each response (for api)
+	APIRecordsPath          string   // Dot path to the record array in each response; empty expects an array root (for api)
+	APIAuthHeader           string   // "Name: value" header added to every request, e.g. "Authorization: Bearer ..." (for api)
+	APIMaxPages             int      // Pages fetched before stopping; 0 means until exhausted (for api)
+	CrawlPages              int      // Follow rel=next pagination links, importing up to this many pages in total; 0 or 1 reads only the input page (for html)
+	FetchSitemaps           bool     // Fetch the child sitemaps of a sitemap index and import their URLs (for sitemap)
 }
 
 // DetectDelimiter attempts to detect the delimiter from a raw line of text.
@@ -38,6 +82,82 @@ func DetectDelimiter(line string) rune {
 	return winner
 }
 
+// delimiterSampleLines is how many lines DetectDelimiterSample scores.
+const delimiterSampleLines = 10
+
+// countQuotedFields counts delimiter-separated fields in a line, treating
+// double-quoted sections as opaque so embedded delimiters don't count.
+func countQuotedFields(line string, delim rune) int {
+	count := 1
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == delim && !inQuotes:
+			count++
+		}
+	}
+	return count
+}
+
+// DetectDelimiterSample detects the delimiter from a multi-line sample.
+// Unlike DetectDelimiter it respects quoted sections and scores candidates
+// by column-count consistency across the first lines, so a header with
+// commas inside quotes no longer hides a semicolon-delimited file. It
+// falls back to comma when no candidate splits the sample.
+func DetectDelimiterSample(sample string) rune {
+	if sample == "" {
+		return ','
+	}
+
+	// The sample usually ends mid-line; drop the final partial line
+	allLines := strings.Split(strings.ReplaceAll(sample, "\r\n", "\n"), "\n")
+	if len(allLines) > 1 && !strings.HasSuffix(sample, "\n") {
+		allLines = allLines[:len(allLines)-1]
+	}
+	var lines []string
+	for _, line := range allLines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == delimiterSampleLines {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return ','
+	}
+
+	delimiters := []rune{',', '\t', ';', '|'}
+	bestScore := 0
+	winner := ','
+	for _, delim := range delimiters {
+		// Modal column count and how many lines agree with it
+		counts := make(map[int]int)
+		for _, line := range lines {
+			counts[countQuotedFields(line, delim)]++
+		}
+		modal, agree := 0, 0
+		for count, freq := range counts {
+			if freq > agree || (freq == agree && count > modal) {
+				modal, agree = count, freq
+			}
+		}
+		if modal < 2 {
+			continue // Delimiter doesn't split the sample at all
+		}
+		// Consistency dominates; column count breaks ties
+		score := agree*1000 + modal
+		if score > bestScore {
+			bestScore = score
+			winner = delim
+		}
+	}
+	return winner
+}
+
 // ColumnCount calculates the number of columns based on a line and delimiter.
 // It assumes the delimiter splits the line directly (ignoring quotes for estimation).
 func ColumnCount(line string, delimiter rune) int {
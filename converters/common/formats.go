@@ -0,0 +1,60 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+// csvFormatPatterns maps value shapes seen in text sources onto
+// spreadsheet number-format codes, so the _mksqlite_formats table speaks
+// one vocabulary regardless of where the column came from. First match
+// wins.
+var csvFormatPatterns = []struct {
+	re     *regexp.Regexp
+	format string
+}{
+	{regexp.MustCompile(`^-?\d{1,3}(,\d{3})+\.\d+$`), "#,##0.00"},
+	{regexp.MustCompile(`^-?\d{1,3}(,\d{3})+$`), "#,##0"},
+	{regexp.MustCompile(`^-?\d+\.\d{2}$`), "0.00"},
+	{regexp.MustCompile(`^-?\d+(\.\d+)?%$`), "0%"},
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}`), "yyyy-mm-dd hh:mm:ss"},
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "yyyy-mm-dd"},
+	{regexp.MustCompile(`^\d{1,2}/\d{1,2}/\d{4}$`), "m/d/yyyy"},
+	{regexp.MustCompile(`^\d{1,2}\.\d{1,2}\.\d{4}$`), "d.m.yyyy"},
+	{regexp.MustCompile(`^\d{1,2}:\d{2}:\d{2}$`), "hh:mm:ss"},
+}
+
+// detectFormat reports the format code a single value renders as, or ""
+// for values without a recognizable pattern.
+func detectFormat(value string) string {
+	for _, p := range csvFormatPatterns {
+		if p.re.MatchString(value) {
+			return p.format
+		}
+	}
+	return ""
+}
+
+// DetectColumnFormats reports, per column, the format code every
+// non-empty sampled value of that column shares; columns whose values
+// disagree or match nothing report "". The result backs GetColumnFormats
+// for text sources, which have no declared formats to read.
+func DetectColumnFormats(rows [][]string, columnCount int) []string {
+	formats := make([]string, columnCount)
+	seen := make([]bool, columnCount)
+	for _, row := range rows {
+		for i := 0; i < columnCount && i < len(row); i++ {
+			value := strings.TrimSpace(row[i])
+			if value == "" {
+				continue
+			}
+			format := detectFormat(value)
+			if !seen[i] {
+				formats[i], seen[i] = format, true
+			} else if formats[i] != format {
+				formats[i] = ""
+			}
+		}
+	}
+	return formats
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectColumnFormats(t *testing.T) {
+	rows := [][]string{
+		{"widget", "1,234.50", "2023-03-15", "85%", "x"},
+		{"gadget", "22,100.00", "2023-04-01", "7%", "2023-04-01"},
+		{"", "9,990.00", "2023-05-20", "12.5%", "1,000"},
+	}
+	got := DetectColumnFormats(rows, 5)
+	want := []string{"", "#,##0.00", "yyyy-mm-dd", "0%", ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectColumnFormats = %v, want %v", got, want)
+	}
+
+	// The last non-grouped amount breaks the column's pattern
+	rows[2][1] = "999"
+	if got := DetectColumnFormats(rows, 5); got[1] != "" {
+		t.Errorf("expected mixed column to report no format, got %q", got[1])
+	}
+
+	tests := map[string]string{
+		"1,234":               "#,##0",
+		"3.50":                "0.00",
+		"12/31/2023":          "m/d/yyyy",
+		"31.12.2023":          "d.m.yyyy",
+		"2023-03-15 10:30:00": "yyyy-mm-dd hh:mm:ss",
+		"10:30:00":            "hh:mm:ss",
+		"plain":               "",
+		"1234":                "",
+	}
+	for value, want := range tests {
+		if got := detectFormat(value); got != want {
+			t.Errorf("detectFormat(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
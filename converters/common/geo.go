@@ -0,0 +1,43 @@
+package common
+
+// BBoxColumns are the bounding box column names appended by the geo
+// converters (geojson, kml) for fast spatial filtering.
+var BBoxColumns = []string{"min_lon", "min_lat", "max_lon", "max_lat"}
+
+// BBox accumulates a bounding box over a set of coordinates.
+type BBox struct {
+	MinLon, MinLat float64
+	MaxLon, MaxLat float64
+	set            bool
+}
+
+// Extend grows the bounding box to include the given coordinate.
+func (b *BBox) Extend(lon, lat float64) {
+	if !b.set {
+		b.MinLon, b.MaxLon = lon, lon
+		b.MinLat, b.MaxLat = lat, lat
+		b.set = true
+		return
+	}
+	if lon < b.MinLon {
+		b.MinLon = lon
+	}
+	if lon > b.MaxLon {
+		b.MaxLon = lon
+	}
+	if lat < b.MinLat {
+		b.MinLat = lat
+	}
+	if lat > b.MaxLat {
+		b.MaxLat = lat
+	}
+}
+
+// Row returns the bounding box as SQLite values in BBoxColumns order,
+// or NULLs if no coordinates were seen.
+func (b *BBox) Row() []interface{} {
+	if !b.set {
+		return []interface{}{nil, nil, nil, nil}
+	}
+	return []interface{}{b.MinLon, b.MinLat, b.MaxLon, b.MaxLat}
+}
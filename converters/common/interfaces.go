@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"io"
+	"io/fs"
 )
 
 // Request represents the minimal request structure needed for file conversion
@@ -29,9 +30,60 @@ type RowProvider interface {
 	ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error
 }
 
+// RawHeaderProvider is an optional interface for RowProviders that retain
+// the original header text before sanitization. When implemented, the
+// import engine records the original→sanitized mapping in a
+// _mksqlite_columns metadata table.
+type RawHeaderProvider interface {
+	GetRawHeaders(tableName string) []string
+}
+
+// GrowingSchemaProvider is an optional interface for RowProviders whose
+// header set can grow while rows stream (e.g. JSON schema union mode).
+// The import engine polls CurrentHeaders after each row and adds any new
+// columns to the table via ALTER TABLE.
+type GrowingSchemaProvider interface {
+	CurrentHeaders(tableName string) []string
+}
+
+// ColumnFormatProvider is an optional interface for RowProviders that know
+// how the source rendered each column (Excel number format codes, patterns
+// detected in text samples). When implemented and ImportOptions.Formats is
+// set, the import engine records the strings in a _mksqlite_formats
+// metadata table; "" entries mean no known format.
+type ColumnFormatProvider interface {
+	GetColumnFormats(tableName string) []string
+}
+
+// PipelinedProvider is an optional interface for RowProviders that already
+// decouple parsing from yielding with their own goroutine and bounded
+// buffer (csv, txt, json). The engine skips its generic scanning pipeline
+// for them to avoid double buffering.
+type PipelinedProvider interface {
+	PipelinesScans() bool
+}
+
+// BatchProvider is an optional interface for RowProviders that can deliver
+// rows as columnar batches, indexed cols[column][row]. The import engine
+// binds each batch with a multi-row prepared statement instead of one Exec
+// per row, but only when no per-row option (filters, transforms, dedupe,
+// error tolerance) is requested.
+type BatchProvider interface {
+	ScanBatches(ctx context.Context, tableName string, yield func(cols [][]interface{}) error) error
+}
+
 // Driver defines the interface that must be implemented by each converter driver.
 type Driver interface {
 	// Open returns a new RowProvider instance that reads from the given source.
 	// The returned RowProvider should also implement StreamConverter if SQL export is supported.
 	Open(source io.Reader, config *ConversionConfig) (RowProvider, error)
 }
+
+// FSDriver is an optional interface for Drivers that can read their input
+// out of an fs.FS tree (embed.FS, fstest.MapFS, a zip archive, ...) instead
+// of an io.Reader or an on-disk path. Embedding applications reach it
+// through converters.OpenFS; drivers without FS support simply don't
+// implement it.
+type FSDriver interface {
+	OpenFS(fsys fs.FS, config *ConversionConfig) (RowProvider, error)
+}
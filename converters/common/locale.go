@@ -0,0 +1,156 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale describes how a locale writes numbers and dates, so a German CSV's
+// "1.234,56" imports as 1234.56 instead of text and "31.12.2023" normalizes
+// to "2023-12-31". Values already in machine format keep parsing: the
+// locale conventions are tried first and plain strconv parsing second.
+type Locale struct {
+	name     string
+	decimal  rune // decimal separator
+	group    rune // digit group separator
+	dayFirst bool // dates read day before month
+	intPat   *regexp.Regexp
+	numPat   *regexp.Regexp
+}
+
+// commaDecimalLanguages write decimals with a comma and group digits with
+// a period (or space), the dominant convention across continental Europe
+// and South America.
+var commaDecimalLanguages = map[string]bool{
+	"cs": true, "da": true, "de": true, "el": true, "es": true,
+	"fi": true, "fr": true, "hu": true, "id": true, "it": true,
+	"nb": true, "nl": true, "no": true, "pl": true, "pt": true,
+	"ro": true, "ru": true, "sv": true, "tr": true, "uk": true,
+}
+
+// monthFirstRegions read dates month before day, against the worldwide
+// day-first default.
+var monthFirstRegions = map[string]bool{"US": true, "PH": true}
+
+var localeTagPattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Za-z]{2})?$`)
+
+// ParseLocale resolves a locale tag like "de_DE" (or "de-DE") into its
+// number and date conventions. An empty tag returns a nil Locale, meaning
+// plain strconv parsing.
+func ParseLocale(tag string) (*Locale, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	normalized := strings.ReplaceAll(tag, "-", "_")
+	lang, region, _ := strings.Cut(normalized, "_")
+	lang = strings.ToLower(lang)
+	region = strings.ToUpper(region)
+	check := lang
+	if region != "" {
+		check += "_" + region
+	}
+	if !localeTagPattern.MatchString(check) {
+		return nil, fmt.Errorf("invalid locale %q (want a tag like de_DE)", tag)
+	}
+
+	loc := &Locale{name: tag, decimal: '.', group: ',', dayFirst: true}
+	if commaDecimalLanguages[lang] {
+		loc.decimal = ','
+		loc.group = '.'
+	}
+	if monthFirstRegions[region] || (lang == "en" && region == "") {
+		loc.dayFirst = false
+	}
+
+	group := regexp.QuoteMeta(string(loc.group))
+	decimal := regexp.QuoteMeta(string(loc.decimal))
+	// Digits with optional three-digit grouping, e.g. 1.234.567 in de_DE
+	loc.intPat = regexp.MustCompile(`^-?(?:\d+|\d{1,3}(?:` + group + `\d{3})+)$`)
+	loc.numPat = regexp.MustCompile(`^-?(?:\d+|\d{1,3}(?:` + group + `\d{3})+)(?:` + decimal + `\d+)?$`)
+	return loc, nil
+}
+
+// normalizeNumber strips group separators and rewrites the decimal
+// separator to a period so strconv can take over.
+func (l *Locale) normalizeNumber(s string) string {
+	s = strings.ReplaceAll(s, string(l.group), "")
+	if l.decimal != '.' {
+		s = strings.ReplaceAll(s, string(l.decimal), ".")
+	}
+	return s
+}
+
+// ParseInt parses an integer written with the locale's digit grouping,
+// falling back to plain strconv for values already in machine format.
+func (l *Locale) ParseInt(s string) (int64, error) {
+	if l != nil && l.intPat.MatchString(s) {
+		return strconv.ParseInt(l.normalizeNumber(s), 10, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// ParseFloat parses a number written with the locale's grouping and
+// decimal separator, falling back to plain strconv.
+func (l *Locale) ParseFloat(s string) (float64, error) {
+	if l != nil && l.numPat.MatchString(s) {
+		return strconv.ParseFloat(l.normalizeNumber(s), 64)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// dateLayouts are tried in order against the whole trimmed value; the 02
+// and 01 verbs swap when the locale is month-first.
+var dateSeparators = []string{".", "/", "-"}
+
+// NormalizeDate rewrites a date written in the locale's order to ISO-8601
+// (YYYY-MM-DD) and reports whether the value was such a date.
+func (l *Locale) NormalizeDate(s string) (string, bool) {
+	if l == nil {
+		return s, false
+	}
+	for _, sep := range dateSeparators {
+		// The one-digit verbs also accept two-digit values, so both
+		// 31.12.2023 and 1.2.2023 parse
+		layout := "2" + sep + "1" + sep + "2006"
+		if !l.dayFirst {
+			layout = "1" + sep + "2" + sep + "2006"
+		}
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return s, false
+}
+
+// CoerceValue converts a string value into what its column type expects
+// under the locale: grouped numbers become int64/float64 for INTEGER and
+// REAL columns, and locale-ordered dates in other columns normalize to
+// ISO-8601. Values that don't match the conventions pass through as-is.
+func (l *Locale) CoerceValue(value interface{}, colType string) interface{} {
+	if l == nil {
+		return value
+	}
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	trimmed := strings.TrimSpace(s)
+	switch strings.ToUpper(colType) {
+	case "INTEGER":
+		if n, err := l.ParseInt(trimmed); err == nil {
+			return n
+		}
+	case "REAL":
+		if f, err := l.ParseFloat(trimmed); err == nil {
+			return f
+		}
+	default:
+		if iso, ok := l.NormalizeDate(trimmed); ok {
+			return iso
+		}
+	}
+	return value
+}
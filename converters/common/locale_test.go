@@ -0,0 +1,101 @@
+package common
+
+import "testing"
+
+func TestParseLocale(t *testing.T) {
+	if loc, err := ParseLocale(""); err != nil || loc != nil {
+		t.Errorf("ParseLocale(\"\") = %v, %v; want nil, nil", loc, err)
+	}
+	if _, err := ParseLocale("not a locale"); err == nil {
+		t.Error("Expected an error for a malformed locale tag")
+	}
+	if loc, err := ParseLocale("de-DE"); err != nil || loc == nil {
+		t.Errorf("Expected de-DE to parse like de_DE, got %v, %v", loc, err)
+	}
+}
+
+func TestLocaleParseNumbers(t *testing.T) {
+	de, err := ParseLocale("de_DE")
+	if err != nil {
+		t.Fatalf("ParseLocale(de_DE) failed: %v", err)
+	}
+	enUS, err := ParseLocale("en_US")
+	if err != nil {
+		t.Fatalf("ParseLocale(en_US) failed: %v", err)
+	}
+
+	floats := []struct {
+		loc      *Locale
+		in       string
+		expected float64
+	}{
+		{de, "1.234,56", 1234.56},
+		{de, "-1.234.567,8", -1234567.8},
+		{de, "3,14", 3.14},
+		{de, "3.14", 3.14}, // machine format still parses
+		{de, "1234,56", 1234.56},
+		{enUS, "1,234.56", 1234.56},
+		{nil, "1234.56", 1234.56},
+	}
+	for _, tt := range floats {
+		got, err := tt.loc.ParseFloat(tt.in)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseFloat(%q) = %v, %v; want %v", tt.in, got, err, tt.expected)
+		}
+	}
+
+	if _, err := de.ParseFloat("12.34,56"); err == nil {
+		t.Error("Expected mis-grouped 12.34,56 to fail under de_DE")
+	}
+
+	if n, err := de.ParseInt("1.234.567"); err != nil || n != 1234567 {
+		t.Errorf("ParseInt(1.234.567) = %v, %v; want 1234567", n, err)
+	}
+	if _, err := de.ParseInt("1.234,56"); err == nil {
+		t.Error("Expected a decimal to fail integer parsing")
+	}
+}
+
+func TestLocaleNormalizeDate(t *testing.T) {
+	de, _ := ParseLocale("de_DE")
+	enUS, _ := ParseLocale("en_US")
+
+	tests := []struct {
+		loc      *Locale
+		in       string
+		expected string
+		ok       bool
+	}{
+		{de, "31.12.2023", "2023-12-31", true},
+		{de, "1.2.2023", "2023-02-01", true},
+		{de, "31/12/2023", "2023-12-31", true},
+		{enUS, "12/31/2023", "2023-12-31", true},
+		{de, "2023-12-31", "2023-12-31", false}, // already ISO, no match
+		{de, "not a date", "not a date", false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.loc.NormalizeDate(tt.in)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("NormalizeDate(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestInferColumnTypesLocale(t *testing.T) {
+	rows := [][]string{
+		{"1.234,56", "1.234", "text"},
+		{"7,5", "567", "more"},
+	}
+	de, _ := ParseLocale("de_DE")
+
+	types := InferColumnTypesLocale(rows, 3, de)
+	if types[0] != "REAL" || types[1] != "INTEGER" || types[2] != "TEXT" {
+		t.Errorf("Unexpected de_DE types: %v", types)
+	}
+
+	// Without the locale the grouped values demote both columns to TEXT
+	types = InferColumnTypes(rows, 3)
+	if types[0] != "TEXT" {
+		t.Errorf("Expected TEXT for grouped values without a locale, got %v", types[0])
+	}
+}
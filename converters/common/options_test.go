@@ -28,6 +28,31 @@ func TestDetectDelimiter(t *testing.T) {
 	}
 }
 
+func TestDetectDelimiterSample(t *testing.T) {
+	tests := []struct {
+		name     string
+		sample   string
+		expected rune
+	}{
+		{"Empty", "", ','},
+		{"SingleLineComma", "a,b,c", ','},
+		{"QuotedCommasSemicolonFile", "\"name, full\";\"address, street\";age\nada;main st;36\nbob;side st;40\n", ';'},
+		{"ConsistencyBeatsHeaderCount", "a,b;c,d\n1;2\n3;4\n5;6\n", ';'},
+		{"TabFile", "a\tb\tc\n1\t2\t3\n", '\t'},
+		{"PartialLastLineIgnored", "a|b|c\n1|2|3\n4|5", '|'},
+		{"NoDelimiter", "abc\ndef\n", ','},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectDelimiterSample(tt.sample)
+			if got != tt.expected {
+				t.Errorf("DetectDelimiterSample(%q) = %q, want %q", tt.sample, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestColumnCount(t *testing.T) {
 	tests := []struct {
 		name      string
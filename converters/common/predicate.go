@@ -0,0 +1,381 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Predicate is a compiled SQL-like filter expression evaluated against raw
+// row values during import, e.g. "status != 'deleted' AND amount > 0".
+// Supported syntax: comparisons (=, ==, !=, <>, <, <=, >, >=), IS NULL and
+// IS NOT NULL, combined with AND, OR, NOT and parentheses. String literals
+// use single quotes with ” escaping a quote; bare words are column names.
+type Predicate struct {
+	expr string
+	root predNode
+}
+
+// ParsePredicate compiles a filter expression.
+func ParsePredicate(expr string) (*Predicate, error) {
+	tokens, err := tokenizePredicate(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &predParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in predicate", p.tokens[p.pos].text)
+	}
+	return &Predicate{expr: expr, root: root}, nil
+}
+
+// Eval reports whether a row matches. Columns maps column names to the raw
+// row values; a column missing from the map evaluates as NULL.
+func (p *Predicate) Eval(columns map[string]interface{}) bool {
+	return p.root.eval(columns)
+}
+
+// String returns the expression the predicate was compiled from.
+func (p *Predicate) String() string {
+	return p.expr
+}
+
+type predNode interface {
+	eval(columns map[string]interface{}) bool
+}
+
+type binaryNode struct {
+	and         bool
+	left, right predNode
+}
+
+func (n *binaryNode) eval(columns map[string]interface{}) bool {
+	if n.and {
+		return n.left.eval(columns) && n.right.eval(columns)
+	}
+	return n.left.eval(columns) || n.right.eval(columns)
+}
+
+type notNode struct {
+	child predNode
+}
+
+func (n *notNode) eval(columns map[string]interface{}) bool {
+	return !n.child.eval(columns)
+}
+
+type compareNode struct {
+	op          string
+	left, right predOperand
+}
+
+func (n *compareNode) eval(columns map[string]interface{}) bool {
+	lv, lok := n.left.value(columns)
+	rv, rok := n.right.value(columns)
+
+	switch n.op {
+	case "is null":
+		return !lok
+	case "is not null":
+		return lok
+	}
+
+	// NULL never compares equal to anything, mirroring SQL semantics.
+	if !lok || !rok {
+		return false
+	}
+
+	if lf, lerr := toFloat(lv); lerr == nil {
+		if rf, rerr := toFloat(rv); rerr == nil {
+			return compareFloats(n.op, lf, rf)
+		}
+	}
+	return compareStrings(n.op, fmt.Sprintf("%v", lv), fmt.Sprintf("%v", rv))
+}
+
+func compareFloats(op string, l, r float64) bool {
+	switch op {
+	case "=":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+func compareStrings(op string, l, r string) bool {
+	switch op {
+	case "=":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case float32:
+		return float64(t), nil
+	case int:
+		return float64(t), nil
+	case int64:
+		return float64(t), nil
+	case uint64:
+		return float64(t), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(t), 64)
+	}
+	return 0, fmt.Errorf("not numeric: %v", v)
+}
+
+// predOperand is one side of a comparison: a column reference or a literal.
+type predOperand struct {
+	column  string
+	literal interface{}
+}
+
+// value resolves the operand; ok is false when it evaluates to NULL.
+func (o predOperand) value(columns map[string]interface{}) (interface{}, bool) {
+	if o.column != "" {
+		v, ok := columns[o.column]
+		if !ok || v == nil {
+			return nil, false
+		}
+		return v, true
+	}
+	return o.literal, o.literal != nil
+}
+
+type predToken struct {
+	kind string // "ident", "string", "number", "op", "lparen", "rparen"
+	text string
+}
+
+func tokenizePredicate(expr string) ([]predToken, error) {
+	var tokens []predToken
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(':
+			tokens = append(tokens, predToken{"lparen", "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, predToken{"rparen", ")"})
+			i++
+		case r == '\'':
+			var b strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						b.WriteRune('\'')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string in predicate: %s", expr)
+			}
+			tokens = append(tokens, predToken{"string", b.String()})
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+				i++
+			}
+			i++
+			switch op {
+			case "==":
+				op = "="
+			case "<>":
+				op = "!="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' in predicate: %s", expr)
+			}
+			tokens = append(tokens, predToken{"op", op})
+		case r == '-' || r == '.' || (r >= '0' && r <= '9'):
+			start := i
+			i++
+			for i < len(runes) && (runes[i] == '.' || (runes[i] >= '0' && runes[i] <= '9')) {
+				i++
+			}
+			text := string(runes[start:i])
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q in predicate", text)
+			}
+			tokens = append(tokens, predToken{"number", text})
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || (runes[i] >= 'a' && runes[i] <= 'z') ||
+				(runes[i] >= 'A' && runes[i] <= 'Z') || (runes[i] >= '0' && runes[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, predToken{"ident", string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q in predicate", string(r))
+		}
+	}
+	return tokens, nil
+}
+
+type predParser struct {
+	tokens []predToken
+	pos    int
+}
+
+func (p *predParser) peekKeyword(word string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == "ident" &&
+		strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+func (p *predParser) parseOr() (predNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *predParser) parseAnd() (predNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *predParser) parseNot() (predNode, error) {
+	if p.peekKeyword("NOT") {
+		p.pos++
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *predParser) parsePrimary() (predNode, error) {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == "lparen" {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != "rparen" {
+			return nil, fmt.Errorf("missing closing parenthesis in predicate")
+		}
+		p.pos++
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *predParser) parseComparison() (predNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peekKeyword("IS") {
+		p.pos++
+		op := "is null"
+		if p.peekKeyword("NOT") {
+			p.pos++
+			op = "is not null"
+		}
+		if !p.peekKeyword("NULL") {
+			return nil, fmt.Errorf("expected NULL after IS in predicate")
+		}
+		p.pos++
+		return &compareNode{op: op, left: left}, nil
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != "op" {
+		return nil, fmt.Errorf("expected comparison operator in predicate")
+	}
+	op := p.tokens[p.pos].text
+	p.pos++
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *predParser) parseOperand() (predOperand, error) {
+	if p.pos >= len(p.tokens) {
+		return predOperand{}, fmt.Errorf("unexpected end of predicate")
+	}
+	tok := p.tokens[p.pos]
+	switch tok.kind {
+	case "string":
+		p.pos++
+		return predOperand{literal: tok.text}, nil
+	case "number":
+		p.pos++
+		f, _ := strconv.ParseFloat(tok.text, 64)
+		return predOperand{literal: f}, nil
+	case "ident":
+		if strings.EqualFold(tok.text, "NULL") {
+			p.pos++
+			return predOperand{}, nil
+		}
+		p.pos++
+		return predOperand{column: tok.text}, nil
+	}
+	return predOperand{}, fmt.Errorf("unexpected token %q in predicate", tok.text)
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestPredicateEval(t *testing.T) {
+	row := map[string]interface{}{
+		"status": "active",
+		"amount": "42.5",
+		"count":  int64(3),
+		"note":   nil,
+	}
+
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{"status = 'active'", true},
+		{"status == 'active'", true},
+		{"status != 'deleted'", true},
+		{"status <> 'active'", false},
+		{"amount > 0", true},
+		{"amount > 100", false},
+		{"amount <= 42.5", true},
+		{"count >= 3", true},
+		{"status != 'deleted' AND amount > 0", true},
+		{"status = 'deleted' OR count < 10", true},
+		{"status = 'deleted' OR count > 10", false},
+		{"NOT status = 'deleted'", true},
+		{"(status = 'deleted' OR count = 3) AND amount > 40", true},
+		{"note IS NULL", true},
+		{"note IS NOT NULL", false},
+		{"status IS NOT NULL", true},
+		{"missing IS NULL", true},
+		{"missing = 'x'", false},
+		{"note = 'x'", false},
+		{"status = 'it''s'", false},
+		{"amount > count", true},
+	}
+
+	for _, tc := range cases {
+		p, err := ParsePredicate(tc.expr)
+		if err != nil {
+			t.Errorf("ParsePredicate(%q) failed: %v", tc.expr, err)
+			continue
+		}
+		if got := p.Eval(row); got != tc.want {
+			t.Errorf("%q = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParsePredicateErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"status =",
+		"status ! 'x'",
+		"(status = 'x'",
+		"status = 'unterminated",
+		"status 'x'",
+		"status IS 'x'",
+		"status = 'x' extra",
+	} {
+		if _, err := ParsePredicate(expr); err == nil {
+			t.Errorf("ParsePredicate(%q) should fail", expr)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package common
+
+// RowError carries the source location and raw text of a row a converter
+// rejected, so the engine can quarantine the row verbatim instead of only
+// recording an error message.
+type RowError struct {
+	Line int    // 1-based source line number, 0 when unknown
+	Raw  string // Original input text of the rejected row
+	Err  error  // Underlying parse error
+}
+
+func (e *RowError) Error() string { return e.Err.Error() }
+
+func (e *RowError) Unwrap() error { return e.Err }
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+)
+
+// SpillThreshold is the number of rows a SpillBuffer keeps in memory before
+// further rows spill to a gob-encoded temp file. Converters that must parse
+// an entire input before serving rows (html, markdown) use the buffer so
+// gigabyte inputs don't hold every row in RAM at once.
+var SpillThreshold = 100000
+
+// SpillBuffer accumulates string rows with bounded memory. The first
+// SpillThreshold rows stay in memory; the rest are appended to a temp file
+// that Scan replays in order. Errors from the temp file are sticky: Append
+// records the first one and Scan reports it, so parse loops don't need an
+// error return at every call site. A nil *SpillBuffer behaves like an empty
+// buffer. Close releases the temp file, if any.
+type SpillBuffer struct {
+	mem   [][]string
+	path  string
+	file  *os.File
+	w     *bufio.Writer
+	enc   *gob.Encoder
+	count int
+	err   error
+}
+
+// NewSpillBuffer returns an empty buffer. The temp file is only created
+// once the in-memory threshold is exceeded.
+func NewSpillBuffer() *SpillBuffer {
+	return &SpillBuffer{}
+}
+
+// Append adds a row to the buffer. The row is not copied; callers must not
+// reuse the backing array afterwards.
+func (b *SpillBuffer) Append(row []string) {
+	if b.err != nil {
+		return
+	}
+	if b.count < SpillThreshold {
+		b.mem = append(b.mem, row)
+		b.count++
+		return
+	}
+	if b.file == nil {
+		f, err := os.CreateTemp("", "mksqlite_spill_*.rows")
+		if err != nil {
+			b.err = err
+			return
+		}
+		b.file = f
+		b.path = f.Name()
+		b.w = bufio.NewWriterSize(f, 65536)
+		b.enc = gob.NewEncoder(b.w)
+	}
+	if err := b.enc.Encode(row); err != nil {
+		b.err = err
+		return
+	}
+	b.count++
+}
+
+// Err reports the first temp-file error hit by Append.
+func (b *SpillBuffer) Err() error {
+	if b == nil {
+		return nil
+	}
+	return b.err
+}
+
+// Len returns the number of buffered rows.
+func (b *SpillBuffer) Len() int {
+	if b == nil {
+		return 0
+	}
+	return b.count
+}
+
+// Sample returns up to n in-memory rows for type inference; n <= 0 returns
+// all of them. Spilled rows are never sampled.
+func (b *SpillBuffer) Sample(n int) [][]string {
+	if b == nil {
+		return nil
+	}
+	if n <= 0 || n > len(b.mem) {
+		n = len(b.mem)
+	}
+	return b.mem[:n]
+}
+
+// Scan replays every buffered row in append order. It may be called more
+// than once; yield errors abort the scan and are returned as-is.
+func (b *SpillBuffer) Scan(yield func(row []string) error) error {
+	if b == nil {
+		return nil
+	}
+	if b.err != nil {
+		return b.err
+	}
+	for _, row := range b.mem {
+		if err := yield(row); err != nil {
+			return err
+		}
+	}
+	if b.file == nil {
+		return nil
+	}
+	if err := b.w.Flush(); err != nil {
+		return err
+	}
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := gob.NewDecoder(bufio.NewReaderSize(f, 65536))
+	for {
+		var row []string
+		if err := dec.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := yield(row); err != nil {
+			return err
+		}
+	}
+}
+
+// Close drops the in-memory rows and removes the temp file, if any.
+func (b *SpillBuffer) Close() error {
+	if b == nil {
+		return nil
+	}
+	b.mem = nil
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	err := os.Remove(b.path)
+	b.file = nil
+	return err
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSpillBufferInMemory(t *testing.T) {
+	b := NewSpillBuffer()
+	defer b.Close()
+
+	b.Append([]string{"a", "1"})
+	b.Append([]string{"b", "2"})
+	if b.Len() != 2 {
+		t.Errorf("Expected 2 rows, got %d", b.Len())
+	}
+	if err := b.Err(); err != nil {
+		t.Fatalf("Unexpected buffer error: %v", err)
+	}
+
+	var got [][]string
+	err := b.Scan(func(row []string) error {
+		got = append(got, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(got) != 2 || got[0][0] != "a" || got[1][1] != "2" {
+		t.Errorf("Unexpected rows: %v", got)
+	}
+}
+
+func TestSpillBufferSpillsToDisk(t *testing.T) {
+	orig := SpillThreshold
+	SpillThreshold = 10
+	defer func() { SpillThreshold = orig }()
+
+	b := NewSpillBuffer()
+	defer b.Close()
+
+	const total = 35
+	for i := 0; i < total; i++ {
+		b.Append([]string{fmt.Sprintf("row%d", i)})
+	}
+	if err := b.Err(); err != nil {
+		t.Fatalf("Unexpected buffer error: %v", err)
+	}
+	if b.Len() != total {
+		t.Errorf("Expected %d rows, got %d", total, b.Len())
+	}
+	if sample := b.Sample(0); len(sample) != 10 {
+		t.Errorf("Expected 10 in-memory sample rows, got %d", len(sample))
+	}
+
+	// Scan twice: spilled rows must replay in order both times
+	for pass := 0; pass < 2; pass++ {
+		i := 0
+		err := b.Scan(func(row []string) error {
+			if want := fmt.Sprintf("row%d", i); row[0] != want {
+				t.Errorf("Pass %d row %d: got %q, want %q", pass, i, row[0], want)
+			}
+			i++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if i != total {
+			t.Errorf("Pass %d scanned %d rows, want %d", pass, i, total)
+		}
+	}
+}
+
+func TestSpillBufferNil(t *testing.T) {
+	var b *SpillBuffer
+	if b.Len() != 0 || b.Err() != nil || b.Sample(5) != nil {
+		t.Error("Nil buffer should behave as empty")
+	}
+	if err := b.Scan(func([]string) error { return nil }); err != nil {
+		t.Errorf("Nil Scan failed: %v", err)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("Nil Close failed: %v", err)
+	}
+}
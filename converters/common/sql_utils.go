@@ -3,7 +3,6 @@ package common
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -83,6 +82,44 @@ func GenColumnNames(rawheaders []string) []string {
 	return GenCompliantNames(rawheaders, CLPRE)
 }
 
+// QuoteIdentifier returns name as a double-quoted SQLite identifier with
+// embedded quotes doubled. Quoted identifiers may contain any character,
+// so Unicode headers like "Größe" or "売上" survive unchanged.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// GenPreservedNames keeps original names by double-quoting them instead
+// of the lossy mangling GenCompliantNames does. Empty names fall back to
+// {prefix}{idx} and duplicates get a numeric suffix, mirroring the
+// sanitizing path.
+func GenPreservedNames(rawnames []string, prefix string) []string {
+	gorgeous := make([]string, len(rawnames))
+
+	counter := map[string]int{}
+	for idx, item := range rawnames {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			gorgeous[idx] = fmt.Sprintf("%s%d", prefix, idx)
+			continue
+		}
+
+		key := strings.ToLower(item)
+		counter[key]++
+		if counter[key] > 1 {
+			item = fmt.Sprintf("%s%d", item, counter[key])
+		}
+		gorgeous[idx] = QuoteIdentifier(item)
+	}
+	return gorgeous
+}
+
+// GenPreservedColumnNames generates quoted SQL column names that keep the
+// raw header text.
+func GenPreservedColumnNames(rawheaders []string) []string {
+	return GenPreservedNames(rawheaders, CLPRE)
+}
+
 // GenTableNames generates sanitized SQL table names from raw table names.
 // if table names are complete junk it will return tb0, tb2, tb2, etc.
 func GenTableNames(rawtables []string) []string {
@@ -92,6 +129,13 @@ func GenTableNames(rawtables []string) []string {
 // InferColumnTypes analyzes row data to determine the most appropriate SQLite data type
 // for each column. It checks rows 5 through 15 (if available) as requested.
 func InferColumnTypes(rows [][]string, columnCount int) []string {
+	return InferColumnTypesLocale(rows, columnCount, nil)
+}
+
+// InferColumnTypesLocale is InferColumnTypes with locale-aware number
+// parsing, so under de_DE a column of "1.234,56" values infers as REAL
+// instead of falling back to TEXT. A nil locale matches InferColumnTypes.
+func InferColumnTypesLocale(rows [][]string, columnCount int, loc *Locale) []string {
 	colTypes := make([]string, columnCount)
 	for i := range colTypes {
 		colTypes[i] = "TEXT" // Default to TEXT
@@ -135,14 +179,14 @@ func InferColumnTypes(rows [][]string, columnCount int) []string {
 
 			// Check Integer
 			if isInt {
-				if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				if _, err := loc.ParseInt(val); err != nil {
 					isInt = false
 				}
 			}
 
 			// Check Real
 			if isReal {
-				if _, err := strconv.ParseFloat(val, 64); err != nil {
+				if _, err := loc.ParseFloat(val); err != nil {
 					isReal = false
 				}
 			}
@@ -349,6 +393,115 @@ func GenCreateTableSQLWithTypes(tableName string, columnNames []string, columnTy
 	return builder.String()
 }
 
+// strictColumnType maps a column type onto the closed set STRICT tables
+// accept (INT, INTEGER, REAL, TEXT, BLOB, ANY); empty or unrecognized
+// types become ANY.
+func strictColumnType(colType string) string {
+	switch strings.ToUpper(strings.TrimSpace(colType)) {
+	case "INT", "INTEGER":
+		return "INTEGER"
+	case "REAL":
+		return "REAL"
+	case "TEXT":
+		return "TEXT"
+	case "BLOB":
+		return "BLOB"
+	}
+	return "ANY"
+}
+
+// GenCreateTableSQLStrict generates a CREATE TABLE ... STRICT statement
+// (SQLite 3.37+), constraining every column to its declared type at
+// insert time. Types outside the STRICT set degrade to ANY.
+func GenCreateTableSQLStrict(tableName string, columnNames []string, columnTypes []string) string {
+	var builder strings.Builder
+	builder.Grow(len(tableName) + len(columnNames)*20)
+
+	builder.WriteString("CREATE TABLE ")
+	builder.WriteString(tableName)
+	builder.WriteString(" (")
+	for i, name := range columnNames {
+		colType := ""
+		if i < len(columnTypes) {
+			colType = columnTypes[i]
+		}
+		builder.WriteString(name)
+		builder.WriteByte(' ')
+		builder.WriteString(strictColumnType(colType))
+		if i < len(columnNames)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteString(") STRICT")
+	return builder.String()
+}
+
+// ForeignKey declares one FOREIGN KEY table constraint: Column references
+// RefColumn of the parent table RefTable.
+type ForeignKey struct {
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// GenCreateTableSQLKeyed generates a CREATE TABLE statement with a
+// PRIMARY KEY table constraint over pkColumns, a FOREIGN KEY constraint
+// per foreignKeys entry and, optionally, the WITHOUT ROWID and STRICT
+// table options. Strict mode constrains every column type like
+// GenCreateTableSQLStrict.
+func GenCreateTableSQLKeyed(tableName string, columnNames, columnTypes, pkColumns []string, foreignKeys []ForeignKey, withoutRowid, strict bool) string {
+	var builder strings.Builder
+	builder.Grow(len(tableName) + len(columnNames)*20)
+
+	builder.WriteString("CREATE TABLE ")
+	builder.WriteString(tableName)
+	builder.WriteString(" (")
+	for i, name := range columnNames {
+		colType := ""
+		if i < len(columnTypes) {
+			colType = columnTypes[i]
+		}
+		if strict {
+			colType = strictColumnType(colType)
+		}
+		builder.WriteString(name)
+		if colType != "" {
+			builder.WriteByte(' ')
+			builder.WriteString(colType)
+		}
+		if i < len(columnNames)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	if len(pkColumns) > 0 {
+		builder.WriteString(", PRIMARY KEY (")
+		builder.WriteString(strings.Join(pkColumns, ", "))
+		builder.WriteByte(')')
+	}
+	for _, fk := range foreignKeys {
+		builder.WriteString(", FOREIGN KEY (")
+		builder.WriteString(fk.Column)
+		builder.WriteString(") REFERENCES ")
+		builder.WriteString(fk.RefTable)
+		builder.WriteString(" (")
+		builder.WriteString(fk.RefColumn)
+		builder.WriteByte(')')
+	}
+	builder.WriteByte(')')
+	var tableOptions []string
+	if strict {
+		tableOptions = append(tableOptions, "STRICT")
+	}
+	if withoutRowid {
+		tableOptions = append(tableOptions, "WITHOUT ROWID")
+	}
+	if len(tableOptions) > 0 {
+		builder.WriteByte(' ')
+		builder.WriteString(strings.Join(tableOptions, ", "))
+	}
+	return builder.String()
+}
+
 // sqliteKeywords is a slice containing all possible SQLite SQL keywords.
 // This list is based on the complete set recognized by SQLite (as of recent versions),
 // sourced from the official documentation: https://sqlite.org/lang_keywords.html
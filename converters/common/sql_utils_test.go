@@ -30,3 +30,20 @@ func TestGenCompliantNamesDigits(t *testing.T) {
 		}
 	}
 }
+
+func TestGenPreservedNames(t *testing.T) {
+	rawnames := []string{"Größe", "売上", `say "hi"`, "", "Größe"}
+	expected := []string{`"Größe"`, `"売上"`, `"say ""hi"""`, "cl3", `"Größe2"`}
+	clean := GenPreservedNames(rawnames, "cl")
+	for i, v := range clean {
+		if v != expected[i] {
+			t.Errorf("at index %d: got %s, want %s", i, v, expected[i])
+		}
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	if got := QuoteIdentifier(`a"b`); got != `"a""b"` {
+		t.Errorf("QuoteIdentifier escaping failed: %s", got)
+	}
+}
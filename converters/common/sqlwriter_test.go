@@ -0,0 +1,95 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSQLWriterBatching(t *testing.T) {
+	var b strings.Builder
+	w := NewSQLWriter(&b, 2)
+
+	if err := w.BeginTable("tb0", []string{"col1", "col2"}, []string{"TEXT", "INTEGER"}); err != nil {
+		t.Fatalf("BeginTable failed: %v", err)
+	}
+	rows := [][]interface{}{
+		{"a", int64(1)},
+		{"b'c", int64(2)},
+		{nil, 3.5},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow failed: %v", err)
+		}
+	}
+	if err := w.EndTable(); err != nil {
+		t.Fatalf("EndTable failed: %v", err)
+	}
+
+	output := b.String()
+
+	// Three rows at two rows per statement means two INSERTs inside one
+	// BEGIN/COMMIT block.
+	if got := strings.Count(output, "INSERT INTO tb0"); got != 2 {
+		t.Errorf("expected 2 INSERT statements, got %d\n%s", got, output)
+	}
+	expectedContains := []string{
+		"CREATE TABLE tb0 (col1 TEXT, col2 INTEGER);",
+		"BEGIN TRANSACTION;",
+		"('a', 1),",
+		"('b''c', 2);",
+		"(NULL, 3.5);",
+		"COMMIT;",
+	}
+	for _, exp := range expectedContains {
+		if !strings.Contains(output, exp) {
+			t.Errorf("output missing %q\n%s", exp, output)
+		}
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	cases := []struct {
+		in   interface{}
+		want string
+	}{
+		{nil, "NULL"},
+		{true, "1"},
+		{false, "0"},
+		{int64(42), "42"},
+		{3.14, "3.14"},
+		{"it's", "'it''s'"},
+		{[]byte{0xde, 0xad, 0xbe, 0xef}, "X'deadbeef'"},
+	}
+	for _, tc := range cases {
+		if got := SQLValue(tc.in); got != tc.want {
+			t.Errorf("SQLValue(%v) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSQLWriterPadsAndTruncates(t *testing.T) {
+	var b strings.Builder
+	w := NewSQLWriter(&b, 0) // default batch size
+
+	if err := w.BeginTable("tb0", []string{"col1", "col2"}, []string{"TEXT", "TEXT"}); err != nil {
+		t.Fatalf("BeginTable failed: %v", err)
+	}
+	if err := w.WriteRow([]interface{}{"only"}); err != nil {
+		t.Fatalf("WriteRow short failed: %v", err)
+	}
+	if err := w.WriteRow([]interface{}{"a", "b", "extra"}); err != nil {
+		t.Fatalf("WriteRow long failed: %v", err)
+	}
+	if err := w.EndTable(); err != nil {
+		t.Fatalf("EndTable failed: %v", err)
+	}
+
+	output := b.String()
+	if !strings.Contains(output, "('only', NULL)") {
+		t.Errorf("short row not padded with NULL:\n%s", output)
+	}
+	if strings.Contains(output, "extra") {
+		t.Errorf("long row not truncated:\n%s", output)
+	}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// ScanUnfoldedLines reads a vCard/iCalendar style stream, unfolding continuation
+// lines (lines starting with a space or tab are appended to the previous line),
+// and calls fn for each logical "NAME;PARAMS:VALUE" content line.
+// The name is upper-cased; params may be empty.
+func ScanUnfoldedLines(r io.Reader, fn func(name, params, value string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 65536), 1024*1024)
+
+	var logical string
+	emit := func() error {
+		if logical == "" {
+			return nil
+		}
+		line := logical
+		logical = ""
+
+		idx := strings.Index(line, ":")
+		if idx == -1 {
+			return nil // Not a content line; skip
+		}
+		nameAndParams := line[:idx]
+		value := line[idx+1:]
+
+		name := nameAndParams
+		params := ""
+		if semi := strings.Index(nameAndParams, ";"); semi != -1 {
+			name = nameAndParams[:semi]
+			params = nameAndParams[semi+1:]
+		}
+		return fn(strings.ToUpper(name), params, value)
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			logical += line[1:]
+			continue
+		}
+		if err := emit(); err != nil {
+			return err
+		}
+		logical = line
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return emit()
+}
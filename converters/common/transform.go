@@ -0,0 +1,229 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Transform is a pipeline of built-in string operations applied to one
+// column's values during import, e.g. "trim | replace(',', ”) | lower".
+// Steps may be separated by '|' or '+'. Available built-ins: trim, lower,
+// upper, replace(old, new), regex_replace(pattern, repl),
+// substring(start[, length]) and parse_date(layout), which emits RFC3339.
+type Transform struct {
+	expr  string
+	steps []transformStep
+}
+
+type transformStep func(string) string
+
+// ParseTransform compiles a transform expression into a pipeline.
+func ParseTransform(expr string) (*Transform, error) {
+	parts, err := splitPipeline(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty transform expression")
+	}
+
+	t := &Transform{expr: expr}
+	for _, part := range parts {
+		step, err := parseStep(part)
+		if err != nil {
+			return nil, err
+		}
+		t.steps = append(t.steps, step)
+	}
+	return t, nil
+}
+
+// Apply runs the pipeline on one value. Only string values are
+// transformed; everything else passes through untouched.
+func (t *Transform) Apply(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	for _, step := range t.steps {
+		s = step(s)
+	}
+	return s
+}
+
+// String returns the expression the transform was compiled from.
+func (t *Transform) String() string {
+	return t.expr
+}
+
+// splitPipeline splits an expression on top-level '|' or '+', respecting
+// single-quoted strings and parentheses so separators inside arguments
+// (regex alternation, escaped quotes) stay intact.
+func splitPipeline(expr string) ([]string, error) {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	inQuote := false
+
+	for _, r := range expr {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+			current.WriteRune(r)
+		case inQuote:
+			current.WriteRune(r)
+		case r == '(':
+			depth++
+			current.WriteRune(r)
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in transform: %s", expr)
+			}
+			current.WriteRune(r)
+		case (r == '|' || r == '+') && depth == 0:
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in transform: %s", expr)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in transform: %s", expr)
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		parts = append(parts, s)
+	}
+	return parts, nil
+}
+
+// parseStep compiles one pipeline step like "trim" or "replace(',', ”)".
+func parseStep(part string) (transformStep, error) {
+	name := part
+	var args []string
+	if idx := strings.IndexByte(part, '('); idx != -1 {
+		if !strings.HasSuffix(part, ")") {
+			return nil, fmt.Errorf("malformed transform step: %s", part)
+		}
+		name = strings.TrimSpace(part[:idx])
+		var err error
+		args, err = splitArgs(part[idx+1 : len(part)-1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed transform step %s: %w", part, err)
+		}
+	}
+
+	switch name {
+	case "trim":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("trim takes no arguments")
+		}
+		return strings.TrimSpace, nil
+	case "lower":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("lower takes no arguments")
+		}
+		return strings.ToLower, nil
+	case "upper":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("upper takes no arguments")
+		}
+		return strings.ToUpper, nil
+	case "replace":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("replace takes 2 arguments, got %d", len(args))
+		}
+		old, new := args[0], args[1]
+		return func(s string) string { return strings.ReplaceAll(s, old, new) }, nil
+	case "regex_replace":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("regex_replace takes 2 arguments, got %d", len(args))
+		}
+		re, err := regexp.Compile(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex_replace pattern: %w", err)
+		}
+		repl := args[1]
+		return func(s string) string { return re.ReplaceAllString(s, repl) }, nil
+	case "substring":
+		if len(args) != 1 && len(args) != 2 {
+			return nil, fmt.Errorf("substring takes 1 or 2 arguments, got %d", len(args))
+		}
+		var start, length int
+		if _, err := fmt.Sscanf(args[0], "%d", &start); err != nil {
+			return nil, fmt.Errorf("invalid substring start: %s", args[0])
+		}
+		length = -1
+		if len(args) == 2 {
+			if _, err := fmt.Sscanf(args[1], "%d", &length); err != nil {
+				return nil, fmt.Errorf("invalid substring length: %s", args[1])
+			}
+		}
+		return func(s string) string {
+			runes := []rune(s)
+			if start >= len(runes) {
+				return ""
+			}
+			end := len(runes)
+			if length >= 0 && start+length < end {
+				end = start + length
+			}
+			return string(runes[start:end])
+		}, nil
+	case "parse_date":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("parse_date takes 1 argument, got %d", len(args))
+		}
+		layout := args[0]
+		return func(s string) string {
+			if ts, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+				return ts.Format(time.RFC3339)
+			}
+			return s
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown transform: %s", name)
+}
+
+// splitArgs splits a step's argument list on commas outside quotes and
+// unwraps single-quoted strings (” escapes a quote).
+func splitArgs(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var rawArgs []string
+	var current strings.Builder
+	inQuote := false
+	for _, r := range raw {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+			current.WriteRune(r)
+		case r == ',' && !inQuote:
+			rawArgs = append(rawArgs, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in arguments: %s", raw)
+	}
+	rawArgs = append(rawArgs, current.String())
+
+	args := make([]string, len(rawArgs))
+	for i, arg := range rawArgs {
+		arg = strings.TrimSpace(arg)
+		if strings.HasPrefix(arg, "'") && strings.HasSuffix(arg, "'") && len(arg) >= 2 {
+			arg = strings.ReplaceAll(arg[1:len(arg)-1], "''", "'")
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestTransformPipeline(t *testing.T) {
+	cases := []struct {
+		expr string
+		in   string
+		want string
+	}{
+		{"trim", "  hi  ", "hi"},
+		{"lower", "HeLLo", "hello"},
+		{"upper", "hi", "HI"},
+		{"trim + replace(',', '')", " 1,234,5 ", "12345"},
+		{"trim | lower", "  MiXeD ", "mixed"},
+		{"regex_replace('[0-9]+', '#')", "a1b22c", "a#b#c"},
+		{"substring(0, 3)", "abcdef", "abc"},
+		{"substring(2)", "abcdef", "cdef"},
+		{"parse_date('02/01/2006')", "25/12/2024", "2024-12-25T00:00:00Z"},
+		{"parse_date('01/02/2006')", "not a date", "not a date"},
+		{"replace('''', '')", "it's", "its"},
+	}
+
+	for _, tc := range cases {
+		tr, err := ParseTransform(tc.expr)
+		if err != nil {
+			t.Errorf("ParseTransform(%q) failed: %v", tc.expr, err)
+			continue
+		}
+		if got := tr.Apply(tc.in); got != tc.want {
+			t.Errorf("%q applied to %q = %q, want %q", tc.expr, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestTransformPassesThroughNonStrings(t *testing.T) {
+	tr, err := ParseTransform("upper")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+	if got := tr.Apply(int64(42)); got != int64(42) {
+		t.Errorf("non-string value changed: %v", got)
+	}
+	if got := tr.Apply(nil); got != nil {
+		t.Errorf("nil value changed: %v", got)
+	}
+}
+
+func TestParseTransformErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"nosuch",
+		"replace(',')",
+		"regex_replace('[', '')",
+		"trim(",
+		"replace(', '')",
+	} {
+		if _, err := ParseTransform(expr); err == nil {
+			t.Errorf("ParseTransform(%q) should fail", expr)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -19,7 +21,7 @@ const (
 )
 
 func init() {
-	converters.Register("csv", &csvDriver{})
+	converters.Register("csv", &csvDriver{}, converters.Capabilities{Extensions: []string{".csv"}, MIMETypes: []string{"text/csv"}, Streaming: true})
 }
 
 type csvDriver struct{}
@@ -43,11 +45,203 @@ var rowWrapperPool = sync.Pool{
 	},
 }
 
+// recordReader abstracts csv.Reader so alternate field splitters
+// (multi-character or regex delimiters) can slot in behind ScanRows.
+type recordReader interface {
+	Read() ([]string, error)
+}
+
+// splitReader reads newline-delimited records and splits each line with an
+// arbitrary function. Quoting is not interpreted in this mode; it exists for
+// delimiters encoding/csv cannot express (multi-rune strings, regexes).
+type splitReader struct {
+	br      *bufio.Reader
+	split   func(string) []string
+	comment string
+}
+
+func (s *splitReader) Read() ([]string, error) {
+	for {
+		line, err := s.br.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if line == "" {
+				return nil, io.EOF
+			}
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+		if s.comment != "" && strings.HasPrefix(line, s.comment) {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+		return s.split(line), nil
+	}
+}
+
+// quoteReader parses delimiter-separated records with a configurable quote
+// character and escape style, for exports encoding/csv cannot read (single
+// quotes, backslash escapes). Quoted fields may contain delimiters and
+// newlines; a quote is only special at the start of a field.
+type quoteReader struct {
+	br        *bufio.Reader
+	comma     rune
+	quote     rune
+	backslash bool // Backslash escapes instead of doubled quotes
+	comment   rune
+}
+
+func (q *quoteReader) Read() ([]string, error) {
+	var record []string
+	var field strings.Builder
+	inQuotes := false
+	started := false
+	for {
+		r, _, err := q.br.ReadRune()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if !started {
+				return nil, io.EOF
+			}
+			if inQuotes {
+				return nil, fmt.Errorf("unterminated quoted field at end of input")
+			}
+			return append(record, field.String()), nil
+		}
+
+		if !started {
+			// Skip blank and comment lines before the record begins
+			if r == '\n' || r == '\r' {
+				continue
+			}
+			if q.comment != 0 && r == q.comment {
+				if _, err := q.br.ReadString('\n'); err != nil {
+					if err == io.EOF {
+						return nil, io.EOF
+					}
+					return nil, err
+				}
+				continue
+			}
+			started = true
+		}
+
+		if inQuotes {
+			switch {
+			case q.backslash && r == '\\':
+				esc, _, err := q.br.ReadRune()
+				if err != nil {
+					return nil, fmt.Errorf("dangling backslash escape at end of input")
+				}
+				field.WriteRune(esc)
+			case r == q.quote:
+				if !q.backslash {
+					// A doubled quote is a literal quote
+					if next, _, err := q.br.ReadRune(); err == nil {
+						if next == q.quote {
+							field.WriteRune(q.quote)
+							continue
+						}
+						q.br.UnreadRune()
+					}
+				}
+				inQuotes = false
+			default:
+				field.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case r == q.quote && field.Len() == 0:
+			inQuotes = true
+		case q.backslash && r == '\\':
+			esc, _, err := q.br.ReadRune()
+			if err != nil {
+				return nil, fmt.Errorf("dangling backslash escape at end of input")
+			}
+			field.WriteRune(esc)
+		case r == q.comma:
+			record = append(record, field.String())
+			field.Reset()
+		case r == '\r':
+			// Swallowed when it ends the line, literal otherwise
+			if next, _, err := q.br.ReadRune(); err == nil {
+				q.br.UnreadRune()
+				if next == '\n' {
+					continue
+				}
+			}
+			field.WriteRune(r)
+		case r == '\n':
+			return append(record, field.String()), nil
+		default:
+			field.WriteRune(r)
+		}
+	}
+}
+
+// lineRecorderWindow is how many recent lines are kept for error reporting.
+// encoding/csv buffers at most a few KB ahead of the record it is parsing,
+// so the offending line is always still inside the window.
+const lineRecorderWindow = 256
+
+// lineRecorder tees raw line text off the reader feeding encoding/csv so
+// parse errors can be quarantined with the original input line.
+type lineRecorder struct {
+	r       io.Reader
+	lineNo  int // 1-based number of the line currently accumulating
+	current []byte
+	ring    [lineRecorderWindow]string
+}
+
+func newLineRecorder(r io.Reader) *lineRecorder {
+	return &lineRecorder{r: r, lineNo: 1}
+}
+
+func (l *lineRecorder) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for _, b := range p[:n] {
+		if b == '\n' {
+			l.ring[l.lineNo%lineRecorderWindow] = strings.TrimRight(string(l.current), "\r")
+			l.current = l.current[:0]
+			l.lineNo++
+			continue
+		}
+		l.current = append(l.current, b)
+	}
+	return n, err
+}
+
+// Text returns the recorded line, or "" once it has left the window.
+func (l *lineRecorder) Text(line int) string {
+	if line <= 0 || line > l.lineNo || line <= l.lineNo-lineRecorderWindow {
+		return ""
+	}
+	if line == l.lineNo {
+		return strings.TrimRight(string(l.current), "\r")
+	}
+	return l.ring[line%lineRecorderWindow]
+}
+
 // CSVConverter converts CSV files to SQLite tables
 type CSVConverter struct {
 	headers      []string
+	rawHeaders   []string
 	bufferedRows [][]string
-	csvReader    *csv.Reader
+	csvReader    recordReader
+	lines        *lineRecorder // Set in recover mode to report raw text
 	Config       common.ConversionConfig
 	timeout      time.Duration
 }
@@ -79,24 +273,118 @@ func NewCSVConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*C
 
 	br := bufio.NewReaderSize(r, 65536)
 
-	// Detect delimiter if not set
-	if config.Delimiter == 0 {
-		peekBytes, _ := br.Peek(2048)
-		sample := string(peekBytes)
-		if idx := strings.IndexAny(sample, "\r\n"); idx != -1 {
-			sample = sample[:idx]
+	// Strip a UTF-8 byte order mark before any parsing
+	if config.TrimBOM {
+		if peek, _ := br.Peek(3); len(peek) == 3 && peek[0] == 0xEF && peek[1] == 0xBB && peek[2] == 0xBF {
+			br.Discard(3)
 		}
-		config.Delimiter = common.DetectDelimiter(sample)
 	}
 
-	reader := csv.NewReader(br)
-	reader.Comma = config.Delimiter
-	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	// Discard leading junk lines (banners, generator comments)
+	for i := 0; i < config.SkipLines; i++ {
+		if _, err := br.ReadString('\n'); err != nil {
+			return nil, fmt.Errorf("CSV file ended while skipping %d leading lines", config.SkipLines)
+		}
+	}
+
+	// Single-rune delimiter strings go through encoding/csv like any other
+	if runes := []rune(config.DelimiterString); len(runes) == 1 {
+		config.Delimiter = runes[0]
+		config.DelimiterString = ""
+	}
+
+	var reader recordReader
+	var recorder *lineRecorder
+	if config.DelimiterRegex != "" || config.DelimiterString != "" {
+		// Alternate line splitter for delimiters encoding/csv cannot express
+		var split func(string) []string
+		if config.DelimiterRegex != "" {
+			re, err := regexp.Compile(config.DelimiterRegex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid delimiter regex: %w", err)
+			}
+			split = func(line string) []string { return re.Split(line, -1) }
+		} else {
+			sep := config.DelimiterString
+			split = func(line string) []string { return strings.Split(line, sep) }
+		}
+		reader = &splitReader{br: br, split: split, comment: config.CommentPrefix}
+	} else {
+		// Detect delimiter if not set, scoring the first lines for
+		// column-count consistency rather than trusting the header alone
+		if config.Delimiter == 0 {
+			peekBytes, _ := br.Peek(4096)
+			config.Delimiter = common.DetectDelimiterSample(string(peekBytes))
+		}
+
+		quote := '"'
+		if config.QuoteChar != "" {
+			runes := []rune(config.QuoteChar)
+			if len(runes) != 1 {
+				return nil, fmt.Errorf("quote character must be a single character, got %q", config.QuoteChar)
+			}
+			quote = runes[0]
+		}
+		switch config.EscapeStyle {
+		case "", "doubled", "backslash":
+		default:
+			return nil, fmt.Errorf("escape style must be doubled or backslash, got %q", config.EscapeStyle)
+		}
+		var commentRune rune
+		if config.CommentPrefix != "" {
+			runes := []rune(config.CommentPrefix)
+			if len(runes) != 1 {
+				return nil, fmt.Errorf("comment prefix must be a single character, got %q", config.CommentPrefix)
+			}
+			commentRune = runes[0]
+		}
+
+		switch {
+		case config.NoQuoting:
+			// Quotes are literal data: split lines on the delimiter only
+			sep := string(config.Delimiter)
+			reader = &splitReader{br: br, split: func(line string) []string { return strings.Split(line, sep) }, comment: config.CommentPrefix}
+		case quote != '"' || config.EscapeStyle == "backslash":
+			// encoding/csv is wired to doubled double quotes; other
+			// conventions go through the configurable parser
+			reader = &quoteReader{br: br, comma: config.Delimiter, quote: quote, backslash: config.EscapeStyle == "backslash", comment: commentRune}
+		default:
+			var source io.Reader = br
+			if config.Recover {
+				recorder = newLineRecorder(br)
+				source = recorder
+			}
+			csvReader := csv.NewReader(source)
+			csvReader.Comma = config.Delimiter
+			csvReader.FieldsPerRecord = -1 // Allow variable number of fields
+			csvReader.LazyQuotes = config.LazyQuotes
+			csvReader.Comment = commentRune
+			reader = csvReader
+		}
+	}
 
 	var headers []string
 	var bufferedRows [][]string
 
-	if config.AdvancedHeaderDetection {
+	if config.NoHeader {
+		// First row is data: read it to size the column set, then buffer it
+		row, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("CSV file is empty")
+			}
+			return nil, fmt.Errorf("failed to read first CSV row: %w", err)
+		}
+		bufferedRows = append(bufferedRows, row)
+		if len(config.ColumnNames) > 0 {
+			headers = config.ColumnNames
+		} else {
+			headers = make([]string, len(row))
+			for i := range headers {
+				headers[i] = fmt.Sprintf("%s%d", common.CLPRE, i)
+			}
+		}
+	} else if config.AdvancedHeaderDetection {
 		var scanRows [][]string
 		// Read up to 10 rows for assessment
 		for i := 0; i < 10; i++ {
@@ -134,10 +422,32 @@ func NewCSVConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*C
 			return nil, fmt.Errorf("failed to read CSV headers: %w", err)
 		}
 		headers = h
+
+		// Locale-aware inference needs sample values to look at, and the
+		// plain streaming path buffers none; read a few rows up front the
+		// same way advanced header detection does. They replay through
+		// ScanRows like any other buffered rows.
+		if config.Locale != "" {
+			for i := 0; i < 10; i++ {
+				row, err := reader.Read()
+				if err != nil {
+					if err == io.EOF {
+						break
+					}
+					return nil, fmt.Errorf("failed to read CSV row for type inference: %w", err)
+				}
+				bufferedRows = append(bufferedRows, row)
+			}
+		}
 	}
 
-	// Sanitize headers
-	sanitizedHeaders := common.GenColumnNames(headers)
+	// Sanitize headers, or quote them verbatim when preserving names
+	var sanitizedHeaders []string
+	if config.PreserveNames {
+		sanitizedHeaders = common.GenPreservedColumnNames(headers)
+	} else {
+		sanitizedHeaders = common.GenColumnNames(headers)
+	}
 
 	var timeout time.Duration
 	if config.ScanTimeout != "" {
@@ -148,8 +458,10 @@ func NewCSVConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*C
 
 	return &CSVConverter{
 		headers:      sanitizedHeaders,
+		rawHeaders:   headers,
 		bufferedRows: bufferedRows,
 		csvReader:    reader,
+		lines:        recorder,
 		Config:       *config,
 		timeout:      timeout,
 	}, nil
@@ -168,13 +480,33 @@ func (c *CSVConverter) GetHeaders(tableName string) []string {
 	return nil
 }
 
+// GetRawHeaders implements common.RawHeaderProvider
+func (c *CSVConverter) GetRawHeaders(tableName string) []string {
+	if tableName == c.Config.TableName {
+		return c.rawHeaders
+	}
+	return nil
+}
+
 // GetColumnTypes implements RowProvider
 func (c *CSVConverter) GetColumnTypes(tableName string) []string {
 	if tableName != c.Config.TableName {
 		return nil
 	}
-	// Use buffered rows for inference
-	return common.InferColumnTypes(c.bufferedRows, len(c.headers))
+	// Use buffered rows for inference; the CLI has already validated the
+	// locale tag, so a parse failure just means plain number parsing
+	loc, _ := common.ParseLocale(c.Config.Locale)
+	return common.InferColumnTypesLocale(c.bufferedRows, len(c.headers), loc)
+}
+
+// GetColumnFormats implements common.ColumnFormatProvider using the rows
+// buffered during header detection; a plain streaming open carries no
+// sample and reports no formats.
+func (c *CSVConverter) GetColumnFormats(tableName string) []string {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	return common.DetectColumnFormats(c.bufferedRows, len(c.headers))
 }
 
 // padRow pads or truncates the row to match the target length.
@@ -254,6 +586,24 @@ func (c *CSVConverter) ScanRows(ctx context.Context, tableName string, yield fun
 				if err == io.EOF {
 					break
 				}
+				// In recover mode, parse errors become row errors and the
+				// reader resynchronizes at the next line. Anything else
+				// (I/O failure) is still fatal.
+				var parseErr *csv.ParseError
+				if c.Config.Recover && errors.As(err, &parseErr) {
+					rowErr := error(fmt.Errorf("skipped unparseable CSV line: %w", err))
+					if c.lines != nil {
+						line := parseErr.StartLine
+						if line == 0 {
+							line = parseErr.Line
+						}
+						rowErr = &common.RowError{Line: line, Raw: c.lines.Text(line), Err: rowErr}
+					}
+					if !send(rowOrError{err: rowErr}) {
+						return
+					}
+					continue
+				}
 				// Send error to consumer
 				send(rowOrError{err: fmt.Errorf("failed to read CSV row: %w", err)})
 				// Continue reading next row ?? Usually error is fatal or we stop.
@@ -313,6 +663,58 @@ func (c *CSVConverter) ScanRows(ctx context.Context, tableName string, yield fun
 	}
 }
 
+// PipelinesScans implements common.PipelinedProvider: ScanRows already runs its reader in a worker goroutine.
+func (c *CSVConverter) PipelinesScans() bool {
+	return true
+}
+
+// ScanBatches implements common.BatchProvider by transposing scanned rows
+// into columnar batches. Row errors abort the scan; the engine only takes
+// this path when error tolerance is off, where the row path would abort too.
+func (c *CSVConverter) ScanBatches(ctx context.Context, tableName string, yield func(cols [][]interface{}) error) error {
+	const batchRows = 1024
+	headers := c.GetHeaders(tableName)
+	if len(headers) == 0 {
+		return nil
+	}
+	cols := make([][]interface{}, len(headers))
+	n := 0
+	flush := func() error {
+		if n == 0 {
+			return nil
+		}
+		if err := yield(cols); err != nil {
+			return err
+		}
+		for i := range cols {
+			cols[i] = cols[i][:0]
+		}
+		n = 0
+		return nil
+	}
+	err := c.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		for i := range cols {
+			var v interface{}
+			if i < len(row) {
+				v = row[i]
+			}
+			cols[i] = append(cols[i], v)
+		}
+		n++
+		if n == batchRows {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
 // ConvertToSQL implements StreamConverter for CSV files (outputs SQL to writer).
 // It uses concurrency to pipeline reading and writing.
 func (c *CSVConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
@@ -320,18 +722,15 @@ func (c *CSVConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 		return fmt.Errorf("CSV reader is not initialized")
 	}
 
-	// Use buffered writer to reduce system calls
 	// Use buffered writer to reduce system calls
 	bw := bufio.NewWriter(writer)
 	defer bw.Flush()
-	writer = bw
 	// Get column types
 	colTypes := c.GetColumnTypes(c.Config.TableName)
 
-	// Write CREATE TABLE statement
-	createTableSQL := common.GenCreateTableSQLWithTypes(c.Config.TableName, c.headers, colTypes)
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+	sqlWriter := common.NewSQLWriter(bw, c.Config.RowsPerInsert)
+	if err := sqlWriter.BeginTable(c.Config.TableName, c.headers, colTypes); err != nil {
+		return err
 	}
 
 	// Channel to pipeline reading and writing
@@ -374,66 +773,15 @@ func (c *CSVConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 			if !ok {
 				goto Done
 			}
-			if _, err := fmt.Fprintf(writer, "INSERT INTO %s (", c.Config.TableName); err != nil {
-				return fmt.Errorf("failed to write INSERT start: %w", err)
-			}
-
-			// Write column names
-			for i, header := range c.headers {
-				if i > 0 {
-					if _, err := writer.Write([]byte(", ")); err != nil {
-						return fmt.Errorf("failed to write column separator: %w", err)
-					}
-				}
-				if _, err := fmt.Fprintf(writer, "%s", header); err != nil {
-					return fmt.Errorf("failed to write column name: %w", err)
-				}
-			}
-
-			if _, err := fmt.Fprintf(writer, ") VALUES ("); err != nil {
-				return fmt.Errorf("failed to write VALUES start: %w", err)
-			}
-
-			// Write values
-			for i, val := range row {
-				if i > 0 {
-					if _, err := io.WriteString(writer, ", "); err != nil {
-						return fmt.Errorf("failed to write value separator: %w", err)
-					}
-				}
-
-				if _, err := io.WriteString(writer, "'"); err != nil {
-					return fmt.Errorf("failed to write value start: %w", err)
-				}
-
-				// Escape single quotes by doubling them
-				last := 0
-				for j := 0; j < len(val); j++ {
-					if val[j] == '\'' {
-						if _, err := io.WriteString(writer, val[last:j+1]); err != nil {
-							return fmt.Errorf("failed to write value chunk: %w", err)
-						}
-						if _, err := io.WriteString(writer, "'"); err != nil {
-							return fmt.Errorf("failed to write escape quote: %w", err)
-						}
-						last = j + 1
-					}
-				}
-				if _, err := io.WriteString(writer, val[last:]); err != nil {
-					return fmt.Errorf("failed to write value end: %w", err)
-				}
-
-				if _, err := io.WriteString(writer, "'"); err != nil {
-					return fmt.Errorf("failed to write value end quote: %w", err)
-				}
-			}
-
-			if _, err := writer.Write([]byte(");\n")); err != nil {
-				return fmt.Errorf("failed to write statement end: %w", err)
+			if err := sqlWriter.WriteStringRow(row); err != nil {
+				return err
 			}
 		}
 	}
 Done:
+	if err := sqlWriter.EndTable(); err != nil {
+		return err
+	}
 
 	// Check for producer error
 	select {
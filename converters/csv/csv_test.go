@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 
 	_ "modernc.org/sqlite"
 )
@@ -265,3 +266,162 @@ func TestEscapingLogic(t *testing.T) {
 		}
 	}
 }
+
+func TestCSVColumnFormats(t *testing.T) {
+	csvContent := `item,amount,when
+widget,"1,234.50",2023-03-15
+gadget,"22,100.00",2023-04-01`
+
+	// Advanced header detection buffers the sample rows the detection runs
+	// on, which is what format detection works from
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		AdvancedHeaderDetection: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	formats := converter.GetColumnFormats(converter.GetTableNames()[0])
+	if len(formats) != 3 {
+		t.Fatalf("Expected 3 formats, got %v", formats)
+	}
+	if formats[0] != "" {
+		t.Errorf("Expected no format for the text column, got %q", formats[0])
+	}
+	if formats[1] != "#,##0.00" {
+		t.Errorf("Expected grouped number format, got %q", formats[1])
+	}
+	if formats[2] != "yyyy-mm-dd" {
+		t.Errorf("Expected ISO date format, got %q", formats[2])
+	}
+}
+
+func TestCSVLocaleStreamedSample(t *testing.T) {
+	csvContent := "item,amount\n" +
+		"widget,\"1.234,56\"\n" +
+		"gadget,\"22.100,00\"\n"
+
+	// No advanced header detection: the plain streaming path must buffer a
+	// sample when a locale is set, or every column infers TEXT and locale
+	// coercion never fires
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		Locale: "de_DE",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	types := converter.GetColumnTypes(converter.GetTableNames()[0])
+	if len(types) != 2 {
+		t.Fatalf("Expected 2 column types, got %v", types)
+	}
+	if types[1] != "REAL" {
+		t.Errorf("Expected REAL for the de_DE amount column, got %q", types[1])
+	}
+
+	// The sampled rows must replay through ScanRows, not get swallowed
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Expected no row errors, got %v", rowErrs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+}
+
+func TestCSVSingleQuote(t *testing.T) {
+	csvContent := "id,name,note\n" +
+		"1,'O''Brien','has a, comma'\n" +
+		"2,plain,'spans\ntwo lines'\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		QuoteChar: "'",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Expected no row errors, got %v", rowErrs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	// A doubled quote inside a quoted field is a literal quote
+	if rows[0][1] != "O'Brien" {
+		t.Errorf("Expected doubled quote unescaped, got %q", rows[0][1])
+	}
+	if rows[0][2] != "has a, comma" {
+		t.Errorf("Expected delimiter kept inside quotes, got %q", rows[0][2])
+	}
+	if rows[1][2] != "spans\ntwo lines" {
+		t.Errorf("Expected newline kept inside quotes, got %q", rows[1][2])
+	}
+}
+
+func TestCSVBackslashEscapes(t *testing.T) {
+	csvContent := "id,name\n" +
+		`1,"say \"hi\""` + "\n" +
+		`2,back\\slash` + "\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		EscapeStyle: "backslash",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Expected no row errors, got %v", rowErrs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][1] != `say "hi"` {
+		t.Errorf("Expected backslash-escaped quotes unescaped, got %q", rows[0][1])
+	}
+	// Escapes also apply outside quoted fields
+	if rows[1][1] != `back\slash` {
+		t.Errorf("Expected escaped backslash collapsed, got %q", rows[1][1])
+	}
+}
+
+func TestCSVNoQuoting(t *testing.T) {
+	csvContent := "id,name\n" +
+		`1,"not a quote` + "\n" +
+		`2,it's fine` + "\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		NoQuoting: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Expected no row errors, got %v", rowErrs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][1] != `"not a quote` {
+		t.Errorf("Expected quote kept verbatim, got %q", rows[0][1])
+	}
+	if rows[1][1] != "it's fine" {
+		t.Errorf("Expected apostrophe kept verbatim, got %q", rows[1][1])
+	}
+}
+
+func TestCSVInvalidQuoteConfig(t *testing.T) {
+	_, err := NewCSVConverterWithConfig(strings.NewReader("a,b\n1,2\n"), &common.ConversionConfig{QuoteChar: "''"})
+	if err == nil || !strings.Contains(err.Error(), "quote character") {
+		t.Errorf("Expected a quote character error, got %v", err)
+	}
+	_, err = NewCSVConverterWithConfig(strings.NewReader("a,b\n1,2\n"), &common.ConversionConfig{EscapeStyle: "cstyle"})
+	if err == nil || !strings.Contains(err.Error(), "escape style") {
+		t.Errorf("Expected an escape style error, got %v", err)
+	}
+}
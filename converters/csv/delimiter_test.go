@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+func TestCSVMultiCharDelimiter(t *testing.T) {
+	csvContent := "name||age||role\nAlice||30||Engineer\nBob||25||Designer\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		DelimiterString: "||",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) != 3 || headers[0] != "name" || headers[2] != "role" {
+		t.Fatalf("Expected name,age,role headers, got %v", headers)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Unexpected row errors: %v", rowErrs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "Alice" || rows[1][2] != "Designer" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestCSVRegexDelimiter(t *testing.T) {
+	csvContent := "name  age\nAlice   30\nBob 25\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		DelimiterRegex: `\s+`,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) != 2 || headers[0] != "name" {
+		t.Fatalf("Expected name,age headers, got %v", headers)
+	}
+
+	rows, _ := scanAll(t, converter)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][1] != "30" || rows[1][1] != "25" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestCSVInvalidRegexDelimiter(t *testing.T) {
+	_, err := NewCSVConverterWithConfig(strings.NewReader("a,b\n"), &common.ConversionConfig{
+		DelimiterRegex: `[`,
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid delimiter regex")
+	}
+}
+
+func TestCSVSingleRuneDelimiterString(t *testing.T) {
+	csvContent := "name;quote\nAlice;\"semi; colon\"\n"
+
+	// A one-rune delimiter string must still get full quote handling
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		DelimiterString: ";",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, _ := scanAll(t, converter)
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	if rows[0][1] != "semi; colon" {
+		t.Errorf("Expected quoted field to survive, got %v", rows[0][1])
+	}
+}
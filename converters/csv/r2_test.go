@@ -2,9 +2,9 @@ package csv_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/csv"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -0,0 +1,192 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+func scanAll(t *testing.T, converter *CSVConverter) (rows [][]interface{}, rowErrs []error) {
+	t.Helper()
+	err := converter.ScanRows(context.Background(), CSVTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			rowErrs = append(rowErrs, rowErr)
+			return nil
+		}
+		copied := make([]interface{}, len(row))
+		copy(copied, row)
+		rows = append(rows, copied)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows, rowErrs
+}
+
+func TestCSVLazyQuotes(t *testing.T) {
+	csvContent := "name,quote\n" +
+		"Alice,she said \"hi\" loudly\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		LazyQuotes: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 0 {
+		t.Fatalf("Expected no row errors with LazyQuotes, got %v", rowErrs)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	if rows[0][1] != `she said "hi" loudly` {
+		t.Errorf("Unexpected quote field: %v", rows[0][1])
+	}
+}
+
+func TestCSVCommentAndSkipLines(t *testing.T) {
+	csvContent := `Export generated 2024-01-01
+# a comment
+name,age
+# another comment
+Alice,30
+Bob,25
+`
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		CommentPrefix: "#",
+		SkipLines:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) != 2 || headers[0] != "name" {
+		t.Fatalf("Expected name,age headers, got %v", headers)
+	}
+
+	rows, _ := scanAll(t, converter)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "Alice" || rows[1][0] != "Bob" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestCSVMultiCharCommentRejected(t *testing.T) {
+	_, err := NewCSVConverterWithConfig(strings.NewReader("a,b\n1,2\n"), &common.ConversionConfig{
+		CommentPrefix: "//",
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a multi-character comment prefix")
+	}
+}
+
+func TestCSVTrimBOM(t *testing.T) {
+	csvContent := "\xEF\xBB\xBFname,age\nAlice,30\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		TrimBOM: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) == 0 || headers[0] != "name" {
+		t.Errorf("Expected BOM-free name header, got %v", headers)
+	}
+}
+
+func TestCSVRecoverMode(t *testing.T) {
+	csvContent := "name,val\n" +
+		"Alice,1\n" +
+		"bad \"line,2\n" +
+		"Bob,3\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		Recover: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows, rowErrs := scanAll(t, converter)
+	if len(rowErrs) != 1 {
+		t.Fatalf("Expected 1 row error, got %d: %v", len(rowErrs), rowErrs)
+	}
+	if !strings.Contains(rowErrs[0].Error(), "unparseable") {
+		t.Errorf("Unexpected row error: %v", rowErrs[0])
+	}
+	// The error carries the source line and raw text for quarantining
+	var rejErr *common.RowError
+	if !errors.As(rowErrs[0], &rejErr) {
+		t.Fatalf("Expected a RowError, got %T", rowErrs[0])
+	}
+	if rejErr.Line != 3 {
+		t.Errorf("Expected line 3, got %d", rejErr.Line)
+	}
+	if rejErr.Raw != `bad "line,2` {
+		t.Errorf("Unexpected raw text: %q", rejErr.Raw)
+	}
+	// The stream must resynchronize and keep the rows around the bad line
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 good rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "Alice" || rows[1][0] != "Bob" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestCSVNoHeader(t *testing.T) {
+	csvContent := "1,Alice,30\n2,Bob,25\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		NoHeader: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) != 3 || headers[0] != "cl0" || headers[2] != "cl2" {
+		t.Fatalf("Expected cl0..cl2 headers, got %v", headers)
+	}
+
+	rows, _ := scanAll(t, converter)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows (first row kept as data), got %d", len(rows))
+	}
+	if rows[0][1] != "Alice" {
+		t.Errorf("Expected first row to be data, got %v", rows[0])
+	}
+}
+
+func TestCSVNoHeaderWithNames(t *testing.T) {
+	csvContent := "1,Alice\n2,Bob\n"
+
+	converter, err := NewCSVConverterWithConfig(strings.NewReader(csvContent), &common.ConversionConfig{
+		NoHeader:    true,
+		ColumnNames: []string{"id", "name"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := converter.GetHeaders(CSVTB)
+	if len(headers) != 2 || headers[0] != "id" || headers[1] != "name" {
+		t.Fatalf("Expected id,name headers, got %v", headers)
+	}
+
+	rows, _ := scanAll(t, converter)
+	if len(rows) != 2 || rows[1][1] != "Bob" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
@@ -0,0 +1,261 @@
+package dbf
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	DBFTB = "tb0"
+
+	headerSize          = 32
+	fieldDescriptorSize = 32
+)
+
+func init() {
+	converters.Register("dbf", &dbfDriver{}, converters.Capabilities{Extensions: []string{".dbf"}, MIMETypes: []string{"application/x-dbf"}})
+}
+
+type dbfDriver struct{}
+
+func (d *dbfDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewDBFConverterWithConfig(source, config)
+}
+
+// dbfField describes one column from the field descriptor array.
+type dbfField struct {
+	Name     string
+	Type     byte // C, N, F, D, L (and anything else treated as text)
+	Length   int
+	Decimals int
+}
+
+// DBFConverter converts dBase .dbf files (including shapefile attribute
+// tables) to a SQLite table, mapping field descriptors to column types and
+// streaming the fixed-width records.
+type DBFConverter struct {
+	reader      *bufio.Reader
+	fields      []dbfField
+	recordCount uint32
+	recordSize  int
+
+	Config common.ConversionConfig
+}
+
+// Ensure DBFConverter implements RowProvider
+var _ common.RowProvider = (*DBFConverter)(nil)
+
+// NewDBFConverter creates a new DBFConverter from an io.Reader.
+func NewDBFConverter(r io.Reader) (*DBFConverter, error) {
+	return NewDBFConverterWithConfig(r, nil)
+}
+
+// NewDBFConverterWithConfig creates a new DBFConverter from an io.Reader with optional config.
+func NewDBFConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*DBFConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{
+			TableName: DBFTB,
+		}
+	}
+	if config.TableName == "" {
+		config.TableName = DBFTB
+	}
+
+	br := bufio.NewReaderSize(r, 65536)
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("failed to read DBF header: %w", err)
+	}
+
+	recordCount := binary.LittleEndian.Uint32(header[4:8])
+	headerLen := int(binary.LittleEndian.Uint16(header[8:10]))
+	recordSize := int(binary.LittleEndian.Uint16(header[10:12]))
+
+	if headerLen < headerSize+1 || recordSize < 1 {
+		return nil, fmt.Errorf("invalid DBF header (header length %d, record size %d)", headerLen, recordSize)
+	}
+
+	// Field descriptors run until the 0x0D terminator.
+	numFields := (headerLen - headerSize - 1) / fieldDescriptorSize
+	fields := make([]dbfField, 0, numFields)
+	fieldTotal := 0
+	for i := 0; i < numFields; i++ {
+		desc := make([]byte, fieldDescriptorSize)
+		if _, err := io.ReadFull(br, desc); err != nil {
+			return nil, fmt.Errorf("failed to read DBF field descriptor %d: %w", i, err)
+		}
+		name := desc[:11]
+		if idx := strings.IndexByte(string(name), 0); idx != -1 {
+			name = name[:idx]
+		}
+		field := dbfField{
+			Name:     strings.TrimSpace(string(name)),
+			Type:     desc[11],
+			Length:   int(desc[16]),
+			Decimals: int(desc[17]),
+		}
+		fields = append(fields, field)
+		fieldTotal += field.Length
+	}
+	if fieldTotal+1 != recordSize {
+		return nil, fmt.Errorf("DBF field lengths (%d+1) do not match record size %d", fieldTotal, recordSize)
+	}
+
+	// Consume everything up to the end of the header (terminator plus any
+	// vendor-specific extra bytes).
+	remaining := headerLen - headerSize - numFields*fieldDescriptorSize
+	if remaining > 0 {
+		if _, err := io.CopyN(io.Discard, br, int64(remaining)); err != nil {
+			return nil, fmt.Errorf("failed to skip DBF header remainder: %w", err)
+		}
+	}
+
+	return &DBFConverter{
+		reader:      br,
+		fields:      fields,
+		recordCount: recordCount,
+		recordSize:  recordSize,
+		Config:      *config,
+	}, nil
+}
+
+// GetTableNames implements RowProvider
+func (c *DBFConverter) GetTableNames() []string {
+	return []string{c.Config.TableName}
+}
+
+// GetHeaders implements RowProvider
+func (c *DBFConverter) GetHeaders(tableName string) []string {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	raw := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		raw[i] = f.Name
+	}
+	return common.GenColumnNames(raw)
+}
+
+// GetColumnTypes implements RowProvider
+func (c *DBFConverter) GetColumnTypes(tableName string) []string {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	colTypes := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		switch f.Type {
+		case 'N', 'F':
+			if f.Decimals > 0 || f.Type == 'F' {
+				colTypes[i] = "REAL"
+			} else {
+				colTypes[i] = "INTEGER"
+			}
+		case 'L':
+			colTypes[i] = "INTEGER"
+		default: // C, D, M and anything unknown
+			colTypes[i] = "TEXT"
+		}
+	}
+	return colTypes
+}
+
+// convertValue converts one raw fixed-width field to a Go value.
+func (c *DBFConverter) convertValue(field dbfField, raw []byte) interface{} {
+	val := strings.TrimSpace(string(raw))
+	switch field.Type {
+	case 'N':
+		if val == "" {
+			return nil
+		}
+		if field.Decimals > 0 {
+			if f, err := strconv.ParseFloat(val, 64); err == nil {
+				return f
+			}
+			return val
+		}
+		if n, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return n
+		}
+		return val
+	case 'F':
+		if val == "" {
+			return nil
+		}
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+		return val
+	case 'D':
+		// Dates are stored as YYYYMMDD
+		if len(val) == 8 {
+			return fmt.Sprintf("%s-%s-%s", val[:4], val[4:6], val[6:8])
+		}
+		return val
+	case 'L':
+		switch val {
+		case "Y", "y", "T", "t":
+			return int64(1)
+		case "N", "n", "F", "f":
+			return int64(0)
+		}
+		return nil
+	default:
+		return val
+	}
+}
+
+// ScanRows implements RowProvider by streaming the fixed-width records.
+// Records flagged as deleted (0x2A) are skipped.
+func (c *DBFConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	if c.reader == nil {
+		return fmt.Errorf("DBF reader is not initialized")
+	}
+
+	record := make([]byte, c.recordSize)
+	for i := uint32(0); i < c.recordCount; i++ {
+		if _, err := io.ReadFull(c.reader, record); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				// Truncated files end early; stop without error like the
+				// end-of-file marker (0x1A) would.
+				return nil
+			}
+			return fmt.Errorf("failed to read DBF record %d: %w", i, err)
+		}
+
+		if record[0] == 0x1A {
+			return nil // End-of-file marker
+		}
+		if record[0] == 0x2A {
+			continue // Deleted record
+		}
+
+		values := make([]interface{}, len(c.fields))
+		offset := 1
+		for j, field := range c.fields {
+			values[j] = c.convertValue(field, record[offset:offset+field.Length])
+			offset += field.Length
+		}
+
+		if err := yield(values, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
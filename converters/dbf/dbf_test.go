@@ -0,0 +1,114 @@
+package dbf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+// buildDBF constructs a minimal dBase III file with the given fields and records.
+func buildDBF(t *testing.T, fields []dbfField, records []string) []byte {
+	t.Helper()
+
+	recordSize := 1
+	for _, f := range fields {
+		recordSize += f.Length
+	}
+	headerLen := headerSize + len(fields)*fieldDescriptorSize + 1
+
+	var buf bytes.Buffer
+	header := make([]byte, headerSize)
+	header[0] = 0x03 // dBase III without memo
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(records)))
+	binary.LittleEndian.PutUint16(header[8:10], uint16(headerLen))
+	binary.LittleEndian.PutUint16(header[10:12], uint16(recordSize))
+	buf.Write(header)
+
+	for _, f := range fields {
+		desc := make([]byte, fieldDescriptorSize)
+		copy(desc[:11], f.Name)
+		desc[11] = f.Type
+		desc[16] = byte(f.Length)
+		desc[17] = byte(f.Decimals)
+		buf.Write(desc)
+	}
+	buf.WriteByte(0x0D) // Header terminator
+
+	for _, rec := range records {
+		if len(rec) != recordSize {
+			t.Fatalf("record %q has length %d, want %d", rec, len(rec), recordSize)
+		}
+		buf.WriteString(rec)
+	}
+	buf.WriteByte(0x1A)
+	return buf.Bytes()
+}
+
+func TestDBFConverter(t *testing.T) {
+	fields := []dbfField{
+		{Name: "NAME", Type: 'C', Length: 10},
+		{Name: "COUNT", Type: 'N', Length: 5},
+		{Name: "PRICE", Type: 'N', Length: 8, Decimals: 2},
+		{Name: "BORN", Type: 'D', Length: 8},
+		{Name: "ACTIVE", Type: 'L', Length: 1},
+	}
+	records := []string{
+		" alice        42    3.5019900102T",
+		"*deleted      99    9.9919990101F", // Deleted record must be skipped
+		" bob           7        19951231F",
+	}
+
+	data := buildDBF(t, fields, records)
+	conv, err := NewDBFConverter(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	headers := conv.GetHeaders(DBFTB)
+	if len(headers) != 5 || headers[0] != "name" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	colTypes := conv.GetColumnTypes(DBFTB)
+	expectedTypes := []string{"TEXT", "INTEGER", "REAL", "TEXT", "INTEGER"}
+	for i, want := range expectedTypes {
+		if colTypes[i] != want {
+			t.Errorf("column %d type = %s, want %s", i, colTypes[i], want)
+		}
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), DBFTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows (deleted skipped), got %d", len(rows))
+	}
+	if rows[0][0] != "alice" {
+		t.Errorf("unexpected name: %v", rows[0][0])
+	}
+	if rows[0][1] != int64(42) {
+		t.Errorf("unexpected count: %v (%T)", rows[0][1], rows[0][1])
+	}
+	if rows[0][2] != 3.5 {
+		t.Errorf("unexpected price: %v", rows[0][2])
+	}
+	if rows[0][3] != "1990-01-02" {
+		t.Errorf("unexpected date: %v", rows[0][3])
+	}
+	if rows[0][4] != int64(1) {
+		t.Errorf("unexpected logical: %v", rows[0][4])
+	}
+	if rows[1][2] != nil {
+		t.Errorf("blank numeric should be NULL: %v", rows[1][2])
+	}
+}
@@ -0,0 +1,281 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	ParagraphsTB = "paragraphs"
+)
+
+func init() {
+	converters.Register("docx", &docxDriver{}, converters.Capabilities{Extensions: []string{".docx"}, MIMETypes: []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"}})
+}
+
+type docxDriver struct{}
+
+func (d *docxDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewDocxConverterWithConfig(source, config)
+}
+
+// tableData holds one extracted Word table.
+type tableData struct {
+	rawName string // Text of the heading preceding the table
+	headers []string
+	rows    [][]string
+}
+
+// paragraphData holds one paragraph with its heading level (0 for body text).
+type paragraphData struct {
+	level int
+	text  string
+}
+
+// DocxConverter extracts Word tables into SQLite tables (named after the
+// preceding heading, like the markdown converter) plus a "paragraphs" table
+// with heading level and text.
+type DocxConverter struct {
+	tables     []tableData
+	tableNames []string
+	paragraphs []paragraphData
+}
+
+// Ensure DocxConverter implements RowProvider
+var _ common.RowProvider = (*DocxConverter)(nil)
+
+// NewDocxConverter creates a new DocxConverter from an io.Reader.
+func NewDocxConverter(r io.Reader) (*DocxConverter, error) {
+	return NewDocxConverterWithConfig(r, nil)
+}
+
+// NewDocxConverterWithConfig creates a new DocxConverter from an io.Reader with optional config.
+func NewDocxConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*DocxConverter, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docx input: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open docx archive: %w", err)
+	}
+
+	var docXML io.ReadCloser
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			docXML, err = f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to open word/document.xml: %w", err)
+			}
+			break
+		}
+	}
+	if docXML == nil {
+		return nil, fmt.Errorf("not a docx file: word/document.xml missing")
+	}
+	defer docXML.Close()
+
+	c := &DocxConverter{}
+	if err := c.parseDocument(docXML); err != nil {
+		return nil, err
+	}
+
+	// Generate table names once, mirroring the markdown converter
+	rawNames := make([]string, len(c.tables))
+	for i, t := range c.tables {
+		if t.rawName != "" {
+			rawNames[i] = t.rawName
+		} else {
+			rawNames[i] = fmt.Sprintf("table%d", i)
+		}
+	}
+	c.tableNames = common.GenTableNames(rawNames)
+
+	return c, nil
+}
+
+// headingLevel extracts the level from a pStyle value like "Heading1" or "Heading2".
+func headingLevel(style string) int {
+	lower := strings.ToLower(style)
+	if !strings.HasPrefix(lower, "heading") {
+		return 0
+	}
+	if level, err := strconv.Atoi(strings.TrimPrefix(lower, "heading")); err == nil {
+		return level
+	}
+	return 0
+}
+
+// parseDocument walks the WordprocessingML token stream collecting paragraphs
+// and tables.
+func (c *DocxConverter) parseDocument(r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+
+	var lastHeading string
+	var inTable bool
+	var currentTable tableData
+	var currentRow []string
+
+	// Paragraph state (also used for text inside table cells)
+	var paraText strings.Builder
+	var paraStyle string
+	var cellText strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("failed to parse document.xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tbl":
+				inTable = true
+				currentTable = tableData{rawName: lastHeading}
+			case "tr":
+				currentRow = nil
+			case "tc":
+				cellText.Reset()
+			case "p":
+				if !inTable {
+					paraText.Reset()
+					paraStyle = ""
+				}
+			case "pStyle":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" {
+						paraStyle = attr.Value
+					}
+				}
+			case "t":
+				var text string
+				if err := decoder.DecodeElement(&text, &t); err != nil {
+					return fmt.Errorf("failed to decode text run: %w", err)
+				}
+				if inTable {
+					cellText.WriteString(text)
+				} else {
+					paraText.WriteString(text)
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "tbl":
+				inTable = false
+				if len(currentTable.rows) > 0 {
+					currentTable.headers = currentTable.rows[0]
+					currentTable.rows = currentTable.rows[1:]
+				}
+				c.tables = append(c.tables, currentTable)
+			case "tr":
+				if inTable {
+					currentTable.rows = append(currentTable.rows, currentRow)
+				}
+			case "tc":
+				currentRow = append(currentRow, strings.TrimSpace(cellText.String()))
+			case "p":
+				if !inTable {
+					text := strings.TrimSpace(paraText.String())
+					if text == "" {
+						continue
+					}
+					level := headingLevel(paraStyle)
+					if level > 0 {
+						lastHeading = text
+					}
+					c.paragraphs = append(c.paragraphs, paragraphData{level: level, text: text})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetTableNames implements RowProvider
+func (c *DocxConverter) GetTableNames() []string {
+	names := make([]string, 0, len(c.tableNames)+1)
+	names = append(names, c.tableNames...)
+	names = append(names, ParagraphsTB)
+	return names
+}
+
+// GetHeaders implements RowProvider
+func (c *DocxConverter) GetHeaders(tableName string) []string {
+	if tableName == ParagraphsTB {
+		if len(c.paragraphs) == 0 {
+			return nil
+		}
+		return []string{"heading_level", "text"}
+	}
+	for i, name := range c.tableNames {
+		if name == tableName {
+			return common.GenColumnNames(c.tables[i].headers)
+		}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *DocxConverter) GetColumnTypes(tableName string) []string {
+	if tableName == ParagraphsTB {
+		return []string{"INTEGER", "TEXT"}
+	}
+	for i, name := range c.tableNames {
+		if name == tableName {
+			return common.InferColumnTypes(c.tables[i].rows, len(c.tables[i].headers))
+		}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *DocxConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName == ParagraphsTB {
+		for _, p := range c.paragraphs {
+			if err := yield([]interface{}{p.level, p.text}, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		return nil
+	}
+
+	for i, name := range c.tableNames {
+		if name == tableName {
+			for _, row := range c.tables[i].rows {
+				values := make([]interface{}, len(row))
+				for j, val := range row {
+					values[j] = val
+				}
+				if err := yield(values, nil); err != nil {
+					return err
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
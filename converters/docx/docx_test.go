@@ -0,0 +1,107 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+)
+
+// buildDocx wraps a document.xml body in a minimal docx zip.
+func buildDocx(t *testing.T, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("failed to create zip entry: %v", err)
+	}
+	doc := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>` + body + `</w:body></w:document>`
+	if _, err := w.Write([]byte(doc)); err != nil {
+		t.Fatalf("failed to write document.xml: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func para(style, text string) string {
+	pPr := ""
+	if style != "" {
+		pPr = `<w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`
+	}
+	return `<w:p>` + pPr + `<w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+func row(cells ...string) string {
+	out := "<w:tr>"
+	for _, cell := range cells {
+		out += `<w:tc><w:p><w:r><w:t>` + cell + `</w:t></w:r></w:p></w:tc>`
+	}
+	return out + "</w:tr>"
+}
+
+func TestDocxConverter(t *testing.T) {
+	body := para("Heading1", "Expenses") +
+		para("", "Some intro text.") +
+		"<w:tbl>" + row("Item", "Cost") + row("Fuel", "100") + row("Food", "50") + "</w:tbl>"
+
+	data := buildDocx(t, body)
+	conv, err := NewDocxConverter(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 2 {
+		t.Fatalf("expected table + paragraphs, got %v", tables)
+	}
+	if tables[0] != "expenses" {
+		t.Errorf("table should be named after heading, got %v", tables[0])
+	}
+
+	headers := conv.GetHeaders("expenses")
+	if len(headers) != 2 || headers[0] != "item" || headers[1] != "cost" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "expenses", func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 data rows, got %d", len(rows))
+	}
+	if rows[0][0] != "Fuel" || rows[0][1] != "100" {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+
+	// Paragraphs table records heading level and text
+	var paras [][]interface{}
+	err = conv.ScanRows(context.Background(), ParagraphsTB, func(r []interface{}, rowErr error) error {
+		paras = append(paras, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows paragraphs failed: %v", err)
+	}
+	if len(paras) != 2 {
+		t.Fatalf("expected 2 paragraphs, got %d", len(paras))
+	}
+	if paras[0][0] != 1 || paras[0][1] != "Expenses" {
+		t.Errorf("unexpected heading paragraph: %v", paras[0])
+	}
+	if paras[1][0] != 0 {
+		t.Errorf("body paragraph should have level 0: %v", paras[1])
+	}
+}
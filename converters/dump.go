@@ -0,0 +1,37 @@
+package converters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// Version identifies the mksqlite build recorded in SQL dump headers.
+// Overridable at build time with -ldflags "-X ...converters.Version=v1.2.3".
+var Version = "dev"
+
+// DumpOptions controls the prelude written around a streaming SQL dump.
+// Row batches are already wrapped in per-table BEGIN/COMMIT blocks by the
+// shared SQL writer; the header adds provenance and disables foreign key
+// enforcement so tables can be restored in any order.
+type DumpOptions struct {
+	Header     bool   // Write the comment header and PRAGMA prelude.
+	SourcePath string // Recorded in the header as the dump's origin.
+}
+
+// DumpToSQL streams a converter's SQL output to writer, optionally
+// prefixed by a comment header and PRAGMA foreign_keys=OFF per opts.
+func DumpToSQL(ctx context.Context, sc common.StreamConverter, writer io.Writer, opts *DumpOptions) error {
+	if opts != nil && opts.Header {
+		header := fmt.Sprintf(
+			"-- mksqlite dump\n-- source: %s\n-- version: %s\n-- generated: %s\nPRAGMA foreign_keys=OFF;\n\n",
+			opts.SourcePath, Version, time.Now().UTC().Format(time.RFC3339))
+		if _, err := io.WriteString(writer, header); err != nil {
+			return fmt.Errorf("failed to write dump header: %w", err)
+		}
+	}
+	return sc.ConvertToSQL(ctx, writer)
+}
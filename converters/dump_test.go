@@ -0,0 +1,52 @@
+package converters
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fakeStreamConverter struct{}
+
+func (f *fakeStreamConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	_, err := io.WriteString(writer, "CREATE TABLE tb0 (col1 TEXT);\n")
+	return err
+}
+
+func TestDumpToSQLHeader(t *testing.T) {
+	var b strings.Builder
+	err := DumpToSQL(context.Background(), &fakeStreamConverter{}, &b, &DumpOptions{
+		Header:     true,
+		SourcePath: "input.csv",
+	})
+	if err != nil {
+		t.Fatalf("DumpToSQL failed: %v", err)
+	}
+
+	output := b.String()
+	for _, exp := range []string{
+		"-- mksqlite dump",
+		"-- source: input.csv",
+		"-- version: " + Version,
+		"-- generated: ",
+		"PRAGMA foreign_keys=OFF;",
+		"CREATE TABLE tb0 (col1 TEXT);",
+	} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("output missing %q\n%s", exp, output)
+		}
+	}
+	if !strings.HasPrefix(output, "-- mksqlite dump") {
+		t.Errorf("header should come first:\n%s", output)
+	}
+
+	// Without options the output is just the converter's SQL
+	b.Reset()
+	if err := DumpToSQL(context.Background(), &fakeStreamConverter{}, &b, nil); err != nil {
+		t.Fatalf("DumpToSQL without options failed: %v", err)
+	}
+	if strings.Contains(b.String(), "PRAGMA") {
+		t.Errorf("unexpected header without options:\n%s", b.String())
+	}
+}
@@ -0,0 +1,337 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	MessagesTB    = "messages"
+	AttachmentsTB = "attachments"
+)
+
+func init() {
+	converters.Register("email", &emailDriver{}, converters.Capabilities{Extensions: []string{".mbox", ".eml"}, MIMETypes: []string{"application/mbox", "message/rfc822"}, Streaming: true})
+}
+
+type emailDriver struct{}
+
+func (d *emailDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewEmailConverterWithConfig(source, config)
+}
+
+// messageRow holds one parsed email message.
+type messageRow struct {
+	MessageID string
+	Date      string
+	From      string
+	To        string
+	Cc        string
+	Subject   string
+	BodyText  string
+}
+
+// attachmentRow holds one attachment found in a message.
+type attachmentRow struct {
+	MessageID string
+	Filename  string
+	MimeType  string
+	Size      int64
+}
+
+// EmailConverter converts mbox and eml inputs to SQLite tables.
+// It produces a "messages" table and an "attachments" table.
+type EmailConverter struct {
+	messages    []messageRow
+	attachments []attachmentRow
+
+	Config common.ConversionConfig
+}
+
+// Ensure EmailConverter implements RowProvider
+var _ common.RowProvider = (*EmailConverter)(nil)
+
+// Ensure EmailConverter implements StreamConverter
+var _ common.StreamConverter = (*EmailConverter)(nil)
+
+// NewEmailConverter creates a new EmailConverter from an io.Reader.
+func NewEmailConverter(r io.Reader) (*EmailConverter, error) {
+	return NewEmailConverterWithConfig(r, nil)
+}
+
+// NewEmailConverterWithConfig creates a new EmailConverter from an io.Reader with optional config.
+// The input format (mbox vs single eml message) is detected from the leading bytes.
+func NewEmailConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*EmailConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	br := bufio.NewReaderSize(r, 65536)
+
+	peek, _ := br.Peek(5)
+	isMbox := bytes.HasPrefix(peek, []byte("From "))
+
+	c := &EmailConverter{Config: *config}
+
+	if isMbox {
+		if err := c.parseMbox(br); err != nil {
+			return nil, err
+		}
+	} else {
+		raw, err := io.ReadAll(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read eml input: %w", err)
+		}
+		if err := c.parseMessage(raw); err != nil {
+			return nil, fmt.Errorf("failed to parse eml message: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// parseMbox splits an mbox stream on "From " separator lines and parses each message.
+func (c *EmailConverter) parseMbox(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 65536), 16*1024*1024)
+
+	var current bytes.Buffer
+	flush := func() error {
+		if current.Len() == 0 {
+			return nil
+		}
+		if err := c.parseMessage(current.Bytes()); err != nil {
+			// A malformed message should not abort the whole mailbox;
+			// record it with whatever we know.
+			c.messages = append(c.messages, messageRow{Subject: fmt.Sprintf("(unparseable message: %v)", err)})
+		}
+		current.Reset()
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue // Skip the mbox separator line itself
+		}
+		// Unescape mbox ">From " quoting
+		if strings.HasPrefix(line, ">From ") {
+			line = line[1:]
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read mbox line: %w", err)
+	}
+	return flush()
+}
+
+// parseMessage parses one RFC 5322 message and appends rows for it.
+func (c *EmailConverter) parseMessage(raw []byte) error {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+
+	row := messageRow{
+		MessageID: strings.Trim(msg.Header.Get("Message-ID"), "<>"),
+		From:      decodeHeader(msg.Header.Get("From")),
+		To:        decodeHeader(msg.Header.Get("To")),
+		Cc:        decodeHeader(msg.Header.Get("Cc")),
+		Subject:   decodeHeader(msg.Header.Get("Subject")),
+	}
+
+	if t, err := msg.Header.Date(); err == nil {
+		row.Date = t.Format(time.RFC3339)
+	} else {
+		row.Date = msg.Header.Get("Date")
+	}
+
+	body, attachments := c.extractParts(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	row.BodyText = body
+	for i := range attachments {
+		attachments[i].MessageID = row.MessageID
+	}
+
+	c.messages = append(c.messages, row)
+	c.attachments = append(c.attachments, attachments...)
+	return nil
+}
+
+// extractParts walks the MIME structure collecting plain text bodies and attachments.
+func (c *EmailConverter) extractParts(contentType, encoding string, body io.Reader) (string, []attachmentRow) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return "", nil
+		}
+		var text strings.Builder
+		var attachments []attachmentRow
+		mr := multipart.NewReader(body, boundary)
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break // io.EOF or malformed trailing content
+			}
+			partType := part.Header.Get("Content-Type")
+			partEnc := part.Header.Get("Content-Transfer-Encoding")
+			filename := part.FileName()
+			if filename != "" {
+				data, _ := io.ReadAll(decodeBody(part, partEnc))
+				partMedia, _, _ := mime.ParseMediaType(partType)
+				attachments = append(attachments, attachmentRow{
+					Filename: filename,
+					MimeType: partMedia,
+					Size:     int64(len(data)),
+				})
+				continue
+			}
+			subText, subAttachments := c.extractParts(partType, partEnc, part)
+			if subText != "" {
+				if text.Len() > 0 {
+					text.WriteByte('\n')
+				}
+				text.WriteString(subText)
+			}
+			attachments = append(attachments, subAttachments...)
+		}
+		return text.String(), attachments
+	}
+
+	if mediaType == "text/plain" || mediaType == "" {
+		data, _ := io.ReadAll(decodeBody(body, encoding))
+		return string(data), nil
+	}
+
+	// Non-text, non-multipart leaf without a filename: ignore the content.
+	return "", nil
+}
+
+// decodeBody wraps a reader with the appropriate transfer-encoding decoder.
+func decodeBody(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// decodeHeader decodes RFC 2047 encoded-words in a header value.
+func decodeHeader(value string) string {
+	dec := new(mime.WordDecoder)
+	decoded, err := dec.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// GetTableNames implements RowProvider
+func (c *EmailConverter) GetTableNames() []string {
+	return []string{MessagesTB, AttachmentsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *EmailConverter) GetHeaders(tableName string) []string {
+	switch tableName {
+	case MessagesTB:
+		return []string{"message_id", "date", "from_addr", "to_addr", "cc", "subject", "body_text"}
+	case AttachmentsTB:
+		return []string{"message_id", "filename", "mime_type", "size"}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *EmailConverter) GetColumnTypes(tableName string) []string {
+	switch tableName {
+	case MessagesTB:
+		return []string{"TEXT", "TEXT", "TEXT", "TEXT", "TEXT", "TEXT", "TEXT"}
+	case AttachmentsTB:
+		return []string{"TEXT", "TEXT", "TEXT", "INTEGER"}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *EmailConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	switch tableName {
+	case MessagesTB:
+		for _, m := range c.messages {
+			values := []interface{}{m.MessageID, m.Date, m.From, m.To, m.Cc, m.Subject, m.BodyText}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	case AttachmentsTB:
+		for _, a := range c.attachments {
+			values := []interface{}{a.MessageID, a.Filename, a.MimeType, a.Size}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// ConvertToSQL implements StreamConverter for email inputs (outputs SQL to writer).
+func (c *EmailConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	sqlWriter := common.NewSQLWriter(writer, c.Config.RowsPerInsert)
+	for _, tableName := range c.GetTableNames() {
+		headers := c.GetHeaders(tableName)
+		colTypes := c.GetColumnTypes(tableName)
+
+		if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
+			return err
+		}
+
+		err := c.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+			if rowErr != nil {
+				return rowErr
+			}
+			return sqlWriter.WriteRow(row)
+		})
+		if err != nil {
+			return err
+		}
+		if err := sqlWriter.EndTable(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
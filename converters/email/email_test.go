@@ -0,0 +1,139 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleMbox = `From alice@example.com Mon Jan  2 15:04:05 2006
+From: Alice <alice@example.com>
+To: Bob <bob@example.com>
+Cc: carol@example.com
+Subject: Hello
+Message-ID: <msg-1@example.com>
+Date: Mon, 02 Jan 2006 15:04:05 -0700
+
+Hi Bob,
+This is the first message.
+
+From bob@example.com Tue Jan  3 10:00:00 2006
+From: Bob <bob@example.com>
+To: Alice <alice@example.com>
+Subject: Re: Hello
+Message-ID: <msg-2@example.com>
+Date: Tue, 03 Jan 2006 10:00:00 -0700
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="BOUNDARY"
+
+--BOUNDARY
+Content-Type: text/plain
+
+Reply with attachment.
+--BOUNDARY
+Content-Type: application/octet-stream
+Content-Disposition: attachment; filename="data.bin"
+Content-Transfer-Encoding: base64
+
+aGVsbG8gd29ybGQ=
+--BOUNDARY--
+`
+
+func collectRows(t *testing.T, c *EmailConverter, table string) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := c.ScanRows(context.Background(), table, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestEmailConverter_Mbox(t *testing.T) {
+	conv, err := NewEmailConverter(strings.NewReader(sampleMbox))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	messages := collectRows(t, conv, MessagesTB)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	first := messages[0]
+	if first[0] != "msg-1@example.com" {
+		t.Errorf("unexpected message_id: %v", first[0])
+	}
+	if !strings.Contains(first[6].(string), "first message") {
+		t.Errorf("unexpected body: %v", first[6])
+	}
+
+	second := messages[1]
+	if !strings.Contains(second[6].(string), "Reply with attachment") {
+		t.Errorf("unexpected multipart body: %v", second[6])
+	}
+
+	attachments := collectRows(t, conv, AttachmentsTB)
+	if len(attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(attachments))
+	}
+	if attachments[0][1] != "data.bin" {
+		t.Errorf("unexpected filename: %v", attachments[0][1])
+	}
+	if attachments[0][3].(int64) != 11 {
+		t.Errorf("unexpected size: %v", attachments[0][3])
+	}
+}
+
+func TestEmailConverter_Eml(t *testing.T) {
+	eml := `From: Alice <alice@example.com>
+To: bob@example.com
+Subject: =?UTF-8?Q?Encoded_subject?=
+Message-ID: <single@example.com>
+Date: Mon, 02 Jan 2006 15:04:05 -0700
+
+Single message body.
+`
+	conv, err := NewEmailConverter(strings.NewReader(eml))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	messages := collectRows(t, conv, MessagesTB)
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if messages[0][5] != "Encoded subject" {
+		t.Errorf("unexpected subject: %v", messages[0][5])
+	}
+}
+
+func TestEmailConverter_StreamSQL(t *testing.T) {
+	conv, err := NewEmailConverter(strings.NewReader(sampleMbox))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	var builder strings.Builder
+	if err := conv.ConvertToSQL(context.Background(), &builder); err != nil {
+		t.Fatalf("ConvertToSQL failed: %v", err)
+	}
+
+	output := builder.String()
+	for _, exp := range []string{
+		"CREATE TABLE messages",
+		"CREATE TABLE attachments",
+		"INSERT INTO messages",
+		"'msg-1@example.com'",
+	} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Output missing expected string: %q", exp)
+		}
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/darianmavgo/mksqlite/converters/common"
 
@@ -19,106 +23,1506 @@ import (
 
 var ErrInterrupted = errors.New("operation interrupted by user")
 var ErrScanTimeout = errors.New("scan timed out")
+var ErrTooManyErrors = errors.New("too many conversion errors")
+
+// errRowLimit stops a table scan once ImportOptions.Limit is reached.
+var errRowLimit = errors.New("row limit reached")
 
 var (
 	// BatchSize defines the number of rows to insert before committing a transaction.
 	// This ensures that long-running streams save progress periodically.
 	BatchSize = 1000
+
+	// PipelineDepth bounds the channel between the scanning goroutine and
+	// the inserting goroutine, so a fast parser can't run unbounded ahead
+	// of SQLite.
+	PipelineDepth = 256
+)
+
+// ImportOptions defines configuration for the import process.
+type ImportOptions struct {
+	LogErrors bool // If true, errors are logged to a table instead of aborting.
+	Verbose   bool // If true, enables detailed logging.
+	Upsert    bool // If true, tables are created if missing and rows use INSERT OR REPLACE.
+
+	// RejectRows quarantines rows that fail to parse verbatim into a
+	// _rejects_<table> table (line number, raw text, message) so they can
+	// be fixed and re-imported. Only rows reported as common.RowError
+	// carry the raw text; the scan continues past them either way.
+	RejectRows bool
+
+	// Strict refuses to coerce rows into the schema: a row whose column
+	// count differs or whose values don't fit the declared column types
+	// aborts the import instead of being silently padded or truncated.
+	// Combined with LogErrors or RejectRows, violating rows go to the
+	// error log or reject store and the import continues.
+	Strict bool
+
+	// MaxErrors aborts the import once this many row errors have been
+	// logged or quarantined in a table; 0 means no limit.
+	MaxErrors int
+
+	// ErrorRate aborts the import when more than this fraction of a
+	// table's rows has failed (0 < rate < 1), evaluated once at least 100
+	// rows have been seen so a bad first line can't trip it. 0 disables
+	// the check.
+	ErrorRate float64
+
+	// Report, when non-nil, is filled with a conversion summary (per-table
+	// row and error counts, duration, schema) as the import runs.
+	Report *ImportReport
+
+	// Provenance recorded in the _mksqlite_meta manifest table.
+	SourcePath string // Path or URL of the input.
+	DriverName string // Name of the converter driver used.
+
+	// Transforms maps "table.column" to a pipeline applied to each value
+	// before insert, e.g. "trim | replace(',', '')".
+	Transforms map[string]*common.Transform
+
+	// PostSQL holds SQL scripts executed in order on the same connection
+	// once population succeeds and before any temp-file copy, so finished
+	// databases can ship views, summary tables, or ANALYZE results.
+	PostSQL []string
+
+	// RawNameViews creates a <table>_raw view per table aliasing the
+	// sanitized columns back to their original (quoted) header names, so
+	// the names seen in the source file stay queryable.
+	RawNameViews bool
+
+	// Engine selects the population strategy. The default builds the
+	// database through a file-backed connection; "fast" is experimental and
+	// keeps the build in memory, serializing the result straight to
+	// io.Writer outputs without a temp-file copy.
+	Engine string
+
+	// SourceSHA256 is the hex digest of the input stream, recorded in
+	// _mksqlite_meta so consumers can tie the database back to the exact
+	// input bytes. The CLI verifies it against --sha256 before trusting it.
+	SourceSHA256 string
+
+	// Lineage appends _source_file, _source_line, and _imported_at columns
+	// to every table so each row keeps pointing at where it came from after
+	// many inputs are merged into one database.
+	Lineage bool
+
+	// Where filters rows during import; rows the predicate rejects are
+	// never inserted. Evaluated against raw values, before Transforms.
+	Where *common.Predicate
+
+	// Columns restricts which source columns become SQLite columns, keyed
+	// by table name. The "" key applies to every table without its own
+	// entry. Source column order is preserved; Where still sees all
+	// columns.
+	Columns map[string][]string
+
+	// Dedupe skips rows whose values have all been seen before, tracked
+	// in memory across the whole import of each table.
+	Dedupe bool
+
+	// DedupeColumns restricts deduplication to the named key columns,
+	// keyed by table name like Columns. Enforced with a unique index and
+	// INSERT OR IGNORE so arbitrarily large inputs stay cheap to dedupe.
+	DedupeColumns map[string][]string
+
+	// Limit stops each table after this many inserted rows; 0 means no
+	// limit. Handy for quick exploratory imports of very large inputs.
+	Limit int
+
+	// SampleRate keeps roughly this fraction of rows (0 < rate < 1),
+	// decided independently per row while streaming. 0 disables sampling.
+	SampleRate float64
+
+	// WideStrategy selects how tables wider than MaxTableColumns are laid
+	// out: WidePartition splits them into <table>_partN tables joined by
+	// rowid, WideEAV pivots them into a (row_id, column, value) table.
+	// Empty rejects over-wide inputs with an error naming the option.
+	WideStrategy string
+
+	// Melt unpivots every table into a long layout: the named id columns
+	// stay as-is and each remaining column becomes one (variable, value)
+	// row per source row. Empty leaves tables in their source shape.
+	Melt []string
+
+	// Locale names the convention text values use for numbers and dates
+	// (e.g. "de_DE"): grouped numbers like "1.234,56" coerce into their
+	// numeric columns and day-first dates normalize to ISO-8601 before
+	// insert. Empty means plain machine-format parsing.
+	Locale string
+
+	// RawFallback adds a companion <col>_raw TEXT column next to every
+	// numeric column: values that fail numeric coercion land there
+	// verbatim with NULL in the numeric column, instead of silently
+	// polluting it with text.
+	RawFallback bool
+
+	// StrictTables creates STRICT tables (SQLite 3.37+): string values
+	// are coerced into their declared numeric types before insert, and
+	// values that don't fit fail the insert instead of being stored with
+	// a drifting type. Combine with LogErrors or RejectRows to divert
+	// them.
+	StrictTables bool
+
+	// PrimaryKey declares key columns per table, keyed like Columns (the
+	// "" entry applies to every table without its own). They are emitted
+	// as a PRIMARY KEY table constraint, so key lookups need no
+	// post-import index build; duplicate keys fail the insert.
+	PrimaryKey map[string][]string
+
+	// WithoutRowid creates WITHOUT ROWID tables, storing rows clustered
+	// by the declared primary key. Requires PrimaryKey.
+	WithoutRowid bool
+
+	// ForeignKeys declares FOREIGN KEY constraints per child table: each
+	// entry makes one of its columns reference a parent table's column.
+	// The constraints go into the CREATE TABLE DDL, and once population
+	// finishes the engine turns foreign key enforcement on and validates
+	// the data with PRAGMA foreign_key_check, so the finished database is
+	// known to be relationally sound. Referenced parent columns get a
+	// unique index, which SQLite requires of foreign key targets.
+	// Combined with LogErrors, violating rows are logged to
+	// _mksqlite_errors instead of failing the import.
+	ForeignKeys map[string][]common.ForeignKey
+
+	// Formats records the source's per-column number and date format
+	// strings (Excel number format codes, patterns detected in text
+	// samples) in a _mksqlite_formats table, for consumers that need to
+	// re-render values the way the source displayed them. Only honoured
+	// for providers that know their formats.
+	Formats bool
+
+	// Deterministic makes converting the same input twice yield
+	// byte-identical databases: manifest and error-log timestamps are
+	// pinned to the epoch and the finished file is rebuilt with VACUUM so
+	// page layout and freelist state don't depend on the build machine.
+	Deterministic bool
+
+	// SplitRows and SplitSize roll the output over to a new numbered part
+	// database once a part holds this many rows or bytes. Only honoured by
+	// ImportToSQLiteParts; 0 disables the corresponding threshold.
+	SplitRows int64
+	SplitSize int64
+
+	// SQLite performance pragmas. Zero values fall back to bulk-load
+	// defaults (journal_mode=OFF, synchronous=OFF, temp_store=MEMORY,
+	// page_size=65536) tuned for one-shot imports.
+	JournalMode string // journal_mode: OFF, WAL, DELETE, TRUNCATE, PERSIST or MEMORY.
+	Synchronous string // synchronous: OFF, NORMAL, FULL or EXTRA.
+	TempStore   string // temp_store: DEFAULT, FILE or MEMORY.
+	MmapSize    int64  // mmap_size in bytes; 0 leaves memory mapping off.
+	PageSize    int    // page_size in bytes; must be a power of two between 512 and 65536.
+
+	// ApplicationID and UserVersion are written into the database header
+	// (PRAGMA application_id / user_version). ApplicationID 0 falls back
+	// to DefaultApplicationID so downstream tooling can recognize
+	// mksqlite-produced files; UserVersion 0 leaves the header field
+	// untouched and is otherwise free for schema revision tracking.
+	ApplicationID int64
+	UserVersion   int64
+}
+
+// DefaultApplicationID marks databases as produced by mksqlite: the bytes
+// "mksq" in the SQLite header's application_id field.
+const DefaultApplicationID = 0x6d6b7371
+
+// Bulk-load pragma defaults applied when the corresponding option is unset.
+const (
+	defaultJournalMode = "OFF"
+	defaultSynchronous = "OFF"
+	defaultTempStore   = "MEMORY"
+	defaultPageSize    = 65536
 )
 
-// ImportOptions defines configuration for the import process.
-type ImportOptions struct {
-	LogErrors bool // If true, errors are logged to a table instead of aborting.
-	Verbose   bool // If true, enables detailed logging.
-}
+// pragmaValues whitelists the string-valued pragma settings so a typo fails
+// fast instead of being silently ignored by SQLite.
+var pragmaValues = map[string][]string{
+	"journal_mode": {"OFF", "WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY"},
+	"synchronous":  {"OFF", "NORMAL", "FULL", "EXTRA"},
+	"temp_store":   {"DEFAULT", "FILE", "MEMORY"},
+}
+
+func pragmaValue(name, value, fallback string) (string, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	value = strings.ToUpper(value)
+	for _, allowed := range pragmaValues[name] {
+		if value == allowed {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s pragma: %s (want one of %s)", name, value, strings.Join(pragmaValues[name], ", "))
+}
+
+// pragmaSQL builds the PRAGMA statements run before population starts.
+func pragmaSQL(opts *ImportOptions) (string, error) {
+	var journal, synchronous, tempStore string
+	pageSize := defaultPageSize
+	var mmapSize int64
+	if opts != nil {
+		journal, synchronous, tempStore = opts.JournalMode, opts.Synchronous, opts.TempStore
+		if opts.PageSize != 0 {
+			pageSize = opts.PageSize
+		}
+		mmapSize = opts.MmapSize
+	}
+
+	journal, err := pragmaValue("journal_mode", journal, defaultJournalMode)
+	if err != nil {
+		return "", err
+	}
+	synchronous, err = pragmaValue("synchronous", synchronous, defaultSynchronous)
+	if err != nil {
+		return "", err
+	}
+	tempStore, err = pragmaValue("temp_store", tempStore, defaultTempStore)
+	if err != nil {
+		return "", err
+	}
+	if pageSize < 512 || pageSize > 65536 || pageSize&(pageSize-1) != 0 {
+		return "", fmt.Errorf("invalid page_size pragma: %d (want a power of two between 512 and 65536)", pageSize)
+	}
+
+	appID := int64(DefaultApplicationID)
+	var userVersion int64
+	if opts != nil {
+		if opts.ApplicationID != 0 {
+			appID = opts.ApplicationID
+		}
+		userVersion = opts.UserVersion
+	}
+	if appID < 0 || appID > 0xFFFFFFFF {
+		return "", fmt.Errorf("invalid application_id pragma: %d (want a 32-bit value)", appID)
+	}
+	if userVersion < 0 || userVersion > 0xFFFFFFFF {
+		return "", fmt.Errorf("invalid user_version pragma: %d (want a 32-bit value)", userVersion)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "PRAGMA page_size = %d; ", pageSize)
+	fmt.Fprintf(&b, "PRAGMA application_id = %d; ", appID)
+	if userVersion != 0 {
+		fmt.Fprintf(&b, "PRAGMA user_version = %d; ", userVersion)
+	}
+	b.WriteString("PRAGMA cache_size = -2000; ")
+	fmt.Fprintf(&b, "PRAGMA journal_mode = %s; ", journal)
+	fmt.Fprintf(&b, "PRAGMA synchronous = %s; ", synchronous)
+	fmt.Fprintf(&b, "PRAGMA temp_store = %s; ", tempStore)
+	if mmapSize > 0 {
+		fmt.Fprintf(&b, "PRAGMA mmap_size = %d; ", mmapSize)
+	}
+	return b.String(), nil
+}
+
+// serializeTo writes the database content straight to the writer using the
+// driver's serialize support, the same bytes a disk backup would produce.
+func serializeTo(db *sql.DB, writer io.Writer) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("fast engine: failed to get connection: %w", err)
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn interface{}) error {
+		ser, ok := driverConn.(interface{ Serialize() ([]byte, error) })
+		if !ok {
+			return fmt.Errorf("fast engine: driver does not support serialization")
+		}
+		buf, err := ser.Serialize()
+		if err != nil {
+			return fmt.Errorf("fast engine: failed to serialize database: %w", err)
+		}
+		if _, err := writer.Write(buf); err != nil {
+			return fmt.Errorf("failed to write to output: %w", err)
+		}
+		return nil
+	})
+}
+
+// deterministicTimestamp stands in for wall-clock times when
+// ImportOptions.Deterministic is set.
+const deterministicTimestamp = "1970-01-01T00:00:00Z"
+
+// canonicalizeDB rewrites a finished database into a reproducible form:
+// timestamps in the bookkeeping tables are pinned to the epoch, and VACUUM
+// rebuilds the file so page layout and freelist state don't vary between
+// runs.
+func canonicalizeDB(db *sql.DB) error {
+	for _, stmt := range []string{
+		`UPDATE _mksqlite_meta SET converted_at = '` + deterministicTimestamp + `'`,
+		`UPDATE _mksqlite_errors SET timestamp = '` + deterministicTimestamp + `'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil && !strings.Contains(err.Error(), "no such table") {
+			return fmt.Errorf("failed to canonicalize timestamps: %w", err)
+		}
+	}
+	if _, err := db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
+
+// ImportToSQLite imports data from a RowProvider and writes the resulting SQLite database
+// to the provided io.Writer.
+// If writer is an *os.File, it writes directly to that file to allow partial data persistence.
+// Otherwise, it uses a temporary file for construction and copies it to the writer.
+func ImportToSQLite(provider common.RowProvider, writer io.Writer, opts *ImportOptions) error {
+	var dbPath string
+	var useTemp bool = true
+	var useMemory bool
+
+	// Check if writer is a file we can use directly
+	if f, ok := writer.(*os.File); ok {
+		stat, err := f.Stat()
+		// Ensure it's a regular file (not stdout/pipe)
+		if err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+			dbPath = f.Name()
+			useTemp = false
+			if opts != nil && opts.Verbose {
+				log.Printf("[MKSQLITE] Using direct file: %s", dbPath)
+			}
+		}
+	}
+
+	// The fast engine builds in memory and serializes to the writer, so
+	// non-file outputs skip the temp file entirely
+	if useTemp && opts != nil && opts.Engine == "fast" {
+		dbPath = ":memory:"
+		useTemp = false
+		useMemory = true
+		if opts.Verbose {
+			log.Printf("[MKSQLITE] Using in-memory database (fast engine)")
+		}
+	}
+
+	if useTemp {
+		// Create a temporary file
+		tmpFile, err := os.CreateTemp("", "mksqlite-*.db")
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %w", err)
+		}
+		dbPath = tmpFile.Name()
+		tmpFile.Close() // Close it so sql.Open can use it
+
+		if opts != nil && opts.Verbose {
+			log.Printf("[MKSQLITE] Created temp file: %s", dbPath)
+		}
+		defer os.Remove(dbPath) // Clean up temp file
+	}
+
+	// Connect to SQLite database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Limit to 1 connection to avoid locking issues and improve tx.Stmt performance
+	db.SetMaxOpenConns(1)
+
+	// Set performance PRAGMAs before any writes so page_size takes effect
+	pragmas, err := pragmaSQL(opts)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	if _, err := db.Exec(pragmas); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to set PRAGMAs: %w", err)
+	}
+
+	// Populate database
+	if opts != nil && opts.Verbose {
+		log.Printf("[MKSQLITE] Starting database population...")
+	}
+	start := time.Now()
+	err = populateDB(db, provider, opts)
+
+	// Post-import hook scripts run on the same connection so their views
+	// and summary tables land in the file before it is copied out
+	if err == nil && opts != nil {
+		for i, script := range opts.PostSQL {
+			if _, execErr := db.Exec(script); execErr != nil {
+				err = fmt.Errorf("post-import SQL script %d failed: %w", i+1, execErr)
+				break
+			}
+		}
+	}
+	// Reproducible outputs are canonicalized last, after the post-import
+	// hooks have made their changes
+	if err == nil && opts != nil && opts.Deterministic {
+		err = canonicalizeDB(db)
+	}
+	// Serialize the in-memory database to the writer before closing; for a
+	// memory build this is the only copy of the data
+	if useMemory && err == nil {
+		err = serializeTo(db, writer)
+	}
+	db.Close() // Close database connection
+
+	// Fill in the summary totals, also for partial imports
+	if opts != nil && opts.Report != nil {
+		report := opts.Report
+		report.Source = opts.SourcePath
+		report.Driver = opts.DriverName
+		report.DurationSeconds = time.Since(start).Seconds()
+		for _, table := range report.Tables {
+			report.Rows += table.Rows
+			report.Errors += table.Errors
+		}
+		if report.DurationSeconds > 0 {
+			report.RowsPerSecond = float64(report.Rows) / report.DurationSeconds
+		}
+	}
+
+	if useTemp {
+		if err != nil {
+			return err // If failed, don't copy
+		}
+
+		// Open temp file for reading
+		f, err := os.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to open temp file for reading: %w", err)
+		}
+		defer f.Close()
+
+		if opts != nil && opts.Verbose {
+			log.Printf("[MKSQLITE] Copying temp database to final output...")
+		}
+		// Copy to writer
+		if _, err := io.Copy(writer, f); err != nil {
+			return fmt.Errorf("failed to write to output: %w", err)
+		}
+	}
+
+	if opts != nil && opts.Verbose {
+		log.Printf("[MKSQLITE] Conversion completed successfully.")
+	}
+	return err
+}
+
+// partPath numbers the output file for one part, inserting the counter
+// before the extension: out.db becomes out_001.db, out_002.db, ...
+func partPath(basePath string, part int) string {
+	ext := filepath.Ext(basePath)
+	return fmt.Sprintf("%s_%03d%s", strings.TrimSuffix(basePath, ext), part, ext)
+}
+
+// openPart creates one part database with the standard PRAGMAs and the full
+// schema, so every part is a self-contained database with identical tables.
+func openPart(path string, pragmas string, schema []string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open part database %s: %w", path, err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(pragmas); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set PRAGMAs on %s: %w", path, err)
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create schema in %s: %w", path, err)
+		}
+	}
+	return db, nil
+}
+
+// partSize reports the on-disk size of one part, including any journal, so
+// the SplitSize check sees bytes that are already committed.
+func partSize(path string) int64 {
+	var size int64
+	for _, p := range []string{path, path + "-wal", path + "-journal"} {
+		if info, err := os.Stat(p); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// ImportToSQLiteParts imports like ImportToSQLite but rolls the output over
+// to a new numbered part database (out_001.db, out_002.db, ...) once a part
+// holds opts.SplitRows rows or opts.SplitSize bytes, for consumers that
+// cannot handle one huge file. Every part carries the full schema, and a
+// table that hits a threshold mid-scan continues in the next part. The
+// thresholds are checked at commit boundaries, so parts can overshoot by up
+// to one batch. Split imports use the plain insert path only: dedupe,
+// filtering, transforms and error tolerance are not supported. It returns
+// the paths of the parts written.
+func ImportToSQLiteParts(provider common.RowProvider, basePath string, opts *ImportOptions) ([]string, error) {
+	if opts == nil || (opts.SplitRows <= 0 && opts.SplitSize <= 0) {
+		return nil, fmt.Errorf("split import requires a positive SplitRows or SplitSize")
+	}
+
+	pragmas, err := pragmaSQL(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the full schema up front so each part gets identical tables
+	var schema []string
+	type tableInsert struct {
+		name      string
+		headers   []string
+		insertSQL string
+	}
+	var tables []tableInsert
+	for _, tableName := range provider.GetTableNames() {
+		headers := provider.GetHeaders(tableName)
+		if len(headers) == 0 {
+			continue
+		}
+		colTypes := provider.GetColumnTypes(tableName)
+		schema = append(schema, common.GenCreateTableSQLWithTypes(tableName, headers, colTypes))
+		insertSQL, err := common.GenPreparedStmt(tableName, headers, common.InsertStmt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate insert statement for table %s: %w", tableName, err)
+		}
+		tables = append(tables, tableInsert{name: tableName, headers: headers, insertSQL: insertSQL})
+	}
+
+	// Stop cleanly on Ctrl+C, keeping the parts written so far
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	start := time.Now()
+	part := 1
+	partFile := partPath(basePath, part)
+	parts := []string{partFile}
+	db, err := openPart(partFile, pragmas, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if db != nil {
+			db.Close()
+		}
+	}()
+
+	var partRows int64
+	for _, table := range tables {
+		if opts.Verbose {
+			log.Printf("[MKSQLITE] Populating table: %s", table.name)
+		}
+
+		// Prepare before Begin: the single connection is shared with the tx
+		stmt, err := db.Prepare(table.insertSQL)
+		if err != nil {
+			return parts, fmt.Errorf("failed to prepare insert for table %s: %w", table.name, err)
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			stmt.Close()
+			return parts, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		txStmt := tx.Stmt(stmt)
+
+		tableRows := 0
+		rowsInTx := 0
+		err = provider.ScanRows(ctx, table.name, func(row []interface{}, rowErr error) error {
+			if rowErr != nil {
+				return rowErr
+			}
+			if len(row) < len(table.headers) {
+				padded := make([]interface{}, len(table.headers))
+				copy(padded, row)
+				row = padded
+			} else if len(row) > len(table.headers) {
+				row = row[:len(table.headers)]
+			}
+			if _, err := txStmt.Exec(row...); err != nil {
+				return fmt.Errorf("failed to insert row in table %s: %w", table.name, err)
+			}
+			tableRows++
+			partRows++
+			rowsInTx++
+			if rowsInTx < BatchSize {
+				return nil
+			}
+
+			// Commit boundary: check thresholds and roll over when hit
+			if err := tx.Commit(); err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			rowsInTx = 0
+			if (opts.SplitRows > 0 && partRows >= opts.SplitRows) ||
+				(opts.SplitSize > 0 && partSize(partFile) >= opts.SplitSize) {
+				stmt.Close()
+				if err := db.Close(); err != nil {
+					return fmt.Errorf("failed to close part %s: %w", partFile, err)
+				}
+				db = nil
+				part++
+				partFile = partPath(basePath, part)
+				db, err = openPart(partFile, pragmas, schema)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, partFile)
+				partRows = 0
+				if opts.Verbose {
+					log.Printf("[MKSQLITE] Rolled over to part: %s", partFile)
+				}
+				if stmt, err = db.Prepare(table.insertSQL); err != nil {
+					return fmt.Errorf("failed to prepare insert for table %s: %w", table.name, err)
+				}
+			}
+			if tx, err = db.Begin(); err != nil {
+				return fmt.Errorf("failed to begin transaction: %w", err)
+			}
+			txStmt = tx.Stmt(stmt)
+			return nil
+		})
+		if err != nil {
+			tx.Rollback()
+			stmt.Close()
+			if errors.Is(err, context.Canceled) {
+				return parts, ErrInterrupted
+			}
+			return parts, err
+		}
+		if err := tx.Commit(); err != nil {
+			stmt.Close()
+			return parts, fmt.Errorf("failed to commit table %s: %w", table.name, err)
+		}
+		stmt.Close()
+
+		if opts.Report != nil {
+			colTypes := provider.GetColumnTypes(table.name)
+			tr := TableReport{Name: table.name, Rows: tableRows}
+			for i, header := range table.headers {
+				colType := "TEXT"
+				if i < len(colTypes) {
+					colType = colTypes[i]
+				}
+				tr.Columns = append(tr.Columns, ColumnReport{Name: header, Type: colType})
+			}
+			opts.Report.Tables = append(opts.Report.Tables, tr)
+		}
+		if opts.Verbose {
+			log.Printf("[MKSQLITE] Finished table %s, total rows: %d", table.name, tableRows)
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		return parts, fmt.Errorf("failed to close part %s: %w", partFile, err)
+	}
+	db = nil
+
+	if opts.Report != nil {
+		report := opts.Report
+		report.Source = opts.SourcePath
+		report.Driver = opts.DriverName
+		report.DurationSeconds = time.Since(start).Seconds()
+		for _, table := range report.Tables {
+			report.Rows += table.Rows
+			report.Errors += table.Errors
+		}
+		if report.DurationSeconds > 0 {
+			report.RowsPerSecond = float64(report.Rows) / report.DurationSeconds
+		}
+	}
+	return parts, nil
+}
+
+// writeColumnMapping records the original→sanitized column name mapping in
+// the _mksqlite_columns metadata table so cl0/cl3-style names stay traceable.
+func writeColumnMapping(db *sql.DB, tableName string, rawHeaders, headers []string) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS _mksqlite_columns (
+		table_name TEXT,
+		position INTEGER,
+		raw_name TEXT,
+		column_name TEXT
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create column mapping table: %w", err)
+	}
+
+	stmt, err := db.Prepare(`INSERT INTO _mksqlite_columns (table_name, position, raw_name, column_name) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare column mapping insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, header := range headers {
+		raw := ""
+		if i < len(rawHeaders) {
+			raw = rawHeaders[i]
+		}
+		if _, err := stmt.Exec(tableName, i, raw, header); err != nil {
+			return fmt.Errorf("failed to record column mapping for %s: %w", tableName, err)
+		}
+	}
+	return nil
+}
+
+// writeFormats records the per-column format strings in the
+// _mksqlite_formats metadata table; columns without a known format are
+// skipped.
+func writeFormats(db *sql.DB, tableName string, headers, formats []string) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS _mksqlite_formats (
+		table_name TEXT,
+		position INTEGER,
+		column_name TEXT,
+		format TEXT
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create formats table: %w", err)
+	}
+
+	stmt, err := db.Prepare(`INSERT INTO _mksqlite_formats (table_name, position, column_name, format) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare formats insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, header := range headers {
+		if i >= len(formats) || formats[i] == "" {
+			continue
+		}
+		if _, err := stmt.Exec(tableName, i, header, formats[i]); err != nil {
+			return fmt.Errorf("failed to record format for %s: %w", tableName, err)
+		}
+	}
+	return nil
+}
+
+// tableSelection resolves a per-table column list, falling back to the ""
+// entry that applies to every table.
+func tableSelection(m map[string][]string, tableName string) []string {
+	if selection, ok := m[tableName]; ok {
+		return selection
+	}
+	return m[""]
+}
+
+// projectionIndexes resolves the column selection for a table into source
+// column indexes, preserving source order. A nil result means no projection.
+func projectionIndexes(opts *ImportOptions, tableName string, headers []string) ([]int, error) {
+	if opts == nil || len(opts.Columns) == 0 {
+		return nil, nil
+	}
+	selection := tableSelection(opts.Columns, tableName)
+	if len(selection) == 0 {
+		return nil, nil
+	}
+
+	selected := make(map[string]bool, len(selection))
+	for _, col := range selection {
+		selected[strings.TrimSpace(col)] = true
+	}
+	var keep []int
+	for i, header := range headers {
+		if selected[header] {
+			keep = append(keep, i)
+		}
+	}
+	if len(keep) == 0 {
+		return nil, fmt.Errorf("no columns in table %s match selection %v", tableName, selection)
+	}
+	return keep, nil
+}
+
+// primaryKeyColumns resolves the declared primary key of one table
+// against its (possibly projected) insert headers; an empty result means
+// no key was declared. WITHOUT ROWID without a resolvable key is an
+// error, since SQLite requires such tables to carry one.
+func primaryKeyColumns(opts *ImportOptions, tableName string, headers []string) ([]string, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	var pk []string
+	for _, col := range tableSelection(opts.PrimaryKey, tableName) {
+		col = strings.TrimSpace(col)
+		found := ""
+		for _, header := range headers {
+			if header == col || strings.Trim(header, `"`) == col {
+				found = header
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("primary key column %s not found in table %s", col, tableName)
+		}
+		pk = append(pk, found)
+	}
+	if len(pk) == 0 && opts.WithoutRowid {
+		return nil, fmt.Errorf("WITHOUT ROWID tables require a primary key (table %s)", tableName)
+	}
+	return pk, nil
+}
+
+// foreignKeyColumns resolves the declared foreign keys of one table
+// against its (possibly projected) insert headers, so the constraints
+// name the sanitized columns actually created.
+func foreignKeyColumns(opts *ImportOptions, tableName string, headers []string) ([]common.ForeignKey, error) {
+	if opts == nil || len(opts.ForeignKeys) == 0 {
+		return nil, nil
+	}
+	var fks []common.ForeignKey
+	for _, fk := range opts.ForeignKeys[tableName] {
+		col := strings.TrimSpace(fk.Column)
+		found := ""
+		for _, header := range headers {
+			if header == col || strings.Trim(header, `"`) == col {
+				found = header
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("foreign key column %s not found in table %s", col, tableName)
+		}
+		fk.Column = found
+		fks = append(fks, fk)
+	}
+	return fks, nil
+}
+
+// checkForeignKeys turns foreign key enforcement on for the rest of the
+// build and validates the populated tables with PRAGMA foreign_key_check.
+// Enforcing the constraints during the load would demand a
+// parent-before-child table order providers don't guarantee, so the data
+// is validated once, after every table is in place. SQLite requires the
+// referenced columns to be uniquely indexed, so each parent column gets a
+// unique index first; duplicate parent values surface there. With
+// logErrors the violations land in _mksqlite_errors and the import
+// continues; otherwise the import fails naming the first one.
+func checkForeignKeys(db *sql.DB, foreignKeys map[string][]common.ForeignKey, logErrors bool) error {
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		return fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+
+	indexed := make(map[string]bool)
+	for _, fks := range foreignKeys {
+		for _, fk := range fks {
+			key := fk.RefTable + "." + fk.RefColumn
+			if indexed[key] {
+				continue
+			}
+			indexed[key] = true
+			indexSQL := fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s_%s_key ON %s (%s)",
+				fk.RefTable, strings.Trim(fk.RefColumn, `"`), fk.RefTable, fk.RefColumn)
+			if _, err := db.Exec(indexSQL); err != nil {
+				return fmt.Errorf("failed to index foreign key parent %s: %w", key, err)
+			}
+		}
+	}
+
+	// Collect before acting: the single pooled connection is held while
+	// the pragma's result set is open
+	type fkViolation struct {
+		table, parent string
+		rowid         interface{}
+	}
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return fmt.Errorf("foreign key check failed: %w", err)
+	}
+	var violations []fkViolation
+	for rows.Next() {
+		var v fkViolation
+		var fkid interface{}
+		if err := rows.Scan(&v.table, &v.rowid, &v.parent, &fkid); err != nil {
+			rows.Close()
+			return fmt.Errorf("foreign key check failed: %w", err)
+		}
+		violations = append(violations, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("foreign key check failed: %w", err)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	first := violations[0]
+	if !logErrors {
+		return fmt.Errorf("foreign key check failed: %d violating row(s), e.g. row %v of %s references a missing %s row",
+			len(violations), first.rowid, first.table, first.parent)
+	}
+	stmt, err := db.Prepare(`INSERT INTO _mksqlite_errors (message, table_name, row_data) VALUES (?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare log statement: %w", err)
+	}
+	defer stmt.Close()
+	for _, v := range violations {
+		msg := fmt.Sprintf("foreign key violation: references a missing %s row", v.parent)
+		if _, err := stmt.Exec(msg, v.table, fmt.Sprintf("rowid %v", v.rowid)); err != nil {
+			return fmt.Errorf("failed to log foreign key violation: %w", err)
+		}
+	}
+	return nil
+}
+
+// projectStrings picks the entries of values at the kept indexes, skipping
+// indexes past the end (column types can be shorter than headers).
+func projectStrings(values []string, keep []int) []string {
+	projected := make([]string, 0, len(keep))
+	for _, idx := range keep {
+		if idx < len(values) {
+			projected = append(projected, values[idx])
+		}
+	}
+	return projected
+}
+
+// ensureMetaTable creates the _mksqlite_meta manifest table if missing.
+func ensureMetaTable(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS _mksqlite_meta (
+		source_file TEXT,
+		converter TEXT,
+		table_name TEXT,
+		position INTEGER,
+		raw_name TEXT,
+		column_name TEXT,
+		column_type TEXT,
+		row_count INTEGER,
+		source_sha256 TEXT,
+		type_confidence REAL,
+		converted_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create meta table: %w", err)
+	}
+	// Databases written before the columns existed (refresh, upsert) grow
+	// them in place; the duplicate-column error on current schemas is expected
+	for _, alter := range []string{
+		`ALTER TABLE _mksqlite_meta ADD COLUMN source_sha256 TEXT`,
+		`ALTER TABLE _mksqlite_meta ADD COLUMN type_confidence REAL`,
+	} {
+		if _, err := db.Exec(alter); err != nil &&
+			!strings.Contains(err.Error(), "duplicate column name") {
+			return fmt.Errorf("failed to widen meta table: %w", err)
+		}
+	}
+	return nil
+}
+
+// evolveSchema reconciles an already existing table with the incoming
+// headers when appending: columns the new file introduces are added with
+// ALTER TABLE ADD COLUMN, and columns it no longer carries stay in place and
+// fill with NULL, instead of the insert failing on a column mismatch. Both
+// kinds of change are logged in _mksqlite_meta with position -1 so the
+// schema history stays traceable.
+func evolveSchema(db *sql.DB, opts *ImportOptions, tableName string, headers, colTypes []string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", tableName))
+	if err != nil {
+		return fmt.Errorf("failed to read columns of table %s: %w", tableName, err)
+	}
+	var existingCols []string
+	for rows.Next() {
+		var cid int
+		var colName, colType string
+		var notNull, pk int
+		var dflt interface{}
+		if err := rows.Scan(&cid, &colName, &colType, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existingCols = append(existingCols, colName)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(existingCols))
+	for _, col := range existingCols {
+		existing[col] = true
+	}
+
+	type schemaChange struct {
+		column, colType, change string
+	}
+	var changes []schemaChange
+
+	incoming := make(map[string]bool, len(headers))
+	for i, header := range headers {
+		name := strings.Trim(header, `"`)
+		incoming[name] = true
+		if existing[name] {
+			continue
+		}
+		colType := ""
+		if i < len(colTypes) {
+			colType = colTypes[i]
+		}
+		alterSQL := strings.TrimSpace(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, header, colType))
+		if _, err := db.Exec(alterSQL); err != nil {
+			return fmt.Errorf("failed to add column %s to table %s: %w", name, tableName, err)
+		}
+		changes = append(changes, schemaChange{column: name, colType: colType, change: "column added"})
+	}
+	for _, col := range existingCols {
+		if !incoming[col] {
+			changes = append(changes, schemaChange{column: col, change: "column missing, filled with NULL"})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := ensureMetaTable(db); err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(`INSERT INTO _mksqlite_meta
+		(source_file, converter, table_name, position, raw_name, column_name, column_type, row_count, source_sha256)
+		VALUES (?, ?, ?, -1, ?, ?, ?, 0, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare schema change insert: %w", err)
+	}
+	defer stmt.Close()
+	var sourcePath, driverName, sourceSHA string
+	if opts != nil {
+		sourcePath = opts.SourcePath
+		driverName = opts.DriverName
+		sourceSHA = opts.SourceSHA256
+	}
+	for _, change := range changes {
+		if _, err := stmt.Exec(sourcePath, driverName, tableName, change.change, change.column, change.colType, sourceSHA); err != nil {
+			return fmt.Errorf("failed to record schema change for %s: %w", tableName, err)
+		}
+	}
+	if opts != nil && opts.Verbose {
+		log.Printf("[MKSQLITE] Table %s schema evolved: %d change(s) recorded", tableName, len(changes))
+	}
+	return nil
+}
+
+// writeMeta records one manifest row per column in the _mksqlite_meta table
+// so downstream consumers can tell what cl0/cl3-style names originally meant
+// and where the data came from. confidences holds the per-column share of
+// inserted values that actually fit the declared type; negative entries
+// (columns with no data) and missing entries record as NULL.
+func writeMeta(db *sql.DB, opts *ImportOptions, tableName string, rawHeaders, headers, colTypes []string, rowCount int, confidences []float64) error {
+	if err := ensureMetaTable(db); err != nil {
+		return err
+	}
+
+	var sourcePath, driverName, sourceSHA string
+	if opts != nil {
+		sourcePath = opts.SourcePath
+		driverName = opts.DriverName
+		sourceSHA = opts.SourceSHA256
+	}
+
+	stmt, err := db.Prepare(`INSERT INTO _mksqlite_meta
+		(source_file, converter, table_name, position, raw_name, column_name, column_type, row_count, source_sha256, type_confidence)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare meta insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, header := range headers {
+		raw := ""
+		if i < len(rawHeaders) {
+			raw = rawHeaders[i]
+		}
+		colType := ""
+		if i < len(colTypes) {
+			colType = colTypes[i]
+		}
+		var confidence interface{}
+		if i < len(confidences) && confidences[i] >= 0 {
+			confidence = confidences[i]
+		}
+		if _, err := stmt.Exec(sourcePath, driverName, tableName, i, raw, header, colType, rowCount, sourceSHA, confidence); err != nil {
+			return fmt.Errorf("failed to record meta for %s: %w", tableName, err)
+		}
+	}
+	return nil
+}
+
+// createRawNameView creates a <table>_raw view that aliases each sanitized
+// column back to its original header text, quoted, so analysts can query
+// with the names they see in the source file.
+// lineageColumns and lineageTypes describe the per-row provenance columns
+// added when ImportOptions.Lineage is set. _source_line is the 1-based row
+// ordinal within the table as streamed from the provider.
+var (
+	lineageColumns = []string{"_source_file", "_source_line", "_imported_at"}
+	lineageTypes   = []string{"TEXT", "INTEGER", "TEXT"}
+)
+
+func createRawNameView(db *sql.DB, tableName string, rawHeaders, headers []string) error {
+	selects := make([]string, 0, len(headers))
+	for i, header := range headers {
+		raw := ""
+		if i < len(rawHeaders) {
+			raw = rawHeaders[i]
+		}
+		if raw == "" || raw == strings.Trim(header, `"`) {
+			selects = append(selects, header)
+			continue
+		}
+		selects = append(selects, fmt.Sprintf(`%s AS "%s"`, header, strings.ReplaceAll(raw, `"`, `""`)))
+	}
+	viewSQL := fmt.Sprintf(`CREATE VIEW IF NOT EXISTS "%s_raw" AS SELECT %s FROM %s`,
+		tableName, strings.Join(selects, ", "), tableName)
+	if _, err := db.Exec(viewSQL); err != nil {
+		return fmt.Errorf("failed to create raw-name view for %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// populateDB handles the common logic of creating tables and inserting rows
+// batchEligible reports whether the columnar fast path can honor the
+// requested options; any per-row feature forces the row path.
+func batchEligible(opts *ImportOptions) bool {
+	if opts == nil {
+		return true
+	}
+	return !opts.LogErrors && !opts.RejectRows && !opts.Strict && !opts.Dedupe && len(opts.DedupeColumns) == 0 &&
+		opts.Where == nil && opts.Limit == 0 && opts.SampleRate == 0 &&
+		len(opts.Transforms) == 0 && !opts.Lineage && opts.Locale == "" && !opts.StrictTables
+}
+
+// strictViolation reports why a row breaks the declared schema, or "" when
+// it conforms: the column count must match exactly, and each value must
+// already fit its column's declared type. Declared types can run short of
+// the headers; unmatched columns and NULLs always pass. A non-nil locale
+// accepts numbers written in that locale's format.
+func strictViolation(row []interface{}, headers, colTypes []string, loc *common.Locale) string {
+	if len(row) != len(headers) {
+		return fmt.Sprintf("column count mismatch: got %d, want %d", len(row), len(headers))
+	}
+	for i, value := range row {
+		if value == nil || i >= len(colTypes) {
+			continue
+		}
+		if !valueFitsType(value, colTypes[i], loc) {
+			header := ""
+			if i < len(headers) {
+				header = headers[i]
+			}
+			return fmt.Sprintf("value %v does not fit %s column %s", value, colTypes[i], header)
+		}
+	}
+	return ""
+}
+
+// valueFitsType reports whether a value already fits a declared column
+// type without SQLite's affinity fudging it; NULLs and unknown types
+// always fit. A non-nil locale accepts numbers in that locale's format.
+func valueFitsType(value interface{}, colType string, loc *common.Locale) bool {
+	switch strings.ToUpper(colType) {
+	case "INTEGER":
+		switch v := value.(type) {
+		case int, int64:
+			return true
+		case string:
+			_, err := loc.ParseInt(strings.TrimSpace(v))
+			return err == nil
+		default:
+			return false
+		}
+	case "REAL":
+		switch v := value.(type) {
+		case int, int64, float64:
+			return true
+		case string:
+			_, err := loc.ParseFloat(strings.TrimSpace(v))
+			return err == nil
+		default:
+			return false
+		}
+	case "BLOB":
+		_, ok := value.([]byte)
+		return ok
+	}
+	return true
+}
+
+// typeConfidence tracks, per column, how many inserted values actually
+// fit the declared type, backing the type_confidence column of
+// _mksqlite_meta: 1.0 means every value fit, lower values flag columns
+// the inference call got wrong for part of the data. NULL values don't
+// count either way.
+type typeConfidence struct {
+	types  []string
+	loc    *common.Locale
+	fits   []int
+	values []int
+}
+
+func newTypeConfidence(colTypes []string, loc *common.Locale) *typeConfidence {
+	return &typeConfidence{
+		types:  colTypes,
+		loc:    loc,
+		fits:   make([]int, len(colTypes)),
+		values: make([]int, len(colTypes)),
+	}
+}
+
+// observe counts one value of column i; a nil tracker counts nothing.
+func (tc *typeConfidence) observe(i int, value interface{}) {
+	if tc == nil || value == nil || i >= len(tc.types) {
+		return
+	}
+	tc.values[i]++
+	if valueFitsType(value, tc.types[i], tc.loc) {
+		tc.fits[i]++
+	}
+}
+
+// confidences returns the per-column fit share; columns that never saw a
+// value report -1 (recorded as NULL).
+func (tc *typeConfidence) confidences() []float64 {
+	out := make([]float64, len(tc.types))
+	for i := range out {
+		if tc.values[i] == 0 {
+			out[i] = -1
+			continue
+		}
+		out[i] = float64(tc.fits[i]) / float64(tc.values[i])
+	}
+	return out
+}
+
+// populateTableFromBatches is the columnar fast path: batches from a
+// common.BatchProvider are bound with one multi-row prepared statement per
+// chunk instead of one Exec per row. Chunks are capped so a statement never
+// exceeds SQLite's conservative host-parameter limit.
+func populateTableFromBatches(db *sql.DB, bp common.BatchProvider, tableName string, headers []string, opts *ImportOptions, tc *typeConfidence) (int, error) {
+	single, err := common.GenPreparedStmt(tableName, headers, common.InsertStmt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate insert statement for table %s: %w", tableName, err)
+	}
+	if opts != nil && opts.Upsert {
+		single = strings.Replace(single, "INSERT INTO ", "INSERT OR REPLACE INTO ", 1)
+	}
+
+	const maxParams = 999
+	chunk := maxParams / len(headers)
+	if chunk < 1 {
+		chunk = 1
+	}
+	if chunk > BatchSize {
+		chunk = BatchSize
+	}
+	tuple := ",(" + strings.Repeat("?,", len(headers)-1) + "?)"
+	multi := single + strings.Repeat(tuple, chunk-1)
+
+	// Prepare both statements before the transaction starts: the single
+	// pooled connection is held once a transaction is open
+	mainMulti, err := db.Prepare(multi)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare batch insert for table %s: %w", tableName, err)
+	}
+	defer mainMulti.Close()
+	mainSingle, err := db.Prepare(single)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare insert statement for table %s: %w", tableName, err)
+	}
+	defer mainSingle.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	multiStmt := tx.Stmt(mainMulti)
+	singleStmt := tx.Stmt(mainSingle)
 
-// ImportToSQLite imports data from a RowProvider and writes the resulting SQLite database
-// to the provided io.Writer.
-// If writer is an *os.File, it writes directly to that file to allow partial data persistence.
-// Otherwise, it uses a temporary file for construction and copies it to the writer.
-func ImportToSQLite(provider common.RowProvider, writer io.Writer, opts *ImportOptions) error {
-	var dbPath string
-	var useTemp bool = true
+	args := make([]interface{}, 0, chunk*len(headers))
+	pending := 0
+	rowCount := 0
+	sinceCommit := 0
 
-	// Check if writer is a file we can use directly
-	if f, ok := writer.(*os.File); ok {
-		stat, err := f.Stat()
-		// Ensure it's a regular file (not stdout/pipe)
-		if err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
-			dbPath = f.Name()
-			useTemp = false
-			if opts != nil && opts.Verbose {
-				log.Printf("[MKSQLITE] Using direct file: %s", dbPath)
+	// flush binds the pending rows: a full chunk goes through the multi-row
+	// statement, a short tail row by row. Commits follow BatchSize like the
+	// row path.
+	flush := func() error {
+		if pending == 0 {
+			return nil
+		}
+		var execErr error
+		if pending == chunk {
+			_, execErr = multiStmt.Exec(args...)
+		} else {
+			for r := 0; r < pending; r++ {
+				if _, execErr = singleStmt.Exec(args[r*len(headers) : (r+1)*len(headers)]...); execErr != nil {
+					break
+				}
+			}
+		}
+		if execErr != nil {
+			return fmt.Errorf("failed to insert batch in table %s: %w", tableName, execErr)
+		}
+		rowCount += pending
+		sinceCommit += pending
+		args = args[:0]
+		pending = 0
+		if sinceCommit >= BatchSize {
+			multiStmt.Close()
+			singleStmt.Close()
+			if err := tx.Commit(); err != nil {
+				return fmt.Errorf("failed to commit transaction for table %s: %w", tableName, err)
 			}
+			var beginErr error
+			tx, beginErr = db.Begin()
+			if beginErr != nil {
+				return fmt.Errorf("failed to begin transaction: %w", beginErr)
+			}
+			multiStmt = tx.Stmt(mainMulti)
+			singleStmt = tx.Stmt(mainSingle)
+			sinceCommit = 0
 		}
+		return nil
 	}
 
-	if useTemp {
-		// Create a temporary file
-		tmpFile, err := os.CreateTemp("", "mksqlite-*.db")
-		if err != nil {
-			return fmt.Errorf("failed to create temp file: %w", err)
+	// Setup signal handling context
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	err = bp.ScanBatches(ctx, tableName, func(cols [][]interface{}) error {
+		if len(cols) != len(headers) {
+			return fmt.Errorf("batch for table %s has %d columns, want %d", tableName, len(cols), len(headers))
 		}
-		dbPath = tmpFile.Name()
-		tmpFile.Close() // Close it so sql.Open can use it
+		if len(cols) == 0 {
+			return nil
+		}
+		n := len(cols[0])
+		for _, col := range cols {
+			if len(col) != n {
+				return fmt.Errorf("ragged batch for table %s", tableName)
+			}
+		}
+		for r := 0; r < n; r++ {
+			for ci, col := range cols {
+				args = append(args, col[r])
+				tc.observe(ci, col[r])
+			}
+			pending++
+			if pending == chunk {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 
-		if opts != nil && opts.Verbose {
-			log.Printf("[MKSQLITE] Created temp file: %s", dbPath)
+	if err != nil {
+		if errors.Is(err, ErrInterrupted) || errors.Is(err, ErrScanTimeout) {
+			if commitErr := tx.Commit(); commitErr != nil {
+				log.Printf("[MKSQLITE] Failed to commit on stop: %v", commitErr)
+			}
+			return rowCount, err
 		}
-		defer os.Remove(dbPath) // Clean up temp file
+		tx.Rollback()
+		return rowCount, fmt.Errorf("failed to scan batches for table %s: %w", tableName, err)
 	}
+	if err := flush(); err != nil {
+		tx.Rollback()
+		return rowCount, err
+	}
+	multiStmt.Close()
+	singleStmt.Close()
+	if err := tx.Commit(); err != nil {
+		return rowCount, fmt.Errorf("failed to commit transaction for table %s: %w", tableName, err)
+	}
+	return rowCount, nil
+}
 
-	// Connect to SQLite database
-	db, err := sql.Open("sqlite", dbPath)
-	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+// scanRowsPipelined runs provider.ScanRows in its own goroutine and feeds
+// rows through a bounded channel, so parsing and insert execution overlap
+// for providers without their own pipeline. Rows are copied before handoff
+// because providers may reuse their row slices between yields.
+func scanRowsPipelined(ctx context.Context, provider common.RowProvider, tableName string, yield func([]interface{}, error) error) error {
+	type rowOrErr struct {
+		row []interface{}
+		err error
 	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	// Limit to 1 connection to avoid locking issues and improve tx.Stmt performance
-	db.SetMaxOpenConns(1)
+	rowsCh := make(chan rowOrErr, PipelineDepth)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(rowsCh)
+		scanErr <- provider.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+			r := rowOrErr{err: rowErr}
+			if row != nil {
+				r.row = append(make([]interface{}, 0, len(row)), row...)
+			}
+			select {
+			case rowsCh <- r:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
 
-	// Set PRAGMA page_size and cache_size for performance
-	if _, err := db.Exec("PRAGMA page_size = 65536; PRAGMA cache_size = -2000;"); err != nil {
-		return fmt.Errorf("failed to set PRAGMAs: %w", err)
+	var yieldErr error
+	for r := range rowsCh {
+		if yieldErr != nil {
+			continue // Drain so the producer can exit
+		}
+		if err := yield(r.row, r.err); err != nil {
+			yieldErr = err
+			cancel()
+		}
 	}
-
-	// Populate database
-	if opts != nil && opts.Verbose {
-		log.Printf("[MKSQLITE] Starting database population...")
+	if yieldErr != nil {
+		return yieldErr
 	}
-	err = populateDB(db, provider, opts)
-	db.Close() // Close database connection
+	return <-scanErr
+}
 
-	if useTemp {
+func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) error {
+	var locale *common.Locale
+	if opts != nil && opts.Locale != "" {
+		loc, err := common.ParseLocale(opts.Locale)
 		if err != nil {
-			return err // If failed, don't copy
+			return err
 		}
+		locale = loc
+	}
 
-		// Open temp file for reading
-		f, err := os.Open(dbPath)
-		if err != nil {
-			return fmt.Errorf("failed to open temp file for reading: %w", err)
-		}
-		defer f.Close()
+	// Melting runs first since it narrows tables, then raw-fallback
+	// companions double the numeric columns; anything still wider than
+	// SQLite's column limit is re-laid-out (or refused) last, before any
+	// schema is derived from the provider
+	provider, err := wrapMeltProvider(provider, opts)
+	if err != nil {
+		return err
+	}
+	provider = wrapRawFallbackProvider(provider, opts, locale)
+	provider, err = wrapWideProvider(provider, opts)
+	if err != nil {
+		return err
+	}
 
-		if opts != nil && opts.Verbose {
-			log.Printf("[MKSQLITE] Copying temp database to final output...")
+	logErrors := opts != nil && opts.LogErrors
+	rejectRows := opts != nil && opts.RejectRows
+	strict := opts != nil && opts.Strict
+	lineage := opts != nil && opts.Lineage
+	strictTables := opts != nil && opts.StrictTables
+
+	var sourceFile interface{}
+	importedAt := ""
+	if lineage {
+		if opts.SourcePath != "" {
+			sourceFile = opts.SourcePath
 		}
-		// Copy to writer
-		if _, err := io.Copy(writer, f); err != nil {
-			return fmt.Errorf("failed to write to output: %w", err)
+		importedAt = time.Now().UTC().Format(time.RFC3339)
+		if opts.Deterministic {
+			importedAt = deterministicTimestamp
 		}
 	}
 
-	if opts != nil && opts.Verbose {
-		log.Printf("[MKSQLITE] Conversion completed successfully.")
+	maxErrors := 0
+	var errorRate float64
+	if opts != nil {
+		maxErrors = opts.MaxErrors
+		errorRate = opts.ErrorRate
+		if errorRate != 0 && (errorRate < 0 || errorRate >= 1) {
+			return fmt.Errorf("invalid error rate %v (want 0 < rate < 1)", errorRate)
+		}
 	}
-	return err
-}
-
-// populateDB handles the common logic of creating tables and inserting rows
-func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) error {
-	logErrors := opts != nil && opts.LogErrors
 
 	if logErrors {
 		_, err := db.Exec(`CREATE TABLE IF NOT EXISTS _mksqlite_errors (
@@ -146,16 +1550,181 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 		}
 
 		colTypes := provider.GetColumnTypes(tableName)
-		createTableSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-		_, err := db.Exec(createTableSQL)
+
+		// Resolve column projection; Where below still sees all columns
+		keep, err := projectionIndexes(opts, tableName, headers)
+		if err != nil {
+			return err
+		}
+		insertHeaders, insertTypes := headers, colTypes
+		var rawHeaders []string
+		if rhp, ok := provider.(common.RawHeaderProvider); ok {
+			rawHeaders = rhp.GetRawHeaders(tableName)
+		}
+		if keep != nil {
+			insertHeaders = projectStrings(headers, keep)
+			insertTypes = projectStrings(colTypes, keep)
+			rawHeaders = projectStrings(rawHeaders, keep)
+		}
+
+		createHeaders, createTypes := insertHeaders, insertTypes
+		if lineage {
+			createHeaders = append(append([]string(nil), insertHeaders...), lineageColumns...)
+			createTypes = make([]string, 0, len(createHeaders))
+			for i := range insertHeaders {
+				colType := ""
+				if i < len(insertTypes) {
+					colType = insertTypes[i]
+				}
+				createTypes = append(createTypes, colType)
+			}
+			createTypes = append(createTypes, lineageTypes...)
+		}
+		pkCols, err := primaryKeyColumns(opts, tableName, insertHeaders)
+		if err != nil {
+			return err
+		}
+		fkCols, err := foreignKeyColumns(opts, tableName, insertHeaders)
 		if err != nil {
+			return err
+		}
+		createTableSQL := common.GenCreateTableSQLWithTypes(tableName, createHeaders, createTypes)
+		if opts != nil && (opts.StrictTables || len(pkCols) > 0 || len(fkCols) > 0) {
+			createTableSQL = common.GenCreateTableSQLKeyed(tableName, createHeaders, createTypes, pkCols, fkCols, opts.WithoutRowid, opts.StrictTables)
+		}
+		if opts != nil && opts.Upsert {
+			createTableSQL = strings.Replace(createTableSQL, "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+		}
+		if _, err = db.Exec(createTableSQL); err != nil {
 			return fmt.Errorf("failed to create table %s: %w", tableName, err)
 		}
 
+		// When appending, a re-converted export may have gained or lost
+		// columns since the table was first created
+		if opts != nil && opts.Upsert {
+			if err := evolveSchema(db, opts, tableName, createHeaders, createTypes); err != nil {
+				return err
+			}
+		}
+
+		// Record the original→sanitized column mapping when available
+		if len(rawHeaders) > 0 {
+			if err := writeColumnMapping(db, tableName, rawHeaders, insertHeaders); err != nil {
+				return err
+			}
+			if opts != nil && opts.RawNameViews {
+				if err := createRawNameView(db, tableName, rawHeaders, insertHeaders); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Preserve the source's format strings when asked and known
+		if opts != nil && opts.Formats {
+			if fp, ok := provider.(common.ColumnFormatProvider); ok {
+				formats := fp.GetColumnFormats(tableName)
+				if keep != nil {
+					formats = projectStrings(formats, keep)
+				}
+				if err := writeFormats(db, tableName, insertHeaders, formats); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Columnar fast path: whole batches bound with multi-row statements
+		if bp, ok := provider.(common.BatchProvider); ok && keep == nil && batchEligible(opts) {
+			tc := newTypeConfidence(insertTypes, locale)
+			rowCount, err := populateTableFromBatches(db, bp, tableName, insertHeaders, opts, tc)
+			if err != nil {
+				return err
+			}
+			if err := writeMeta(db, opts, tableName, rawHeaders, insertHeaders, insertTypes, rowCount, tc.confidences()); err != nil {
+				return err
+			}
+			if opts != nil && opts.Report != nil {
+				table := TableReport{Name: tableName, Rows: rowCount}
+				for i, header := range insertHeaders {
+					colType := "TEXT"
+					if i < len(insertTypes) {
+						colType = insertTypes[i]
+					}
+					table.Columns = append(table.Columns, ColumnReport{Name: header, Type: colType})
+				}
+				opts.Report.Tables = append(opts.Report.Tables, table)
+			}
+			if opts != nil && opts.Verbose {
+				log.Printf("[MKSQLITE] Finished table %s, total rows: %d", tableName, rowCount)
+			}
+			continue
+		}
+
+		// Keyed dedupe: enforce with a unique index and INSERT OR IGNORE
+		var dedupeKey []string
+		if opts != nil && len(opts.DedupeColumns) > 0 {
+			for _, col := range tableSelection(opts.DedupeColumns, tableName) {
+				col = strings.TrimSpace(col)
+				found := ""
+				for _, header := range insertHeaders {
+					if header == col || strings.Trim(header, `"`) == col {
+						found = header
+						break
+					}
+				}
+				if found == "" {
+					return fmt.Errorf("dedupe column %s not found in table %s", col, tableName)
+				}
+				dedupeKey = append(dedupeKey, found)
+			}
+		}
+		if len(dedupeKey) > 0 {
+			indexSQL := fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s_dedupe_idx ON %s (%s)",
+				tableName, tableName, strings.Join(dedupeKey, ", "))
+			if _, err := db.Exec(indexSQL); err != nil {
+				return fmt.Errorf("failed to create dedupe index for table %s: %w", tableName, err)
+			}
+		}
+
 		// Generate insert statement
-		insertSQL, err := common.GenPreparedStmt(tableName, headers, common.InsertStmt)
+		buildInsertSQL := func(cols []string) (string, error) {
+			if lineage {
+				cols = append(append([]string(nil), cols...), lineageColumns...)
+			}
+			stmtSQL, err := common.GenPreparedStmt(tableName, cols, common.InsertStmt)
+			if err != nil {
+				return "", fmt.Errorf("failed to generate insert statement for table %s: %w", tableName, err)
+			}
+			if opts != nil && opts.Upsert {
+				stmtSQL = strings.Replace(stmtSQL, "INSERT INTO ", "INSERT OR REPLACE INTO ", 1)
+			} else if len(dedupeKey) > 0 {
+				stmtSQL = strings.Replace(stmtSQL, "INSERT INTO ", "INSERT OR IGNORE INTO ", 1)
+			}
+			return stmtSQL, nil
+		}
+		insertSQL, err := buildInsertSQL(insertHeaders)
 		if err != nil {
-			return fmt.Errorf("failed to generate insert statement for table %s: %w", tableName, err)
+			return err
+		}
+
+		// Keyless full-row dedupe tracks seen rows in memory
+		var seenRows map[string]struct{}
+		if opts != nil && opts.Dedupe && len(dedupeKey) == 0 {
+			seenRows = make(map[string]struct{})
+		}
+
+		gsp, _ := provider.(common.GrowingSchemaProvider)
+
+		limit := 0
+		var sampler *rand.Rand
+		if opts != nil {
+			limit = opts.Limit
+			if opts.SampleRate < 0 || opts.SampleRate >= 1 {
+				if opts.SampleRate != 0 {
+					return fmt.Errorf("invalid sample rate %v (want 0 < rate < 1)", opts.SampleRate)
+				}
+			} else if opts.SampleRate > 0 {
+				sampler = rand.New(rand.NewSource(time.Now().UnixNano()))
+			}
 		}
 
 		// Prepare statement on connection
@@ -174,6 +1743,24 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 			defer mainLogStmt.Close()
 		}
 
+		var mainRejectStmt *sql.Stmt
+		if rejectRows {
+			rejectsTable := "_rejects_" + tableName
+			_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+				line_number INTEGER,
+				raw_text TEXT,
+				message TEXT
+			)`, rejectsTable))
+			if err != nil {
+				return fmt.Errorf("failed to create rejects table for %s: %w", tableName, err)
+			}
+			mainRejectStmt, err = db.Prepare(fmt.Sprintf(`INSERT INTO %s (line_number, raw_text, message) VALUES (?, ?, ?)`, rejectsTable))
+			if err != nil {
+				return fmt.Errorf("failed to prepare rejects statement: %w", err)
+			}
+			defer mainRejectStmt.Close()
+		}
+
 		// Begin transaction
 		tx, err := db.Begin()
 		if err != nil {
@@ -188,27 +1775,177 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 		if logErrors {
 			logStmt = tx.Stmt(mainLogStmt)
 		}
+		var rejectStmt *sql.Stmt
+		if rejectRows {
+			rejectStmt = tx.Stmt(mainRejectStmt)
+		}
+
+		// Resolve per-column transforms for this table
+		var colTransforms []*common.Transform
+		if opts != nil && len(opts.Transforms) > 0 {
+			colTransforms = make([]*common.Transform, len(insertHeaders))
+			for i, header := range insertHeaders {
+				colTransforms[i] = opts.Transforms[tableName+"."+header]
+			}
+		}
+
+		var whereColumns map[string]interface{}
+		if opts != nil && opts.Where != nil {
+			whereColumns = make(map[string]interface{}, len(headers))
+		}
 
 		rowCount := 0
+		errorCount := 0
+		sourceLine := 0
+		tc := newTypeConfidence(insertTypes, locale)
+
+		// errorBudget enforces MaxErrors/ErrorRate after each logged or
+		// quarantined row error. The rate check waits for a minimum sample
+		// so one bad leading line can't abort a huge import.
+		const errorRateMinRows = 100
+		errorBudget := func() error {
+			errorCount++
+			if maxErrors > 0 && errorCount >= maxErrors {
+				return fmt.Errorf("%w: %d in table %s (max %d)", ErrTooManyErrors, errorCount, tableName, maxErrors)
+			}
+			if errorRate > 0 {
+				total := rowCount + errorCount
+				if total >= errorRateMinRows && float64(errorCount)/float64(total) > errorRate {
+					return fmt.Errorf("%w: %d of %d rows failed in table %s (rate limit %v)", ErrTooManyErrors, errorCount, total, tableName, errorRate)
+				}
+			}
+			return nil
+		}
 
 		// Setup signal handling context
 		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 		defer cancel()
 
-		// Insert rows using streaming ScanRows
-		err = provider.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+		// Insert rows using streaming ScanRows; providers without their own
+		// pipeline get the engine's scanning goroutine in front of them
+		scan := provider.ScanRows
+		if pp, ok := provider.(common.PipelinedProvider); !ok || !pp.PipelinesScans() {
+			scan = func(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+				return scanRowsPipelined(ctx, provider, tableName, yield)
+			}
+		}
+		err = scan(ctx, tableName, func(row []interface{}, rowErr error) error {
+			sourceLine++
 			if rowErr != nil {
+				// Quarantine rejected rows verbatim when their raw text is known
+				var rejErr *common.RowError
+				if rejectRows && errors.As(rowErr, &rejErr) {
+					var line interface{}
+					if rejErr.Line > 0 {
+						line = rejErr.Line
+					}
+					if _, err := rejectStmt.Exec(line, rejErr.Raw, rowErr.Error()); err != nil {
+						return fmt.Errorf("failed to quarantine row: %w", err)
+					}
+					return errorBudget() // Continue unless the budget is spent
+				}
 				if logErrors {
 					// Log provider error
 					rowData := fmt.Sprintf("%v", row) // Best effort string rep (might be nil or empty)
 					if _, err := logStmt.Exec(rowErr.Error(), tableName, rowData); err != nil {
 						return fmt.Errorf("failed to log error: %w", err)
 					}
-					return nil // Continue
+					return errorBudget() // Continue unless the budget is spent
 				}
 				return rowErr
 			}
 
+			// Stop the scan once the row limit is hit
+			if limit > 0 && rowCount >= limit {
+				return errRowLimit
+			}
+
+			// Probabilistic sampling decides per row while streaming
+			if sampler != nil && sampler.Float64() >= opts.SampleRate {
+				return nil
+			}
+
+			// Grow the table when the provider discovers new columns
+			// mid-stream; projections keep their fixed column set.
+			if gsp != nil && keep == nil {
+				current := gsp.CurrentHeaders(tableName)
+				if len(current) > len(insertHeaders) {
+					// Commit the batch first: the single pooled connection
+					// is held by the transaction, and preparing the wider
+					// insert needs it back
+					stmt.Close()
+					if logStmt != nil {
+						logStmt.Close()
+					}
+					if rejectStmt != nil {
+						rejectStmt.Close()
+					}
+					if err := tx.Commit(); err != nil {
+						return fmt.Errorf("failed to commit transaction for table %s: %w", tableName, err)
+					}
+
+					for _, col := range current[len(insertHeaders):] {
+						alterSQL := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TEXT", tableName, col)
+						if _, err := db.Exec(alterSQL); err != nil {
+							return fmt.Errorf("failed to add column %s to table %s: %w", col, tableName, err)
+						}
+					}
+					insertHeaders = append([]string(nil), current...)
+					headers = insertHeaders
+
+					newSQL, err := buildInsertSQL(insertHeaders)
+					if err != nil {
+						return err
+					}
+					newStmt, err := db.Prepare(newSQL)
+					if err != nil {
+						return fmt.Errorf("failed to prepare widened insert for table %s: %w", tableName, err)
+					}
+					mainStmt.Close()
+					mainStmt = newStmt
+
+					tx, err = db.Begin()
+					if err != nil {
+						return fmt.Errorf("failed to begin transaction: %w", err)
+					}
+					stmt = tx.Stmt(mainStmt)
+					if logErrors {
+						logStmt = tx.Stmt(mainLogStmt)
+					}
+					if rejectRows {
+						rejectStmt = tx.Stmt(mainRejectStmt)
+					}
+
+					if opts != nil && len(opts.Transforms) > 0 {
+						colTransforms = make([]*common.Transform, len(insertHeaders))
+						for i, header := range insertHeaders {
+							colTransforms[i] = opts.Transforms[tableName+"."+header]
+						}
+					}
+				}
+			}
+
+			// Strict mode refuses the padding/truncation below and the
+			// type fudging SQLite would do on insert
+			if strict {
+				if msg := strictViolation(row, headers, colTypes, locale); msg != "" {
+					rowData := fmt.Sprintf("%v", row)
+					if rejectRows {
+						if _, err := rejectStmt.Exec(sourceLine, rowData, msg); err != nil {
+							return fmt.Errorf("failed to quarantine row: %w", err)
+						}
+						return errorBudget() // Continue unless the budget is spent
+					}
+					if logErrors {
+						if _, err := logStmt.Exec(msg, tableName, rowData); err != nil {
+							return fmt.Errorf("failed to log error: %w", err)
+						}
+						return errorBudget() // Continue unless the budget is spent
+					}
+					return fmt.Errorf("strict mode: %s in table %s", msg, tableName)
+				}
+			}
+
 			// Ensure row has the same number of columns as headers
 			if len(row) < len(headers) {
 				// Pad with nil (NULL)
@@ -227,7 +1964,85 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 				row = row[:len(headers)]
 			}
 
-			_, err := stmt.Exec(row...)
+			// Filter on raw values before any transforms run
+			if whereColumns != nil {
+				for i, header := range headers {
+					whereColumns[header] = row[i]
+				}
+				if !opts.Where.Eval(whereColumns) {
+					return nil // Row filtered out
+				}
+			}
+
+			// Drop columns outside the projection
+			if keep != nil {
+				projected := make([]interface{}, len(keep))
+				for i, idx := range keep {
+					projected[i] = row[idx]
+				}
+				row = projected
+			}
+
+			// Apply per-column transforms before insert
+			for i, tr := range colTransforms {
+				if tr != nil {
+					row[i] = tr.Apply(row[i])
+				}
+			}
+
+			// Locale-written numbers and dates become values SQLite's
+			// column affinity can actually use
+			if locale != nil {
+				for i := range row {
+					colType := "TEXT"
+					if i < len(insertTypes) {
+						colType = insertTypes[i]
+					}
+					row[i] = locale.CoerceValue(row[i], colType)
+				}
+			}
+
+			// STRICT tables refuse drifting inserts, so string values are
+			// coerced into their declared numeric types first; values
+			// that don't fit stay strings and fail at insert
+			if strictTables {
+				for i := range row {
+					if i >= len(insertTypes) {
+						break
+					}
+					if s, ok := row[i].(string); ok {
+						if v, ok := coerceNumeric(strings.TrimSpace(s), insertTypes[i], locale); ok {
+							row[i] = v
+						}
+					}
+				}
+			}
+
+			// Skip rows already seen when full-row dedupe is on
+			if seenRows != nil {
+				var keyBuilder strings.Builder
+				for _, v := range row {
+					fmt.Fprintf(&keyBuilder, "%v\x1f", v)
+				}
+				key := keyBuilder.String()
+				if _, dup := seenRows[key]; dup {
+					return nil
+				}
+				seenRows[key] = struct{}{}
+			}
+
+			// Track how well the inserted values fit the inferred types
+			for i, v := range row {
+				tc.observe(i, v)
+			}
+
+			args := row
+			if lineage {
+				args = make([]interface{}, 0, len(row)+len(lineageColumns))
+				args = append(args, row...)
+				args = append(args, sourceFile, sourceLine, importedAt)
+			}
+			res, err := stmt.Exec(args...)
 			if err != nil {
 				if logErrors {
 					// Log insertion error
@@ -235,17 +2050,27 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 					if _, err := logStmt.Exec(err.Error(), tableName, rowData); err != nil {
 						return fmt.Errorf("failed to log insert error: %w", err)
 					}
-					return nil // Continue
+					return errorBudget() // Continue unless the budget is spent
 				}
 				return fmt.Errorf("failed to insert row in table %s: %w", tableName, err)
 			}
 
+			// Duplicates swallowed by INSERT OR IGNORE don't count
+			if len(dedupeKey) > 0 {
+				if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+					return nil
+				}
+			}
+
 			rowCount++
 			if rowCount%BatchSize == 0 {
 				stmt.Close()
 				if logStmt != nil {
 					logStmt.Close()
 				}
+				if rejectStmt != nil {
+					rejectStmt.Close()
+				}
 				if err := tx.Commit(); err != nil {
 					return fmt.Errorf("failed to commit transaction for table %s: %w", tableName, err)
 				}
@@ -259,6 +2084,9 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 				if logErrors {
 					logStmt = tx.Stmt(mainLogStmt)
 				}
+				if rejectRows {
+					rejectStmt = tx.Stmt(mainRejectStmt)
+				}
 			}
 			return nil
 		})
@@ -267,7 +2095,13 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 		if logStmt != nil {
 			logStmt.Close()
 		}
+		if rejectStmt != nil {
+			rejectStmt.Close()
+		}
 
+		if errors.Is(err, errRowLimit) {
+			err = nil // Limit reached; commit what we have and move on
+		}
 		if err != nil {
 			if errors.Is(err, ErrInterrupted) || errors.Is(err, ErrScanTimeout) {
 				if opts != nil && opts.Verbose {
@@ -286,9 +2120,30 @@ func populateDB(db *sql.DB, provider common.RowProvider, opts *ImportOptions) er
 		if err := tx.Commit(); err != nil {
 			return fmt.Errorf("failed to commit transaction for table %s: %w", tableName, err)
 		}
+		if err := writeMeta(db, opts, tableName, rawHeaders, insertHeaders, insertTypes, rowCount, tc.confidences()); err != nil {
+			return err
+		}
+		if opts != nil && opts.Report != nil {
+			table := TableReport{Name: tableName, Rows: rowCount, Errors: errorCount}
+			for i, header := range insertHeaders {
+				colType := "TEXT" // Columns added mid-stream are always TEXT
+				if i < len(insertTypes) {
+					colType = insertTypes[i]
+				}
+				table.Columns = append(table.Columns, ColumnReport{Name: header, Type: colType})
+			}
+			opts.Report.Tables = append(opts.Report.Tables, table)
+		}
 		if opts != nil && opts.Verbose {
 			log.Printf("[MKSQLITE] Finished table %s, total rows: %d", tableName, rowCount)
 		}
 	}
+
+	// Declared relationships are validated once all tables are populated
+	if opts != nil && len(opts.ForeignKeys) > 0 {
+		if err := checkForeignKeys(db, opts.ForeignKeys, logErrors); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/darianmavgo/mksqlite/converters/common"
 
@@ -130,53 +134,199 @@ func TestImportToSQLiteWriter(t *testing.T) {
 	}
 }
 
-// ErrorMockProvider simulates errors during scanning
-type ErrorMockProvider struct {
-	MockProvider
-	rowErrors map[string]map[int]error
-}
+func TestImportToSQLiteUpsert(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "upsert_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
 
-func (m *ErrorMockProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
-	rows := m.rows[tableName]
-	for i, row := range rows {
-		var rowErr error
-		if errs, ok := m.rowErrors[tableName]; ok {
-			if err, ok := errs[i]; ok {
-				rowErr = err
-			}
+	// Pre-create the table with a primary key; with Upsert enabled the
+	// existing schema is kept and re-imports replace rows instead of
+	// duplicating them.
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE "tb0" ("col1" TEXT PRIMARY KEY, "col2" TEXT)`); err != nil {
+		t.Fatalf("Failed to pre-create table: %v", err)
+	}
+	db.Close()
+
+	importRows := func(rows [][]interface{}) {
+		t.Helper()
+		provider := &MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"col1", "col2"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": rows,
+			},
 		}
-		if err := yield(row, rowErr); err != nil {
-			return err
+		f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+		if err != nil {
+			t.Fatalf("Failed to open temp file: %v", err)
+		}
+		err = ImportToSQLite(provider, f, &ImportOptions{Upsert: true})
+		f.Close()
+		if err != nil {
+			t.Fatalf("ImportToSQLite failed: %v", err)
 		}
 	}
-	return nil
+
+	importRows([][]interface{}{{"a", "1"}, {"b", "2"}})
+	importRows([][]interface{}{{"a", "9"}, {"c", "3"}}) // a updated, c new
+
+	db, err = sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT count(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to query count: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 rows after upsert, got %d", count)
+	}
+
+	var val string
+	if err := db.QueryRow("SELECT col2 FROM tb0 WHERE col1 = 'a'").Scan(&val); err != nil {
+		t.Fatalf("Failed to query updated row: %v", err)
+	}
+	if val != "9" {
+		t.Errorf("Expected updated value 9, got %s", val)
+	}
 }
 
-func TestImportToSQLiteWithLogging(t *testing.T) {
-	// Setup mock provider with some errors
-	provider := &ErrorMockProvider{
+// RawHeaderMockProvider is a MockProvider that also reports raw headers.
+type RawHeaderMockProvider struct {
+	MockProvider
+	rawHeaders map[string][]string
+}
+
+func (m *RawHeaderMockProvider) GetRawHeaders(tableName string) []string {
+	return m.rawHeaders[tableName]
+}
+
+func TestImportToSQLiteFastEngine(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"col1", "col2"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {{"val1", "val2"}, {"val3", "val4"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ImportToSQLite(provider, &buf, &ImportOptions{Engine: "fast"}); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	if buf.Len() < 16 || !bytes.Equal(buf.Bytes()[:16], []byte("SQLite format 3\000")) {
+		t.Fatal("Fast engine output is not a SQLite file")
+	}
+
+	// Reopen the serialized bytes from disk and query them
+	tmpFile, err := os.CreateTemp("", "fast_engine_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	defer os.Remove(dbPath)
+	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
+		t.Fatalf("Failed to write database: %v", err)
+	}
+	tmpFile.Close()
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows, got %d", count)
+	}
+}
+
+func TestImportToSQLiteColumnMapping(t *testing.T) {
+	provider := &RawHeaderMockProvider{
 		MockProvider: MockProvider{
 			tableNames: []string{"tb0"},
 			headers: map[string][]string{
-				"tb0": {"col1"},
+				"tb0": {"cl0", "grsse"},
 			},
 			rows: map[string][][]interface{}{
-				"tb0": {
-					{"val1"},
-					{"val2"}, // This one will have error
-					{"val3"},
-				},
+				"tb0": {{"a", "b"}},
 			},
 		},
-		rowErrors: map[string]map[int]error{
-			"tb0": {
-				1: fmt.Errorf("mock error for row 2"),
+		rawHeaders: map[string][]string{
+			"tb0": {"4 sale", "Größe"},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "colmap_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, nil)
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var raw, col string
+	err = db.QueryRow("SELECT raw_name, column_name FROM _mksqlite_columns WHERE table_name = 'tb0' AND position = 1").Scan(&raw, &col)
+	if err != nil {
+		t.Fatalf("Failed to query column mapping: %v", err)
+	}
+	if raw != "Größe" || col != "grsse" {
+		t.Errorf("Unexpected mapping: %s -> %s", raw, col)
+	}
+}
+
+func TestImportToSQLiteRawNameViews(t *testing.T) {
+	provider := &RawHeaderMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"first_name", "age"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {{"Alice", "30"}},
 			},
 		},
+		rawHeaders: map[string][]string{
+			"tb0": {"First Name", "age"},
+		},
 	}
 
-	// Temp output file
-	tmpFile, err := os.CreateTemp("", "logging_test_*.db")
+	tmpFile, err := os.CreateTemp("", "rawview_test_*.db")
 	if err != nil {
 		t.Fatalf("Failed to create temp file: %v", err)
 	}
@@ -184,56 +334,2392 @@ func TestImportToSQLiteWithLogging(t *testing.T) {
 	tmpFile.Close()
 	defer os.Remove(dbPath)
 
-	// Re-open as *os.File to pass to ImportToSQLite (so it writes to disk)
 	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
 	if err != nil {
 		t.Fatalf("Failed to open temp file: %v", err)
 	}
+	err = ImportToSQLite(provider, f, &ImportOptions{RawNameViews: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
 
-	// Call ImportToSQLite with logging enabled
-	err = ImportToSQLite(provider, f, &ImportOptions{LogErrors: true})
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var name, age string
+	err = db.QueryRow(`SELECT "First Name", age FROM tb0_raw`).Scan(&name, &age)
+	if err != nil {
+		t.Fatalf("Failed to query raw-name view: %v", err)
+	}
+	if name != "Alice" || age != "30" {
+		t.Errorf("Unexpected view row: %s, %s", name, age)
+	}
+}
+
+func TestImportToSQLiteLineage(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"name"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {{"Alice"}, {"Bob"}},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "lineage_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Lineage: true, SourcePath: "input.csv"})
 	f.Close()
 	if err != nil {
 		t.Fatalf("ImportToSQLite failed: %v", err)
 	}
 
-	// Verify DB content
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
 		t.Fatalf("Failed to open DB: %v", err)
 	}
 	defer db.Close()
 
-	// Check valid rows (val1 and val3 should be there)
-	var count int
-	err = db.QueryRow("SELECT count(*) FROM tb0").Scan(&count)
+	var sourceFile, importedAt string
+	var sourceLine int
+	err = db.QueryRow(`SELECT _source_file, _source_line, _imported_at FROM tb0 WHERE name = 'Bob'`).
+		Scan(&sourceFile, &sourceLine, &importedAt)
 	if err != nil {
-		t.Fatalf("Failed to query count: %v", err)
+		t.Fatalf("Failed to query lineage columns: %v", err)
 	}
-	if count != 2 {
-		t.Errorf("Expected 2 valid rows, got %d", count)
+	if sourceFile != "input.csv" || sourceLine != 2 {
+		t.Errorf("Unexpected lineage: %s line %d", sourceFile, sourceLine)
+	}
+	if _, err := time.Parse(time.RFC3339, importedAt); err != nil {
+		t.Errorf("_imported_at %q is not RFC3339: %v", importedAt, err)
 	}
+}
 
-	// Check error log
-	err = db.QueryRow("SELECT count(*) FROM _mksqlite_errors").Scan(&count)
+// BatchMockProvider is a MockProvider that also delivers columnar batches.
+type BatchMockProvider struct {
+	MockProvider
+	batchRows int
+}
+
+func (m *BatchMockProvider) ScanBatches(ctx context.Context, tableName string, yield func(cols [][]interface{}) error) error {
+	headers := m.headers[tableName]
+	cols := make([][]interface{}, len(headers))
+	n := 0
+	flush := func() error {
+		if n == 0 {
+			return nil
+		}
+		if err := yield(cols); err != nil {
+			return err
+		}
+		for i := range cols {
+			cols[i] = cols[i][:0]
+		}
+		n = 0
+		return nil
+	}
+	for _, row := range m.rows[tableName] {
+		for i := range cols {
+			cols[i] = append(cols[i], row[i])
+		}
+		n++
+		if n == m.batchRows {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+func TestImportToSQLiteColumnarBatches(t *testing.T) {
+	const total = 2500
+	rows := make([][]interface{}, total)
+	for i := range rows {
+		rows[i] = []interface{}{fmt.Sprintf("name%d", i), i}
+	}
+	provider := &BatchMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"name", "num"},
+			},
+			colTypes: map[string][]string{
+				"tb0": {"TEXT", "INTEGER"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": rows,
+			},
+		},
+		batchRows: 64,
+	}
+
+	tmpFile, err := os.CreateTemp("", "batch_test_*.db")
 	if err != nil {
-		// Table might not exist if no errors were logged (but we expect one)
-		t.Fatalf("Failed to query error log count: %v", err)
+		t.Fatalf("Failed to create temp file: %v", err)
 	}
-	if count != 1 {
-		t.Errorf("Expected 1 error log, got %d", count)
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	report := &ImportReport{}
+	err = ImportToSQLite(provider, f, &ImportOptions{Report: report})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
 	}
 
-	var msg, tbl string
-	err = db.QueryRow("SELECT message, table_name FROM _mksqlite_errors LIMIT 1").Scan(&msg, &tbl)
+	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
-		t.Fatalf("Failed to query error log: %v", err)
+		t.Fatalf("Failed to open DB: %v", err)
 	}
+	defer db.Close()
 
-	if msg != "mock error for row 2" {
-		t.Errorf("Unexpected error message: %s", msg)
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
 	}
-	if tbl != "tb0" {
-		t.Errorf("Unexpected table name: %s", tbl)
+	if count != total {
+		t.Errorf("Expected %d rows, got %d", total, count)
+	}
+	var name string
+	if err := db.QueryRow("SELECT name FROM tb0 WHERE num = 1234").Scan(&name); err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if name != "name1234" {
+		t.Errorf("Unexpected row value: %s", name)
+	}
+	if report.Rows != total {
+		t.Errorf("Report rows = %d, want %d", report.Rows, total)
+	}
+}
+
+func TestImportToSQLiteColumnarBatchesRowPathFallback(t *testing.T) {
+	// A per-row option (here a transform) must force the row path even when
+	// the provider can deliver batches
+	tr, err := common.ParseTransform("upper")
+	if err != nil {
+		t.Fatalf("Failed to parse transform: %v", err)
+	}
+	provider := &BatchMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"name"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {{"alice"}},
+			},
+		},
+		batchRows: 64,
+	}
+
+	tmpFile, err := os.CreateTemp("", "batch_fallback_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		Transforms: map[string]*common.Transform{"tb0.name": tr},
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM tb0").Scan(&name); err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if name != "ALICE" {
+		t.Errorf("Expected transform to run via the row path, got %s", name)
+	}
+}
+
+func TestImportToSQLiteMetaManifest(t *testing.T) {
+	provider := &RawHeaderMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"cl0", "amount"},
+			},
+			colTypes: map[string][]string{
+				"tb0": {"TEXT", "INTEGER"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {{"a", 1}, {"b", 2}},
+			},
+		},
+		rawHeaders: map[string][]string{
+			"tb0": {"4 sale", "Amount"},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "meta_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		SourcePath: "input.csv",
+		DriverName: "csv",
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var source, converterName, raw, col, colType string
+	var rowCount int
+	err = db.QueryRow(`SELECT source_file, converter, raw_name, column_name, column_type, row_count
+		FROM _mksqlite_meta WHERE table_name = 'tb0' AND position = 1`).
+		Scan(&source, &converterName, &raw, &col, &colType, &rowCount)
+	if err != nil {
+		t.Fatalf("Failed to query meta manifest: %v", err)
+	}
+	if source != "input.csv" || converterName != "csv" {
+		t.Errorf("Unexpected provenance: %s / %s", source, converterName)
+	}
+	if raw != "Amount" || col != "amount" || colType != "INTEGER" {
+		t.Errorf("Unexpected column record: %s -> %s (%s)", raw, col, colType)
+	}
+	if rowCount != 2 {
+		t.Errorf("Expected row_count 2, got %d", rowCount)
+	}
+
+	var ts string
+	if err := db.QueryRow("SELECT converted_at FROM _mksqlite_meta LIMIT 1").Scan(&ts); err != nil || ts == "" {
+		t.Errorf("Expected conversion timestamp, got %q (err %v)", ts, err)
+	}
+}
+
+func TestImportToSQLitePragmas(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"col1"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {{"val1"}},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "pragma_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		JournalMode: "wal",
+		Synchronous: "NORMAL",
+		PageSize:    4096,
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var pageSize int
+	if err := db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		t.Fatalf("Failed to query page_size: %v", err)
+	}
+	if pageSize != 4096 {
+		t.Errorf("Expected page_size 4096, got %d", pageSize)
+	}
+
+	// journal_mode=WAL persists in the database file
+	var journal string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journal); err != nil {
+		t.Fatalf("Failed to query journal_mode: %v", err)
+	}
+	if !strings.EqualFold(journal, "wal") {
+		t.Errorf("Expected journal_mode wal, got %s", journal)
+	}
+
+	// Invalid pragma values should fail fast
+	var buf bytes.Buffer
+	if err := ImportToSQLite(provider, &buf, &ImportOptions{JournalMode: "bogus"}); err == nil {
+		t.Error("Expected error for invalid journal_mode")
+	}
+	if err := ImportToSQLite(provider, &buf, &ImportOptions{PageSize: 1000}); err == nil {
+		t.Error("Expected error for non-power-of-two page_size")
+	}
+}
+
+// ErrorMockProvider simulates errors during scanning
+type ErrorMockProvider struct {
+	MockProvider
+	rowErrors map[string]map[int]error
+}
+
+func (m *ErrorMockProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	rows := m.rows[tableName]
+	for i, row := range rows {
+		var rowErr error
+		if errs, ok := m.rowErrors[tableName]; ok {
+			if err, ok := errs[i]; ok {
+				rowErr = err
+			}
+		}
+		if err := yield(row, rowErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestImportToSQLiteWithLogging(t *testing.T) {
+	// Setup mock provider with some errors
+	provider := &ErrorMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"col1"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {
+					{"val1"},
+					{"val2"}, // This one will have error
+					{"val3"},
+				},
+			},
+		},
+		rowErrors: map[string]map[int]error{
+			"tb0": {
+				1: fmt.Errorf("mock error for row 2"),
+			},
+		},
+	}
+
+	// Temp output file
+	tmpFile, err := os.CreateTemp("", "logging_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	// Re-open as *os.File to pass to ImportToSQLite (so it writes to disk)
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+
+	// Call ImportToSQLite with logging enabled
+	err = ImportToSQLite(provider, f, &ImportOptions{LogErrors: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	// Verify DB content
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// Check valid rows (val1 and val3 should be there)
+	var count int
+	err = db.QueryRow("SELECT count(*) FROM tb0").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to query count: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 valid rows, got %d", count)
+	}
+
+	// Check error log
+	err = db.QueryRow("SELECT count(*) FROM _mksqlite_errors").Scan(&count)
+	if err != nil {
+		// Table might not exist if no errors were logged (but we expect one)
+		t.Fatalf("Failed to query error log count: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 error log, got %d", count)
+	}
+
+	var msg, tbl string
+	err = db.QueryRow("SELECT message, table_name FROM _mksqlite_errors LIMIT 1").Scan(&msg, &tbl)
+	if err != nil {
+		t.Fatalf("Failed to query error log: %v", err)
+	}
+
+	if msg != "mock error for row 2" {
+		t.Errorf("Unexpected error message: %s", msg)
+	}
+	if tbl != "tb0" {
+		t.Errorf("Unexpected table name: %s", tbl)
+	}
+}
+
+func TestImportToSQLiteRejectRows(t *testing.T) {
+	provider := &ErrorMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"col1"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {
+					{"val1"},
+					{nil}, // This one will be rejected
+					{"val3"},
+				},
+			},
+		},
+		rowErrors: map[string]map[int]error{
+			"tb0": {
+				1: &common.RowError{Line: 3, Raw: `bad "line,2`, Err: fmt.Errorf("skipped unparseable CSV line")},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "rejects_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+
+	err = ImportToSQLite(provider, f, &ImportOptions{RejectRows: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// Good rows were imported
+	var count int
+	if err := db.QueryRow("SELECT count(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to query count: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 valid rows, got %d", count)
+	}
+
+	// The rejected row was quarantined verbatim with its source line
+	var line int
+	var raw, msg string
+	err = db.QueryRow("SELECT line_number, raw_text, message FROM _rejects_tb0 LIMIT 1").Scan(&line, &raw, &msg)
+	if err != nil {
+		t.Fatalf("Failed to query rejects table: %v", err)
+	}
+	if line != 3 {
+		t.Errorf("Expected line 3, got %d", line)
+	}
+	if raw != `bad "line,2` {
+		t.Errorf("Unexpected raw text: %q", raw)
+	}
+	if !strings.Contains(msg, "unparseable") {
+		t.Errorf("Unexpected message: %q", msg)
+	}
+}
+
+func TestImportToSQLiteMaxErrors(t *testing.T) {
+	provider := &ErrorMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"col1"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {
+					{"val1"},
+					{nil},
+					{nil},
+					{"val4"},
+				},
+			},
+		},
+		rowErrors: map[string]map[int]error{
+			"tb0": {
+				1: fmt.Errorf("bad row 2"),
+				2: fmt.Errorf("bad row 3"),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{LogErrors: true, MaxErrors: 2})
+	if !errors.Is(err, ErrTooManyErrors) {
+		t.Fatalf("Expected ErrTooManyErrors, got %v", err)
+	}
+
+	// Under the limit the same input imports fine
+	buf.Reset()
+	if err := ImportToSQLite(provider, &buf, &ImportOptions{LogErrors: true, MaxErrors: 3}); err != nil {
+		t.Fatalf("ImportToSQLite failed under the error limit: %v", err)
+	}
+}
+
+func TestImportToSQLiteInvalidErrorRate(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"col1"}},
+		rows:       map[string][][]interface{}{"tb0": {{"val1"}}},
+	}
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{ErrorRate: 1.5})
+	if err == nil || !strings.Contains(err.Error(), "invalid error rate") {
+		t.Fatalf("Expected invalid error rate error, got %v", err)
+	}
+}
+
+func TestImportToSQLitePostSQL(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"col1"}},
+		rows:       map[string][][]interface{}{"tb0": {{"val1"}, {"val2"}}},
+	}
+
+	var buf bytes.Buffer
+	opts := &ImportOptions{PostSQL: []string{
+		"CREATE VIEW v_counts AS SELECT COUNT(*) AS n FROM tb0;",
+		"ANALYZE;",
+	}}
+	if err := ImportToSQLite(provider, &buf, opts); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "post_sql.db")
+	if err := os.WriteFile(dbPath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write database: %v", err)
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRow("SELECT n FROM v_counts").Scan(&n); err != nil {
+		t.Fatalf("Failed to query hook-created view: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected view to report 2 rows, got %d", n)
+	}
+
+	// A broken script fails the import with a script index
+	buf.Reset()
+	err = ImportToSQLite(provider, &buf, &ImportOptions{PostSQL: []string{"CREATE VIEW"}})
+	if err == nil || !strings.Contains(err.Error(), "post-import SQL script 1") {
+		t.Fatalf("Expected post-import script error, got %v", err)
+	}
+}
+
+func TestImportToSQLiteReport(t *testing.T) {
+	provider := &ErrorMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"col1", "col2"},
+			},
+			colTypes: map[string][]string{
+				"tb0": {"TEXT", "INTEGER"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {
+					{"val1", 1},
+					{nil, nil},
+					{"val3", 3},
+				},
+			},
+		},
+		rowErrors: map[string]map[int]error{
+			"tb0": {
+				1: fmt.Errorf("bad row 2"),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	report := &ImportReport{}
+	err := ImportToSQLite(provider, &buf, &ImportOptions{LogErrors: true, Report: report})
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	if len(report.Tables) != 1 {
+		t.Fatalf("Expected 1 table in report, got %d", len(report.Tables))
+	}
+	tb := report.Tables[0]
+	if tb.Name != "tb0" {
+		t.Errorf("Expected table tb0, got %q", tb.Name)
+	}
+	if tb.Rows != 2 {
+		t.Errorf("Expected 2 rows in report, got %d", tb.Rows)
+	}
+	if tb.Errors != 1 {
+		t.Errorf("Expected 1 error in report, got %d", tb.Errors)
+	}
+	if len(tb.Columns) != 2 || tb.Columns[0].Name != "col1" || tb.Columns[1].Type != "INTEGER" {
+		t.Errorf("Unexpected columns in report: %v", tb.Columns)
+	}
+	if report.Rows != 2 || report.Errors != 1 {
+		t.Errorf("Unexpected report totals: rows=%d errors=%d", report.Rows, report.Errors)
+	}
+	if report.DurationSeconds <= 0 {
+		t.Errorf("Expected a positive duration, got %v", report.DurationSeconds)
+	}
+}
+
+func TestImportToSQLiteTransforms(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"name", "price"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {{"  Widget  ", "1,234"}},
+		},
+	}
+
+	tr, err := common.ParseTransform("trim + replace(',', '')")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "transform_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		Transforms: map[string]*common.Transform{
+			"tb0.price": tr,
+		},
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var name, price string
+	if err := db.QueryRow("SELECT name, price FROM tb0").Scan(&name, &price); err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if name != "  Widget  " {
+		t.Errorf("Untransformed column changed: %q", name)
+	}
+	if price != "1234" {
+		t.Errorf("Expected transformed price 1234, got %q", price)
+	}
+}
+
+func TestImportToSQLiteWhereFilter(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"status", "amount"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"active", "10"},
+				{"deleted", "20"},
+				{"active", "0"},
+			},
+		},
+	}
+
+	where, err := common.ParsePredicate("status != 'deleted' AND amount > 0")
+	if err != nil {
+		t.Fatalf("ParsePredicate failed: %v", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "where_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Where: where})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 row after filtering, got %d", count)
+	}
+
+	var status string
+	if err := db.QueryRow("SELECT status FROM tb0").Scan(&status); err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if status != "active" {
+		t.Errorf("Unexpected surviving row status: %s", status)
+	}
+}
+
+func TestImportToSQLiteColumnProjection(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "name", "internal_notes"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "alice", "secret"},
+				{"2", "bob", "hidden"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "projection_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		Columns: map[string][]string{"": {"id", "name"}},
+		Where:   mustPredicate(t, "internal_notes != 'hidden'"),
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM tb0")
+	if err != nil {
+		t.Fatalf("Failed to query table: %v", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatalf("Failed to get columns: %v", err)
+	}
+	if len(cols) != 2 || cols[0] != "id" || cols[1] != "name" {
+		t.Errorf("Expected columns [id name], got %v", cols)
+	}
+
+	count := 0
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			t.Fatalf("Failed to scan row: %v", err)
+		}
+		if name != "alice" {
+			t.Errorf("Unexpected row: %s / %s", id, name)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 row (projection keeps Where working), got %d", count)
+	}
+}
+
+func mustPredicate(t *testing.T, expr string) *common.Predicate {
+	t.Helper()
+	p, err := common.ParsePredicate(expr)
+	if err != nil {
+		t.Fatalf("ParsePredicate(%q) failed: %v", expr, err)
+	}
+	return p
+}
+
+func TestImportToSQLiteProjectionNoMatch(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {{"1"}},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "projection_err_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		Columns: map[string][]string{"": {"nope"}},
+	})
+	f.Close()
+	if err == nil {
+		t.Error("Expected error when no columns match the selection")
+	}
+}
+
+func TestImportToSQLiteDedupeFullRow(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "name"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "alice"},
+				{"1", "alice"},
+				{"1", "bob"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "dedupe_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Dedupe: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows after full-row dedupe, got %d", count)
+	}
+}
+
+func TestImportToSQLiteDedupeKeyed(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "name"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "alice"},
+				{"1", "bob"},
+				{"2", "carol"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "dedupe_key_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		Dedupe:        true,
+		DedupeColumns: map[string][]string{"": {"id"}},
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM tb0 WHERE id = '1'").Scan(&name); err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if name != "alice" {
+		t.Errorf("Expected first row to win keyed dedupe, got %s", name)
+	}
+
+	var rowCount int
+	if err := db.QueryRow("SELECT row_count FROM _mksqlite_meta LIMIT 1").Scan(&rowCount); err != nil {
+		t.Fatalf("Failed to query meta: %v", err)
+	}
+	if rowCount != 2 {
+		t.Errorf("Expected meta row_count 2 after keyed dedupe, got %d", rowCount)
+	}
+}
+
+func TestImportToSQLiteLimit(t *testing.T) {
+	rows := make([][]interface{}, 10)
+	for i := range rows {
+		rows[i] = []interface{}{fmt.Sprintf("row%d", i)}
+	}
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"content"},
+		},
+		rows: map[string][][]interface{}{"tb0": rows},
+	}
+
+	tmpFile, err := os.CreateTemp("", "limit_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Limit: 3})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 rows with limit, got %d", count)
+	}
+
+	var rowCount int
+	if err := db.QueryRow("SELECT row_count FROM _mksqlite_meta LIMIT 1").Scan(&rowCount); err != nil {
+		t.Fatalf("Failed to query meta: %v", err)
+	}
+	if rowCount != 3 {
+		t.Errorf("Expected meta row_count 3, got %d", rowCount)
+	}
+}
+
+func TestImportToSQLiteInvalidSampleRate(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"content"}},
+		rows:       map[string][][]interface{}{"tb0": {{"a"}}},
+	}
+
+	tmpFile, err := os.CreateTemp("", "sample_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{SampleRate: 1.5})
+	f.Close()
+	if err == nil {
+		t.Error("Expected error for sample rate outside (0, 1)")
+	}
+}
+
+func TestImportToSQLiteParts(t *testing.T) {
+	// Rotation only happens at commit boundaries, so shrink the batch size
+	// to make the thresholds bite with a small fixture
+	oldBatch := BatchSize
+	BatchSize = 10
+	defer func() { BatchSize = oldBatch }()
+
+	var rows [][]interface{}
+	for i := 0; i < 25; i++ {
+		rows = append(rows, []interface{}{fmt.Sprintf("name%d", i), int64(i)})
+	}
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"name", "num"}},
+		colTypes:   map[string][]string{"tb0": {"TEXT", "INTEGER"}},
+		rows:       map[string][][]interface{}{"tb0": rows},
+	}
+
+	basePath := filepath.Join(t.TempDir(), "out.db")
+	report := &ImportReport{}
+	parts, err := ImportToSQLiteParts(provider, basePath, &ImportOptions{SplitRows: 10, Report: report})
+	if err != nil {
+		t.Fatalf("ImportToSQLiteParts failed: %v", err)
+	}
+
+	expected := []string{
+		strings.TrimSuffix(basePath, ".db") + "_001.db",
+		strings.TrimSuffix(basePath, ".db") + "_002.db",
+		strings.TrimSuffix(basePath, ".db") + "_003.db",
+	}
+	if len(parts) != len(expected) {
+		t.Fatalf("Expected %d parts, got %v", len(expected), parts)
+	}
+	for i, p := range parts {
+		if p != expected[i] {
+			t.Errorf("Part %d: got %s, want %s", i, p, expected[i])
+		}
+	}
+
+	total := 0
+	var schema string
+	for _, p := range parts {
+		db, err := sql.Open("sqlite", p)
+		if err != nil {
+			t.Fatalf("Failed to open part %s: %v", p, err)
+		}
+		var partSchema string
+		if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'tb0'").Scan(&partSchema); err != nil {
+			t.Fatalf("Failed to read schema from %s: %v", p, err)
+		}
+		if schema == "" {
+			schema = partSchema
+		} else if partSchema != schema {
+			t.Errorf("Part %s schema differs: %q vs %q", p, partSchema, schema)
+		}
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+			t.Fatalf("Failed to count rows in %s: %v", p, err)
+		}
+		total += count
+		db.Close()
+	}
+	if total != 25 {
+		t.Errorf("Expected 25 rows across parts, got %d", total)
+	}
+
+	if report.Rows != 25 {
+		t.Errorf("Expected report rows 25, got %d", report.Rows)
+	}
+}
+
+func TestImportToSQLitePartsRequiresThreshold(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"col1"}},
+		rows:       map[string][][]interface{}{"tb0": {{"a"}}},
+	}
+	basePath := filepath.Join(t.TempDir(), "out.db")
+	if _, err := ImportToSQLiteParts(provider, basePath, &ImportOptions{}); err == nil {
+		t.Error("Expected error when no split threshold is set")
+	}
+}
+
+func TestImportToSQLiteSchemaEvolution(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "evolve_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	importTable := func(headers []string, rows [][]interface{}) {
+		t.Helper()
+		provider := &MockProvider{
+			tableNames: []string{"tb0"},
+			headers:    map[string][]string{"tb0": headers},
+			rows:       map[string][][]interface{}{"tb0": rows},
+		}
+		f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+		if err != nil {
+			t.Fatalf("Failed to open temp file: %v", err)
+		}
+		err = ImportToSQLite(provider, f, &ImportOptions{Upsert: true})
+		f.Close()
+		if err != nil {
+			t.Fatalf("ImportToSQLite failed: %v", err)
+		}
+	}
+
+	importTable([]string{"col1", "col2"}, [][]interface{}{{"a", "1"}})
+	// The re-converted export gained col3 and dropped col2
+	importTable([]string{"col1", "col3"}, [][]interface{}{{"b", "2"}})
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen DB: %v", err)
+	}
+	defer db.Close()
+
+	var col2, col3 interface{}
+	if err := db.QueryRow("SELECT col2, col3 FROM tb0 WHERE col1 = 'b'").Scan(&col2, &col3); err != nil {
+		t.Fatalf("Failed to query evolved table: %v", err)
+	}
+	if col2 != nil {
+		t.Errorf("Expected NULL for dropped col2, got %v", col2)
+	}
+	if col3 != "2" {
+		t.Errorf("Expected col3 value 2, got %v", col3)
+	}
+	if err := db.QueryRow("SELECT col3 FROM tb0 WHERE col1 = 'a'").Scan(&col3); err != nil {
+		t.Fatalf("Failed to query old row: %v", err)
+	}
+	if col3 != nil {
+		t.Errorf("Expected NULL col3 on the pre-evolution row, got %v", col3)
+	}
+
+	// Both changes are logged in the manifest with position -1
+	var changes int
+	if err := db.QueryRow("SELECT count(*) FROM _mksqlite_meta WHERE position = -1").Scan(&changes); err != nil {
+		t.Fatalf("Failed to query schema changes: %v", err)
+	}
+	if changes != 2 {
+		t.Errorf("Expected 2 logged schema changes, got %d", changes)
+	}
+}
+
+func TestImportToSQLiteStrict(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"name", "num"}},
+		colTypes:   map[string][]string{"tb0": {"TEXT", "INTEGER"}},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"a", "1"},
+				{"b"},          // short row: padded today, refused in strict mode
+				{"c", "seven"}, // not an integer
+			},
+		},
+	}
+
+	// Without an error sink, the first violation aborts
+	var buf bytes.Buffer
+	if err := ImportToSQLite(provider, &buf, &ImportOptions{Strict: true}); err == nil {
+		t.Error("Expected strict mode to abort on a short row")
+	}
+
+	// With --log, violations are diverted and the import continues
+	tmpFile, err := os.CreateTemp("", "strict_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Strict: true, LogErrors: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT count(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 conforming row, got %d", count)
+	}
+	if err := db.QueryRow("SELECT count(*) FROM _mksqlite_errors").Scan(&count); err != nil {
+		t.Fatalf("Failed to count logged errors: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 logged violations, got %d", count)
+	}
+}
+
+func TestImportToSQLiteDeterministic(t *testing.T) {
+	newProvider := func() *RawHeaderMockProvider {
+		return &RawHeaderMockProvider{
+			MockProvider: MockProvider{
+				tableNames: []string{"tb0"},
+				headers:    map[string][]string{"tb0": {"col1", "col2"}},
+				rows: map[string][][]interface{}{
+					"tb0": {{"a", "1"}, {"b", "2"}},
+				},
+			},
+			rawHeaders: map[string][]string{"tb0": {"Col 1", "Col 2"}},
+		}
+	}
+
+	convert := func() []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := ImportToSQLite(newProvider(), &buf, &ImportOptions{Deterministic: true}); err != nil {
+			t.Fatalf("ImportToSQLite failed: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	first := convert()
+	time.Sleep(1100 * time.Millisecond) // cross a wall-clock second boundary
+	second := convert()
+	if !bytes.Equal(first, second) {
+		t.Error("Expected byte-identical output for identical input")
+	}
+}
+
+func TestImportToSQLiteApplicationID(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": {"col1"}},
+		rows:       map[string][][]interface{}{"tb0": {{"a"}}},
+	}
+
+	importWith := func(opts *ImportOptions) (appID, userVersion int64) {
+		t.Helper()
+		tmpFile, err := os.CreateTemp("", "appid_test_*.db")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %v", err)
+		}
+		dbPath := tmpFile.Name()
+		tmpFile.Close()
+		defer os.Remove(dbPath)
+
+		f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+		if err != nil {
+			t.Fatalf("Failed to open temp file: %v", err)
+		}
+		err = ImportToSQLite(provider, f, opts)
+		f.Close()
+		if err != nil {
+			t.Fatalf("ImportToSQLite failed: %v", err)
+		}
+
+		db, err := sql.Open("sqlite", dbPath)
+		if err != nil {
+			t.Fatalf("Failed to open DB: %v", err)
+		}
+		defer db.Close()
+		if err := db.QueryRow("PRAGMA application_id").Scan(&appID); err != nil {
+			t.Fatalf("Failed to read application_id: %v", err)
+		}
+		if err := db.QueryRow("PRAGMA user_version").Scan(&userVersion); err != nil {
+			t.Fatalf("Failed to read user_version: %v", err)
+		}
+		return appID, userVersion
+	}
+
+	appID, userVersion := importWith(nil)
+	if appID != DefaultApplicationID {
+		t.Errorf("Expected default application_id %#x, got %#x", int64(DefaultApplicationID), appID)
+	}
+	if userVersion != 0 {
+		t.Errorf("Expected default user_version 0, got %d", userVersion)
+	}
+
+	appID, userVersion = importWith(&ImportOptions{ApplicationID: 0x1234, UserVersion: 7})
+	if appID != 0x1234 {
+		t.Errorf("Expected application_id 0x1234, got %#x", appID)
+	}
+	if userVersion != 7 {
+		t.Errorf("Expected user_version 7, got %d", userVersion)
+	}
+}
+
+// wideTestProvider builds a MockProvider with one table of n sequentially
+// named columns, so wide-table tests stay readable at small widths.
+func wideTestProvider(n int, rows [][]interface{}) *MockProvider {
+	headers := make([]string, n)
+	for i := range headers {
+		headers[i] = fmt.Sprintf("c%d", i)
+	}
+	return &MockProvider{
+		tableNames: []string{"tb0"},
+		headers:    map[string][]string{"tb0": headers},
+		rows:       map[string][][]interface{}{"tb0": rows},
+	}
+}
+
+func TestImportToSQLiteWidePartition(t *testing.T) {
+	oldLimit := MaxTableColumns
+	MaxTableColumns = 3
+	defer func() { MaxTableColumns = oldLimit }()
+
+	provider := wideTestProvider(7, [][]interface{}{
+		{"a0", "a1", "a2", "a3", "a4", "a5", "a6"},
+		{"b0", "b1", "b2", nil, "b4", "b5", "b6"},
+		{"c0", "c1", "c2", "c3", "c4", "c5", "c6"},
+	})
+
+	tmpFile, err := os.CreateTemp("", "wide_partition_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{WideStrategy: WidePartition})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// 7 columns at a limit of 3 should make parts of 3, 3, and 1 columns
+	widths := map[string]int{"tb0_part1": 3, "tb0_part2": 3, "tb0_part3": 1}
+	for table, want := range widths {
+		var count int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM pragma_table_info('%s')", table)
+		if err := db.QueryRow(query).Scan(&count); err != nil {
+			t.Fatalf("Failed to inspect %s: %v", table, err)
+		}
+		if count != want {
+			t.Errorf("Expected %d columns in %s, got %d", want, table, count)
+		}
+	}
+
+	// Rows reassemble by joining the parts on rowid
+	var c0, c3, c6 interface{}
+	err = db.QueryRow(`SELECT p1.c0, p2.c3, p3.c6 FROM tb0_part1 p1
+		JOIN tb0_part2 p2 ON p2.rowid = p1.rowid
+		JOIN tb0_part3 p3 ON p3.rowid = p1.rowid
+		WHERE p1.rowid = 2`).Scan(&c0, &c3, &c6)
+	if err != nil {
+		t.Fatalf("Failed to join parts: %v", err)
+	}
+	if c0 != "b0" || c3 != nil || c6 != "b6" {
+		t.Errorf("Unexpected joined row: got %v, %v, %v; want b0, <nil>, b6", c0, c3, c6)
+	}
+
+	var rows int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0_part3").Scan(&rows); err != nil {
+		t.Fatalf("Failed to count tb0_part3: %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("Expected 3 rows in tb0_part3, got %d", rows)
+	}
+}
+
+// reusingRowProvider yields every row through one reused slice, as the
+// streaming converters do with their pooled row buffers.
+type reusingRowProvider struct {
+	*MockProvider
+}
+
+func (m *reusingRowProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	var scratch []interface{}
+	for _, row := range m.rows[tableName] {
+		if cap(scratch) < len(row) {
+			scratch = make([]interface{}, len(row))
+		}
+		scratch = scratch[:len(row)]
+		copy(scratch, row)
+		if err := yield(scratch, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestImportToSQLiteWidePartitionReusedRows(t *testing.T) {
+	oldLimit := MaxTableColumns
+	MaxTableColumns = 3
+	defer func() { MaxTableColumns = oldLimit }()
+
+	// Later partitions replay buffered rows, so a provider that reuses its
+	// row slice must not leave every buffered row aliasing the last yield
+	provider := &reusingRowProvider{wideTestProvider(5, [][]interface{}{
+		{"a0", "a1", "a2", "a3", "a4"},
+		{"b0", "b1", "b2", "b3", "b4"},
+		{"c0", "c1", "c2", "c3", "c4"},
+	})}
+
+	tmpFile, err := os.CreateTemp("", "wide_reused_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{WideStrategy: WidePartition})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT rowid, c3 FROM tb0_part2 ORDER BY rowid")
+	if err != nil {
+		t.Fatalf("Failed to query tb0_part2: %v", err)
+	}
+	defer rows.Close()
+	want := []string{"a3", "b3", "c3"}
+	for rows.Next() {
+		var rowid int
+		var c3 string
+		if err := rows.Scan(&rowid, &c3); err != nil {
+			t.Fatalf("Failed to scan: %v", err)
+		}
+		if c3 != want[rowid-1] {
+			t.Errorf("Row %d of tb0_part2: got c3=%q, want %q", rowid, c3, want[rowid-1])
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Row iteration failed: %v", err)
+	}
+}
+
+func TestImportToSQLiteWideEAV(t *testing.T) {
+	oldLimit := MaxTableColumns
+	MaxTableColumns = 3
+	defer func() { MaxTableColumns = oldLimit }()
+
+	provider := wideTestProvider(5, [][]interface{}{
+		{"a0", nil, "a2", nil, "a4"},
+		{nil, "b1", nil, nil, nil},
+	})
+
+	tmpFile, err := os.CreateTemp("", "wide_eav_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{WideStrategy: WideEAV})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// Only the 4 non-NULL cells become rows
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count tb0: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 EAV rows, got %d", count)
+	}
+
+	var value string
+	err = db.QueryRow("SELECT value FROM tb0 WHERE row_id = 2 AND column = 'b1'").Scan(&value)
+	if err == nil {
+		t.Fatalf("Expected lookup by source column name c1, found stray column b1")
+	}
+	err = db.QueryRow("SELECT value FROM tb0 WHERE row_id = 2 AND column = 'c1'").Scan(&value)
+	if err != nil {
+		t.Fatalf("Failed to look up cell (2, c1): %v", err)
+	}
+	if value != "b1" {
+		t.Errorf("Expected cell value b1, got %q", value)
+	}
+}
+
+func TestImportToSQLiteWideNoStrategy(t *testing.T) {
+	oldLimit := MaxTableColumns
+	MaxTableColumns = 3
+	defer func() { MaxTableColumns = oldLimit }()
+
+	provider := wideTestProvider(4, [][]interface{}{{"a0", "a1", "a2", "a3"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, nil)
+	if err == nil {
+		t.Fatal("Expected an error for a 4-column table at a limit of 3")
+	}
+	if !strings.Contains(err.Error(), "--wide-strategy") {
+		t.Errorf("Expected the error to name --wide-strategy, got: %v", err)
+	}
+}
+
+func TestImportToSQLiteWidePartitionRejectsFiltering(t *testing.T) {
+	oldLimit := MaxTableColumns
+	MaxTableColumns = 3
+	defer func() { MaxTableColumns = oldLimit }()
+
+	provider := wideTestProvider(4, [][]interface{}{{"a0", "a1", "a2", "a3"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{WideStrategy: WidePartition, Limit: 1})
+	if err == nil {
+		t.Fatal("Expected an error combining partitioning with --limit")
+	}
+	if !strings.Contains(err.Error(), "misaligned") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestImportToSQLiteMelt(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"region", "y2021", "y2022", "y2023"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"north", int64(10), int64(11), int64(12)},
+				{"south", int64(20), nil, int64(22)},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "melt_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Melt: []string{"region"}})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// 3 value columns over 2 rows, minus the one NULL cell
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count tb0: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 melted rows, got %d", count)
+	}
+
+	var value int64
+	err = db.QueryRow("SELECT value FROM tb0 WHERE region = 'south' AND variable = 'y2023'").Scan(&value)
+	if err != nil {
+		t.Fatalf("Failed to look up melted cell: %v", err)
+	}
+	if value != 22 {
+		t.Errorf("Expected melted value 22, got %d", value)
+	}
+
+	err = db.QueryRow("SELECT value FROM tb0 WHERE region = 'south' AND variable = 'y2022'").Scan(&value)
+	if err != sql.ErrNoRows {
+		t.Errorf("Expected the NULL cell to be skipped, got err=%v value=%d", err, value)
+	}
+}
+
+func TestImportToSQLiteMeltMissingIDColumn(t *testing.T) {
+	provider := wideTestProvider(3, [][]interface{}{{"a0", "a1", "a2"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{Melt: []string{"nope"}})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown melt id column")
+	}
+	if !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Expected the error to name the missing column, got: %v", err)
+	}
+}
+
+func TestImportToSQLiteLocale(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"count", "amount", "booked"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER", "REAL", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1.234.567", "1.234,56", "31.12.2023"},
+				{"42", "3,14", "not a date"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "locale_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Locale: "de_DE"})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int64
+	var amount float64
+	var booked string
+	err = db.QueryRow("SELECT count, amount, booked FROM tb0 WHERE rowid = 1").Scan(&count, &amount, &booked)
+	if err != nil {
+		t.Fatalf("Failed to query row: %v", err)
+	}
+	if count != 1234567 {
+		t.Errorf("Expected count 1234567, got %d", count)
+	}
+	if amount != 1234.56 {
+		t.Errorf("Expected amount 1234.56, got %v", amount)
+	}
+	if booked != "2023-12-31" {
+		t.Errorf("Expected ISO date 2023-12-31, got %q", booked)
+	}
+
+	// Grouped values must be stored as numbers, not leftover text
+	var storedType string
+	if err := db.QueryRow("SELECT typeof(amount) FROM tb0 WHERE rowid = 1").Scan(&storedType); err != nil {
+		t.Fatalf("Failed to check stored type: %v", err)
+	}
+	if storedType != "real" {
+		t.Errorf("Expected amount stored as real, got %s", storedType)
+	}
+
+	err = db.QueryRow("SELECT count, amount, booked FROM tb0 WHERE rowid = 2").Scan(&count, &amount, &booked)
+	if err != nil {
+		t.Fatalf("Failed to query second row: %v", err)
+	}
+	if count != 42 || amount != 3.14 || booked != "not a date" {
+		t.Errorf("Unexpected second row: %d, %v, %q", count, amount, booked)
+	}
+}
+
+func TestImportToSQLiteInvalidLocale(t *testing.T) {
+	provider := wideTestProvider(2, [][]interface{}{{"a0", "a1"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{Locale: "not a locale"})
+	if err == nil {
+		t.Fatal("Expected an error for a malformed locale")
+	}
+	if !strings.Contains(err.Error(), "locale") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestImportToSQLiteTypeConfidence(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "note"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "a"},
+				{"2", "b"},
+				{"3", "c"},
+				{"oops", "d"}, // 3 of 4 id values fit INTEGER
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "confidence_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, nil)
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var confidence float64
+	err = db.QueryRow("SELECT type_confidence FROM _mksqlite_meta WHERE table_name = 'tb0' AND column_name = 'id'").Scan(&confidence)
+	if err != nil {
+		t.Fatalf("Failed to read type_confidence: %v", err)
+	}
+	if confidence != 0.75 {
+		t.Errorf("Expected confidence 0.75 for the mixed id column, got %v", confidence)
+	}
+
+	err = db.QueryRow("SELECT type_confidence FROM _mksqlite_meta WHERE table_name = 'tb0' AND column_name = 'note'").Scan(&confidence)
+	if err != nil {
+		t.Fatalf("Failed to read note confidence: %v", err)
+	}
+	if confidence != 1.0 {
+		t.Errorf("Expected confidence 1.0 for the text column, got %v", confidence)
+	}
+}
+
+func TestImportToSQLiteRawFallback(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "note"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "a"},
+				{"n/a", "b"},
+				{"3", "c"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "rawfallback_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{RawFallback: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// The companion sits right after its numeric column; text columns
+	// get none
+	rows, err := db.Query("SELECT name FROM pragma_table_info('tb0') ORDER BY cid")
+	if err != nil {
+		t.Fatalf("Failed to inspect tb0: %v", err)
+	}
+	var cols []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, name)
+	}
+	rows.Close()
+	want := "id,id_raw,note"
+	if got := strings.Join(cols, ","); got != want {
+		t.Fatalf("Unexpected columns: got %s, want %s", got, want)
+	}
+
+	var id interface{}
+	var idRaw interface{}
+	err = db.QueryRow("SELECT id, id_raw FROM tb0 WHERE rowid = 2").Scan(&id, &idRaw)
+	if err != nil {
+		t.Fatalf("Failed to query diverted row: %v", err)
+	}
+	if id != nil || idRaw != "n/a" {
+		t.Errorf("Expected NULL id with raw 'n/a', got %v, %v", id, idRaw)
+	}
+
+	err = db.QueryRow("SELECT id, id_raw FROM tb0 WHERE rowid = 3").Scan(&id, &idRaw)
+	if err != nil {
+		t.Fatalf("Failed to query clean row: %v", err)
+	}
+	if id != int64(3) || idRaw != nil {
+		t.Errorf("Expected id 3 with NULL raw, got %v, %v", id, idRaw)
+	}
+}
+
+func TestImportToSQLiteStrictTables(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "score", "note"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER", "REAL", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1", "2.5", "a"},
+				{"2", "3.5", "b"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "strict_tables_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{StrictTables: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var ddl string
+	if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE name = 'tb0'").Scan(&ddl); err != nil {
+		t.Fatalf("Failed to read DDL: %v", err)
+	}
+	if !strings.HasSuffix(ddl, "STRICT") {
+		t.Errorf("Expected STRICT table DDL, got: %s", ddl)
+	}
+
+	// String values were coerced to their declared types before insert
+	var idType, scoreType string
+	err = db.QueryRow("SELECT typeof(id), typeof(score) FROM tb0 WHERE rowid = 1").Scan(&idType, &scoreType)
+	if err != nil {
+		t.Fatalf("Failed to check stored types: %v", err)
+	}
+	if idType != "integer" || scoreType != "real" {
+		t.Errorf("Expected integer/real storage, got %s/%s", idType, scoreType)
+	}
+}
+
+func TestImportToSQLiteStrictTablesRejectsDrift(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{"1"},
+				{"not a number"},
+				{"3"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "strict_drift_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{StrictTables: true, LogErrors: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tb0").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 conforming rows, got %d", count)
+	}
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM _mksqlite_errors").Scan(&count); err != nil {
+		t.Fatalf("Failed to count logged errors: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 logged type-drift error, got %d", count)
+	}
+}
+
+func TestImportToSQLitePrimaryKey(t *testing.T) {
+	provider := &MockProvider{
+		tableNames: []string{"tb0"},
+		headers: map[string][]string{
+			"tb0": {"id", "name"},
+		},
+		colTypes: map[string][]string{
+			"tb0": {"INTEGER", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"tb0": {
+				{int64(1), "a"},
+				{int64(2), "b"},
+			},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "pk_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		PrimaryKey:   map[string][]string{"": {"id"}},
+		WithoutRowid: true,
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var ddl string
+	if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE name = 'tb0'").Scan(&ddl); err != nil {
+		t.Fatalf("Failed to read DDL: %v", err)
+	}
+	if !strings.Contains(ddl, "PRIMARY KEY (id)") {
+		t.Errorf("Expected a PRIMARY KEY clause, got: %s", ddl)
+	}
+	if !strings.Contains(ddl, "WITHOUT ROWID") {
+		t.Errorf("Expected WITHOUT ROWID, got: %s", ddl)
+	}
+
+	var pk int
+	if err := db.QueryRow("SELECT pk FROM pragma_table_info('tb0') WHERE name = 'id'").Scan(&pk); err != nil {
+		t.Fatalf("Failed to inspect primary key: %v", err)
+	}
+	if pk != 1 {
+		t.Errorf("Expected id to be the primary key, got pk=%d", pk)
+	}
+}
+
+func TestImportToSQLitePrimaryKeyMissingColumn(t *testing.T) {
+	provider := wideTestProvider(2, [][]interface{}{{"a0", "a1"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{PrimaryKey: map[string][]string{"": {"nope"}}})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown primary key column")
+	}
+	if !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Expected the error to name the missing column, got: %v", err)
+	}
+}
+
+func TestImportToSQLiteWithoutRowidRequiresKey(t *testing.T) {
+	provider := wideTestProvider(2, [][]interface{}{{"a0", "a1"}})
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{WithoutRowid: true})
+	if err == nil {
+		t.Fatal("Expected an error for WITHOUT ROWID with no primary key")
+	}
+	if !strings.Contains(err.Error(), "primary key") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// relationTestProvider is a two-table source with orders referencing
+// customers; the child table comes first so the check must not depend on
+// load order.
+func relationTestProvider(orders [][]interface{}) *MockProvider {
+	return &MockProvider{
+		tableNames: []string{"orders", "customers"},
+		headers: map[string][]string{
+			"orders":    {"id", "customer_id"},
+			"customers": {"id", "name"},
+		},
+		colTypes: map[string][]string{
+			"orders":    {"INTEGER", "INTEGER"},
+			"customers": {"INTEGER", "TEXT"},
+		},
+		rows: map[string][][]interface{}{
+			"orders": orders,
+			"customers": {
+				{int64(1), "a"},
+				{int64(2), "b"},
+			},
+		},
+	}
+}
+
+var relationTestKeys = map[string][]common.ForeignKey{
+	"orders": {{Column: "customer_id", RefTable: "customers", RefColumn: "id"}},
+}
+
+func TestImportToSQLiteForeignKeys(t *testing.T) {
+	provider := relationTestProvider([][]interface{}{
+		{int64(10), int64(1)},
+		{int64(11), int64(2)},
+	})
+
+	tmpFile, err := os.CreateTemp("", "fk_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{
+		ForeignKeys: relationTestKeys,
+		PrimaryKey:  map[string][]string{"customers": {"id"}},
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var ddl string
+	if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE name = 'orders'").Scan(&ddl); err != nil {
+		t.Fatalf("Failed to read DDL: %v", err)
+	}
+	if !strings.Contains(ddl, "FOREIGN KEY (customer_id) REFERENCES customers (id)") {
+		t.Errorf("Expected a FOREIGN KEY clause, got: %s", ddl)
+	}
+
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		t.Fatalf("foreign_key_check failed: %v", err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		t.Error("Expected no foreign key violations")
+	}
+}
+
+func TestImportToSQLiteForeignKeyViolation(t *testing.T) {
+	orders := [][]interface{}{
+		{int64(10), int64(1)},
+		{int64(11), int64(99)}, // no such customer
+	}
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(relationTestProvider(orders), &buf, &ImportOptions{ForeignKeys: relationTestKeys})
+	if err == nil {
+		t.Fatal("Expected an error for a violated foreign key")
+	}
+	if !strings.Contains(err.Error(), "foreign key check failed") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// With LogErrors the violation is recorded and the import finishes
+	tmpFile, err := os.CreateTemp("", "fk_log_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(relationTestProvider(orders), f, &ImportOptions{ForeignKeys: relationTestKeys, LogErrors: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM _mksqlite_errors WHERE message LIKE 'foreign key violation%'").Scan(&count); err != nil {
+		t.Fatalf("Failed to count logged violations: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 logged violation, got %d", count)
+	}
+}
+
+func TestImportToSQLiteForeignKeyMissingColumn(t *testing.T) {
+	provider := relationTestProvider(nil)
+
+	var buf bytes.Buffer
+	err := ImportToSQLite(provider, &buf, &ImportOptions{
+		ForeignKeys: map[string][]common.ForeignKey{"orders": {{Column: "nope", RefTable: "customers", RefColumn: "id"}}},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown foreign key column")
+	}
+	if !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Expected the error to name the missing column, got: %v", err)
+	}
+}
+
+// formatMockProvider adds declared column formats to MockProvider.
+type formatMockProvider struct {
+	MockProvider
+	formats map[string][]string
+}
+
+func (m *formatMockProvider) GetColumnFormats(tableName string) []string {
+	return m.formats[tableName]
+}
+
+func TestImportToSQLiteFormats(t *testing.T) {
+	provider := &formatMockProvider{
+		MockProvider: MockProvider{
+			tableNames: []string{"tb0"},
+			headers: map[string][]string{
+				"tb0": {"item", "amount", "day"},
+			},
+			rows: map[string][][]interface{}{
+				"tb0": {{"widget", "1,234.50", "2023-03-15"}},
+			},
+		},
+		formats: map[string][]string{
+			"tb0": {"", "#,##0.00", "yyyy-mm-dd"},
+		},
+	}
+
+	tmpFile, err := os.CreateTemp("", "formats_test_*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	dbPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(dbPath)
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Failed to open temp file: %v", err)
+	}
+	err = ImportToSQLite(provider, f, &ImportOptions{Formats: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM _mksqlite_formats").Scan(&count); err != nil {
+		t.Fatalf("Failed to count formats: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 format rows (unformatted column skipped), got %d", count)
+	}
+	var format string
+	if err := db.QueryRow("SELECT format FROM _mksqlite_formats WHERE table_name = 'tb0' AND column_name = 'amount'").Scan(&format); err != nil {
+		t.Fatalf("Failed to read format: %v", err)
+	}
+	if format != "#,##0.00" {
+		t.Errorf("Expected #,##0.00 for amount, got %q", format)
 	}
 }
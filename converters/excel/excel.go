@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/darianmavgo/mksqlite/converters"
@@ -14,7 +16,7 @@ import (
 )
 
 func init() {
-	converters.Register("excel", &excelDriver{})
+	converters.Register("excel", &excelDriver{}, converters.Capabilities{Extensions: []string{".xlsx", ".xls"}, MIMETypes: []string{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/vnd.ms-excel"}, Streaming: true})
 }
 
 type excelDriver struct{}
@@ -27,9 +29,20 @@ func (d *excelDriver) Open(source io.Reader, config *common.ConversionConfig) (c
 type ExcelConverter struct {
 	tableNames     []string
 	headers        map[string][]string // map tableName to headers
+	rawHeaders     map[string][]string // map tableName to original header text
 	sheetMap       map[string]string   // map tableName to sheetName
 	file           *excelize.File
 	headerRowIndex map[string]int // map tableName to header row index (0-based)
+	rowsPerInsert  int
+	rawFormulas    bool
+	cellLinks      bool
+	cellNotes      bool
+	dates          string                       // "text" or "unix", empty means no date conversion
+	date1904       bool                         // workbook uses the 1904 date epoch
+	dateStyles     map[int]bool                 // cache of style index to "formats as date"
+	fmtStyles      map[int]string               // cache of style index to number format code
+	dataCols       map[string]int               // map tableName to column count before _link/_note extras
+	comments       map[string]map[string]string // map sheetName to cell reference to comment text
 }
 
 // Ensure ExcelConverter implements RowProvider
@@ -60,11 +73,33 @@ func NewExcelConverterWithConfig(r io.Reader, config *common.ConversionConfig) (
 		f.Close()
 		return nil, fmt.Errorf("no sheets found in Excel file")
 	}
+	if config != nil && len(config.Sheets) > 0 {
+		sheets = filterSheets(sheets, config.Sheets)
+		if len(sheets) == 0 {
+			f.Close()
+			return nil, fmt.Errorf("none of the requested sheets %v exist in the workbook", config.Sheets)
+		}
+	}
+
+	rawFormulas := config != nil && config.RawFormulas
+	cellLinks := config != nil && config.CellLinks
+	cellNotes := config != nil && config.CellNotes
+	dates := ""
+	if config != nil {
+		dates = config.Dates
+	}
+	date1904 := false
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		date1904 = *props.Date1904
+	}
 
 	tableNames := common.GenTableNames(sheets)
 	headersMap := make(map[string][]string)
+	rawHeadersMap := make(map[string][]string)
 	sheetMap := make(map[string]string)
 	headerRowIndex := make(map[string]int)
+	dataCols := make(map[string]int)
+	commentsMap := make(map[string]map[string]string)
 
 	for idx, sheetName := range sheets {
 		tableName := tableNames[idx]
@@ -112,17 +147,130 @@ func NewExcelConverterWithConfig(r io.Reader, config *common.ConversionConfig) (
 		}
 
 		if len(headerRow) > 0 {
-			headersMap[tableName] = common.GenColumnNames(headerRow)
+			if config != nil && config.PreserveNames {
+				headersMap[tableName] = common.GenPreservedColumnNames(headerRow)
+			} else {
+				headersMap[tableName] = common.GenColumnNames(headerRow)
+			}
+			rawHeadersMap[tableName] = headerRow
+			dataCols[tableName] = len(headersMap[tableName])
+			if cellLinks || cellNotes {
+				extended := appendCellColumns(headersMap[tableName], cellLinks, cellNotes)
+				rawHeadersMap[tableName] = append(rawHeadersMap[tableName], extended[dataCols[tableName]:]...)
+				headersMap[tableName] = extended
+			}
+		}
+
+		if cellNotes {
+			if comments, err := f.GetComments(sheetName); err == nil && len(comments) > 0 {
+				byCell := make(map[string]string, len(comments))
+				for _, c := range comments {
+					byCell[c.Cell] = commentText(c)
+				}
+				commentsMap[sheetName] = byCell
+			}
 		}
 	}
 
-	return &ExcelConverter{
+	e := &ExcelConverter{
 		tableNames:     tableNames,
 		headers:        headersMap,
+		rawHeaders:     rawHeadersMap,
 		sheetMap:       sheetMap,
 		file:           f,
 		headerRowIndex: headerRowIndex,
-	}, nil
+		rawFormulas:    rawFormulas,
+		cellLinks:      cellLinks,
+		cellNotes:      cellNotes,
+		dates:          dates,
+		date1904:       date1904,
+		dateStyles:     make(map[int]bool),
+		fmtStyles:      make(map[int]string),
+		dataCols:       dataCols,
+		comments:       commentsMap,
+	}
+	if config != nil {
+		e.rowsPerInsert = config.RowsPerInsert
+	}
+	return e, nil
+}
+
+// appendCellColumns extends the header list with a <col>_link and/or
+// <col>_note column for every data column.
+func appendCellColumns(headers []string, links, notes bool) []string {
+	extended := append([]string(nil), headers...)
+	if links {
+		for _, h := range headers {
+			extended = append(extended, h+"_link")
+		}
+	}
+	if notes {
+		for _, h := range headers {
+			extended = append(extended, h+"_note")
+		}
+	}
+	return extended
+}
+
+// commentText flattens a cell comment to plain text; newer workbooks carry
+// the text in rich-text runs rather than the Text field.
+func commentText(c excelize.Comment) string {
+	if c.Text != "" {
+		return c.Text
+	}
+	var b strings.Builder
+	for _, run := range c.Paragraph {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// builtInDateNumFmts lists the built-in number format indexes that render a
+// serial number as a date or time.
+var builtInDateNumFmts = func() map[int]bool {
+	m := make(map[int]bool)
+	for _, r := range [][2]int{{14, 22}, {27, 36}, {45, 47}, {50, 58}} {
+		for i := r[0]; i <= r[1]; i++ {
+			m[i] = true
+		}
+	}
+	return m
+}()
+
+// isDateFormatCode reports whether a custom number format code renders
+// dates, probing for date tokens after dropping quoted literals and
+// bracketed sections (colors, locale prefixes).
+func isDateFormatCode(code string) bool {
+	var b strings.Builder
+	inQuote, inBracket := false, false
+	for _, r := range code {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == '[' && !inQuote:
+			inBracket = true
+		case r == ']' && !inQuote:
+			inBracket = false
+		case !inQuote && !inBracket:
+			b.WriteRune(r)
+		}
+	}
+	return strings.ContainsAny(strings.ToLower(b.String()), "ymdhs")
+}
+
+// filterSheets keeps the sheets named in wanted, preserving workbook order.
+func filterSheets(sheets, wanted []string) []string {
+	keep := make(map[string]bool, len(wanted))
+	for _, name := range wanted {
+		keep[name] = true
+	}
+	var filtered []string
+	for _, name := range sheets {
+		if keep[name] {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
 }
 
 // GetTableNames implements RowProvider
@@ -135,6 +283,11 @@ func (e *ExcelConverter) GetHeaders(tableName string) []string {
 	return e.headers[tableName]
 }
 
+// GetRawHeaders implements common.RawHeaderProvider
+func (e *ExcelConverter) GetRawHeaders(tableName string) []string {
+	return e.rawHeaders[tableName]
+}
+
 // GetColumnTypes implements RowProvider
 func (e *ExcelConverter) GetColumnTypes(tableName string) []string {
 	sheetName, ok := e.sheetMap[tableName]
@@ -178,6 +331,7 @@ func (e *ExcelConverter) GetColumnTypes(tableName string) []string {
 	}
 
 	var scannedRows [][]string
+	dateCols := make([]bool, len(headers))
 	for i := 0; i < 20 && rows.Next(); i++ {
 		cols, err := rows.Columns()
 		if err != nil {
@@ -190,9 +344,28 @@ func (e *ExcelConverter) GetColumnTypes(tableName string) []string {
 			cols = padded
 		}
 		scannedRows = append(scannedRows, cols)
+		if e.dates != "" {
+			for j := range cols {
+				if j < len(dateCols) && !dateCols[j] && e.isDateCell(sheetName, j+1, skipCount+len(scannedRows)) {
+					dateCols[j] = true
+				}
+			}
+		}
 	}
 
-	return common.InferColumnTypes(scannedRows, len(headers))
+	types := common.InferColumnTypes(scannedRows, len(headers))
+	if e.dates != "" {
+		dateType := "TEXT"
+		if e.dates == "unix" {
+			dateType = "INTEGER"
+		}
+		for j, isDate := range dateCols {
+			if isDate && j < len(types) {
+				types[j] = dateType
+			}
+		}
+	}
+	return types
 }
 
 // ScanRows implements RowProvider
@@ -219,7 +392,9 @@ func (e *ExcelConverter) ScanRows(ctx context.Context, tableName string, yield f
 		}
 	}
 
+	rowNum := skipCount
 	for rows.Next() {
+		rowNum++
 		row, err := rows.Columns()
 		if err != nil {
 			return fmt.Errorf("failed to read row: %w", err)
@@ -231,6 +406,16 @@ func (e *ExcelConverter) ScanRows(ctx context.Context, tableName string, yield f
 			interfaceRow[i] = val
 		}
 
+		if e.dates != "" {
+			e.convertDateCells(interfaceRow, sheetName, rowNum)
+		}
+		if e.rawFormulas {
+			e.substituteFormulas(interfaceRow, sheetName, rowNum)
+		}
+		if e.cellLinks || e.cellNotes {
+			interfaceRow = e.appendCellValues(interfaceRow, sheetName, tableName, rowNum)
+		}
+
 		if err := yield(interfaceRow, nil); err != nil {
 			return err
 		}
@@ -246,6 +431,188 @@ func (e *ExcelConverter) ScanRows(ctx context.Context, tableName string, yield f
 	return nil
 }
 
+// builtinNumFmtCodes maps the built-in number format ids (ECMA-376
+// §18.8.30) onto their format codes. Id 0 ("General") is omitted since it
+// carries no formatting worth preserving.
+var builtinNumFmtCodes = map[int]string{
+	1: "0", 2: "0.00", 3: "#,##0", 4: "#,##0.00",
+	9: "0%", 10: "0.00%", 11: "0.00E+00", 12: "# ?/?", 13: "# ??/??",
+	14: "mm-dd-yy", 15: "d-mmm-yy", 16: "d-mmm", 17: "mmm-yy",
+	18: "h:mm AM/PM", 19: "h:mm:ss AM/PM", 20: "h:mm", 21: "h:mm:ss",
+	22: "m/d/yy h:mm",
+	37: "#,##0 ;(#,##0)", 38: "#,##0 ;[Red](#,##0)",
+	39: "#,##0.00;(#,##0.00)", 40: "#,##0.00;[Red](#,##0.00)",
+	45: "mm:ss", 46: "[h]:mm:ss", 47: "mmss.0", 48: "##0.0E+0", 49: "@",
+}
+
+// styleFormatCode resolves a style index to its number format code,
+// caching the answer per style. Unstyled cells resolve to "".
+func (e *ExcelConverter) styleFormatCode(styleID int) string {
+	if code, ok := e.fmtStyles[styleID]; ok {
+		return code
+	}
+	code := ""
+	if style, err := e.file.GetStyle(styleID); err == nil && style != nil {
+		if style.CustomNumFmt != nil {
+			code = *style.CustomNumFmt
+		} else {
+			code = builtinNumFmtCodes[style.NumFmt]
+		}
+	}
+	e.fmtStyles[styleID] = code
+	return code
+}
+
+// GetColumnFormats implements common.ColumnFormatProvider: each data
+// column reports the number format of its first formatted cell in the
+// leading data rows, so consumers can re-render values the way the sheet
+// displayed them. The _link/_note extras carry no format.
+func (e *ExcelConverter) GetColumnFormats(tableName string) []string {
+	sheetName, ok := e.sheetMap[tableName]
+	if !ok {
+		return nil
+	}
+	headers := e.headers[tableName]
+	formats := make([]string, len(headers))
+	dataCols := e.dataCols[tableName]
+	firstDataRow := e.headerRowIndex[tableName] + 2 // 1-based, past the header
+	for col := 0; col < dataCols; col++ {
+		for row := firstDataRow; row < firstDataRow+20; row++ {
+			cell, err := excelize.CoordinatesToCellName(col+1, row)
+			if err != nil {
+				break
+			}
+			styleID, err := e.file.GetCellStyle(sheetName, cell)
+			if err != nil {
+				continue
+			}
+			if code := e.styleFormatCode(styleID); code != "" {
+				formats[col] = code
+				break
+			}
+		}
+	}
+	return formats
+}
+
+// isDateStyle reports whether a style index formats values as dates,
+// caching the answer per style.
+func (e *ExcelConverter) isDateStyle(styleID int) bool {
+	if v, ok := e.dateStyles[styleID]; ok {
+		return v
+	}
+	v := false
+	if style, err := e.file.GetStyle(styleID); err == nil && style != nil {
+		if style.CustomNumFmt != nil {
+			v = isDateFormatCode(*style.CustomNumFmt)
+		} else {
+			v = builtInDateNumFmts[style.NumFmt]
+		}
+	}
+	e.dateStyles[styleID] = v
+	return v
+}
+
+// isDateCell reports whether the cell at the 1-based coordinates carries a
+// date number format.
+func (e *ExcelConverter) isDateCell(sheetName string, col, row int) bool {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return false
+	}
+	styleID, err := e.file.GetCellStyle(sheetName, cell)
+	if err != nil {
+		return false
+	}
+	return e.isDateStyle(styleID)
+}
+
+// convertDateCells rewrites date-styled cells in the row from their serial
+// number to the configured representation: ISO-8601 text ("text") or epoch
+// seconds ("unix"). The workbook's 1900/1904 epoch is honoured; cells whose
+// underlying value isn't a serial number pass through unchanged.
+func (e *ExcelConverter) convertDateCells(row []interface{}, sheetName string, rowNum int) {
+	for i := range row {
+		cell, err := excelize.CoordinatesToCellName(i+1, rowNum)
+		if err != nil {
+			continue
+		}
+		styleID, err := e.file.GetCellStyle(sheetName, cell)
+		if err != nil || !e.isDateStyle(styleID) {
+			continue
+		}
+		raw, err := e.file.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true})
+		if err != nil || raw == "" {
+			continue
+		}
+		serial, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+		tm, err := excelize.ExcelDateToTime(serial, e.date1904)
+		if err != nil {
+			continue
+		}
+		switch {
+		case e.dates == "unix":
+			row[i] = tm.Unix()
+		case serial == math.Trunc(serial):
+			row[i] = tm.Format("2006-01-02")
+		default:
+			row[i] = tm.Format("2006-01-02T15:04:05")
+		}
+	}
+}
+
+// substituteFormulas replaces the cached result of each formula cell in the
+// row with its formula text, prefixed with "=" as the sheet shows it.
+func (e *ExcelConverter) substituteFormulas(row []interface{}, sheetName string, rowNum int) {
+	for i := range row {
+		cell, err := excelize.CoordinatesToCellName(i+1, rowNum)
+		if err != nil {
+			continue
+		}
+		if formula, err := e.file.GetCellFormula(sheetName, cell); err == nil && formula != "" {
+			row[i] = "=" + formula
+		}
+	}
+}
+
+// appendCellValues pads the row to the table's data column count and appends
+// the per-cell hyperlink targets and comment texts matching the <col>_link
+// and <col>_note header extension. Cells without a link or comment yield
+// NULL.
+func (e *ExcelConverter) appendCellValues(row []interface{}, sheetName, tableName string, rowNum int) []interface{} {
+	dataCols := e.dataCols[tableName]
+	for len(row) < dataCols {
+		row = append(row, nil)
+	}
+	row = row[:dataCols]
+	if e.cellLinks {
+		for i := 0; i < dataCols; i++ {
+			var link interface{}
+			if cell, err := excelize.CoordinatesToCellName(i+1, rowNum); err == nil {
+				if ok, target, err := e.file.GetCellHyperLink(sheetName, cell); err == nil && ok {
+					link = target
+				}
+			}
+			row = append(row, link)
+		}
+	}
+	if e.cellNotes {
+		for i := 0; i < dataCols; i++ {
+			var note interface{}
+			if cell, err := excelize.CoordinatesToCellName(i+1, rowNum); err == nil {
+				if text, ok := e.comments[sheetName][cell]; ok {
+					note = text
+				}
+			}
+			row = append(row, note)
+		}
+	}
+	return row
+}
+
 // Close closes the underlying Excel file
 func (e *ExcelConverter) Close() error {
 	if e.file != nil {
@@ -261,6 +628,7 @@ func (e *ExcelConverter) ConvertToSQL(ctx context.Context, writer io.Writer) err
 	}
 
 	bw := bufio.NewWriter(writer)
+	sqlWriter := common.NewSQLWriter(bw, e.rowsPerInsert)
 
 	for _, tableName := range e.tableNames {
 		headers := e.headers[tableName]
@@ -271,72 +639,23 @@ func (e *ExcelConverter) ConvertToSQL(ctx context.Context, writer io.Writer) err
 		// Get column types
 		colTypes := e.GetColumnTypes(tableName)
 
-		// Write CREATE TABLE statement
-		createTableSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-		if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-			return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+		if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
+			return err
 		}
 
 		err := e.ScanRows(ctx, tableName, func(row []interface{}, err error) error {
 			if err != nil {
 				return err
 			}
-			if _, err := fmt.Fprintf(bw, "INSERT INTO %s (", tableName); err != nil {
-				return fmt.Errorf("failed to write INSERT start: %w", err)
-			}
-
-			// Write column names
-			for i, header := range headers {
-				if i > 0 {
-					if _, err := bw.Write([]byte(", ")); err != nil {
-						return fmt.Errorf("failed to write column separator: %w", err)
-					}
-				}
-				if _, err := fmt.Fprintf(bw, "%s", header); err != nil {
-					return fmt.Errorf("failed to write column name: %w", err)
-				}
-			}
-
-			if _, err := fmt.Fprintf(bw, ") VALUES ("); err != nil {
-				return fmt.Errorf("failed to write VALUES start: %w", err)
-			}
-
-			// Write values
-			for i, val := range row {
-				if i > 0 {
-					if _, err := bw.Write([]byte(", ")); err != nil {
-						return fmt.Errorf("failed to write value separator: %w", err)
-					}
-				}
-
-				// Handle value types. Excelize returns strings for everything usually, but ScanRows returns interface{}.
-				strVal := ""
-				switch v := val.(type) {
-				case string:
-					strVal = v
-				default:
-					strVal = fmt.Sprintf("%v", v)
-				}
-
-				// Escape single quotes by doubling them
-				escapedVal := strings.ReplaceAll(strVal, "'", "''")
-				if _, err := fmt.Fprintf(bw, "'%s'", escapedVal); err != nil {
-					return fmt.Errorf("failed to write value: %w", err)
-				}
-			}
-
-			if _, err := bw.Write([]byte(");\n")); err != nil {
-				return fmt.Errorf("failed to write statement end: %w", err)
-			}
-			return nil
+			return sqlWriter.WriteRow(row)
 		})
 
 		if err != nil {
 			return err
 		}
 
-		if _, err := bw.Write([]byte("\n")); err != nil {
-			return fmt.Errorf("failed to write table separator: %w", err)
+		if err := sqlWriter.EndTable(); err != nil {
+			return err
 		}
 	}
 
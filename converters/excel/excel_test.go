@@ -1,6 +1,7 @@
 package excel
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"os"
@@ -9,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+
+	"github.com/xuri/excelize/v2"
 
 	_ "modernc.org/sqlite"
 )
@@ -151,3 +155,206 @@ func TestExcelConvertToSQL(t *testing.T) {
 		t.Error("Expected INSERT INTO in SQL output")
 	}
 }
+
+func TestExcelCellOptions(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	f.SetCellValue(sheet, "A1", "item")
+	f.SetCellValue(sheet, "B1", "amount")
+	f.SetCellValue(sheet, "A2", "widget")
+	f.SetCellValue(sheet, "B2", 10)
+	if err := f.SetCellFormula(sheet, "B3", "SUM(B2:B2)"); err != nil {
+		t.Fatalf("SetCellFormula failed: %v", err)
+	}
+	f.SetCellValue(sheet, "A3", "total")
+	if err := f.SetCellHyperLink(sheet, "A2", "https://example.com/widget", "External"); err != nil {
+		t.Fatalf("SetCellHyperLink failed: %v", err)
+	}
+	if err := f.AddComment(sheet, excelize.Comment{Cell: "B2", Author: "auditor", Paragraph: []excelize.RichTextRun{{Text: "verified"}}}); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("WriteToBuffer failed: %v", err)
+	}
+	f.Close()
+
+	config := &common.ConversionConfig{RawFormulas: true, CellLinks: true, CellNotes: true}
+	converter, err := NewExcelConverterWithConfig(bytes.NewReader(buf.Bytes()), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	defer converter.Close()
+
+	tableName := converter.GetTableNames()[0]
+	headers := converter.GetHeaders(tableName)
+	want := []string{"item", "amount", "item_link", "amount_link", "item_note", "amount_note"}
+	if len(headers) != len(want) {
+		t.Fatalf("Expected headers %v, got %v", want, headers)
+	}
+	for i, h := range want {
+		if headers[i] != h {
+			t.Errorf("Expected header %d to be %q, got %q", i, h, headers[i])
+		}
+	}
+
+	var scanned [][]interface{}
+	err = converter.ScanRows(context.Background(), tableName, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		scanned = append(scanned, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(scanned) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(scanned))
+	}
+	first := scanned[0]
+	if len(first) != len(want) {
+		t.Fatalf("Expected %d values per row, got %d", len(want), len(first))
+	}
+	if first[2] != "https://example.com/widget" {
+		t.Errorf("Expected hyperlink target in item_link, got %v", first[2])
+	}
+	if first[5] != "verified" {
+		t.Errorf("Expected comment text in amount_note, got %v", first[5])
+	}
+	if scanned[1][1] != "=SUM(B2:B2)" {
+		t.Errorf("Expected formula text for B3, got %v", scanned[1][1])
+	}
+	if scanned[1][2] != nil {
+		t.Errorf("Expected NULL link for cell without hyperlink, got %v", scanned[1][2])
+	}
+}
+
+func TestExcelDateConversion(t *testing.T) {
+	buildWorkbook := func(t *testing.T) []byte {
+		f := excelize.NewFile()
+		sheet := f.GetSheetName(0)
+		f.SetCellValue(sheet, "A1", "when")
+		f.SetCellValue(sheet, "B1", "amount")
+		dateStyle, err := f.NewStyle(&excelize.Style{NumFmt: 14})
+		if err != nil {
+			t.Fatalf("NewStyle failed: %v", err)
+		}
+		f.SetCellValue(sheet, "A2", 45000) // serial for 2023-03-15
+		if err := f.SetCellStyle(sheet, "A2", "A2", dateStyle); err != nil {
+			t.Fatalf("SetCellStyle failed: %v", err)
+		}
+		f.SetCellValue(sheet, "B2", 42)
+		buf, err := f.WriteToBuffer()
+		if err != nil {
+			t.Fatalf("WriteToBuffer failed: %v", err)
+		}
+		f.Close()
+		return buf.Bytes()
+	}
+	scanFirst := func(t *testing.T, data []byte, mode string) []interface{} {
+		config := &common.ConversionConfig{Dates: mode}
+		converter, err := NewExcelConverterWithConfig(bytes.NewReader(data), config)
+		if err != nil {
+			t.Fatalf("Failed to create converter: %v", err)
+		}
+		defer converter.Close()
+		var first []interface{}
+		tableName := converter.GetTableNames()[0]
+		err = converter.ScanRows(context.Background(), tableName, func(row []interface{}, rowErr error) error {
+			if rowErr != nil {
+				return rowErr
+			}
+			if first == nil {
+				first = row
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ScanRows failed: %v", err)
+		}
+		if first == nil {
+			t.Fatal("Expected at least one row")
+		}
+		return first
+	}
+
+	data := buildWorkbook(t)
+
+	row := scanFirst(t, data, "text")
+	if row[0] != "2023-03-15" {
+		t.Errorf("Expected ISO-8601 date, got %v", row[0])
+	}
+	if row[1] != "42" {
+		t.Errorf("Expected non-date cell untouched, got %v", row[1])
+	}
+
+	row = scanFirst(t, data, "unix")
+	want := int64(1678838400) // 2023-03-15T00:00:00Z
+	if row[0] != want {
+		t.Errorf("Expected epoch seconds %d, got %v", want, row[0])
+	}
+
+	config := &common.ConversionConfig{Dates: "unix"}
+	converter, err := NewExcelConverterWithConfig(bytes.NewReader(data), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	defer converter.Close()
+	types := converter.GetColumnTypes(converter.GetTableNames()[0])
+	if len(types) != 2 || types[0] != "INTEGER" {
+		t.Errorf("Expected INTEGER type for date column, got %v", types)
+	}
+}
+
+func TestExcelColumnFormats(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	f.SetCellValue(sheet, "A1", "item")
+	f.SetCellValue(sheet, "B1", "amount")
+	f.SetCellValue(sheet, "C1", "ratio")
+	moneyStyle, err := f.NewStyle(&excelize.Style{NumFmt: 4}) // #,##0.00
+	if err != nil {
+		t.Fatalf("NewStyle failed: %v", err)
+	}
+	custom := "0.000%"
+	ratioStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &custom})
+	if err != nil {
+		t.Fatalf("NewStyle failed: %v", err)
+	}
+	f.SetCellValue(sheet, "A2", "widget")
+	f.SetCellValue(sheet, "B2", 1234.5)
+	f.SetCellValue(sheet, "C2", 0.125)
+	if err := f.SetCellStyle(sheet, "B2", "B2", moneyStyle); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if err := f.SetCellStyle(sheet, "C2", "C2", ratioStyle); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("WriteToBuffer failed: %v", err)
+	}
+	f.Close()
+
+	converter, err := NewExcelConverter(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	defer converter.Close()
+
+	tableName := converter.GetTableNames()[0]
+	formats := converter.GetColumnFormats(tableName)
+	if len(formats) != 3 {
+		t.Fatalf("Expected 3 formats, got %v", formats)
+	}
+	if formats[0] != "" {
+		t.Errorf("Expected no format for the unstyled column, got %q", formats[0])
+	}
+	if formats[1] != "#,##0.00" {
+		t.Errorf("Expected built-in money format, got %q", formats[1])
+	}
+	if formats[2] != "0.000%" {
+		t.Errorf("Expected custom format code, got %q", formats[2])
+	}
+}
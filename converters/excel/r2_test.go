@@ -2,9 +2,9 @@ package excel_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/excel"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
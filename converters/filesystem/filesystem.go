@@ -2,14 +2,17 @@ package filesystem
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"io/fs"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
@@ -22,7 +25,7 @@ const (
 )
 
 func init() {
-	converters.Register("filesystem", &filesystemDriver{})
+	converters.Register("filesystem", &filesystemDriver{}, converters.Capabilities{Streaming: true})
 }
 
 type filesystemDriver struct{}
@@ -41,6 +44,21 @@ func (d *filesystemDriver) Open(source io.Reader, config *common.ConversionConfi
 				c.SetTimeout(d)
 			}
 		}
+		if config.MediaMetadata {
+			c.SetMediaMetadata(true)
+		}
+		if config.HashAlgorithm != "" {
+			if err := c.SetHash(config.HashAlgorithm); err != nil {
+				return nil, err
+			}
+		}
+		if config.FollowSymlinks {
+			c.SetFollowSymlinks(true)
+		}
+		if config.InlineContent > 0 {
+			c.SetInlineContent(config.InlineContent)
+		}
+		c.rowsPerInsert = config.RowsPerInsert
 		return c, nil
 	}
 	// Fallback to trying to get the path from the source reader if it's a file
@@ -55,6 +73,12 @@ type FilesystemConverter struct {
 	inputPath      string
 	resumptionPath string
 	timeout        time.Duration
+	mediaMetadata  bool      // Emit the media_metadata companion table
+	hashAlgo       string    // "sha256" or "md5"; adds a content hash column
+	followSymlinks bool      // Descend into symlinked directories
+	inlineContent  int64     // Files up to this size get a BLOB content column; 0 disables
+	visited        *sync.Map // dev:inode pairs seen this scan (cycle protection)
+	rowsPerInsert  int       // Rows per multi-row INSERT in SQL output
 }
 
 // Ensure FilesystemConverter implements RowProvider
@@ -91,18 +115,108 @@ func (c *FilesystemConverter) SetTimeout(d time.Duration) {
 	c.timeout = d
 }
 
+// SetHash enables a content hash column ("sha256" or "md5") so duplicate
+// files can be found with a GROUP BY query.
+func (c *FilesystemConverter) SetHash(algo string) error {
+	switch algo {
+	case "sha256", "md5":
+		c.hashAlgo = algo
+		return nil
+	}
+	return fmt.Errorf("unsupported hash algorithm: %s (want sha256 or md5)", algo)
+}
+
+// SetFollowSymlinks makes the scan descend into symlinked directories.
+// Cycles are broken by tracking visited device/inode pairs.
+func (c *FilesystemConverter) SetFollowSymlinks(follow bool) {
+	c.followSymlinks = follow
+}
+
+// SetInlineContent adds a BLOB content column holding the bytes of files
+// up to maxBytes; larger files and directories get NULL.
+func (c *FilesystemConverter) SetInlineContent(maxBytes int64) {
+	c.inlineContent = maxBytes
+}
+
+// readContent loads a file's bytes for the content column, or nil when the
+// file exceeds the inline limit or can't be read.
+func (c *FilesystemConverter) readContent(path string, size int64) interface{} {
+	if size > c.inlineContent {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// markVisited records the entry's device/inode pair and reports whether it
+// was seen for the first time. Platforms without stat data never block.
+func (c *FilesystemConverter) markVisited(info fs.FileInfo) bool {
+	id, ok := fileID(info)
+	if !ok {
+		return true
+	}
+	_, loaded := c.visited.LoadOrStore(id, struct{}{})
+	return !loaded
+}
+
+// hashFile computes the configured digest of a file's contents.
+func (c *FilesystemConverter) hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var h hash.Hash
+	switch c.hashAlgo {
+	case "sha256":
+		h = sha256.New()
+	case "md5":
+		h = md5.New()
+	default:
+		return "", fmt.Errorf("unsupported hash algorithm: %s", c.hashAlgo)
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // GetTableNames implements RowProvider
 func (c *FilesystemConverter) GetTableNames() []string {
+	if c.mediaMetadata {
+		return []string{FSTB, MediaTB}
+	}
 	return []string{FSTB}
 }
 
 // GetHeaders implements RowProvider
 func (c *FilesystemConverter) GetHeaders(tableName string) []string {
 	if tableName == FSTB {
-		return []string{
+		headers := []string{
 			"path", "name", "size", "extension",
 			"mod_time", "create_time", "permissions",
 			"is_dir", "mime_type",
+			"is_symlink", "link_target",
+			"owner", "group_name", "inode", "nlink", "xattrs",
+		}
+		if c.hashAlgo != "" {
+			headers = append(headers, c.hashAlgo)
+		}
+		if c.inlineContent > 0 {
+			headers = append(headers, "content")
+		}
+		return headers
+	}
+	if tableName == MediaTB && c.mediaMetadata {
+		return []string{
+			"path", "media_type",
+			"camera_make", "camera_model", "taken_at",
+			"gps_lat", "gps_lon",
+			"title", "artist", "album",
 		}
 	}
 	return nil
@@ -111,10 +225,27 @@ func (c *FilesystemConverter) GetHeaders(tableName string) []string {
 // GetColumnTypes implements RowProvider
 func (c *FilesystemConverter) GetColumnTypes(tableName string) []string {
 	if tableName == FSTB {
-		return []string{
+		colTypes := []string{
 			"TEXT", "TEXT", "INTEGER", "TEXT",
 			"TEXT", "TEXT", "TEXT",
 			"INTEGER", "TEXT",
+			"INTEGER", "TEXT",
+			"TEXT", "TEXT", "INTEGER", "INTEGER", "TEXT",
+		}
+		if c.hashAlgo != "" {
+			colTypes = append(colTypes, "TEXT")
+		}
+		if c.inlineContent > 0 {
+			colTypes = append(colTypes, "BLOB")
+		}
+		return colTypes
+	}
+	if tableName == MediaTB && c.mediaMetadata {
+		return []string{
+			"TEXT", "TEXT",
+			"TEXT", "TEXT", "TEXT",
+			"REAL", "REAL",
+			"TEXT", "TEXT", "TEXT",
 		}
 	}
 	return nil
@@ -122,10 +253,16 @@ func (c *FilesystemConverter) GetColumnTypes(tableName string) []string {
 
 // ScanRows implements RowProvider
 func (c *FilesystemConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName == MediaTB && c.mediaMetadata {
+		return c.scanMediaRows(ctx, yield)
+	}
 	if tableName != FSTB {
 		return nil
 	}
 
+	// Fresh cycle-protection state for this scan
+	c.visited = &sync.Map{}
+
 	// Configuration
 	const numWorkers = 32
 	// Semaphore to limit concurrency
@@ -282,6 +419,16 @@ func (c *FilesystemConverter) ScanRows(ctx context.Context, tableName string, yi
 func (c *FilesystemConverter) processDir(ctx context.Context, dirPath string, wg *sync.WaitGroup, sem chan struct{}, results chan<- []interface{}) {
 	defer wg.Done()
 
+	// When following symlinks, refuse to re-enter a directory we have
+	// already scanned (cycle protection via device/inode tracking).
+	if c.followSymlinks {
+		info, err := os.Stat(dirPath)
+		if err != nil || !c.markVisited(info) {
+			<-sem
+			return
+		}
+	}
+
 	// Read directory with timeout
 	// Default 30s timeout for directory listing
 	entries, err := runWithTimeout(30*time.Second, func() ([]fs.DirEntry, error) {
@@ -296,6 +443,16 @@ func (c *FilesystemConverter) processDir(ctx context.Context, dirPath string, wg
 		return
 	}
 
+	// Emit a row for the directory itself, keeping parity with
+	// ConvertToSQL's walk which includes directories.
+	if row := c.dirRow(dirPath); row != nil {
+		select {
+		case results <- row:
+		case <-ctx.Done():
+			return
+		}
+	}
+
 	for _, d := range entries {
 		select {
 		case <-ctx.Done():
@@ -320,6 +477,20 @@ func (c *FilesystemConverter) processDir(ctx context.Context, dirPath string, wg
 				return
 			}
 		} else {
+			// Symlinked directories are descended into only when
+			// following is enabled; the link itself still gets a row.
+			if c.followSymlinks && d.Type()&fs.ModeSymlink != 0 {
+				if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
+					select {
+					case sem <- struct{}{}:
+						wg.Add(1)
+						go c.processDir(ctx, fullPath, wg, sem, results)
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
 			// Backpressure: Acquire semaphore BEFORE spawning the goroutine.
 			// This prevents creating millions of goroutines for large directories.
 			select {
@@ -359,10 +530,35 @@ func (c *FilesystemConverter) processFile(ctx context.Context, path string, d fs
 	ext := filepath.Ext(path)
 	name := d.Name()
 
+	isSymlink := 0
+	var linkTarget interface{}
+	if d.Type()&fs.ModeSymlink != 0 {
+		isSymlink = 1
+		if target, err := os.Readlink(path); err == nil {
+			linkTarget = target
+		}
+	}
+
+	owner, group, inode, nlink, xattrs := statExtra(info, path)
+
 	row := []interface{}{
 		relPath, name, size, ext,
 		modTime, createTime, permissions,
 		isDir, mimeType,
+		isSymlink, linkTarget,
+		owner, group, inode, nlink, xattrs,
+	}
+
+	if c.hashAlgo != "" {
+		// Computed inside the worker pool so large trees hash concurrently
+		if digest, err := c.hashFile(path); err == nil {
+			row = append(row, digest)
+		} else {
+			row = append(row, nil)
+		}
+	}
+	if c.inlineContent > 0 {
+		row = append(row, c.readContent(path, size))
 	}
 
 	select {
@@ -371,6 +567,40 @@ func (c *FilesystemConverter) processFile(ctx context.Context, path string, d fs
 	}
 }
 
+// dirRow builds a listing row for a directory itself (is_dir=1).
+func (c *FilesystemConverter) dirRow(dirPath string) []interface{} {
+	if c.resumptionPath != "" && dirPath < c.resumptionPath {
+		return nil
+	}
+
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return nil
+	}
+
+	relPath, err := filepath.Rel(c.inputPath, dirPath)
+	if err != nil {
+		relPath = dirPath
+	}
+
+	owner, group, inode, nlink, xattrs := statExtra(info, dirPath)
+
+	row := []interface{}{
+		relPath, info.Name(), info.Size(), "",
+		info.ModTime().Format(time.RFC3339), getCreateTime(info).Format(time.RFC3339), info.Mode().String(),
+		1, "inode/directory",
+		0, nil,
+		owner, group, inode, nlink, xattrs,
+	}
+	if c.hashAlgo != "" {
+		row = append(row, nil)
+	}
+	if c.inlineContent > 0 {
+		row = append(row, nil)
+	}
+	return row
+}
+
 func (c *FilesystemConverter) detectMimeType(path string) string {
 	// Use a short timeout for individual file reads to prevent hangs
 	timeout := 5 * time.Second
@@ -409,17 +639,12 @@ func (c *FilesystemConverter) ConvertToSQL(ctx context.Context, writer io.Writer
 	}
 
 	inputPath := c.inputPath
-	headers := []string{
-		"path", "name", "size", "extension",
-		"mod_time", "create_time", "permissions",
-		"is_dir", "mime_type",
-	}
+	headers := c.GetHeaders(FSTB)
 
-	// Write CREATE TABLE statement
 	colTypes := c.GetColumnTypes(FSTB)
-	createTableSQL := common.GenCreateTableSQLWithTypes(FSTB, headers, colTypes)
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+	sqlWriter := common.NewSQLWriter(writer, c.rowsPerInsert)
+	if err := sqlWriter.BeginTable(FSTB, headers, colTypes); err != nil {
+		return err
 	}
 
 	// Walk directory
@@ -476,32 +701,53 @@ func (c *FilesystemConverter) ConvertToSQL(ctx context.Context, writer io.Writer
 			mimeType,
 		}
 
-		if _, err := fmt.Fprintf(writer, "INSERT INTO %s (path, name, size, extension, mod_time, create_time, permissions, is_dir, mime_type) VALUES (", FSTB); err != nil {
-			return fmt.Errorf("failed to write INSERT start: %w", err)
+		isSymlink := "0"
+		linkTarget := ""
+		if d.Type()&fs.ModeSymlink != 0 {
+			isSymlink = "1"
+			linkTarget, _ = os.Readlink(path)
 		}
+		row = append(row, isSymlink, linkTarget)
 
-		// Write values
-		for i, val := range row {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write value separator: %w", err)
-				}
-			}
-			// Escape single quotes by doubling them
-			escapedVal := strings.ReplaceAll(val, "'", "''")
-			if _, err := fmt.Fprintf(writer, "'%s'", escapedVal); err != nil {
-				return fmt.Errorf("failed to write value: %w", err)
+		owner, group, inode, nlink, xattrs := statExtra(info, path)
+		row = append(row, sqlString(owner), sqlString(group), sqlString(inode), sqlString(nlink), sqlString(xattrs))
+
+		if c.hashAlgo != "" {
+			digest := ""
+			if !d.IsDir() {
+				digest, _ = c.hashFile(path)
 			}
+			row = append(row, digest)
 		}
 
-		if _, err := writer.Write([]byte(");\n")); err != nil {
-			return fmt.Errorf("failed to write statement end: %w", err)
+		if c.inlineContent > 0 {
+			// The content column is binary, so the row switches to the
+			// value-based writer for X'..' encoding
+			values := make([]interface{}, len(row), len(row)+1)
+			for i, v := range row {
+				values[i] = v
+			}
+			var content interface{}
+			if !d.IsDir() {
+				content = c.readContent(path, info.Size())
+			}
+			return sqlWriter.WriteRow(append(values, content))
 		}
-
-		return nil
+		return sqlWriter.WriteStringRow(row)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return sqlWriter.EndTable()
+}
+
+// sqlString renders a value for SQL export, using "" for NULLs.
+func sqlString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
 }
 
 // runWithTimeout executes fn and returns its result, or an error if timeout is exceeded.
@@ -0,0 +1,260 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// FSConverter is the fs.FS-backed variant of FilesystemConverter. It lists
+// an abstract file tree (embed.FS, fstest.MapFS, a zip archive, ...) into
+// the portable subset of the tb0 columns that fs.FS can supply: symlink,
+// ownership and inode data are unavailable through the interface and are
+// dropped rather than emitted as always-NULL columns. The optional hash
+// and content columns still work because fs.FS can read file bytes.
+type FSConverter struct {
+	fsys          fs.FS
+	hashAlgo      string // "sha256" or "md5"; adds a content hash column
+	inlineContent int64  // Files up to this size get a BLOB content column; 0 disables
+	rowsPerInsert int    // Rows per multi-row INSERT in SQL output
+}
+
+// Ensure FSConverter implements RowProvider
+var _ common.RowProvider = (*FSConverter)(nil)
+
+// Ensure FSConverter implements StreamConverter
+var _ common.StreamConverter = (*FSConverter)(nil)
+
+// Ensure the filesystem driver exposes the FS entry point
+var _ common.FSDriver = (*filesystemDriver)(nil)
+
+// OpenFS implements common.FSDriver, wiring the subset of config options
+// that make sense without a real filesystem underneath.
+func (d *filesystemDriver) OpenFS(fsys fs.FS, config *common.ConversionConfig) (common.RowProvider, error) {
+	c := NewFSConverter(fsys)
+	if config != nil {
+		if config.HashAlgorithm != "" {
+			if err := c.SetHash(config.HashAlgorithm); err != nil {
+				return nil, err
+			}
+		}
+		if config.InlineContent > 0 {
+			c.SetInlineContent(config.InlineContent)
+		}
+		c.rowsPerInsert = config.RowsPerInsert
+	}
+	return c, nil
+}
+
+// NewFSConverter creates a converter that lists the given fs.FS tree.
+func NewFSConverter(fsys fs.FS) *FSConverter {
+	return &FSConverter{fsys: fsys}
+}
+
+// SetHash enables a content hash column ("sha256" or "md5") so duplicate
+// files can be found with a GROUP BY query.
+func (c *FSConverter) SetHash(algo string) error {
+	switch algo {
+	case "sha256", "md5":
+		c.hashAlgo = algo
+		return nil
+	}
+	return fmt.Errorf("unsupported hash algorithm: %s (want sha256 or md5)", algo)
+}
+
+// SetInlineContent adds a BLOB content column holding the bytes of files
+// up to maxBytes; larger files and directories get NULL.
+func (c *FSConverter) SetInlineContent(maxBytes int64) {
+	c.inlineContent = maxBytes
+}
+
+// GetTableNames implements RowProvider
+func (c *FSConverter) GetTableNames() []string {
+	return []string{FSTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *FSConverter) GetHeaders(tableName string) []string {
+	if tableName != FSTB {
+		return nil
+	}
+	headers := []string{
+		"path", "name", "size", "extension",
+		"mod_time", "permissions",
+		"is_dir", "mime_type",
+	}
+	if c.hashAlgo != "" {
+		headers = append(headers, c.hashAlgo)
+	}
+	if c.inlineContent > 0 {
+		headers = append(headers, "content")
+	}
+	return headers
+}
+
+// GetColumnTypes implements RowProvider
+func (c *FSConverter) GetColumnTypes(tableName string) []string {
+	if tableName != FSTB {
+		return nil
+	}
+	colTypes := []string{
+		"TEXT", "TEXT", "INTEGER", "TEXT",
+		"TEXT", "TEXT",
+		"INTEGER", "TEXT",
+	}
+	if c.hashAlgo != "" {
+		colTypes = append(colTypes, "TEXT")
+	}
+	if c.inlineContent > 0 {
+		colTypes = append(colTypes, "BLOB")
+	}
+	return colTypes
+}
+
+// hashFSFile computes the configured digest of a file's contents through fsys.
+func (c *FSConverter) hashFSFile(name string) (string, error) {
+	f, err := c.fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var h hash.Hash
+	switch c.hashAlgo {
+	case "sha256":
+		h = sha256.New()
+	case "md5":
+		h = md5.New()
+	default:
+		return "", fmt.Errorf("unsupported hash algorithm: %s", c.hashAlgo)
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readFSContent loads a file's bytes for the content column, or nil when
+// the file exceeds the inline limit or can't be read.
+func (c *FSConverter) readFSContent(name string, size int64) interface{} {
+	if size > c.inlineContent {
+		return nil
+	}
+	data, err := fs.ReadFile(c.fsys, name)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// detectFSMimeType sniffs a file's MIME type from its first bytes.
+func (c *FSConverter) detectFSMimeType(name string) string {
+	f, err := c.fsys.Open(name)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer f.Close()
+
+	buffer := make([]byte, 512)
+	n, err := f.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "application/octet-stream"
+	}
+	return http.DetectContentType(buffer[:n])
+}
+
+// row builds a listing row for one walked entry.
+func (c *FSConverter) row(name string, d fs.DirEntry) ([]interface{}, error) {
+	info, err := d.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	isDir := 0
+	mimeType := "inode/directory"
+	ext := ""
+	if !d.IsDir() {
+		mimeType = c.detectFSMimeType(name)
+		ext = path.Ext(name)
+	} else {
+		isDir = 1
+	}
+
+	row := []interface{}{
+		name, d.Name(), info.Size(), ext,
+		info.ModTime().Format(time.RFC3339), info.Mode().String(),
+		isDir, mimeType,
+	}
+
+	if c.hashAlgo != "" {
+		var digest interface{}
+		if !d.IsDir() {
+			if h, err := c.hashFSFile(name); err == nil {
+				digest = h
+			}
+		}
+		row = append(row, digest)
+	}
+	if c.inlineContent > 0 {
+		var content interface{}
+		if !d.IsDir() {
+			content = c.readFSContent(name, info.Size())
+		}
+		row = append(row, content)
+	}
+	return row, nil
+}
+
+// ScanRows implements RowProvider. Unlike the on-disk scanner there is no
+// worker pool: fs.FS trees are typically in-memory or archive-backed, so
+// a plain sequential walk is both simpler and fast enough.
+func (c *FSConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != FSTB {
+		return nil
+	}
+	return fs.WalkDir(c.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		row, err := c.row(name, d)
+		if err != nil {
+			return err
+		}
+		return yield(row, nil)
+	})
+}
+
+// ConvertToSQL implements StreamConverter for fs.FS trees.
+func (c *FSConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	headers := c.GetHeaders(FSTB)
+	colTypes := c.GetColumnTypes(FSTB)
+	sqlWriter := common.NewSQLWriter(writer, c.rowsPerInsert)
+	if err := sqlWriter.BeginTable(FSTB, headers, colTypes); err != nil {
+		return err
+	}
+	err := c.ScanRows(ctx, FSTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		return sqlWriter.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return sqlWriter.EndTable()
+}
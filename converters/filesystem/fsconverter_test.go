@@ -0,0 +1,117 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"testing/fstest"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	_ "github.com/darianmavgo/mksqlite/converters/csv"
+)
+
+func TestFSConverterScanRows(t *testing.T) {
+	fsys := fstest.MapFS{
+		"readme.txt":      {Data: []byte("hello")},
+		"docs/manual.txt": {Data: []byte("manual text")},
+	}
+
+	conv := NewFSConverter(fsys)
+	headers := conv.GetHeaders(FSTB)
+	colTypes := conv.GetColumnTypes(FSTB)
+	if len(headers) != len(colTypes) {
+		t.Fatalf("column types out of sync with headers: %v vs %v", colTypes, headers)
+	}
+
+	rows := make(map[string][]interface{})
+	err := conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows[r[0].(string)] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	// Root, docs dir and two files
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d: %v", len(rows), rows)
+	}
+	file, ok := rows["docs/manual.txt"]
+	if !ok {
+		t.Fatal("missing row for docs/manual.txt")
+	}
+	if size := file[2].(int64); size != int64(len("manual text")) {
+		t.Errorf("unexpected size: %d", size)
+	}
+	if ext := file[3].(string); ext != ".txt" {
+		t.Errorf("unexpected extension: %s", ext)
+	}
+	dir, ok := rows["docs"]
+	if !ok {
+		t.Fatal("missing row for docs directory")
+	}
+	if isDir := dir[6].(int); isDir != 1 {
+		t.Errorf("expected is_dir=1 for docs, got %v", dir[6])
+	}
+}
+
+func TestFSConverterHashAndContent(t *testing.T) {
+	payload := []byte("inline me")
+	fsys := fstest.MapFS{
+		"small.bin": {Data: payload},
+		"big.bin":   {Data: make([]byte, 1024)},
+	}
+
+	conv := NewFSConverter(fsys)
+	if err := conv.SetHash("sha256"); err != nil {
+		t.Fatalf("SetHash failed: %v", err)
+	}
+	conv.SetInlineContent(64)
+
+	headers := conv.GetHeaders(FSTB)
+	if headers[len(headers)-2] != "sha256" || headers[len(headers)-1] != "content" {
+		t.Fatalf("expected trailing sha256 and content columns, got %v", headers)
+	}
+
+	rows := make(map[string][]interface{})
+	err := conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		rows[r[0].(string)] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	sum := sha256.Sum256(payload)
+	small := rows["small.bin"]
+	if digest := small[len(small)-2].(string); digest != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected digest: %s", digest)
+	}
+	if data, ok := small[len(small)-1].([]byte); !ok || string(data) != string(payload) {
+		t.Errorf("expected inline bytes, got %v", small[len(small)-1])
+	}
+	big := rows["big.bin"]
+	if big[len(big)-1] != nil {
+		t.Errorf("expected NULL content for file over the limit, got %v", big[len(big)-1])
+	}
+}
+
+func TestOpenFS(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("a")}}
+
+	provider, err := converters.OpenFS("filesystem", fsys, nil)
+	if err != nil {
+		t.Fatalf("OpenFS failed: %v", err)
+	}
+	if _, ok := provider.(*FSConverter); !ok {
+		t.Fatalf("expected *FSConverter, got %T", provider)
+	}
+
+	if _, err := converters.OpenFS("csv", fsys, nil); err == nil {
+		t.Error("expected error for driver without FS support")
+	}
+}
@@ -0,0 +1,115 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashColumn(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("duplicate me")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "c.txt"), []byte("unique"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	if err := conv.SetHash("sha3"); err == nil {
+		t.Error("expected error for unsupported algorithm")
+	}
+	if err := conv.SetHash("sha256"); err != nil {
+		t.Fatalf("SetHash failed: %v", err)
+	}
+
+	headers := conv.GetHeaders(FSTB)
+	if headers[len(headers)-1] != "sha256" {
+		t.Fatalf("expected sha256 column, got %v", headers)
+	}
+	colTypes := conv.GetColumnTypes(FSTB)
+	if len(colTypes) != len(headers) {
+		t.Fatalf("column types out of sync with headers: %v vs %v", colTypes, headers)
+	}
+
+	digests := make(map[string]string)
+	err = conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		if digest, ok := r[len(r)-1].(string); ok {
+			digests[r[0].(string)] = digest
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	want := hex.EncodeToString(sum[:])
+	if digests["a.txt"] != want {
+		t.Errorf("unexpected digest for a.txt: %s", digests["a.txt"])
+	}
+	if digests["a.txt"] != digests["b.txt"] {
+		t.Errorf("duplicate files should share a digest: %s vs %s", digests["a.txt"], digests["b.txt"])
+	}
+	if digests["c.txt"] == digests["a.txt"] {
+		t.Error("distinct content should produce distinct digests")
+	}
+}
+
+func TestInlineContentColumn(t *testing.T) {
+	dir := t.TempDir()
+	small := []byte("tiny payload")
+	if err := os.WriteFile(filepath.Join(dir, "small.bin"), small, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "big.bin"), make([]byte, 1024), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	conv.SetInlineContent(64)
+
+	headers := conv.GetHeaders(FSTB)
+	if headers[len(headers)-1] != "content" {
+		t.Fatalf("expected content column, got %v", headers)
+	}
+	colTypes := conv.GetColumnTypes(FSTB)
+	if colTypes[len(colTypes)-1] != "BLOB" {
+		t.Fatalf("expected BLOB type for content, got %v", colTypes)
+	}
+
+	contents := make(map[string]interface{})
+	err = conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		contents[r[0].(string)] = r[len(r)-1]
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	data, ok := contents["small.bin"].([]byte)
+	if !ok || string(data) != string(small) {
+		t.Errorf("expected small file bytes inline, got %v", contents["small.bin"])
+	}
+	if contents["big.bin"] != nil {
+		t.Errorf("expected NULL content for file over the limit, got %v", contents["big.bin"])
+	}
+}
@@ -0,0 +1,137 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dhowden/tag"
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+const (
+	MediaTB = "media_metadata"
+)
+
+// mediaRow holds extracted EXIF/ID3 metadata for one media file.
+type mediaRow struct {
+	path      string
+	mediaType string // "image", "audio" or "video"
+	make      interface{}
+	model     interface{}
+	takenAt   interface{}
+	gpsLat    interface{}
+	gpsLon    interface{}
+	title     interface{}
+	artist    interface{}
+	album     interface{}
+}
+
+// mediaType classifies a file extension, returning "" for non-media files.
+func mediaType(ext string) string {
+	switch ext {
+	case ".jpg", ".jpeg", ".tif", ".tiff", ".png", ".heic", ".webp":
+		return "image"
+	case ".mp3", ".flac", ".m4a", ".ogg", ".wav":
+		return "audio"
+	case ".mp4", ".mov", ".mkv", ".avi", ".webm":
+		return "video"
+	}
+	return ""
+}
+
+// SetMediaMetadata enables the companion media_metadata table, populated
+// with EXIF/ID3 data for image, audio and video files.
+func (c *FilesystemConverter) SetMediaMetadata(enabled bool) {
+	c.mediaMetadata = enabled
+}
+
+// extractMediaMetadata reads what metadata it can from the file; missing
+// fields stay NULL, so partial reads still produce a useful row.
+func extractMediaMetadata(path, relPath, kind string) mediaRow {
+	row := mediaRow{path: relPath, mediaType: kind}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return row
+	}
+	defer f.Close()
+
+	switch kind {
+	case "image":
+		x, err := exif.Decode(f)
+		if err != nil {
+			return row
+		}
+		if val, err := x.Get(exif.Make); err == nil {
+			if s, err := val.StringVal(); err == nil {
+				row.make = strings.TrimSpace(s)
+			}
+		}
+		if val, err := x.Get(exif.Model); err == nil {
+			if s, err := val.StringVal(); err == nil {
+				row.model = strings.TrimSpace(s)
+			}
+		}
+		if t, err := x.DateTime(); err == nil {
+			row.takenAt = t.Format(time.RFC3339)
+		}
+		if lat, lon, err := x.LatLong(); err == nil {
+			row.gpsLat = lat
+			row.gpsLon = lon
+		}
+	case "audio", "video":
+		m, err := tag.ReadFrom(f)
+		if err != nil {
+			return row
+		}
+		if v := m.Title(); v != "" {
+			row.title = v
+		}
+		if v := m.Artist(); v != "" {
+			row.artist = v
+		}
+		if v := m.Album(); v != "" {
+			row.album = v
+		}
+		if v := m.Year(); v != 0 {
+			row.takenAt = time.Date(v, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		}
+	}
+	return row
+}
+
+// scanMediaRows walks the tree serially yielding one row per media file.
+func (c *FilesystemConverter) scanMediaRows(ctx context.Context, yield func([]interface{}, error) error) error {
+	return filepath.WalkDir(c.inputPath, func(path string, d os.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err != nil || d.IsDir() {
+			return nil // Skip unreadable entries, keep walking
+		}
+
+		kind := mediaType(strings.ToLower(filepath.Ext(path)))
+		if kind == "" {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(c.inputPath, path)
+		if err != nil {
+			relPath = path
+		}
+
+		row := extractMediaMetadata(path, relPath, kind)
+		return yield([]interface{}{
+			row.path, row.mediaType,
+			row.make, row.model, row.takenAt,
+			row.gpsLat, row.gpsLon,
+			row.title, row.artist, row.album,
+		}, nil)
+	})
+}
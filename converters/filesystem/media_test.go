@@ -0,0 +1,106 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildID3v2 builds a minimal ID3v2.3 tag with the given text frames.
+func buildID3v2(frames map[string]string) []byte {
+	var body []byte
+	for id, text := range frames {
+		frame := append([]byte{0}, []byte(text)...) // ISO-8859-1 encoding byte
+		header := make([]byte, 10)
+		copy(header, id)
+		binary.BigEndian.PutUint32(header[4:8], uint32(len(frame)))
+		body = append(body, header...)
+		body = append(body, frame...)
+	}
+
+	tag := make([]byte, 10)
+	copy(tag, "ID3")
+	tag[3] = 3 // v2.3
+	// Syncsafe size
+	size := len(body)
+	tag[6] = byte(size >> 21 & 0x7f)
+	tag[7] = byte(size >> 14 & 0x7f)
+	tag[8] = byte(size >> 7 & 0x7f)
+	tag[9] = byte(size & 0x7f)
+	return append(tag, body...)
+}
+
+func TestMediaMetadataTable(t *testing.T) {
+	dir := t.TempDir()
+
+	mp3 := buildID3v2(map[string]string{
+		"TIT2": "Test Song",
+		"TPE1": "Test Artist",
+		"TALB": "Test Album",
+	})
+	if err := os.WriteFile(filepath.Join(dir, "song.mp3"), mp3, 0644); err != nil {
+		t.Fatalf("failed to write mp3: %v", err)
+	}
+	// Non-media file must not appear in the media table
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("failed to write txt: %v", err)
+	}
+	// Unparseable image still gets a row with NULL metadata
+	if err := os.WriteFile(filepath.Join(dir, "broken.jpg"), []byte("not a jpeg"), 0644); err != nil {
+		t.Fatalf("failed to write jpg: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	// Table is hidden until enabled
+	if len(conv.GetTableNames()) != 1 {
+		t.Fatalf("media table should be off by default: %v", conv.GetTableNames())
+	}
+	conv.SetMediaMetadata(true)
+	tables := conv.GetTableNames()
+	if len(tables) != 2 || tables[1] != MediaTB {
+		t.Fatalf("expected media_metadata table, got %v", tables)
+	}
+
+	headers := conv.GetHeaders(MediaTB)
+	if len(headers) != 10 || headers[0] != "path" || headers[2] != "camera_make" {
+		t.Fatalf("unexpected media headers: %v", headers)
+	}
+
+	rows := make(map[string][]interface{})
+	err = conv.ScanRows(context.Background(), MediaTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows[r[0].(string)] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 media rows, got %d: %v", len(rows), rows)
+	}
+
+	song := rows["song.mp3"]
+	if song == nil {
+		t.Fatal("missing row for song.mp3")
+	}
+	if song[1] != "audio" || song[7] != "Test Song" || song[8] != "Test Artist" || song[9] != "Test Album" {
+		t.Errorf("unexpected audio metadata: %v", song)
+	}
+
+	broken := rows["broken.jpg"]
+	if broken == nil {
+		t.Fatal("missing row for broken.jpg")
+	}
+	if broken[1] != "image" || broken[2] != nil || broken[4] != nil {
+		t.Errorf("broken image should have NULL metadata: %v", broken)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+
+package filesystem
+
+import (
+	"io/fs"
+)
+
+// statExtra returns NULLs on platforms without unix stat data.
+func statExtra(info fs.FileInfo, path string) (owner, group, inode, nlink, xattrs interface{}) {
+	return nil, nil, nil, nil, nil
+}
+
+// fileID reports no identity on platforms without unix stat data, so
+// cycle protection never blocks a directory there.
+func fileID(info fs.FileInfo) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,88 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDirectoryRowsAndOwner(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatalf("failed to create subdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "file.txt"), []byte("hi"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	headers := conv.GetHeaders(FSTB)
+	idx := make(map[string]int)
+	for i, h := range headers {
+		idx[h] = i
+	}
+	for _, col := range []string{"owner", "group_name", "inode", "nlink", "xattrs"} {
+		if _, ok := idx[col]; !ok {
+			t.Fatalf("missing column %s in %v", col, headers)
+		}
+	}
+
+	rows := make(map[string][]interface{})
+	err = conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows[r[idx["path"]].(string)] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	// Root and subdirectory must appear with is_dir=1
+	root, ok := rows["."]
+	if !ok {
+		t.Fatalf("missing root directory row, got paths %v", keys(rows))
+	}
+	if root[idx["is_dir"]] != 1 || root[idx["mime_type"]] != "inode/directory" {
+		t.Errorf("unexpected root row: %v", root)
+	}
+	sub, ok := rows["sub"]
+	if !ok {
+		t.Fatal("missing subdirectory row")
+	}
+	if sub[idx["is_dir"]] != 1 {
+		t.Errorf("sub should be a directory row: %v", sub)
+	}
+
+	file, ok := rows[filepath.Join("sub", "file.txt")]
+	if !ok {
+		t.Fatal("missing file row")
+	}
+	if file[idx["is_dir"]] != 0 {
+		t.Errorf("file should not be a directory row: %v", file)
+	}
+
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+		if file[idx["owner"]] == nil || file[idx["inode"]] == nil || file[idx["nlink"]] == nil {
+			t.Errorf("expected owner/inode/nlink on unix: %v", file)
+		}
+		if file[idx["owner"]] != root[idx["owner"]] {
+			t.Errorf("file and root should share an owner: %v vs %v", file[idx["owner"]], root[idx["owner"]])
+		}
+	}
+}
+
+func keys(m map[string][]interface{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
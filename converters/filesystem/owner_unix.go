@@ -0,0 +1,65 @@
+//go:build linux || darwin
+
+package filesystem
+
+import (
+	"io/fs"
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// ownerCache memoizes uid/gid name lookups; large trees repeat a handful
+// of owners millions of times.
+var ownerCache sync.Map // "u:1000" / "g:1000" -> string
+
+func lookupOwner(uid uint32) string {
+	key := "u:" + strconv.FormatUint(uint64(uid), 10)
+	if cached, ok := ownerCache.Load(key); ok {
+		return cached.(string)
+	}
+	name := strconv.FormatUint(uint64(uid), 10)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	ownerCache.Store(key, name)
+	return name
+}
+
+func lookupGroup(gid uint32) string {
+	key := "g:" + strconv.FormatUint(uint64(gid), 10)
+	if cached, ok := ownerCache.Load(key); ok {
+		return cached.(string)
+	}
+	name := strconv.FormatUint(uint64(gid), 10)
+	if g, err := user.LookupGroupId(name); err == nil {
+		name = g.Name
+	}
+	ownerCache.Store(key, name)
+	return name
+}
+
+// fileID returns a device:inode key identifying the file uniquely on disk.
+func fileID(info fs.FileInfo) (string, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatUint(uint64(stat.Dev), 10) + ":" + strconv.FormatUint(uint64(stat.Ino), 10), true
+}
+
+// statExtra returns owner, group, inode and link count for the entry, with
+// NULLs when the platform-specific stat data is unavailable.
+func statExtra(info fs.FileInfo, path string) (owner, group, inode, nlink, xattrs interface{}) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil, nil, nil, nil, nil
+	}
+	owner = lookupOwner(stat.Uid)
+	group = lookupGroup(stat.Gid)
+	inode = int64(stat.Ino)
+	nlink = int64(stat.Nlink)
+	xattrs = listXattrs(path)
+	return
+}
@@ -0,0 +1,110 @@
+//go:build linux || darwin
+
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func scanPaths(t *testing.T, conv *FilesystemConverter) map[string][]interface{} {
+	t.Helper()
+	headers := conv.GetHeaders(FSTB)
+	idx := make(map[string]int)
+	for i, h := range headers {
+		idx[h] = i
+	}
+	rows := make(map[string][]interface{})
+	err := conv.ScanRows(context.Background(), FSTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows[r[idx["path"]].(string)] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func colIdx(conv *FilesystemConverter, name string) int {
+	for i, h := range conv.GetHeaders(FSTB) {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestSymlinkColumns(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "target.txt"), []byte("hi"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.Symlink("target.txt", filepath.Join(dir, "link.txt")); err != nil {
+		t.Skipf("cannot create symlinks: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	rows := scanPaths(t, conv)
+	isSymlink := colIdx(conv, "is_symlink")
+	linkTarget := colIdx(conv, "link_target")
+
+	link, ok := rows["link.txt"]
+	if !ok {
+		t.Fatal("missing symlink row")
+	}
+	if link[isSymlink] != 1 || link[linkTarget] != "target.txt" {
+		t.Errorf("unexpected symlink row: is_symlink=%v target=%v", link[isSymlink], link[linkTarget])
+	}
+
+	target := rows["target.txt"]
+	if target[isSymlink] != 0 || target[linkTarget] != nil {
+		t.Errorf("regular file should not be marked as symlink: %v", target)
+	}
+}
+
+func TestFollowSymlinksWithCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatalf("failed to create subdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "inner.txt"), []byte("hi"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	// Link pointing back at the root creates a cycle
+	if err := os.Symlink(dir, filepath.Join(dir, "sub", "loop")); err != nil {
+		t.Skipf("cannot create symlinks: %v", err)
+	}
+
+	conv, err := NewFilesystemConverter(dir)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	conv.SetFollowSymlinks(true)
+
+	// Must terminate despite the cycle
+	rows := scanPaths(t, conv)
+
+	if _, ok := rows[filepath.Join("sub", "inner.txt")]; !ok {
+		t.Errorf("missing inner file, got %v", keys(rows))
+	}
+	loop, ok := rows[filepath.Join("sub", "loop")]
+	if !ok {
+		t.Fatal("missing loop symlink row")
+	}
+	if loop[colIdx(conv, "is_symlink")] != 1 {
+		t.Errorf("loop should be marked as symlink: %v", loop)
+	}
+	// The cycle must not duplicate the tree under loop/
+	if _, ok := rows[filepath.Join("sub", "loop", "sub", "inner.txt")]; ok {
+		t.Error("cycle was followed past the visited root")
+	}
+}
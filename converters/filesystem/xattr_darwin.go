@@ -0,0 +1,9 @@
+//go:build darwin
+
+package filesystem
+
+// listXattrs is a no-op on darwin; the stdlib syscall package does not
+// expose listxattr there.
+func listXattrs(path string) interface{} {
+	return nil
+}
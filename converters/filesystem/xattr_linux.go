@@ -0,0 +1,20 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"strings"
+	"syscall"
+)
+
+// listXattrs returns the extended attribute names of path joined with ";",
+// or nil when there are none or the call fails.
+func listXattrs(path string) interface{} {
+	buf := make([]byte, 4096)
+	n, err := syscall.Listxattr(path, buf)
+	if err != nil || n == 0 {
+		return nil
+	}
+	names := strings.Split(strings.TrimRight(string(buf[:n]), "\x00"), "\x00")
+	return strings.Join(names, ";")
+}
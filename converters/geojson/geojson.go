@@ -0,0 +1,326 @@
+package geojson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	FeaturesTB = "features"
+)
+
+func init() {
+	converters.Register("geojson", &geojsonDriver{}, converters.Capabilities{Extensions: []string{".geojson"}, MIMETypes: []string{"application/geo+json"}})
+}
+
+type geojsonDriver struct{}
+
+func (d *geojsonDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewGeoJSONConverterWithConfig(source, config)
+}
+
+// geometry mirrors a GeoJSON geometry object. Coordinates are kept as
+// generic nested slices so one walk handles every geometry type.
+type geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+	Geometries  []geometry  `json:"geometries"`
+}
+
+type feature struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   *geometry              `json:"geometry"`
+}
+
+type featureCollection struct {
+	Type        string                 `json:"type"`
+	Features    []feature              `json:"features"`
+	Geometry    *geometry              `json:"geometry"`
+	Properties  map[string]interface{} `json:"properties"`
+	Coordinates interface{}            `json:"coordinates"`
+	Geometries  []geometry             `json:"geometries"`
+}
+
+// GeoJSONConverter flattens a GeoJSON FeatureCollection into a "features"
+// table: one row per feature, properties as columns, the geometry as WKT
+// TEXT, plus bounding box columns for spatial filtering.
+type GeoJSONConverter struct {
+	features []feature
+	propKeys []string // Sorted union of property keys across all features
+	headers  []string
+}
+
+// Ensure GeoJSONConverter implements RowProvider
+var _ common.RowProvider = (*GeoJSONConverter)(nil)
+
+// NewGeoJSONConverter creates a new GeoJSONConverter from an io.Reader.
+func NewGeoJSONConverter(r io.Reader) (*GeoJSONConverter, error) {
+	return NewGeoJSONConverterWithConfig(r, nil)
+}
+
+// NewGeoJSONConverterWithConfig creates a new GeoJSONConverter from an io.Reader with optional config.
+func NewGeoJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*GeoJSONConverter, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geojson input: %w", err)
+	}
+
+	var root featureCollection
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse geojson: %w", err)
+	}
+
+	c := &GeoJSONConverter{}
+	switch root.Type {
+	case "FeatureCollection":
+		c.features = root.Features
+	case "Feature":
+		c.features = []feature{{Type: root.Type, Properties: root.Properties, Geometry: root.Geometry}}
+	case "":
+		return nil, fmt.Errorf("not a geojson document: missing type")
+	default:
+		// Bare geometry
+		c.features = []feature{{Geometry: &geometry{
+			Type:        root.Type,
+			Coordinates: root.Coordinates,
+			Geometries:  root.Geometries,
+		}}}
+	}
+
+	// Union of property keys across all features, sorted for stable columns
+	keySet := make(map[string]bool)
+	for _, f := range c.features {
+		for k := range f.Properties {
+			keySet[k] = true
+		}
+	}
+	c.propKeys = make([]string, 0, len(keySet))
+	for k := range keySet {
+		c.propKeys = append(c.propKeys, k)
+	}
+	sort.Strings(c.propKeys)
+
+	rawHeaders := make([]string, 0, len(c.propKeys)+5)
+	rawHeaders = append(rawHeaders, c.propKeys...)
+	rawHeaders = append(rawHeaders, "geometry")
+	rawHeaders = append(rawHeaders, common.BBoxColumns...)
+	c.headers = common.GenColumnNames(rawHeaders)
+
+	return c, nil
+}
+
+// formatCoord renders a float without trailing zeros, matching WKT convention.
+func formatCoord(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// position extracts a [lon, lat, ...] coordinate from decoded JSON.
+func position(v interface{}) (lon, lat float64, ok bool) {
+	arr, isArr := v.([]interface{})
+	if !isArr || len(arr) < 2 {
+		return 0, 0, false
+	}
+	lon, lonOK := arr[0].(float64)
+	lat, latOK := arr[1].(float64)
+	return lon, lat, lonOK && latOK
+}
+
+// coordsToWKT renders nested coordinate arrays at the given depth:
+// depth 0 is a single position, 1 a ring/line, 2 a polygon, 3 a multipolygon.
+func coordsToWKT(coords interface{}, depth int, bbox *common.BBox) (string, error) {
+	if depth == 0 {
+		lon, lat, ok := position(coords)
+		if !ok {
+			return "", fmt.Errorf("invalid coordinate: %v", coords)
+		}
+		bbox.Extend(lon, lat)
+		return formatCoord(lon) + " " + formatCoord(lat), nil
+	}
+
+	arr, ok := coords.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid coordinate array: %v", coords)
+	}
+	parts := make([]string, len(arr))
+	for i, elem := range arr {
+		part, err := coordsToWKT(elem, depth-1, bbox)
+		if err != nil {
+			return "", err
+		}
+		if depth > 1 {
+			part = "(" + part + ")"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// geometryToWKT converts a GeoJSON geometry to WKT, extending bbox with
+// every coordinate visited.
+func geometryToWKT(g *geometry, bbox *common.BBox) (string, error) {
+	if g == nil {
+		return "", nil
+	}
+	depths := map[string]int{
+		"Point":           0,
+		"MultiPoint":      1,
+		"LineString":      1,
+		"MultiLineString": 2,
+		"Polygon":         2,
+		"MultiPolygon":    3,
+	}
+	if depth, ok := depths[g.Type]; ok {
+		body, err := coordsToWKT(g.Coordinates, depth, bbox)
+		if err != nil {
+			return "", err
+		}
+		return strings.ToUpper(g.Type) + " (" + body + ")", nil
+	}
+	if g.Type == "GeometryCollection" {
+		parts := make([]string, len(g.Geometries))
+		for i := range g.Geometries {
+			part, err := geometryToWKT(&g.Geometries[i], bbox)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return "GEOMETRYCOLLECTION (" + strings.Join(parts, ", ") + ")", nil
+	}
+	return "", fmt.Errorf("unsupported geometry type: %s", g.Type)
+}
+
+// GetTableNames implements RowProvider
+func (c *GeoJSONConverter) GetTableNames() []string {
+	return []string{FeaturesTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *GeoJSONConverter) GetHeaders(tableName string) []string {
+	if tableName != FeaturesTB {
+		return nil
+	}
+	return c.headers
+}
+
+// GetColumnTypes implements RowProvider
+func (c *GeoJSONConverter) GetColumnTypes(tableName string) []string {
+	if tableName != FeaturesTB {
+		return nil
+	}
+	colTypes := make([]string, len(c.headers))
+	for i, key := range c.propKeys {
+		colTypes[i] = inferPropertyType(key, c.features)
+	}
+	colTypes[len(c.propKeys)] = "TEXT" // geometry
+	for i := len(c.propKeys) + 1; i < len(colTypes); i++ {
+		colTypes[i] = "REAL" // bbox
+	}
+	return colTypes
+}
+
+// inferPropertyType scans up to 15 features for a consistent numeric type.
+func inferPropertyType(key string, features []feature) string {
+	isInt, isReal, hasData := true, true, false
+	for i, f := range features {
+		if i >= 15 {
+			break
+		}
+		val, ok := f.Properties[key]
+		if !ok || val == nil {
+			continue
+		}
+		hasData = true
+		switch v := val.(type) {
+		case float64:
+			if v != float64(int64(v)) {
+				isInt = false
+			}
+		case bool:
+			// Stored as 0/1
+		default:
+			return "TEXT"
+		}
+	}
+	if !hasData {
+		return "TEXT"
+	}
+	if isInt {
+		return "INTEGER"
+	}
+	if isReal {
+		return "REAL"
+	}
+	return "TEXT"
+}
+
+// ScanRows implements RowProvider
+func (c *GeoJSONConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != FeaturesTB {
+		return nil
+	}
+
+	for _, f := range c.features {
+		row := make([]interface{}, 0, len(c.headers))
+		for _, key := range c.propKeys {
+			row = append(row, propertyValue(f.Properties[key]))
+		}
+
+		var bbox common.BBox
+		wkt, err := geometryToWKT(f.Geometry, &bbox)
+		if err != nil {
+			if yieldErr := yield(nil, err); yieldErr != nil {
+				return yieldErr
+			}
+			continue
+		}
+		if wkt == "" {
+			row = append(row, nil)
+		} else {
+			row = append(row, wkt)
+		}
+		row = append(row, bbox.Row()...)
+
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+// propertyValue converts a decoded JSON property into a SQLite value;
+// nested objects and arrays are stored as JSON text.
+func propertyValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	default:
+		return v
+	}
+}
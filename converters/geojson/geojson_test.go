@@ -0,0 +1,128 @@
+package geojson
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "properties": {"name": "Office", "floors": 3},
+      "geometry": {"type": "Point", "coordinates": [-122.4, 37.78]}
+    },
+    {
+      "type": "Feature",
+      "properties": {"name": "Route", "length_km": 1.5},
+      "geometry": {"type": "LineString", "coordinates": [[-122.5, 37.7], [-122.3, 37.8]]}
+    },
+    {
+      "type": "Feature",
+      "properties": {"name": "Park"},
+      "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [0, 1], [1, 1], [0, 0]]]}
+    }
+  ]
+}`
+
+func TestGeoJSONConverter(t *testing.T) {
+	conv, err := NewGeoJSONConverter(strings.NewReader(sampleGeoJSON))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 1 || tables[0] != FeaturesTB {
+		t.Fatalf("expected features table, got %v", tables)
+	}
+
+	headers := conv.GetHeaders(FeaturesTB)
+	// floors, length_km, name + geometry + 4 bbox columns
+	if len(headers) != 8 {
+		t.Fatalf("expected 8 columns, got %v", headers)
+	}
+	if headers[3] != "geometry" || headers[4] != "min_lon" {
+		t.Errorf("unexpected header layout: %v", headers)
+	}
+
+	colTypes := conv.GetColumnTypes(FeaturesTB)
+	if colTypes[0] != "INTEGER" { // floors
+		t.Errorf("floors should be INTEGER, got %s", colTypes[0])
+	}
+	if colTypes[1] != "REAL" { // length_km
+		t.Errorf("length_km should be REAL, got %s", colTypes[1])
+	}
+	if colTypes[4] != "REAL" { // min_lon
+		t.Errorf("bbox columns should be REAL, got %s", colTypes[4])
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), FeaturesTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 features, got %d", len(rows))
+	}
+
+	// Point feature: WKT geometry and degenerate bbox
+	if rows[0][3] != "POINT (-122.4 37.78)" {
+		t.Errorf("unexpected point WKT: %v", rows[0][3])
+	}
+	if rows[0][4] != -122.4 || rows[0][6] != -122.4 {
+		t.Errorf("point bbox should collapse to the point: %v", rows[0][4:])
+	}
+
+	// LineString feature: bbox spans both endpoints
+	if rows[1][3] != "LINESTRING (-122.5 37.7, -122.3 37.8)" {
+		t.Errorf("unexpected linestring WKT: %v", rows[1][3])
+	}
+	if rows[1][4] != -122.5 || rows[1][6] != -122.3 || rows[1][5] != 37.7 || rows[1][7] != 37.8 {
+		t.Errorf("unexpected linestring bbox: %v", rows[1][4:])
+	}
+
+	// Polygon feature: missing properties are NULL
+	if rows[2][0] != nil || rows[2][1] != nil {
+		t.Errorf("missing properties should be NULL: %v", rows[2][:2])
+	}
+	if rows[2][3] != "POLYGON ((0 0, 0 1, 1 1, 0 0))" {
+		t.Errorf("unexpected polygon WKT: %v", rows[2][3])
+	}
+}
+
+func TestGeoJSONSingleFeature(t *testing.T) {
+	input := `{"type": "Feature", "properties": {"id": 7}, "geometry": {"type": "Point", "coordinates": [1, 2]}}`
+	conv, err := NewGeoJSONConverter(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), FeaturesTB, func(r []interface{}, rowErr error) error {
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 feature, got %d", len(rows))
+	}
+	if rows[0][1] != "POINT (1 2)" {
+		t.Errorf("unexpected WKT: %v", rows[0][1])
+	}
+}
+
+func TestGeoJSONRejectsNonGeoJSON(t *testing.T) {
+	if _, err := NewGeoJSONConverter(strings.NewReader(`{"rows": [1, 2]}`)); err == nil {
+		t.Error("expected error for non-geojson input")
+	}
+}
@@ -0,0 +1,261 @@
+package gpx
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	TracksTB   = "tracks"
+	SegmentsTB = "segments"
+	PointsTB   = "points"
+)
+
+func init() {
+	converters.Register("gpx", &gpxDriver{}, converters.Capabilities{Extensions: []string{".gpx"}, MIMETypes: []string{"application/gpx+xml"}})
+}
+
+type gpxDriver struct{}
+
+func (d *gpxDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewGPXConverterWithConfig(source, config)
+}
+
+// trackPoint holds one <trkpt> with its common extensions.
+type trackPoint struct {
+	segmentID int
+	lat, lon  float64
+	ele       interface{} // nil when absent
+	time      string
+	hr        interface{} // heart rate from TrackPointExtension, nil when absent
+	cadence   interface{}
+}
+
+type segment struct {
+	segmentID  int
+	trackID    int
+	pointCount int
+}
+
+type track struct {
+	trackID int
+	name    string
+	typ     string
+}
+
+// GPXConverter turns GPX files into "tracks", "segments" and "points"
+// tables so exported workouts become queryable time series. Points carry
+// elevation, timestamp and the heart rate / cadence extensions.
+type GPXConverter struct {
+	tracks   []track
+	segments []segment
+	points   []trackPoint
+}
+
+// Ensure GPXConverter implements RowProvider
+var _ common.RowProvider = (*GPXConverter)(nil)
+
+// NewGPXConverter creates a new GPXConverter from an io.Reader.
+func NewGPXConverter(r io.Reader) (*GPXConverter, error) {
+	return NewGPXConverterWithConfig(r, nil)
+}
+
+// NewGPXConverterWithConfig creates a new GPXConverter from an io.Reader with optional config.
+func NewGPXConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*GPXConverter, error) {
+	c := &GPXConverter{}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parse walks the GPX token stream collecting tracks, segments and points.
+func (c *GPXConverter) parse(r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+
+	var sawGPX bool
+	var currentTrack *track
+	var currentSegment *segment
+	var currentPoint *trackPoint
+	var inExtensions bool
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("failed to parse gpx: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "gpx":
+				sawGPX = true
+			case "trk":
+				c.tracks = append(c.tracks, track{trackID: len(c.tracks) + 1})
+				currentTrack = &c.tracks[len(c.tracks)-1]
+			case "name":
+				if currentTrack != nil && currentSegment == nil {
+					currentTrack.name = decodeText(decoder, &t)
+				}
+			case "type":
+				if currentTrack != nil && currentSegment == nil {
+					currentTrack.typ = decodeText(decoder, &t)
+				}
+			case "trkseg":
+				if currentTrack == nil {
+					continue
+				}
+				c.segments = append(c.segments, segment{
+					segmentID: len(c.segments) + 1,
+					trackID:   currentTrack.trackID,
+				})
+				currentSegment = &c.segments[len(c.segments)-1]
+			case "trkpt":
+				if currentSegment == nil {
+					continue
+				}
+				pt := trackPoint{segmentID: currentSegment.segmentID}
+				for _, attr := range t.Attr {
+					val, err := strconv.ParseFloat(attr.Value, 64)
+					if err != nil {
+						return fmt.Errorf("invalid trkpt %s %q: %w", attr.Name.Local, attr.Value, err)
+					}
+					switch attr.Name.Local {
+					case "lat":
+						pt.lat = val
+					case "lon":
+						pt.lon = val
+					}
+				}
+				c.points = append(c.points, pt)
+				currentPoint = &c.points[len(c.points)-1]
+				currentSegment.pointCount++
+			case "ele":
+				if currentPoint != nil {
+					if val, err := strconv.ParseFloat(decodeText(decoder, &t), 64); err == nil {
+						currentPoint.ele = val
+					}
+				}
+			case "time":
+				if currentPoint != nil {
+					currentPoint.time = decodeText(decoder, &t)
+				}
+			case "extensions":
+				inExtensions = currentPoint != nil
+			case "hr", "heartrate":
+				if inExtensions && currentPoint != nil {
+					if val, err := strconv.Atoi(strings.TrimSpace(decodeText(decoder, &t))); err == nil {
+						currentPoint.hr = val
+					}
+				}
+			case "cad", "cadence":
+				if inExtensions && currentPoint != nil {
+					if val, err := strconv.Atoi(strings.TrimSpace(decodeText(decoder, &t))); err == nil {
+						currentPoint.cadence = val
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "trk":
+				currentTrack = nil
+			case "trkseg":
+				currentSegment = nil
+			case "trkpt":
+				currentPoint = nil
+			case "extensions":
+				inExtensions = false
+			}
+		}
+	}
+
+	if !sawGPX {
+		return fmt.Errorf("not a gpx document")
+	}
+	return nil
+}
+
+// decodeText reads the character content of the current element.
+func decodeText(decoder *xml.Decoder, start *xml.StartElement) string {
+	var text string
+	decoder.DecodeElement(&text, start)
+	return strings.TrimSpace(text)
+}
+
+// GetTableNames implements RowProvider
+func (c *GPXConverter) GetTableNames() []string {
+	return []string{TracksTB, SegmentsTB, PointsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *GPXConverter) GetHeaders(tableName string) []string {
+	switch tableName {
+	case TracksTB:
+		return []string{"track_id", "name", "type"}
+	case SegmentsTB:
+		return []string{"segment_id", "track_id", "point_count"}
+	case PointsTB:
+		return []string{"segment_id", "lat", "lon", "ele", "time", "hr", "cadence"}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *GPXConverter) GetColumnTypes(tableName string) []string {
+	switch tableName {
+	case TracksTB:
+		return []string{"INTEGER", "TEXT", "TEXT"}
+	case SegmentsTB:
+		return []string{"INTEGER", "INTEGER", "INTEGER"}
+	case PointsTB:
+		return []string{"INTEGER", "REAL", "REAL", "REAL", "TEXT", "INTEGER", "INTEGER"}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *GPXConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	var rows [][]interface{}
+	switch tableName {
+	case TracksTB:
+		for _, t := range c.tracks {
+			rows = append(rows, []interface{}{t.trackID, t.name, t.typ})
+		}
+	case SegmentsTB:
+		for _, s := range c.segments {
+			rows = append(rows, []interface{}{s.segmentID, s.trackID, s.pointCount})
+		}
+	case PointsTB:
+		for _, p := range c.points {
+			var ts interface{}
+			if p.time != "" {
+				ts = p.time
+			}
+			rows = append(rows, []interface{}{p.segmentID, p.lat, p.lon, p.ele, ts, p.hr, p.cadence})
+		}
+	default:
+		return nil
+	}
+
+	for _, row := range rows {
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
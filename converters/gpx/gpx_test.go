@@ -0,0 +1,114 @@
+package gpx
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1"
+     xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <trk>
+    <name>Morning Run</name>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="37.78" lon="-122.4">
+        <ele>12.5</ele>
+        <time>2024-05-01T07:00:00Z</time>
+        <extensions>
+          <gpxtpx:TrackPointExtension>
+            <gpxtpx:hr>140</gpxtpx:hr>
+            <gpxtpx:cad>85</gpxtpx:cad>
+          </gpxtpx:TrackPointExtension>
+        </extensions>
+      </trkpt>
+      <trkpt lat="37.781" lon="-122.401">
+        <ele>13</ele>
+        <time>2024-05-01T07:00:05Z</time>
+      </trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="37.79" lon="-122.41"/>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func scan(t *testing.T, conv *GPXConverter, table string) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := conv.ScanRows(context.Background(), table, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows %s failed: %v", table, err)
+	}
+	return rows
+}
+
+func TestGPXConverter(t *testing.T) {
+	conv, err := NewGPXConverter(strings.NewReader(sampleGPX))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 3 {
+		t.Fatalf("expected tracks/segments/points, got %v", tables)
+	}
+
+	tracks := scan(t, conv, TracksTB)
+	if len(tracks) != 1 {
+		t.Fatalf("expected 1 track, got %d", len(tracks))
+	}
+	if tracks[0][1] != "Morning Run" || tracks[0][2] != "running" {
+		t.Errorf("unexpected track row: %v", tracks[0])
+	}
+
+	segments := scan(t, conv, SegmentsTB)
+	if len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(segments))
+	}
+	if segments[0][1] != 1 || segments[0][2] != 2 {
+		t.Errorf("unexpected first segment: %v", segments[0])
+	}
+	if segments[1][2] != 1 {
+		t.Errorf("second segment should have 1 point: %v", segments[1])
+	}
+
+	points := scan(t, conv, PointsTB)
+	if len(points) != 3 {
+		t.Fatalf("expected 3 points, got %d", len(points))
+	}
+
+	// First point has elevation, time and extensions
+	if points[0][1] != 37.78 || points[0][2] != -122.4 {
+		t.Errorf("unexpected coordinates: %v", points[0])
+	}
+	if points[0][3] != 12.5 || points[0][4] != "2024-05-01T07:00:00Z" {
+		t.Errorf("unexpected ele/time: %v", points[0])
+	}
+	if points[0][5] != 140 || points[0][6] != 85 {
+		t.Errorf("unexpected hr/cadence: %v", points[0])
+	}
+
+	// Second point has no extensions
+	if points[1][5] != nil || points[1][6] != nil {
+		t.Errorf("missing extensions should be NULL: %v", points[1])
+	}
+
+	// Third point belongs to the second segment and has only coordinates
+	if points[2][0] != 2 || points[2][3] != nil || points[2][4] != nil {
+		t.Errorf("unexpected bare point: %v", points[2])
+	}
+}
+
+func TestGPXRejectsNonGPX(t *testing.T) {
+	if _, err := NewGPXConverter(strings.NewReader("<kml></kml>")); err == nil {
+		t.Error("expected error for non-gpx input")
+	}
+}
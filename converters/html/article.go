@@ -0,0 +1,208 @@
+package html
+
+import (
+	"math"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+
+	"golang.org/x/net/html"
+)
+
+// Article extraction pulls the main readable content of a page into an
+// "articles" table with one row per page: title, byline, published, text.
+// The body is located with a simplified readability heuristic: paragraphs
+// score by length and punctuation, scores accumulate on their containers,
+// and the densest container wins after a link-density penalty. Boilerplate
+// regions (navigation, sidebars, footers) score poorly because their text
+// is short and link-heavy.
+
+var articleHeaders = []string{"title", "byline", "published", "text"}
+
+// extractArticle builds the articles table row for one document. It reports
+// false when the page has neither a title nor readable body text.
+func extractArticle(doc *html.Node) (tableData, bool) {
+	title := metaContent(doc, "property", "og:title")
+	if title == "" {
+		title = firstElementText(doc, "title")
+	}
+	if title == "" {
+		title = firstElementText(doc, "h1")
+	}
+
+	byline := metaContent(doc, "name", "author")
+	if byline == "" {
+		byline = bylineText(doc)
+	}
+
+	published := metaContent(doc, "property", "article:published_time")
+	if published == "" {
+		published = timeText(doc)
+	}
+
+	text := mainText(doc)
+	if title == "" && text == "" {
+		return tableData{}, false
+	}
+
+	rows := common.NewSpillBuffer()
+	rows.Append([]string{title, byline, published, text})
+	return tableData{
+		rawName: "articles",
+		headers: articleHeaders,
+		rows:    rows,
+	}, true
+}
+
+// metaContent returns the content of the first <meta> whose attrKey
+// attribute equals want (case-insensitively), e.g. property="og:title".
+func metaContent(doc *html.Node, attrKey, want string) string {
+	var found string
+	walkElements(doc, func(n *html.Node) bool {
+		if n.Data == "meta" && strings.EqualFold(attrVal(n, attrKey), want) {
+			found = strings.TrimSpace(attrVal(n, "content"))
+			return found == ""
+		}
+		return true
+	})
+	return found
+}
+
+// firstElementText returns the text of the first element with the given tag.
+func firstElementText(doc *html.Node, tag string) string {
+	var found string
+	walkElements(doc, func(n *html.Node) bool {
+		if n.Data == tag {
+			found = extractText(n)
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// bylineText looks for the markup conventions that carry an author name:
+// a rel="author" link or an element classed "byline" or "author".
+func bylineText(doc *html.Node) string {
+	var found string
+	walkElements(doc, func(n *html.Node) bool {
+		if relContains(n, "author") || classContains(n, "byline") || classContains(n, "author") {
+			found = extractText(n)
+			return found == ""
+		}
+		return true
+	})
+	return found
+}
+
+// timeText returns the first <time> element's datetime attribute, falling
+// back to its visible text.
+func timeText(doc *html.Node) string {
+	var found string
+	walkElements(doc, func(n *html.Node) bool {
+		if n.Data == "time" {
+			found = strings.TrimSpace(attrVal(n, "datetime"))
+			if found == "" {
+				found = extractText(n)
+			}
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// walkElements visits element nodes depth-first until visit returns false.
+// Script and style subtrees are skipped.
+func walkElements(n *html.Node, visit func(*html.Node) bool) bool {
+	if n.Type == html.ElementNode {
+		if n.Data == "script" || n.Data == "style" {
+			return true
+		}
+		if !visit(n) {
+			return false
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if !walkElements(c, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// boilerplateTags are containers whose paragraphs never count toward the
+// article body. Tables are excluded too since they become their own tables.
+var boilerplateTags = map[string]bool{
+	"nav": true, "aside": true, "footer": true, "header": true,
+	"form": true, "table": true, "script": true, "style": true,
+}
+
+// mainText scores every paragraph container and returns the paragraphs of
+// the best one joined by blank lines, or "" when nothing reads like prose.
+func mainText(doc *html.Node) string {
+	scores := make(map[*html.Node]float64)
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if boilerplateTags[n.Data] {
+				return
+			}
+			if n.Data == "p" {
+				t := extractText(n)
+				// Fragments shorter than a sentence are navigation noise
+				if len(t) >= 25 && n.Parent != nil {
+					score := 1 + float64(strings.Count(t, ",")) + math.Min(float64(len(t))/100, 3)
+					scores[n.Parent] += score
+					if gp := n.Parent.Parent; gp != nil {
+						scores[gp] += score / 2
+					}
+				}
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(doc)
+
+	var best *html.Node
+	bestScore := 0.0
+	for n, score := range scores {
+		score *= 1 - linkDensity(n)
+		if score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	if best == nil {
+		return ""
+	}
+
+	var paragraphs []string
+	walkElements(best, func(n *html.Node) bool {
+		if n.Data == "p" {
+			if t := extractText(n); t != "" {
+				paragraphs = append(paragraphs, t)
+			}
+		}
+		return true
+	})
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// linkDensity is the fraction of a node's text that sits inside links.
+func linkDensity(n *html.Node) float64 {
+	total := len(extractText(n))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walkElements(n, func(e *html.Node) bool {
+		if e.Data == "a" {
+			linked += len(extractText(e))
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
@@ -5,7 +5,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/common"
@@ -14,25 +18,26 @@ import (
 )
 
 func init() {
-	converters.Register("html", &htmlDriver{})
+	converters.Register("html", &htmlDriver{}, converters.Capabilities{Extensions: []string{".html", ".htm"}, MIMETypes: []string{"text/html"}, Streaming: true})
 }
 
 type htmlDriver struct{}
 
 func (d *htmlDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
-	return NewHTMLConverter(source)
+	return NewHTMLConverterWithConfig(source, config)
 }
 
 // HTMLConverter converts HTML files to SQLite tables
 type HTMLConverter struct {
-	tables     []tableData
-	tableNames []string
+	tables        []tableData
+	tableNames    []string
+	rowsPerInsert int
 }
 
 type tableData struct {
 	rawName string
 	headers []string
-	rows    [][]string
+	rows    *common.SpillBuffer
 }
 
 // Ensure HTMLConverter implements RowProvider
@@ -43,10 +48,28 @@ var _ common.StreamConverter = (*HTMLConverter)(nil)
 
 // NewHTMLConverter creates a new HTMLConverter from an io.Reader
 func NewHTMLConverter(r io.Reader) (*HTMLConverter, error) {
-	tables, err := parseHTML(bufio.NewReaderSize(r, 65536))
+	return NewHTMLConverterWithConfig(r, nil)
+}
+
+// NewHTMLConverterWithConfig creates a new HTMLConverter from an io.Reader with optional config.
+func NewHTMLConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*HTMLConverter, error) {
+	extractKV := config != nil && config.ExtractKeyValue
+	extractArt := config != nil && config.ExtractArticle
+	tables, next, err := parseHTML(bufio.NewReaderSize(r, 65536), extractKV, extractArt)
 	if err != nil {
 		return nil, err
 	}
+	if config != nil && config.CrawlPages > 1 && next != "" {
+		tables, err = crawlNextPages(tables, next, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range tables {
+		if err := t.rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to buffer rows: %w", err)
+		}
+	}
 
 	// Generate table names once
 	rawNames := make([]string, len(tables))
@@ -59,10 +82,14 @@ func NewHTMLConverter(r io.Reader) (*HTMLConverter, error) {
 	}
 	tableNames := common.GenTableNames(rawNames)
 
-	return &HTMLConverter{
+	c := &HTMLConverter{
 		tables:     tables,
 		tableNames: tableNames,
-	}, nil
+	}
+	if config != nil {
+		c.rowsPerInsert = config.RowsPerInsert
+	}
+	return c, nil
 }
 
 // GetTableNames implements RowProvider
@@ -85,7 +112,7 @@ func (c *HTMLConverter) GetColumnTypes(tableName string) []string {
 	for i, name := range c.tableNames {
 		if name == tableName {
 			headers := c.tables[i].headers
-			rows := c.tables[i].rows
+			rows := c.tables[i].rows.Sample(0)
 			return common.InferColumnTypes(rows, len(headers))
 		}
 	}
@@ -96,8 +123,7 @@ func (c *HTMLConverter) GetColumnTypes(tableName string) []string {
 func (c *HTMLConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
 	for i, name := range c.tableNames {
 		if name == tableName {
-			rows := c.tables[i].rows
-			for _, row := range rows {
+			return c.tables[i].rows.Scan(func(row []string) error {
 				interfaceRow := make([]interface{}, len(row))
 				for c, val := range row {
 					interfaceRow[c] = val
@@ -110,14 +136,25 @@ func (c *HTMLConverter) ScanRows(ctx context.Context, tableName string, yield fu
 				case <-ctx.Done():
 					return ctx.Err()
 				default:
+					return nil
 				}
-			}
-			return nil
+			})
 		}
 	}
 	return nil
 }
 
+// Close releases the row buffers' temp files, if any spilled to disk.
+func (c *HTMLConverter) Close() error {
+	var firstErr error
+	for _, t := range c.tables {
+		if err := t.rows.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // ConvertToSQL implements StreamConverter for HTML files (outputs SQL to writer)
 func (c *HTMLConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
 	if len(c.tables) == 0 {
@@ -125,7 +162,7 @@ func (c *HTMLConverter) ConvertToSQL(ctx context.Context, writer io.Writer) erro
 	}
 
 	for i, t := range c.tables {
-		if len(t.headers) == 0 && len(t.rows) == 0 {
+		if len(t.headers) == 0 && t.rows.Len() == 0 {
 			continue
 		}
 
@@ -133,7 +170,7 @@ func (c *HTMLConverter) ConvertToSQL(ctx context.Context, writer io.Writer) erro
 		sanitizedHeaders := common.GenColumnNames(t.headers)
 		colTypes := c.GetColumnTypes(tableName)
 
-		if err := writeHTMLTableSQL(ctx, tableName, sanitizedHeaders, colTypes, t.rows, writer); err != nil {
+		if err := writeHTMLTableSQL(ctx, tableName, sanitizedHeaders, colTypes, t.rows, writer, c.rowsPerInsert); err != nil {
 			return err
 		}
 	}
@@ -141,89 +178,342 @@ func (c *HTMLConverter) ConvertToSQL(ctx context.Context, writer io.Writer) erro
 	return nil
 }
 
-func writeHTMLTableSQL(ctx context.Context, tableName string, headers []string, colTypes []string, rows [][]string, writer io.Writer) error {
-	createTableSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+func writeHTMLTableSQL(ctx context.Context, tableName string, headers []string, colTypes []string, rows *common.SpillBuffer, writer io.Writer, rowsPerInsert int) error {
+	sqlWriter := common.NewSQLWriter(writer, rowsPerInsert)
+	if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
+		return err
 	}
 
-	for _, row := range rows {
-		if _, err := fmt.Fprintf(writer, "INSERT INTO %s (", tableName); err != nil {
-			return fmt.Errorf("failed to write INSERT start: %w", err)
+	err := rows.Scan(func(row []string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
 		}
 
-		for i, header := range headers {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write column separator: %w", err)
-				}
+		return sqlWriter.WriteStringRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return sqlWriter.EndTable()
+}
+
+// parseHTML collects the tables of one document along with the href of its
+// rel=next pagination link (a <link> or <a> element), if any.
+func parseHTML(reader io.Reader, extractKV, extractArt bool) ([]tableData, string, error) {
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var tables []tableData
+	var nextURL string
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if nextURL == "" && (n.Data == "link" || n.Data == "a") && relContains(n, "next") {
+				nextURL = attrVal(n, "href")
+			}
+			if n.Data == "table" {
+				tables = append(tables, extractTable(n))
+				return
 			}
-			if _, err := fmt.Fprintf(writer, "%s", header); err != nil {
-				return fmt.Errorf("failed to write column name: %w", err)
+			if extractKV {
+				if n.Data == "dl" {
+					if t, ok := extractDefinitionList(n); ok {
+						tables = append(tables, t)
+					}
+					return
+				}
+				if t, ok := extractLabelValueGrid(n); ok {
+					tables = append(tables, t)
+					return
+				}
 			}
 		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+	if extractArt {
+		if t, ok := extractArticle(doc); ok {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nextURL, nil
+}
 
-		if _, err := writer.Write([]byte(") VALUES (")); err != nil {
-			return fmt.Errorf("failed to write VALUES start: %w", err)
+// crawlNextPages follows rel=next links from the input page, merging each
+// fetched page's tables into tables, until config.CrawlPages pages have been
+// read in total, the chain ends, or a link loops back to a visited page.
+// Links are resolved against the page they appear on, so InputPath must be
+// the URL of the input page.
+func crawlNextPages(tables []tableData, next string, config *common.ConversionConfig) ([]tableData, error) {
+	base, err := url.Parse(config.InputPath)
+	if err != nil || !base.IsAbs() {
+		return nil, fmt.Errorf("crawl mode needs the page URL as input to resolve the next link %q", next)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if config.ScanTimeout != "" {
+		if d, err := time.ParseDuration(config.ScanTimeout); err == nil {
+			client.Timeout = d
 		}
+	}
 
-		// Ensure row length matches headers
-		currentRow := row
-		if len(currentRow) < len(headers) {
-			for len(currentRow) < len(headers) {
-				currentRow = append(currentRow, "")
-			}
-		} else if len(currentRow) > len(headers) {
-			currentRow = currentRow[:len(headers)]
+	seen := map[string]bool{base.String(): true}
+	for fetched := 1; fetched < config.CrawlPages && next != ""; fetched++ {
+		ref, err := url.Parse(next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next link %q: %w", next, err)
+		}
+		pageURL := base.ResolveReference(ref)
+		if seen[pageURL.String()] {
+			break
+		}
+		seen[pageURL.String()] = true
+
+		resp, err := client.Get(pageURL.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", pageURL, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to fetch %s: status %d", pageURL, resp.StatusCode)
+		}
+		pageTables, pageNext, err := parseHTML(bufio.NewReaderSize(resp.Body, 65536), config.ExtractKeyValue, config.ExtractArticle)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", pageURL, err)
 		}
+		tables, err = mergeTables(tables, pageTables)
+		if err != nil {
+			return nil, err
+		}
+		base = pageURL
+		next = pageNext
+	}
+	return tables, nil
+}
 
-		for i, val := range currentRow {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write value separator: %w", err)
+// mergeTables appends each page table's rows to the existing table with the
+// same headers, so paginated listings accumulate into one table. Tables with
+// no match (or headerless ones) are kept as new tables.
+func mergeTables(tables, pageTables []tableData) ([]tableData, error) {
+	for _, pt := range pageTables {
+		merged := false
+		if len(pt.headers) > 0 {
+			for i := range tables {
+				if sameHeaders(tables[i].headers, pt.headers) {
+					err := pt.rows.Scan(func(row []string) error {
+						tables[i].rows.Append(row)
+						return nil
+					})
+					pt.rows.Close()
+					if err != nil {
+						return nil, err
+					}
+					merged = true
+					break
 				}
 			}
-			escapedVal := strings.ReplaceAll(val, "'", "''")
-			if _, err := fmt.Fprintf(writer, "'%s'", escapedVal); err != nil {
-				return fmt.Errorf("failed to write value: %w", err)
-			}
 		}
+		if !merged {
+			tables = append(tables, pt)
+		}
+	}
+	return tables, nil
+}
 
-		if _, err := writer.Write([]byte(");\n")); err != nil {
-			return fmt.Errorf("failed to write statement end: %w", err)
+func sameHeaders(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
 		}
-		// Check cancel
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
+	}
+	return true
+}
+
+// attrVal returns the value of the named attribute, or "".
+func attrVal(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
 		}
 	}
-	if _, err := writer.Write([]byte("\n")); err != nil {
-		return fmt.Errorf("failed to write table separator: %w", err)
+	return ""
+}
+
+// relContains reports whether the rel attribute of n lists the given link type.
+func relContains(n *html.Node, typ string) bool {
+	for _, rel := range strings.Fields(attrVal(n, "rel")) {
+		if strings.EqualFold(rel, typ) {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
-func parseHTML(reader io.Reader) ([]tableData, error) {
-	doc, err := html.Parse(reader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+// classContains reports whether any class of n contains the given word.
+func classContains(n *html.Node, word string) bool {
+	for _, class := range strings.Fields(attrVal(n, "class")) {
+		if strings.Contains(strings.ToLower(class), word) {
+			return true
+		}
 	}
+	return false
+}
 
-	var tables []tableData
-	var f func(*html.Node)
-	f = func(n *html.Node) {
-		if n.Type == html.ElementNode && n.Data == "table" {
-			t := extractTable(n)
-			tables = append(tables, t)
+// extractDefinitionList turns a <dl> into a two-column key/value table,
+// pairing each <dd> with the most recent <dt>.
+func extractDefinitionList(n *html.Node) (tableData, bool) {
+	rows := common.NewSpillBuffer()
+	var key string
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.Data {
+		case "dt":
+			key = extractText(c)
+		case "dd":
+			rows.Append([]string{key, extractText(c)})
+		}
+	}
+	if rows.Len() == 0 {
+		return tableData{}, false
+	}
+	return tableData{
+		rawName: attrVal(n, "id"),
+		headers: []string{"key", "value"},
+		rows:    rows,
+	}, true
+}
+
+// labelValuePairs scans direct children for label-classed elements followed
+// by value-classed siblings.
+func labelValuePairs(n *html.Node) [][]string {
+	var rows [][]string
+	var key string
+	haveKey := false
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		if classContains(c, "label") {
+			key = extractText(c)
+			haveKey = true
+			continue
+		}
+		if haveKey && classContains(c, "value") {
+			rows = append(rows, []string{key, extractText(c)})
 		}
+		haveKey = false
+	}
+	return rows
+}
+
+// extractLabelValueGrid recognizes the two common key/value panel shapes:
+// a container whose children alternate label/value elements, or a container
+// of row wrappers each holding one label/value pair. At least two pairs are
+// required so stray labels don't become tables.
+func extractLabelValueGrid(n *html.Node) (tableData, bool) {
+	pairs := labelValuePairs(n)
+	if len(pairs) == 0 {
+		// Row-wrapped shape: each child holds one pair
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			f(c)
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if p := labelValuePairs(c); len(p) == 1 {
+				pairs = append(pairs, p[0])
+			}
 		}
 	}
-	f(doc)
-	return tables, nil
+	if len(pairs) < 2 {
+		return tableData{}, false
+	}
+	rows := common.NewSpillBuffer()
+	for _, pair := range pairs {
+		rows.Append(pair)
+	}
+	return tableData{
+		rawName: attrVal(n, "id"),
+		headers: []string{"key", "value"},
+		rows:    rows,
+	}, true
+}
+
+type htmlCell struct {
+	text    string
+	colspan int
+	rowspan int
+}
+
+type htmlRow struct {
+	cells  []htmlCell
+	inHead bool
+}
+
+// intAttr returns the attribute parsed as a positive integer, or 1.
+func intAttr(n *html.Node, key string) int {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			if v, err := strconv.Atoi(strings.TrimSpace(attr.Val)); err == nil && v > 1 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// expandSpans turns span-carrying cells into a rectangular grid by repeating
+// cell text across its colspan and into later rows for its rowspan.
+func expandSpans(rawRows []htmlRow) [][]string {
+	type carry struct {
+		text      string
+		remaining int
+	}
+	pending := make(map[int]*carry)
+
+	out := make([][]string, 0, len(rawRows))
+	for _, tr := range rawRows {
+		var row []string
+		col := 0
+		fill := func() {
+			for {
+				p, ok := pending[col]
+				if !ok {
+					break
+				}
+				row = append(row, p.text)
+				p.remaining--
+				if p.remaining == 0 {
+					delete(pending, col)
+				}
+				col++
+			}
+		}
+
+		for _, cell := range tr.cells {
+			fill() // Cells held open by an earlier rowspan come first
+			for k := 0; k < cell.colspan; k++ {
+				if cell.rowspan > 1 {
+					pending[col] = &carry{text: cell.text, remaining: cell.rowspan - 1}
+				}
+				row = append(row, cell.text)
+				col++
+			}
+		}
+		fill()
+		out = append(out, row)
+	}
+	return out
 }
 
 func extractTable(n *html.Node) tableData {
@@ -234,18 +524,31 @@ func extractTable(n *html.Node) tableData {
 			break
 		}
 	}
+	if name == "" {
+		// Fall back to the caption text
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.Data == "caption" {
+				name = extractText(c)
+				break
+			}
+		}
+	}
 
-	var rows [][]string
-	var visitRows func(*html.Node)
-	visitRows = func(node *html.Node) {
+	var rawRows []htmlRow
+	var visitRows func(*html.Node, bool)
+	visitRows = func(node *html.Node, inHead bool) {
 		if node.Type == html.ElementNode && node.Data == "tr" {
-			var row []string
+			var cells []htmlCell
 			for c := node.FirstChild; c != nil; c = c.NextSibling {
 				if c.Type == html.ElementNode && (c.Data == "td" || c.Data == "th") {
-					row = append(row, extractText(c))
+					cells = append(cells, htmlCell{
+						text:    extractText(c),
+						colspan: intAttr(c, "colspan"),
+						rowspan: intAttr(c, "rowspan"),
+					})
 				}
 			}
-			rows = append(rows, row)
+			rawRows = append(rawRows, htmlRow{cells: cells, inHead: inHead})
 			return // Don't look for TRs inside TRs
 		}
 
@@ -254,19 +557,46 @@ func extractTable(n *html.Node) tableData {
 			if c.Type == html.ElementNode && c.Data == "table" {
 				continue
 			}
-			visitRows(c)
+			visitRows(c, inHead || (c.Type == html.ElementNode && c.Data == "thead"))
 		}
 	}
-	visitRows(n)
+	visitRows(n, false)
 
-	if len(rows) == 0 {
+	if len(rawRows) == 0 {
 		return tableData{rawName: name}
 	}
 
+	expanded := expandSpans(rawRows)
+
+	// Prefer the first <thead> row as headers; data is everything outside it
+	headerIdx := -1
+	for i, tr := range rawRows {
+		if tr.inHead {
+			headerIdx = i
+			break
+		}
+	}
+	rows := common.NewSpillBuffer()
+	if headerIdx >= 0 {
+		for i, tr := range rawRows {
+			if !tr.inHead {
+				rows.Append(expanded[i])
+			}
+		}
+		return tableData{
+			rawName: name,
+			headers: expanded[headerIdx],
+			rows:    rows,
+		}
+	}
+
+	for _, row := range expanded[1:] {
+		rows.Append(row)
+	}
 	return tableData{
 		rawName: name,
-		headers: rows[0],
-		rows:    rows[1:],
+		headers: expanded[0],
+		rows:    rows,
 	}
 }
 
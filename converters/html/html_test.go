@@ -3,12 +3,15 @@ package html
 import (
 	"context"
 	"database/sql"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 
 	_ "modernc.org/sqlite"
 )
@@ -172,3 +175,326 @@ func TestHTMLConvertToSQL(t *testing.T) {
 		t.Error("Expected INSERT INTO in SQL output")
 	}
 }
+
+func TestHTMLSpansAndThead(t *testing.T) {
+	content := `
+<html>
+<body>
+<table>
+<caption>Quarterly Sales</caption>
+<thead>
+<tr><th>Region</th><th colspan="2">Sales</th></tr>
+</thead>
+<tbody>
+<tr><td rowspan="2">North</td><td>Q1</td><td>100</td></tr>
+<tr><td>Q2</td><td>110</td></tr>
+<tr><td>South</td><td>Q1</td><td>90</td></tr>
+</tbody>
+</table>
+</body>
+</html>
+`
+	converter, err := NewHTMLConverter(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := converter.GetTableNames()
+	if len(tables) != 1 {
+		t.Fatalf("Expected 1 table, got %v", tables)
+	}
+	// Caption text becomes the table name when no id attribute exists
+	if tables[0] != "quarterly_sales" {
+		t.Errorf("Expected table named from caption, got %q", tables[0])
+	}
+
+	headers := converter.GetHeaders(tables[0])
+	if len(headers) != 3 {
+		t.Fatalf("Expected 3 headers (colspan expanded), got %v", headers)
+	}
+	if headers[0] != "region" {
+		t.Errorf("Expected thead row as headers, got %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = converter.ScanRows(context.Background(), tables[0], func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 data rows, got %d: %v", len(rows), rows)
+	}
+	// The rowspan cell repeats into the second row
+	if rows[1][0] != "North" {
+		t.Errorf("Expected rowspan to carry North into row 2, got %v", rows[1])
+	}
+	if rows[1][1] != "Q2" || rows[1][2] != "110" {
+		t.Errorf("Row 2 cells shifted: %v", rows[1])
+	}
+	if rows[2][0] != "South" {
+		t.Errorf("Unexpected row 3: %v", rows[2])
+	}
+}
+
+func TestHTMLDefinitionList(t *testing.T) {
+	content := `
+<html>
+<body>
+<dl id="specs">
+<dt>Weight</dt><dd>12kg</dd>
+<dt>Color</dt><dd>Red</dd>
+<dt>Ports</dt><dd>USB</dd><dd>HDMI</dd>
+</dl>
+</body>
+</html>
+`
+	config := &common.ConversionConfig{ExtractKeyValue: true}
+	converter, err := NewHTMLConverterWithConfig(strings.NewReader(content), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := converter.GetTableNames()
+	if len(tables) != 1 || tables[0] != "specs" {
+		t.Fatalf("Expected specs table, got %v", tables)
+	}
+
+	headers := converter.GetHeaders("specs")
+	// "key" is a reserved keyword in SQLite, so GenColumnNames changes it to cl0
+	if len(headers) != 2 || headers[0] != "cl0" || headers[1] != "value" {
+		t.Fatalf("Expected cl0/value headers, got %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = converter.ScanRows(context.Background(), "specs", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows (each dd is one row), got %d: %v", len(rows), rows)
+	}
+	// The second dd pairs with the most recent dt
+	if rows[3][0] != "Ports" || rows[3][1] != "HDMI" {
+		t.Errorf("Unexpected last row: %v", rows[3])
+	}
+}
+
+func TestHTMLLabelValueGrid(t *testing.T) {
+	content := `
+<html>
+<body>
+<div id="panel">
+<div class="row"><span class="label">Owner</span><span class="value">Alice</span></div>
+<div class="row"><span class="label">Status</span><span class="value">Active</span></div>
+</div>
+</body>
+</html>
+`
+	config := &common.ConversionConfig{ExtractKeyValue: true}
+	converter, err := NewHTMLConverterWithConfig(strings.NewReader(content), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := converter.GetTableNames()
+	if len(tables) != 1 || tables[0] != "panel" {
+		t.Fatalf("Expected panel table, got %v", tables)
+	}
+
+	var rows [][]interface{}
+	err = converter.ScanRows(context.Background(), "panel", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "Owner" || rows[0][1] != "Alice" {
+		t.Errorf("Unexpected first row: %v", rows[0])
+	}
+}
+
+func TestHTMLCrawlPages(t *testing.T) {
+	page := func(rows string, next string) string {
+		link := ""
+		if next != "" {
+			link = `<link rel="next" href="` + next + `">`
+		}
+		return `<html><head>` + link + `</head><body>
+<table id="listing">
+<tr><th>Name</th><th>Price</th></tr>
+` + rows + `
+</table>
+</body></html>`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(page("<tr><td>a</td><td>1</td></tr><tr><td>b</td><td>2</td></tr>", "/page2")))
+	})
+	mux.HandleFunc("/page2", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(page("<tr><td>c</td><td>3</td></tr>", "page3")))
+	})
+	mux.HandleFunc("/page3", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(page("<tr><td>d</td><td>4</td></tr>", "")))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	fetch := func(crawlPages int) *HTMLConverter {
+		t.Helper()
+		resp, err := http.Get(srv.URL + "/page1")
+		if err != nil {
+			t.Fatalf("Failed to fetch first page: %v", err)
+		}
+		defer resp.Body.Close()
+		config := &common.ConversionConfig{
+			InputPath:  srv.URL + "/page1",
+			CrawlPages: crawlPages,
+		}
+		converter, err := NewHTMLConverterWithConfig(resp.Body, config)
+		if err != nil {
+			t.Fatalf("Failed to create converter: %v", err)
+		}
+		return converter
+	}
+	countRows := func(converter *HTMLConverter) int {
+		t.Helper()
+		count := 0
+		err := converter.ScanRows(context.Background(), "listing", func(row []interface{}, rowErr error) error {
+			count++
+			return rowErr
+		})
+		if err != nil {
+			t.Fatalf("ScanRows failed: %v", err)
+		}
+		return count
+	}
+
+	// All three pages merge into one table; the relative page3 link
+	// resolves against page2's URL
+	converter := fetch(3)
+	if tables := converter.GetTableNames(); len(tables) != 1 || tables[0] != "listing" {
+		t.Fatalf("Expected a single merged listing table, got %v", tables)
+	}
+	if count := countRows(converter); count != 4 {
+		t.Errorf("Expected 4 rows across 3 pages, got %d", count)
+	}
+
+	// The page limit stops the crawl after the second page
+	if count := countRows(fetch(2)); count != 3 {
+		t.Errorf("Expected 3 rows with a 2-page limit, got %d", count)
+	}
+
+	// Without opting in, only the input page is read
+	if count := countRows(fetch(0)); count != 2 {
+		t.Errorf("Expected 2 rows without crawling, got %d", count)
+	}
+}
+
+func TestHTMLKeyValueOffByDefault(t *testing.T) {
+	content := `<html><body><dl><dt>A</dt><dd>1</dd></dl></body></html>`
+	converter, err := NewHTMLConverter(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if tables := converter.GetTableNames(); len(tables) != 0 {
+		t.Errorf("Expected no tables without ExtractKeyValue, got %v", tables)
+	}
+}
+
+func TestHTMLArticleExtraction(t *testing.T) {
+	content := `
+<html>
+<head>
+<title>Fallback Title</title>
+<meta property="og:title" content="The Big Story">
+<meta property="article:published_time" content="2024-06-01T08:00:00Z">
+</head>
+<body>
+<nav><p>Home is where you go first, then News, then Sports, then Weather pages.</p></nav>
+<div class="content">
+<span class="byline">Jane Smith</span>
+<p>The first paragraph of the story runs long enough to count as prose, with commas, clauses, and other signals of real writing.</p>
+<p>A second paragraph continues the story, adding detail after detail, so the container accumulates a convincing score.</p>
+<p>short</p>
+</div>
+<aside><p>Related links, more links, and even more links live here, all of them links.</p></aside>
+<footer><p>Copyright notice</p></footer>
+</body>
+</html>
+`
+	config := &common.ConversionConfig{ExtractArticle: true}
+	converter, err := NewHTMLConverterWithConfig(strings.NewReader(content), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := converter.GetTableNames()
+	if len(tables) != 1 || tables[0] != "articles" {
+		t.Fatalf("Expected an articles table, got %v", tables)
+	}
+
+	headers := converter.GetHeaders("articles")
+	want := []string{"title", "byline", "published", "text"}
+	if len(headers) != len(want) {
+		t.Fatalf("Expected %v headers, got %v", want, headers)
+	}
+	for i, h := range want {
+		if headers[i] != h {
+			t.Fatalf("Expected %v headers, got %v", want, headers)
+		}
+	}
+
+	var rows [][]interface{}
+	err = converter.ScanRows(context.Background(), "articles", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 article row, got %d: %v", len(rows), rows)
+	}
+
+	// The og:title meta wins over the <title> element
+	if rows[0][0] != "The Big Story" {
+		t.Errorf("Unexpected title: %v", rows[0][0])
+	}
+	if rows[0][1] != "Jane Smith" {
+		t.Errorf("Unexpected byline: %v", rows[0][1])
+	}
+	if rows[0][2] != "2024-06-01T08:00:00Z" {
+		t.Errorf("Unexpected published time: %v", rows[0][2])
+	}
+	text, _ := rows[0][3].(string)
+	if !strings.Contains(text, "first paragraph of the story") || !strings.Contains(text, "second paragraph") {
+		t.Errorf("Article text missing body paragraphs: %q", text)
+	}
+	if strings.Contains(text, "Home is where") || strings.Contains(text, "Copyright") {
+		t.Errorf("Article text includes boilerplate: %q", text)
+	}
+}
+
+func TestHTMLArticleOffByDefault(t *testing.T) {
+	content := `<html><head><title>T</title></head><body><p>Long enough paragraph, with a comma, to count as article prose here.</p></body></html>`
+	converter, err := NewHTMLConverter(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	if tables := converter.GetTableNames(); len(tables) != 0 {
+		t.Errorf("Expected no tables without ExtractArticle, got %v", tables)
+	}
+}
@@ -2,9 +2,9 @@ package html_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/html"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -0,0 +1,205 @@
+package ics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	EventsTB = "events"
+)
+
+func init() {
+	converters.Register("ics", &icsDriver{}, converters.Capabilities{Extensions: []string{".ics"}, MIMETypes: []string{"text/calendar"}, Streaming: true})
+}
+
+type icsDriver struct{}
+
+func (d *icsDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewICSConverterWithConfig(source, config)
+}
+
+// eventRow holds one parsed VEVENT.
+type eventRow struct {
+	UID      string
+	Summary  string
+	DtStart  string
+	DtEnd    string
+	Location string
+	RRule    string
+}
+
+// ICSConverter converts .ics calendar exports to a SQLite "events" table.
+type ICSConverter struct {
+	events []eventRow
+
+	Config common.ConversionConfig
+}
+
+// Ensure ICSConverter implements RowProvider
+var _ common.RowProvider = (*ICSConverter)(nil)
+
+// Ensure ICSConverter implements StreamConverter
+var _ common.StreamConverter = (*ICSConverter)(nil)
+
+// NewICSConverter creates a new ICSConverter from an io.Reader.
+func NewICSConverter(r io.Reader) (*ICSConverter, error) {
+	return NewICSConverterWithConfig(r, nil)
+}
+
+// NewICSConverterWithConfig creates a new ICSConverter from an io.Reader with optional config.
+func NewICSConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*ICSConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	c := &ICSConverter{Config: *config}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parse reads the iCalendar stream collecting VEVENT components.
+func (c *ICSConverter) parse(r io.Reader) error {
+	var current *eventRow
+
+	err := common.ScanUnfoldedLines(r, func(name, params, value string) error {
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				current = &eventRow{}
+			}
+		case "END":
+			if strings.EqualFold(value, "VEVENT") && current != nil {
+				c.events = append(c.events, *current)
+				current = nil
+			}
+		case "UID":
+			if current != nil {
+				current.UID = value
+			}
+		case "SUMMARY":
+			if current != nil {
+				current.Summary = unescapeText(value)
+			}
+		case "DTSTART":
+			if current != nil {
+				current.DtStart = normalizeDateTime(value)
+			}
+		case "DTEND":
+			if current != nil {
+				current.DtEnd = normalizeDateTime(value)
+			}
+		case "LOCATION":
+			if current != nil {
+				current.Location = unescapeText(value)
+			}
+		case "RRULE":
+			if current != nil {
+				current.RRule = value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse iCalendar input: %w", err)
+	}
+	return nil
+}
+
+// normalizeDateTime converts iCalendar date/date-time values to RFC 3339 where possible.
+// Unrecognized formats are passed through unchanged.
+func normalizeDateTime(value string) string {
+	formats := []struct {
+		layout string
+		utc    bool
+	}{
+		{"20060102T150405Z", true},
+		{"20060102T150405", false},
+		{"20060102", false},
+	}
+	for _, f := range formats {
+		if t, err := time.Parse(f.layout, value); err == nil {
+			if f.layout == "20060102" {
+				return t.Format("2006-01-02")
+			}
+			return t.Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+// unescapeText reverses iCalendar TEXT escaping (\n, \, \; \,).
+func unescapeText(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
+
+// GetTableNames implements RowProvider
+func (c *ICSConverter) GetTableNames() []string {
+	return []string{EventsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *ICSConverter) GetHeaders(tableName string) []string {
+	if tableName == EventsTB {
+		return []string{"uid", "summary", "dtstart", "dtend", "location", "rrule"}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *ICSConverter) GetColumnTypes(tableName string) []string {
+	if tableName == EventsTB {
+		return []string{"TEXT", "TEXT", "TEXT", "TEXT", "TEXT", "TEXT"}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *ICSConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != EventsTB {
+		return nil
+	}
+	for _, event := range c.events {
+		values := []interface{}{event.UID, event.Summary, event.DtStart, event.DtEnd, event.Location, event.RRule}
+		if err := yield(values, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+// ConvertToSQL implements StreamConverter for iCalendar files (outputs SQL to writer).
+func (c *ICSConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	headers := c.GetHeaders(EventsTB)
+	colTypes := c.GetColumnTypes(EventsTB)
+
+	sqlWriter := common.NewSQLWriter(writer, c.Config.RowsPerInsert)
+	if err := sqlWriter.BeginTable(EventsTB, headers, colTypes); err != nil {
+		return err
+	}
+
+	err := c.ScanRows(ctx, EventsTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		return sqlWriter.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return sqlWriter.EndTable()
+}
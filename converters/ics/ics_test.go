@@ -0,0 +1,70 @@
+package ics
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleICS = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:event-1@example.com
+SUMMARY:Team standup\, daily
+DTSTART:20240105T090000Z
+DTEND:20240105T091500Z
+LOCATION:Room 1
+RRULE:FREQ=DAILY;COUNT=10
+END:VEVENT
+BEGIN:VEVENT
+UID:event-2@example.com
+SUMMARY:Long meeting with a folded des
+ cription line
+DTSTART;VALUE=DATE:20240210
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestICSConverter(t *testing.T) {
+	conv, err := NewICSConverter(strings.NewReader(sampleICS))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), EventsTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(rows))
+	}
+
+	if rows[0][0] != "event-1@example.com" {
+		t.Errorf("unexpected uid: %v", rows[0][0])
+	}
+	if rows[0][1] != "Team standup, daily" {
+		t.Errorf("unexpected summary: %v", rows[0][1])
+	}
+	if rows[0][2] != "2024-01-05T09:00:00Z" {
+		t.Errorf("unexpected dtstart: %v", rows[0][2])
+	}
+	if rows[0][5] != "FREQ=DAILY;COUNT=10" {
+		t.Errorf("unexpected rrule: %v", rows[0][5])
+	}
+
+	// Folded summary line should be unfolded, date-only DTSTART normalized.
+	if rows[1][1] != "Long meeting with a folded description line" {
+		t.Errorf("unexpected folded summary: %v", rows[1][1])
+	}
+	if rows[1][2] != "2024-02-10" {
+		t.Errorf("unexpected date-only dtstart: %v", rows[1][2])
+	}
+}
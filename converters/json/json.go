@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,7 +17,7 @@ import (
 )
 
 func init() {
-	converters.Register("json", &jsonDriver{})
+	converters.Register("json", &jsonDriver{}, converters.Capabilities{Extensions: []string{".json"}, MIMETypes: []string{"application/json"}, Streaming: true})
 }
 
 type jsonDriver struct{}
@@ -41,6 +42,22 @@ type JSONConverter struct {
 	seeker   io.ReadSeeker
 	objData  map[string]interface{} // If we load fully
 	timeout  time.Duration
+
+	rowsPerInsert int
+	flattenDepth  int
+	explodeArrays bool
+	schemaSample  int
+	blobFields    map[string]bool // Fields whose base64 text decodes to BLOB values
+
+	// Child rows buffered while scanning the parent in explode mode
+	childRows map[string][][]interface{}
+
+	// Rows buffered by the schema-union pre-scan
+	buffered []map[string]interface{}
+
+	// Headers grown mid-stream in schema-union mode, set from the
+	// consumer side of ScanRows so the engine can poll CurrentHeaders
+	streamHeaders map[string][]string
 }
 
 type jsonTableInfo struct {
@@ -48,6 +65,12 @@ type jsonTableInfo struct {
 	rawHeaders []string
 	// For object-based streaming (seeker)
 	arrayKey string
+	offset   int64 // Byte offset of the array's '[' when the reader seeks
+
+	// Explode mode: nested array keys routed to child tables
+	children    map[string]string // raw key -> child table name
+	parentTable string            // set on child tables
+	sampleRow   map[string]interface{}
 }
 
 // Ensure JSONConverter implements RowProvider
@@ -66,6 +89,7 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 	seeker, isSeeker := r.(io.ReadSeeker)
 
 	dec := json.NewDecoder(bufio.NewReaderSize(r, 65536))
+	dec.UseNumber()
 
 	// Peek the first token to determine structure
 	token, err := dec.Token()
@@ -96,6 +120,22 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 		tables:   make(map[string]*jsonTableInfo),
 		timeout:  timeout,
 	}
+	if config != nil {
+		c.rowsPerInsert = config.RowsPerInsert
+		c.flattenDepth = config.FlattenDepth
+		c.explodeArrays = config.ExplodeArrays
+		c.schemaSample = config.SchemaSample
+		if len(config.BlobFields) > 0 {
+			c.blobFields = make(map[string]bool, len(config.BlobFields))
+			for _, field := range config.BlobFields {
+				c.blobFields[field] = true
+			}
+		}
+	}
+	if c.explodeArrays {
+		c.childRows = make(map[string][][]interface{})
+	}
+	c.streamHeaders = make(map[string][]string)
 
 	if delim == '[' {
 		// Root is Array
@@ -104,7 +144,37 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 		c.decoder = dec // Keep using this decoder
 
 		// Read first element to determine headers
-		if dec.More() {
+		if c.schemaSample != 0 {
+			// Pre-scan: buffer records and union their keys so columns
+			// appearing after the first record aren't dropped
+			keySet := make(map[string]bool)
+			for dec.More() && (c.schemaSample < 0 || len(c.buffered) < c.schemaSample) {
+				var elem interface{}
+				if err := dec.Decode(&elem); err != nil {
+					return nil, fmt.Errorf("failed to decode element during schema scan: %w", err)
+				}
+				rowMap, ok := elem.(map[string]interface{})
+				if !ok {
+					rowMap = map[string]interface{}{"value": elem}
+				}
+				rowMap = flattenObject(rowMap, c.flattenDepth)
+				c.buffered = append(c.buffered, rowMap)
+				for k := range rowMap {
+					keySet[k] = true
+				}
+			}
+			rawHeaders := make([]string, 0, len(keySet))
+			for k := range keySet {
+				rawHeaders = append(rawHeaders, k)
+			}
+			sort.Strings(rawHeaders)
+			info := &jsonTableInfo{rawHeaders: rawHeaders}
+			c.tables[c.arrayTable] = info
+			if len(c.buffered) > 0 {
+				c.registerChildTables(c.arrayTable, info, c.buffered[0])
+			}
+			info.headers = common.GenColumnNames(info.rawHeaders)
+		} else if dec.More() {
 			var firstElem interface{}
 			if err := dec.Decode(&firstElem); err != nil {
 				return nil, fmt.Errorf("failed to decode first element: %w", err)
@@ -115,13 +185,13 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 				// If strictly not an object, maybe it's a list of primitives?
 				rowMap = map[string]interface{}{"value": firstElem}
 			}
+			rowMap = flattenObject(rowMap, c.flattenDepth)
 
 			c.firstRow = rowMap
-			rawHeaders := extractRawHeaders(rowMap)
-			c.tables[c.arrayTable] = &jsonTableInfo{
-				rawHeaders: rawHeaders,
-				headers:    common.GenColumnNames(rawHeaders),
-			}
+			info := &jsonTableInfo{rawHeaders: extractRawHeaders(rowMap)}
+			c.tables[c.arrayTable] = info
+			c.registerChildTables(c.arrayTable, info, rowMap)
+			info.headers = common.GenColumnNames(info.rawHeaders)
 		} else {
 			// Empty array
 			c.tables[c.arrayTable] = &jsonTableInfo{headers: []string{}, rawHeaders: []string{}}
@@ -129,48 +199,66 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 
 	} else if delim == '{' {
 		// Root is Object
-		c.objData = make(map[string]interface{})
+		var names []string
 
-		// Parse the object manually
-		for dec.More() {
-			keyToken, err := dec.Token()
+		if isSeeker {
+			// Streamed object root: remember where each array value
+			// starts so ScanRows can seek back instead of holding the
+			// whole document in memory
+			var err error
+			names, err = c.scanObjectRoot(dec)
 			if err != nil {
-				return nil, fmt.Errorf("failed to read key: %w", err)
-			}
-			key, ok := keyToken.(string)
-			if !ok {
-				return nil, fmt.Errorf("expected string key")
+				return nil, err
 			}
+		} else {
+			c.objData = make(map[string]interface{})
 
-			var val interface{}
-			if err := dec.Decode(&val); err != nil {
-				return nil, fmt.Errorf("failed to decode value for key %s: %w", key, err)
+			// Parse the object manually
+			for dec.More() {
+				keyToken, err := dec.Token()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read key: %w", err)
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected string key")
+				}
+
+				var val interface{}
+				if err := dec.Decode(&val); err != nil {
+					return nil, fmt.Errorf("failed to decode value for key %s: %w", key, err)
+				}
+				c.objData[key] = val
 			}
-			c.objData[key] = val
-		}
 
-		// Consume closing '}'
-		if _, err := dec.Token(); err != nil {
-			return nil, fmt.Errorf("expected closing '}'")
-		}
+			// Consume closing '}'
+			if _, err := dec.Token(); err != nil {
+				return nil, fmt.Errorf("expected closing '}'")
+			}
 
-		// Analyze for tables
-		var names []string
-		for k, v := range c.objData {
-			if arr, ok := v.([]interface{}); ok {
-				names = append(names, k)
-				// Determine headers from first element of array
-				var rawHeaders []string
-				if len(arr) > 0 {
-					if firstObj, ok := arr[0].(map[string]interface{}); ok {
-						rawHeaders = extractRawHeaders(firstObj)
-					} else {
-						rawHeaders = []string{"value"}
+			// Analyze for tables
+			for k, v := range c.objData {
+				if arr, ok := v.([]interface{}); ok {
+					names = append(names, k)
+					// Determine headers from first element of array
+					var rawHeaders []string
+					var sample map[string]interface{}
+					if len(arr) > 0 {
+						if firstObj, ok := arr[0].(map[string]interface{}); ok {
+							sample = flattenObject(firstObj, c.flattenDepth)
+							rawHeaders = extractRawHeaders(sample)
+						} else {
+							rawHeaders = []string{"value"}
+						}
+						if c.schemaSample != 0 {
+							rawHeaders = c.unionKeys(arr)
+						}
+					}
+					c.tables[k] = &jsonTableInfo{
+						rawHeaders: rawHeaders,
+						headers:    common.GenColumnNames(rawHeaders),
+						sampleRow:  sample,
 					}
-				}
-				c.tables[k] = &jsonTableInfo{
-					rawHeaders: rawHeaders,
-					headers:    common.GenColumnNames(rawHeaders),
 				}
 			}
 		}
@@ -186,6 +274,15 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 		}
 		c.tables = newTables
 
+		// Register child tables for nested arrays in explode mode
+		for _, sanitized := range append([]string(nil), c.tableNames...) {
+			info := c.tables[sanitized]
+			if info.sampleRow != nil {
+				c.registerChildTables(sanitized, info, info.sampleRow)
+				info.headers = common.GenColumnNames(info.rawHeaders)
+			}
+		}
+
 	} else {
 		return nil, fmt.Errorf("unexpected delimiter: %v", delim)
 	}
@@ -193,6 +290,250 @@ func NewJSONConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*
 	return c, nil
 }
 
+// flattenObject expands nested objects into underscored keys up to depth
+// levels deep ("address" -> "address_city"). Arrays and anything nested
+// beyond the depth stay JSON-encoded. Depth 0 disables flattening.
+func flattenObject(m map[string]interface{}, depth int) map[string]interface{} {
+	if depth <= 0 {
+		return m
+	}
+	out := make(map[string]interface{}, len(m))
+	flattenInto(out, "", m, depth)
+	return out
+}
+
+func flattenInto(out map[string]interface{}, prefix string, m map[string]interface{}, depth int) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "_" + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok && depth > 0 {
+			flattenInto(out, key, nested, depth-1)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+// scanObjectRoot walks an object root without retaining values: for every
+// key holding an array it records the array's byte offset, samples elements
+// for headers (honoring SchemaSample) and skips the rest token-wise. The
+// caller must hold a seekable reader so ScanRows can revisit each array.
+func (c *JSONConverter) scanObjectRoot(dec *json.Decoder) ([]string, error) {
+	var names []string
+	for dec.More() {
+		keyToken, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %w", err)
+		}
+		key, ok := keyToken.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string key")
+		}
+
+		valToken, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value for key %s: %w", key, err)
+		}
+		delim, isDelim := valToken.(json.Delim)
+		if !isDelim {
+			continue // Primitive value, fully consumed by Token
+		}
+
+		if delim == '{' {
+			// Nested object: not a table, skip its tokens
+			if err := skipCompound(dec); err != nil {
+				return nil, fmt.Errorf("failed to skip value for key %s: %w", key, err)
+			}
+			continue
+		}
+
+		// Array value: sample headers, then skip the remainder
+		offset := dec.InputOffset() - 1 // Points just past '['
+		limit := 1
+		if c.schemaSample < 0 {
+			limit = -1
+		} else if c.schemaSample > 0 {
+			limit = c.schemaSample
+		}
+
+		keySet := make(map[string]bool)
+		var sample map[string]interface{}
+		count := 0
+		for dec.More() && (limit < 0 || count < limit) {
+			var elem interface{}
+			if err := dec.Decode(&elem); err != nil {
+				return nil, fmt.Errorf("failed to decode element for key %s: %w", key, err)
+			}
+			obj, ok := elem.(map[string]interface{})
+			if !ok {
+				obj = map[string]interface{}{"value": elem}
+			}
+			flat := flattenObject(obj, c.flattenDepth)
+			if sample == nil {
+				sample = flat
+			}
+			for k := range flat {
+				keySet[k] = true
+			}
+			count++
+		}
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("failed to skip element for key %s: %w", key, err)
+			}
+			if d, ok := t.(json.Delim); ok && (d == '{' || d == '[') {
+				if err := skipCompound(dec); err != nil {
+					return nil, fmt.Errorf("failed to skip element for key %s: %w", key, err)
+				}
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("failed to read closing bracket for key %s: %w", key, err)
+		}
+
+		rawHeaders := make([]string, 0, len(keySet))
+		for k := range keySet {
+			rawHeaders = append(rawHeaders, k)
+		}
+		sort.Strings(rawHeaders)
+		c.tables[key] = &jsonTableInfo{
+			rawHeaders: rawHeaders,
+			headers:    common.GenColumnNames(rawHeaders),
+			sampleRow:  sample,
+			offset:     offset,
+		}
+		names = append(names, key)
+	}
+
+	// Consume closing '}'
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("expected closing '}'")
+	}
+	return names, nil
+}
+
+// skipCompound consumes tokens until the already-opened object or array is
+// balanced again.
+func skipCompound(dec *json.Decoder) error {
+	depth := 1
+	for depth > 0 {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := t.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+// unionKeys unions the flattened keys across an in-memory array, honoring
+// the configured sample size (negative = every record).
+func (c *JSONConverter) unionKeys(arr []interface{}) []string {
+	keySet := make(map[string]bool)
+	for i, elem := range arr {
+		if c.schemaSample > 0 && i >= c.schemaSample {
+			break
+		}
+		if obj, ok := elem.(map[string]interface{}); ok {
+			for k := range flattenObject(obj, c.flattenDepth) {
+				keySet[k] = true
+			}
+		} else {
+			keySet["value"] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CurrentHeaders implements common.GrowingSchemaProvider: in schema-union
+// mode the header set can grow past the pre-scanned sample while streaming.
+func (c *JSONConverter) CurrentHeaders(tableName string) []string {
+	if headers, ok := c.streamHeaders[tableName]; ok {
+		return headers
+	}
+	return c.GetHeaders(tableName)
+}
+
+// registerChildTables finds nested arrays of objects in a sample row and, in
+// explode mode, registers one child table per key (named after the key path,
+// e.g. jsontb0_items) with a parent_id column referencing the parent's rowid.
+// Exploded keys are pruned from the parent's raw headers.
+func (c *JSONConverter) registerChildTables(parentTable string, parentInfo *jsonTableInfo, sample map[string]interface{}) {
+	if !c.explodeArrays {
+		return
+	}
+	for _, key := range extractRawHeaders(sample) {
+		arr, ok := sample[key].([]interface{})
+		if !ok || len(arr) == 0 {
+			continue
+		}
+		firstObj, ok := arr[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		childTable := parentTable + "_" + common.GenColumnNames([]string{key})[0]
+		childKeys := extractRawHeaders(firstObj)
+		c.tables[childTable] = &jsonTableInfo{
+			rawHeaders:  append([]string{"parent_id"}, childKeys...),
+			headers:     append([]string{"parent_id"}, common.GenColumnNames(childKeys)...),
+			parentTable: parentTable,
+			sampleRow:   firstObj,
+		}
+		if parentInfo.children == nil {
+			parentInfo.children = make(map[string]string)
+		}
+		parentInfo.children[key] = childTable
+		c.tableNames = append(c.tableNames, childTable)
+	}
+
+	if len(parentInfo.children) > 0 {
+		kept := parentInfo.rawHeaders[:0]
+		for _, k := range parentInfo.rawHeaders {
+			if _, exploded := parentInfo.children[k]; !exploded {
+				kept = append(kept, k)
+			}
+		}
+		parentInfo.rawHeaders = kept
+	}
+}
+
+// explodeRow buffers the child rows of one parent row, stamping them with the
+// parent's 1-based insert position (the parent rowid).
+func (c *JSONConverter) explodeRow(info *jsonTableInfo, rowMap map[string]interface{}, parentID int) {
+	for key, childTable := range info.children {
+		arr, ok := rowMap[key].([]interface{})
+		if !ok {
+			continue
+		}
+		childInfo := c.tables[childTable]
+		for _, elem := range arr {
+			obj, ok := elem.(map[string]interface{})
+			if !ok {
+				obj = map[string]interface{}{"value": elem}
+			}
+			row := flattenRow(obj, childInfo.rawHeaders, c.blobFields)
+			row[0] = parentID
+			c.childRows[childTable] = append(c.childRows[childTable], row)
+		}
+	}
+}
+
 func extractRawHeaders(row map[string]interface{}) []string {
 	keys := make([]string, 0, len(row))
 	for k := range row {
@@ -229,7 +570,14 @@ func (c *JSONConverter) GetColumnTypes(tableName string) []string {
 
 	// Helper to infer type from a Go value
 	inferType := func(val interface{}) string {
-		switch val.(type) {
+		switch v := val.(type) {
+		case json.Number:
+			// UseNumber keeps the literal text: no fraction or exponent
+			// means an exact INTEGER column.
+			if _, ok := convertNumber(v).(int64); ok {
+				return "INTEGER"
+			}
+			return "REAL"
 		case float64:
 			// JSON numbers are float64. Check if it's an integer.
 			// However, without inspecting the raw text or checking for decimals, it's hard.
@@ -256,19 +604,46 @@ func (c *JSONConverter) GetColumnTypes(tableName string) []string {
 		}
 	}
 
+	// Child tables: FK column plus types inferred from the sample element
+	if info.parentTable != "" {
+		colTypes[0] = "INTEGER REFERENCES " + info.parentTable + "(rowid)"
+		if info.sampleRow != nil {
+			for i, rawHeader := range info.rawHeaders[1:] {
+				if val, ok := info.sampleRow[rawHeader]; ok {
+					colTypes[i+1] = inferType(val)
+				}
+			}
+		}
+		return c.blobTypes(info, colTypes)
+	}
+
 	// Strategy:
 	// 1. If streaming (arrayTable active) and tableName matches: use c.firstRow
 	// 2. If in-memory (objData active): scan sample rows
 
 	if c.arrayTable != "" && tableName == c.arrayTable {
-		if c.firstRow != nil {
+		sample := c.firstRow
+		if sample == nil && len(c.buffered) > 0 {
+			sample = c.buffered[0]
+		}
+		if sample != nil {
 			for i, rawHeader := range info.rawHeaders {
-				if val, ok := c.firstRow[rawHeader]; ok {
+				if val, ok := sample[rawHeader]; ok {
 					colTypes[i] = inferType(val)
 				}
 			}
 		}
-		return colTypes
+		return c.blobTypes(info, colTypes)
+	}
+
+	if c.objData == nil && info.sampleRow != nil {
+		// Object root scanned through the seeker: infer from the sampled element
+		for i, rawHeader := range info.rawHeaders {
+			if val, ok := info.sampleRow[rawHeader]; ok {
+				colTypes[i] = inferType(val)
+			}
+		}
+		return c.blobTypes(info, colTypes)
 	}
 
 	if c.objData != nil {
@@ -296,6 +671,7 @@ func (c *JSONConverter) GetColumnTypes(tableName string) []string {
 					if !ok {
 						continue // Skip primitives for now or handle?
 					}
+					rowMap = flattenObject(rowMap, c.flattenDepth)
 
 					val, ok := rowMap[rawHeader]
 					if !ok || val == nil {
@@ -327,31 +703,95 @@ func (c *JSONConverter) GetColumnTypes(tableName string) []string {
 		}
 	}
 
+	return c.blobTypes(info, colTypes)
+}
+
+// blobTypes overrides config-tagged blob fields to BLOB columns.
+func (c *JSONConverter) blobTypes(info *jsonTableInfo, colTypes []string) []string {
+	if len(c.blobFields) == 0 {
+		return colTypes
+	}
+	for i, rawHeader := range info.rawHeaders {
+		if i < len(colTypes) && c.blobFields[rawHeader] {
+			colTypes[i] = "BLOB"
+		}
+	}
 	return colTypes
 }
 
 // ScanRows implements RowProvider
+// PipelinesScans implements common.PipelinedProvider: streamed scans already
+// decode in a producer goroutine behind a bounded channel.
+func (c *JSONConverter) PipelinesScans() bool {
+	return true
+}
+
 func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
 	info, ok := c.tables[tableName]
 	if !ok {
 		return nil
 	}
 
+	// Child tables replay the rows buffered while scanning their parent
+	if info.parentTable != "" {
+		for _, row := range c.childRows[tableName] {
+			if err := yield(row, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Case 1: Root Array Streaming
 	if c.arrayTable != "" && tableName == c.arrayTable {
+		parentIdx := 0
+
 		// Yield first row if exists
 		if c.firstRow != nil {
-			row := flattenRow(c.firstRow, info.rawHeaders)
+			if len(info.children) > 0 {
+				parentIdx++
+				c.explodeRow(info, c.firstRow, parentIdx)
+			}
+			row := flattenRow(c.firstRow, info.rawHeaders, c.blobFields)
 			if err := yield(row, nil); err != nil {
 				return err
 			}
 			c.firstRow = nil // Consumed
 		}
 
+		// Replay rows buffered by the schema-union pre-scan
+		for _, rowMap := range c.buffered {
+			if len(info.children) > 0 {
+				parentIdx++
+				c.explodeRow(info, rowMap, parentIdx)
+			}
+			row := flattenRow(rowMap, info.rawHeaders, c.blobFields)
+			if err := yield(row, nil); err != nil {
+				return err
+			}
+		}
+		c.buffered = nil
+
+		// Schema-union growth state: owned by the producer goroutine,
+		// applied on the consumer side so the engine polls a stable view
+		var localRaw []string
+		var knownKeys map[string]bool
+		if c.schemaSample != 0 {
+			localRaw = append([]string(nil), info.rawHeaders...)
+			knownKeys = make(map[string]bool, len(localRaw))
+			for _, k := range localRaw {
+				knownKeys[k] = true
+			}
+			for k := range info.children {
+				knownKeys[k] = true // exploded keys never become columns
+			}
+		}
+
 		// Stream the rest
 		type rowOrError struct {
-			row []interface{}
-			err error
+			row     []interface{}
+			headers []string // non-nil when the header set grew
+			err     error
 		}
 		rowsCh := make(chan rowOrError, 100)
 		cancelCh := make(chan struct{})
@@ -366,6 +806,53 @@ func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield fu
 				default:
 				}
 
+				if c.flattenDepth > 0 || c.explodeArrays || c.schemaSample != 0 {
+					// Flattening, exploding and schema union all need
+					// fully decoded objects
+					var elem interface{}
+					if err := c.decoder.Decode(&elem); err != nil {
+						select {
+						case rowsCh <- rowOrError{err: fmt.Errorf("error decoding element: %w", err)}:
+						case <-cancelCh:
+						}
+						return
+					}
+					rowMap, ok := elem.(map[string]interface{})
+					if !ok {
+						rowMap = map[string]interface{}{"value": elem}
+					}
+					rowMap = flattenObject(rowMap, c.flattenDepth)
+					if len(info.children) > 0 {
+						parentIdx++
+						c.explodeRow(info, rowMap, parentIdx)
+					}
+
+					rawHeaders := info.rawHeaders
+					var grown []string
+					if knownKeys != nil {
+						added := false
+						for _, k := range extractRawHeaders(rowMap) {
+							if !knownKeys[k] {
+								knownKeys[k] = true
+								localRaw = append(localRaw, k)
+								added = true
+							}
+						}
+						if added {
+							grown = common.GenColumnNames(localRaw)
+						}
+						rawHeaders = localRaw
+					}
+
+					row := flattenRow(rowMap, rawHeaders, c.blobFields)
+					select {
+					case rowsCh <- rowOrError{row: row, headers: grown}:
+					case <-cancelCh:
+						return
+					}
+					continue
+				}
+
 				t, err := c.decoder.Token()
 				if err != nil {
 					select {
@@ -427,7 +914,7 @@ func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield fu
 					rowMap = map[string]json.RawMessage{"value": raw}
 				}
 
-				row := flattenRowRaw(rowMap, info.rawHeaders)
+				row := flattenRowRaw(rowMap, info.rawHeaders, c.blobFields)
 				select {
 				case rowsCh <- rowOrError{row: row}:
 				case <-cancelCh:
@@ -453,6 +940,9 @@ func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield fu
 				if item.err != nil {
 					return item.err
 				}
+				if item.headers != nil {
+					c.streamHeaders[tableName] = item.headers
+				}
 				if err := yield(item.row, nil); err != nil {
 					return err
 				}
@@ -464,16 +954,144 @@ func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield fu
 		}
 	}
 
+	// Case 2: Object root re-scanned through the seeker
+	if c.objData == nil && c.isSeeker {
+		if _, err := c.seeker.Seek(info.offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to table %s: %w", tableName, err)
+		}
+		dec := json.NewDecoder(bufio.NewReaderSize(c.seeker, 65536))
+		dec.UseNumber()
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("failed to re-open array for table %s: %w", tableName, err)
+		}
+
+		wd := common.NewWatchdog(c.timeout)
+		wdDone := wd.Start()
+		defer wd.Stop()
+
+		var localRaw []string
+		var knownKeys map[string]bool
+		if c.schemaSample != 0 {
+			localRaw = append([]string(nil), info.rawHeaders...)
+			knownKeys = make(map[string]bool, len(localRaw))
+			for _, k := range localRaw {
+				knownKeys[k] = true
+			}
+			for k := range info.children {
+				knownKeys[k] = true
+			}
+		}
+
+		parentIdx := 0
+		for dec.More() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-wdDone:
+				return converters.ErrScanTimeout
+			default:
+			}
+
+			var elem interface{}
+			if err := dec.Decode(&elem); err != nil {
+				return fmt.Errorf("failed to decode element for table %s: %w", tableName, err)
+			}
+			wd.Kick()
+
+			rowMap, ok := elem.(map[string]interface{})
+			if !ok {
+				rowMap = map[string]interface{}{"value": elem}
+			}
+			rowMap = flattenObject(rowMap, c.flattenDepth)
+			if len(info.children) > 0 {
+				parentIdx++
+				c.explodeRow(info, rowMap, parentIdx)
+			}
+
+			rawHeaders := info.rawHeaders
+			if knownKeys != nil {
+				added := false
+				for _, k := range extractRawHeaders(rowMap) {
+					if !knownKeys[k] {
+						knownKeys[k] = true
+						localRaw = append(localRaw, k)
+						added = true
+					}
+				}
+				if added {
+					c.streamHeaders[tableName] = common.GenColumnNames(localRaw)
+				}
+				rawHeaders = localRaw
+			}
+
+			row := flattenRow(rowMap, rawHeaders, c.blobFields)
+			if err := yield(row, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Case 2: In-Memory Object
 	if c.objData != nil {
 		originalKey := info.arrayKey
 		if arr, ok := c.objData[originalKey].([]interface{}); ok {
+			wd := common.NewWatchdog(c.timeout)
+			wdDone := wd.Start()
+			defer wd.Stop()
+
+			// Rows past the schema sample can still introduce new keys
+			var localRaw []string
+			var knownKeys map[string]bool
+			if c.schemaSample != 0 {
+				localRaw = append([]string(nil), info.rawHeaders...)
+				knownKeys = make(map[string]bool, len(localRaw))
+				for _, k := range localRaw {
+					knownKeys[k] = true
+				}
+				for k := range info.children {
+					knownKeys[k] = true
+				}
+			}
+
+			parentIdx := 0
 			for _, val := range arr {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-wdDone:
+					return converters.ErrScanTimeout
+				default:
+				}
+				wd.Kick()
+
 				rowMap, ok := val.(map[string]interface{})
 				if !ok {
 					rowMap = map[string]interface{}{"value": val}
 				}
-				row := flattenRow(rowMap, info.rawHeaders)
+				rowMap = flattenObject(rowMap, c.flattenDepth)
+				if len(info.children) > 0 {
+					parentIdx++
+					c.explodeRow(info, rowMap, parentIdx)
+				}
+
+				rawHeaders := info.rawHeaders
+				if knownKeys != nil {
+					added := false
+					for _, k := range extractRawHeaders(rowMap) {
+						if !knownKeys[k] {
+							knownKeys[k] = true
+							localRaw = append(localRaw, k)
+							added = true
+						}
+					}
+					if added {
+						c.streamHeaders[tableName] = common.GenColumnNames(localRaw)
+					}
+					rawHeaders = localRaw
+				}
+
+				row := flattenRow(rowMap, rawHeaders, c.blobFields)
 				if err := yield(row, nil); err != nil {
 					return err
 				}
@@ -484,7 +1102,37 @@ func (c *JSONConverter) ScanRows(ctx context.Context, tableName string, yield fu
 	return nil
 }
 
-func flattenRow(rowMap map[string]interface{}, rawHeaders []string) []interface{} {
+// convertNumber maps a JSON number literal to an int64 when it has no
+// fraction or exponent, so 64-bit IDs (snowflakes) survive exactly instead
+// of being rounded through float64. Everything else becomes float64, with
+// the literal text as a last resort for out-of-range values.
+func convertNumber(n json.Number) interface{} {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return s
+}
+
+// decodeBlobField decodes the base64 text of a config-tagged blob field
+// into raw bytes; values that aren't valid base64 stay as text.
+func decodeBlobField(val interface{}) interface{} {
+	s, ok := val.(string)
+	if !ok {
+		return val
+	}
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data
+	}
+	return val
+}
+
+func flattenRow(rowMap map[string]interface{}, rawHeaders []string, blobs map[string]bool) []interface{} {
 	row := make([]interface{}, len(rawHeaders))
 	for i, key := range rawHeaders {
 		val, ok := rowMap[key]
@@ -493,6 +1141,11 @@ func flattenRow(rowMap map[string]interface{}, rawHeaders []string) []interface{
 			continue
 		}
 
+		if blobs[key] {
+			row[i] = decodeBlobField(val)
+			continue
+		}
+
 		// Handle nesting: "Anything more nested than that can be added to a json field in each row."
 		switch v := val.(type) {
 		case map[string]interface{}, []interface{}:
@@ -502,6 +1155,8 @@ func flattenRow(rowMap map[string]interface{}, rawHeaders []string) []interface{
 			} else {
 				row[i] = fmt.Sprintf("%v", v) // Fallback
 			}
+		case json.Number:
+			row[i] = convertNumber(v)
 		default:
 			row[i] = v
 		}
@@ -585,7 +1240,7 @@ func reconstructRawJSON(t json.Token, dec *json.Decoder) (json.RawMessage, error
 	return nil, fmt.Errorf("unexpected delimiter: %v", delim)
 }
 
-func flattenRowRaw(rowMap map[string]json.RawMessage, rawHeaders []string) []interface{} {
+func flattenRowRaw(rowMap map[string]json.RawMessage, rawHeaders []string, blobs map[string]bool) []interface{} {
 	row := make([]interface{}, len(rawHeaders))
 	for i, key := range rawHeaders {
 		val, ok := rowMap[key]
@@ -607,13 +1262,20 @@ func flattenRowRaw(rowMap map[string]json.RawMessage, rawHeaders []string) []int
 			row[i] = string(val)
 		} else {
 			// It's primitive, unmarshal it
+			dec := json.NewDecoder(bytes.NewReader(val))
+			dec.UseNumber()
 			var primitive interface{}
-			if err := json.Unmarshal(val, &primitive); err != nil {
+			if err := dec.Decode(&primitive); err != nil {
 				row[i] = string(val) // Fallback
+			} else if n, ok := primitive.(json.Number); ok {
+				row[i] = convertNumber(n)
 			} else {
 				row[i] = primitive
 			}
 		}
+		if blobs[key] {
+			row[i] = decodeBlobField(row[i])
+		}
 	}
 	return row
 }
@@ -621,12 +1283,12 @@ func flattenRowRaw(rowMap map[string]json.RawMessage, rawHeaders []string) []int
 // ConvertToSQL implements StreamConverter
 func (c *JSONConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
 	bw := bufio.NewWriter(writer)
+	sqlWriter := common.NewSQLWriter(bw, c.rowsPerInsert)
 	for _, tableName := range c.GetTableNames() {
 		headers := c.GetHeaders(tableName)
 		colTypes := c.GetColumnTypes(tableName)
 
-		createSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-		if _, err := fmt.Fprintf(bw, "%s;\n\n", createSQL); err != nil {
+		if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
 			return err
 		}
 
@@ -634,56 +1296,12 @@ func (c *JSONConverter) ConvertToSQL(ctx context.Context, writer io.Writer) erro
 			if err != nil {
 				return err
 			}
-			if _, err := fmt.Fprintf(bw, "INSERT INTO %s (", tableName); err != nil {
-				return err
-			}
-			// columns
-			for i, h := range headers {
-				if i > 0 {
-					if _, err := fmt.Fprint(bw, ", "); err != nil {
-						return err
-					}
-				}
-				if _, err := fmt.Fprint(bw, h); err != nil {
-					return err
-				}
-			}
-			if _, err := fmt.Fprint(bw, ") VALUES ("); err != nil {
-				return err
-			}
-			// values
-			for i, val := range row {
-				if i > 0 {
-					if _, err := fmt.Fprint(bw, ", "); err != nil {
-						return err
-					}
-				}
-				// handle types
-				switch v := val.(type) {
-				case nil:
-					if _, err := fmt.Fprint(bw, "NULL"); err != nil {
-						return err
-					}
-				case string:
-					escaped := strings.ReplaceAll(v, "'", "''")
-					if _, err := fmt.Fprintf(bw, "'%s'", escaped); err != nil {
-						return err
-					}
-				default:
-					if _, err := fmt.Fprintf(bw, "'%v'", v); err != nil {
-						return err
-					}
-				}
-			}
-			if _, err := fmt.Fprint(bw, ");\n"); err != nil {
-				return err
-			}
-			return nil
+			return sqlWriter.WriteRow(row)
 		})
 		if err != nil {
 			return err
 		}
-		if _, err := fmt.Fprint(bw, "\n"); err != nil {
+		if err := sqlWriter.EndTable(); err != nil {
 			return err
 		}
 	}
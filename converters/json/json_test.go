@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 
 	_ "modernc.org/sqlite"
 )
@@ -287,3 +288,418 @@ func TestJSONPrimitiveFirst(t *testing.T) {
 		t.Errorf("Row 3: expected '[1,2]', got '%s'", val)
 	}
 }
+
+func TestJSONFlattenDepth(t *testing.T) {
+	jsonContent := `[
+        {"id": 1, "address": {"city": "Berlin", "geo": {"lat": 52.5, "lon": 13.4}}, "tags": ["a", "b"]},
+        {"id": 2, "address": {"city": "Paris", "geo": {"lat": 48.8, "lon": 2.3}}, "tags": []}
+    ]`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverterWithConfig(reader, &common.ConversionConfig{FlattenDepth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := conv.GetHeaders("jsontb0")
+	headerSet := make(map[string]bool)
+	for _, h := range headers {
+		headerSet[h] = true
+	}
+	for _, want := range []string{"address_city", "address_geo_lat", "address_geo_lon", "id", "tags"} {
+		if !headerSet[want] {
+			t.Errorf("Expected flattened header %s, got %v", want, headers)
+		}
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "jsontb0", func(row []interface{}, err error) error {
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	cityIdx, tagsIdx := -1, -1
+	for i, h := range headers {
+		switch h {
+		case "address_city":
+			cityIdx = i
+		case "tags":
+			tagsIdx = i
+		}
+	}
+	if rows[1][cityIdx] != "Paris" {
+		t.Errorf("Expected flattened city Paris, got %v", rows[1][cityIdx])
+	}
+	if s, ok := rows[0][tagsIdx].(string); !ok || !strings.Contains(s, `"a"`) {
+		t.Errorf("Expected tags to stay JSON-encoded, got %v", rows[0][tagsIdx])
+	}
+}
+
+func TestJSONFlattenDepthLimited(t *testing.T) {
+	jsonContent := `[{"a": {"b": {"c": 1}}}]`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverterWithConfig(reader, &common.ConversionConfig{FlattenDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := conv.GetHeaders("jsontb0")
+	if len(headers) != 1 || headers[0] != "a_b" {
+		t.Fatalf("Expected single header a_b at depth 1, got %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "jsontb0", func(row []interface{}, err error) error {
+		rows = append(rows, row)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if s, ok := rows[0][0].(string); !ok || !strings.Contains(s, `"c"`) {
+		t.Errorf("Expected deeper nesting JSON-encoded, got %v", rows[0][0])
+	}
+}
+
+func TestJSONExplodeArrays(t *testing.T) {
+	jsonContent := `[
+        {"id": 1, "customer": "alice", "items": [{"sku": "A1", "qty": 2}, {"sku": "B2", "qty": 1}]},
+        {"id": 2, "customer": "bob", "items": [{"sku": "C3", "qty": 5}]}
+    ]`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverterWithConfig(reader, &common.ConversionConfig{ExplodeArrays: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 2 || tables[0] != "jsontb0" || tables[1] != "jsontb0_items" {
+		t.Fatalf("Expected [jsontb0 jsontb0_items], got %v", tables)
+	}
+
+	parentHeaders := conv.GetHeaders("jsontb0")
+	for _, h := range parentHeaders {
+		if h == "items" {
+			t.Errorf("Exploded key should not remain a parent column: %v", parentHeaders)
+		}
+	}
+
+	childHeaders := conv.GetHeaders("jsontb0_items")
+	if len(childHeaders) != 3 || childHeaders[0] != "parent_id" {
+		t.Fatalf("Expected child headers [parent_id qty sku], got %v", childHeaders)
+	}
+
+	childTypes := conv.GetColumnTypes("jsontb0_items")
+	if !strings.Contains(childTypes[0], "REFERENCES jsontb0(rowid)") {
+		t.Errorf("Expected FK declaration on parent_id, got %v", childTypes)
+	}
+
+	outputDir := "../../test_output/json_test"
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	outPath := filepath.Join(outputDir, "json_explode.db")
+	os.Remove(outPath)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := converters.ImportToSQLite(conv, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+	f.Close()
+
+	db, err := sql.Open("sqlite", outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM jsontb0_items").Scan(&count); err != nil {
+		t.Fatalf("Failed to count child rows: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 child rows, got %d", count)
+	}
+
+	var sku string
+	err = db.QueryRow(`SELECT i.sku FROM jsontb0_items i
+		JOIN jsontb0 p ON p.rowid = i.parent_id
+		WHERE CAST(p.id AS INT) = 2`).Scan(&sku)
+	if err != nil {
+		t.Fatalf("Failed to join child to parent: %v", err)
+	}
+	if sku != "C3" {
+		t.Errorf("Expected joined sku C3, got %s", sku)
+	}
+}
+
+func TestJSONSchemaUnion(t *testing.T) {
+	jsonContent := `[
+        {"name": "Alice"},
+        {"name": "Bob", "city": "NY"},
+        {"name": "Carol", "email": "c@example.com"}
+    ]`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverterWithConfig(reader, &common.ConversionConfig{SchemaSample: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := conv.GetHeaders("jsontb0")
+	if len(headers) != 3 || headers[0] != "city" || headers[1] != "email" || headers[2] != "name" {
+		t.Fatalf("Expected union headers [city email name], got %v", headers)
+	}
+
+	outputDir := "../../test_output/json_test"
+	os.MkdirAll(outputDir, 0755)
+	outPath := filepath.Join(outputDir, "json_union.db")
+	os.Remove(outPath)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := converters.ImportToSQLite(conv, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+	f.Close()
+
+	db, err := sql.Open("sqlite", outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var email string
+	if err := db.QueryRow("SELECT email FROM jsontb0 WHERE name = 'Carol'").Scan(&email); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if email != "c@example.com" {
+		t.Errorf("Expected unioned email column value, got %q", email)
+	}
+}
+
+func TestJSONSchemaGrowthPastSample(t *testing.T) {
+	jsonContent := `[
+        {"name": "Alice"},
+        {"name": "Bob", "city": "NY"}
+    ]`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverterWithConfig(reader, &common.ConversionConfig{SchemaSample: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The pre-scan only saw the first record
+	headers := conv.GetHeaders("jsontb0")
+	if len(headers) != 1 || headers[0] != "name" {
+		t.Fatalf("Expected sampled headers [name], got %v", headers)
+	}
+
+	outputDir := "../../test_output/json_test"
+	os.MkdirAll(outputDir, 0755)
+	outPath := filepath.Join(outputDir, "json_union_growth.db")
+	os.Remove(outPath)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := converters.ImportToSQLite(conv, f, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+	f.Close()
+
+	db, err := sql.Open("sqlite", outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The city column must have been added via ALTER TABLE mid-stream
+	var city string
+	if err := db.QueryRow("SELECT city FROM jsontb0 WHERE name = 'Bob'").Scan(&city); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if city != "NY" {
+		t.Errorf("Expected city NY for row past the sample, got %q", city)
+	}
+
+	var cityForAlice interface{}
+	if err := db.QueryRow("SELECT city FROM jsontb0 WHERE name = 'Alice'").Scan(&cityForAlice); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if cityForAlice != nil {
+		t.Errorf("Expected NULL city for earlier row, got %v", cityForAlice)
+	}
+}
+
+func TestJSONObjectStreamed(t *testing.T) {
+	jsonContent := `{
+        "note": "metadata",
+        "nested": {"skip": "me"},
+        "users": [{"id": 1, "name": "A"}, {"id": 2, "name": "B"}, {"id": 3, "name": "C"}]
+    }`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverter(reader)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	// Seekable readers must not load the whole document into memory
+	if conv.objData != nil {
+		t.Fatal("Expected objData to stay nil for a seekable reader")
+	}
+
+	types := conv.GetColumnTypes("users")
+	if len(types) != 2 || types[0] != "INTEGER" {
+		t.Errorf("Expected id column to be INTEGER, got %v", types)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "users", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+	if rows[2][1] != "C" {
+		t.Errorf("Expected last name C, got %v", rows[2][1])
+	}
+}
+
+func TestJSONObjectContextCancel(t *testing.T) {
+	jsonContent := `{"users": [{"id": 1}, {"id": 2}]}`
+
+	reader := strings.NewReader(jsonContent)
+	conv, err := NewJSONConverter(reader)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = conv.ScanRows(ctx, "users", func(row []interface{}, rowErr error) error {
+		return rowErr
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestJSONNumericPrecision(t *testing.T) {
+	// 2^53+1 and a Discord-style snowflake: both round if parsed as float64
+	jsonContent := `[
+        {"id": 9007199254740993, "score": 1.5},
+        {"id": 1146163165860638721, "score": 2}
+    ]`
+
+	conv, err := NewJSONConverter(strings.NewReader(jsonContent))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	colTypes := conv.GetColumnTypes("jsontb0")
+	headers := conv.GetHeaders("jsontb0")
+	for i, h := range headers {
+		if h == "id" && colTypes[i] != "INTEGER" {
+			t.Errorf("Expected INTEGER type for id, got %s", colTypes[i])
+		}
+		if h == "score" && colTypes[i] != "REAL" {
+			t.Errorf("Expected REAL type for score, got %s", colTypes[i])
+		}
+	}
+
+	var ids []int64
+	err = conv.ScanRows(context.Background(), "jsontb0", func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		for i, h := range headers {
+			if h == "id" {
+				id, ok := row[i].(int64)
+				if !ok {
+					t.Fatalf("Expected int64 id, got %T (%v)", row[i], row[i])
+				}
+				ids = append(ids, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 9007199254740993 || ids[1] != 1146163165860638721 {
+		t.Errorf("IDs lost precision: %v", ids)
+	}
+}
+
+func TestJSONBlobFields(t *testing.T) {
+	// "aGVsbG8=" is base64 for "hello"
+	jsonContent := `[
+        {"name": "a", "payload": "aGVsbG8="},
+        {"name": "b", "payload": null}
+    ]`
+
+	config := &common.ConversionConfig{BlobFields: []string{"payload"}}
+	conv, err := NewJSONConverterWithConfig(strings.NewReader(jsonContent), config)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	headers := conv.GetHeaders("jsontb0")
+	colTypes := conv.GetColumnTypes("jsontb0")
+	for i, h := range headers {
+		if h == "payload" && colTypes[i] != "BLOB" {
+			t.Errorf("Expected BLOB type for payload, got %s", colTypes[i])
+		}
+	}
+
+	var payloads []interface{}
+	err = conv.ScanRows(context.Background(), "jsontb0", func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		for i, h := range headers {
+			if h == "payload" {
+				payloads = append(payloads, row[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(payloads) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(payloads))
+	}
+	data, ok := payloads[0].([]byte)
+	if !ok || string(data) != "hello" {
+		t.Errorf("Expected decoded blob bytes, got %T (%v)", payloads[0], payloads[0])
+	}
+	if payloads[1] != nil {
+		t.Errorf("Expected NULL payload, got %v", payloads[1])
+	}
+}
@@ -2,9 +2,9 @@ package json_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/json"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -0,0 +1,306 @@
+package kml
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	PlacemarksTB = "placemarks"
+)
+
+func init() {
+	converters.Register("kml", &kmlDriver{}, converters.Capabilities{Extensions: []string{".kml"}, MIMETypes: []string{"application/vnd.google-earth.kml+xml"}})
+}
+
+type kmlDriver struct{}
+
+func (d *kmlDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewKMLConverterWithConfig(source, config)
+}
+
+// placemark holds one parsed KML Placemark.
+type placemark struct {
+	name        string
+	description string
+	extended    map[string]string // ExtendedData name -> value
+	wkt         string
+	bbox        common.BBox
+}
+
+// KMLConverter flattens KML placemarks into a "placemarks" table: one row
+// per placemark with name, description, ExtendedData fields as columns,
+// the geometry as WKT TEXT, plus bounding box columns.
+type KMLConverter struct {
+	placemarks []placemark
+	extKeys    []string // Sorted union of ExtendedData names
+	headers    []string
+}
+
+// Ensure KMLConverter implements RowProvider
+var _ common.RowProvider = (*KMLConverter)(nil)
+
+// NewKMLConverter creates a new KMLConverter from an io.Reader.
+func NewKMLConverter(r io.Reader) (*KMLConverter, error) {
+	return NewKMLConverterWithConfig(r, nil)
+}
+
+// NewKMLConverterWithConfig creates a new KMLConverter from an io.Reader with optional config.
+func NewKMLConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*KMLConverter, error) {
+	c := &KMLConverter{}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+
+	keySet := make(map[string]bool)
+	for _, p := range c.placemarks {
+		for k := range p.extended {
+			keySet[k] = true
+		}
+	}
+	c.extKeys = make([]string, 0, len(keySet))
+	for k := range keySet {
+		c.extKeys = append(c.extKeys, k)
+	}
+	sort.Strings(c.extKeys)
+
+	rawHeaders := make([]string, 0, len(c.extKeys)+7)
+	rawHeaders = append(rawHeaders, "name", "description")
+	rawHeaders = append(rawHeaders, c.extKeys...)
+	rawHeaders = append(rawHeaders, "geometry")
+	rawHeaders = append(rawHeaders, common.BBoxColumns...)
+	c.headers = common.GenColumnNames(rawHeaders)
+
+	return c, nil
+}
+
+// parse walks the KML token stream collecting placemarks.
+func (c *KMLConverter) parse(r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+
+	var current *placemark
+	var geoms []string  // WKT parts of the current placemark
+	var dataName string // Current ExtendedData Data name attribute
+	var sawKML bool
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("failed to parse kml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "kml", "Document", "Folder":
+				sawKML = true
+			case "Placemark":
+				current = &placemark{extended: make(map[string]string)}
+				geoms = nil
+			case "name":
+				if current != nil {
+					current.name = decodeText(decoder, &t)
+				}
+			case "description":
+				if current != nil {
+					current.description = decodeText(decoder, &t)
+				}
+			case "Data", "SimpleData":
+				if current == nil {
+					continue
+				}
+				dataName = ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "name" {
+						dataName = attr.Value
+					}
+				}
+				if t.Name.Local == "SimpleData" && dataName != "" {
+					current.extended[dataName] = decodeText(decoder, &t)
+				}
+			case "value":
+				if current != nil && dataName != "" {
+					current.extended[dataName] = decodeText(decoder, &t)
+					dataName = ""
+				}
+			case "Point", "LineString", "Polygon":
+				if current == nil {
+					continue
+				}
+				wkt, err := c.parseGeometry(decoder, t.Name.Local, &current.bbox)
+				if err != nil {
+					return err
+				}
+				if wkt != "" {
+					geoms = append(geoms, wkt)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "Placemark" && current != nil {
+				switch len(geoms) {
+				case 0:
+				case 1:
+					current.wkt = geoms[0]
+				default:
+					current.wkt = "GEOMETRYCOLLECTION (" + strings.Join(geoms, ", ") + ")"
+				}
+				c.placemarks = append(c.placemarks, *current)
+				current = nil
+			}
+		}
+	}
+
+	if !sawKML && len(c.placemarks) == 0 {
+		return fmt.Errorf("not a kml document")
+	}
+	return nil
+}
+
+// decodeText reads the character content of the current element.
+func decodeText(decoder *xml.Decoder, start *xml.StartElement) string {
+	var text string
+	decoder.DecodeElement(&text, start)
+	return strings.TrimSpace(text)
+}
+
+// parseGeometry consumes a Point/LineString/Polygon element and renders WKT.
+// Polygon rings are collected from outer and inner boundaries in order.
+func (c *KMLConverter) parseGeometry(decoder *xml.Decoder, kind string, bbox *common.BBox) (string, error) {
+	var rings []string
+	depth := 1
+	for depth > 0 {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", kind, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "coordinates" {
+				text := decodeText(decoder, &t)
+				ring, err := coordinatesToWKT(text, bbox)
+				if err != nil {
+					return "", err
+				}
+				rings = append(rings, ring)
+			} else {
+				depth++
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	if len(rings) == 0 {
+		return "", nil
+	}
+	switch kind {
+	case "Point":
+		return "POINT (" + rings[0] + ")", nil
+	case "LineString":
+		return "LINESTRING (" + rings[0] + ")", nil
+	case "Polygon":
+		for i, ring := range rings {
+			rings[i] = "(" + ring + ")"
+		}
+		return "POLYGON (" + strings.Join(rings, ", ") + ")", nil
+	}
+	return "", nil
+}
+
+// coordinatesToWKT converts a KML coordinates block ("lon,lat[,ele] ..."
+// tuples separated by whitespace) into a WKT coordinate list.
+func coordinatesToWKT(text string, bbox *common.BBox) (string, error) {
+	var parts []string
+	for _, tuple := range strings.Fields(text) {
+		fields := strings.Split(tuple, ",")
+		if len(fields) < 2 {
+			return "", fmt.Errorf("invalid kml coordinate: %s", tuple)
+		}
+		lon, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid longitude %q: %w", fields[0], err)
+		}
+		lat, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid latitude %q: %w", fields[1], err)
+		}
+		bbox.Extend(lon, lat)
+		parts = append(parts, strconv.FormatFloat(lon, 'f', -1, 64)+" "+strconv.FormatFloat(lat, 'f', -1, 64))
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// GetTableNames implements RowProvider
+func (c *KMLConverter) GetTableNames() []string {
+	return []string{PlacemarksTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *KMLConverter) GetHeaders(tableName string) []string {
+	if tableName != PlacemarksTB {
+		return nil
+	}
+	return c.headers
+}
+
+// GetColumnTypes implements RowProvider
+func (c *KMLConverter) GetColumnTypes(tableName string) []string {
+	if tableName != PlacemarksTB {
+		return nil
+	}
+	colTypes := make([]string, len(c.headers))
+	for i := range colTypes {
+		colTypes[i] = "TEXT"
+	}
+	for i := len(c.headers) - 4; i < len(c.headers); i++ {
+		colTypes[i] = "REAL" // bbox
+	}
+	return colTypes
+}
+
+// ScanRows implements RowProvider
+func (c *KMLConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != PlacemarksTB {
+		return nil
+	}
+
+	for _, p := range c.placemarks {
+		row := make([]interface{}, 0, len(c.headers))
+		row = append(row, p.name, p.description)
+		for _, key := range c.extKeys {
+			if val, ok := p.extended[key]; ok {
+				row = append(row, val)
+			} else {
+				row = append(row, nil)
+			}
+		}
+		if p.wkt != "" {
+			row = append(row, p.wkt)
+		} else {
+			row = append(row, nil)
+		}
+		row = append(row, p.bbox.Row()...)
+
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
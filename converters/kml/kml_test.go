@@ -0,0 +1,118 @@
+package kml
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleKML = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Placemark>
+      <name>HQ</name>
+      <description>Main office</description>
+      <ExtendedData>
+        <Data name="region"><value>west</value></Data>
+      </ExtendedData>
+      <Point>
+        <coordinates>-122.4,37.78,0</coordinates>
+      </Point>
+    </Placemark>
+    <Placemark>
+      <name>Trail</name>
+      <LineString>
+        <coordinates>
+          -122.5,37.7,0
+          -122.3,37.8,0
+        </coordinates>
+      </LineString>
+    </Placemark>
+  </Document>
+</kml>`
+
+func TestKMLConverter(t *testing.T) {
+	conv, err := NewKMLConverter(strings.NewReader(sampleKML))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 1 || tables[0] != PlacemarksTB {
+		t.Fatalf("expected placemarks table, got %v", tables)
+	}
+
+	headers := conv.GetHeaders(PlacemarksTB)
+	// name, description, region + geometry + 4 bbox columns
+	if len(headers) != 8 {
+		t.Fatalf("expected 8 columns, got %v", headers)
+	}
+	if headers[0] != "name" || headers[2] != "region" || headers[3] != "geometry" {
+		t.Errorf("unexpected header layout: %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), PlacemarksTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 placemarks, got %d", len(rows))
+	}
+
+	if rows[0][0] != "HQ" || rows[0][2] != "west" {
+		t.Errorf("unexpected first placemark: %v", rows[0])
+	}
+	if rows[0][3] != "POINT (-122.4 37.78)" {
+		t.Errorf("unexpected point WKT: %v", rows[0][3])
+	}
+
+	if rows[1][2] != nil {
+		t.Errorf("missing ExtendedData should be NULL: %v", rows[1][2])
+	}
+	if rows[1][3] != "LINESTRING (-122.5 37.7, -122.3 37.8)" {
+		t.Errorf("unexpected linestring WKT: %v", rows[1][3])
+	}
+	if rows[1][4] != -122.5 || rows[1][6] != -122.3 {
+		t.Errorf("unexpected linestring bbox: %v", rows[1][4:])
+	}
+}
+
+func TestKMLPolygon(t *testing.T) {
+	input := `<kml><Placemark><Polygon><outerBoundaryIs><LinearRing>
+<coordinates>0,0 0,1 1,1 0,0</coordinates>
+</LinearRing></outerBoundaryIs></Polygon></Placemark></kml>`
+
+	conv, err := NewKMLConverter(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), PlacemarksTB, func(r []interface{}, rowErr error) error {
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 placemark, got %d", len(rows))
+	}
+	// No ExtendedData: name, description, geometry, bbox
+	if rows[0][2] != "POLYGON ((0 0, 0 1, 1 1, 0 0))" {
+		t.Errorf("unexpected polygon WKT: %v", rows[0][2])
+	}
+}
+
+func TestKMLRejectsNonKML(t *testing.T) {
+	if _, err := NewKMLConverter(strings.NewReader("<html><body>hi</body></html>")); err == nil {
+		t.Error("expected error for non-kml input")
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 func init() {
-	converters.Register("markdown", &markdownDriver{})
+	converters.Register("markdown", &markdownDriver{}, converters.Capabilities{Extensions: []string{".md"}, MIMETypes: []string{"text/markdown"}, Streaming: true})
 }
 
 type markdownDriver struct{}
@@ -24,14 +24,15 @@ func (d *markdownDriver) Open(source io.Reader, config *common.ConversionConfig)
 
 // MarkdownConverter converts Markdown files to SQLite tables
 type MarkdownConverter struct {
-	tables     []tableData
-	tableNames []string
+	tables        []tableData
+	tableNames    []string
+	rowsPerInsert int
 }
 
 type tableData struct {
 	rawName string
 	headers []string
-	rows    [][]string
+	rows    *common.SpillBuffer
 }
 
 // Ensure MarkdownConverter implements RowProvider
@@ -46,6 +47,11 @@ func NewMarkdownConverter(r io.Reader, config *common.ConversionConfig) (*Markdo
 	if err != nil {
 		return nil, err
 	}
+	for _, t := range tables {
+		if err := t.rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to buffer rows: %w", err)
+		}
+	}
 
 	// Generate table names once
 	rawNames := make([]string, len(tables))
@@ -58,10 +64,14 @@ func NewMarkdownConverter(r io.Reader, config *common.ConversionConfig) (*Markdo
 	}
 	tableNames := common.GenTableNames(rawNames)
 
-	return &MarkdownConverter{
+	c := &MarkdownConverter{
 		tables:     tables,
 		tableNames: tableNames,
-	}, nil
+	}
+	if config != nil {
+		c.rowsPerInsert = config.RowsPerInsert
+	}
+	return c, nil
 }
 
 // GetTableNames implements RowProvider
@@ -84,7 +94,7 @@ func (c *MarkdownConverter) GetColumnTypes(tableName string) []string {
 	for i, name := range c.tableNames {
 		if name == tableName {
 			headers := c.tables[i].headers
-			rows := c.tables[i].rows
+			rows := c.tables[i].rows.Sample(0)
 			return common.InferColumnTypes(rows, len(headers))
 		}
 	}
@@ -95,9 +105,8 @@ func (c *MarkdownConverter) GetColumnTypes(tableName string) []string {
 func (c *MarkdownConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
 	for i, name := range c.tableNames {
 		if name == tableName {
-			rows := c.tables[i].rows
 			var interfaceRow []interface{}
-			for _, row := range rows {
+			return c.tables[i].rows.Scan(func(row []string) error {
 				if cap(interfaceRow) < len(row) {
 					interfaceRow = make([]interface{}, len(row))
 				} else {
@@ -114,14 +123,25 @@ func (c *MarkdownConverter) ScanRows(ctx context.Context, tableName string, yiel
 				case <-ctx.Done():
 					return ctx.Err()
 				default:
+					return nil
 				}
-			}
-			return nil
+			})
 		}
 	}
 	return nil
 }
 
+// Close releases the row buffers' temp files, if any spilled to disk.
+func (c *MarkdownConverter) Close() error {
+	var firstErr error
+	for _, t := range c.tables {
+		if err := t.rows.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // ConvertToSQL implements StreamConverter
 func (c *MarkdownConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
 	if len(c.tables) == 0 {
@@ -129,7 +149,7 @@ func (c *MarkdownConverter) ConvertToSQL(ctx context.Context, writer io.Writer)
 	}
 
 	for i, t := range c.tables {
-		if len(t.headers) == 0 && len(t.rows) == 0 {
+		if len(t.headers) == 0 && t.rows.Len() == 0 {
 			continue
 		}
 
@@ -137,7 +157,7 @@ func (c *MarkdownConverter) ConvertToSQL(ctx context.Context, writer io.Writer)
 		sanitizedHeaders := common.GenColumnNames(t.headers)
 		colTypes := c.GetColumnTypes(tableName)
 
-		if err := writeTableSQL(ctx, tableName, sanitizedHeaders, colTypes, t.rows, writer); err != nil {
+		if err := writeTableSQL(ctx, tableName, sanitizedHeaders, colTypes, t.rows, writer, c.rowsPerInsert); err != nil {
 			return err
 		}
 	}
@@ -145,77 +165,35 @@ func (c *MarkdownConverter) ConvertToSQL(ctx context.Context, writer io.Writer)
 	return nil
 }
 
-func writeTableSQL(ctx context.Context, tableName string, headers []string, colTypes []string, rows [][]string, writer io.Writer) error {
-	createTableSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+func writeTableSQL(ctx context.Context, tableName string, headers []string, colTypes []string, rows *common.SpillBuffer, writer io.Writer, rowsPerInsert int) error {
+	sqlWriter := common.NewSQLWriter(writer, rowsPerInsert)
+	if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
+		return err
 	}
 
-	for _, row := range rows {
-		if _, err := fmt.Fprintf(writer, "INSERT INTO %s (", tableName); err != nil {
-			return fmt.Errorf("failed to write INSERT start: %w", err)
-		}
-
-		for i, header := range headers {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write column separator: %w", err)
-				}
-			}
-			if _, err := fmt.Fprintf(writer, "%s", header); err != nil {
-				return fmt.Errorf("failed to write column name: %w", err)
-			}
-		}
-
-		if _, err := writer.Write([]byte(") VALUES (")); err != nil {
-			return fmt.Errorf("failed to write VALUES start: %w", err)
-		}
-
-		// Ensure row length matches headers
-		currentRow := row
-		if len(currentRow) < len(headers) {
-			for len(currentRow) < len(headers) {
-				currentRow = append(currentRow, "")
-			}
-		} else if len(currentRow) > len(headers) {
-			currentRow = currentRow[:len(headers)]
-		}
-
-		for i, val := range currentRow {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write value separator: %w", err)
-				}
-			}
-			// Escape single quotes for SQL
-			escapedVal := strings.ReplaceAll(val, "'", "''")
-			if _, err := fmt.Fprintf(writer, "'%s'", escapedVal); err != nil {
-				return fmt.Errorf("failed to write value: %w", err)
-			}
-		}
-
-		if _, err := writer.Write([]byte(");\n")); err != nil {
-			return fmt.Errorf("failed to write statement end: %w", err)
-		}
-		// Check cancel
+	err := rows.Scan(func(row []string) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
+
+		return sqlWriter.WriteStringRow(row)
+	})
+	if err != nil {
+		return err
 	}
-	if _, err := writer.Write([]byte("\n")); err != nil {
-		return fmt.Errorf("failed to write table separator: %w", err)
-	}
-	return nil
+	return sqlWriter.EndTable()
 }
 
 // Regex for headers and anchors
 var (
-	headerRegex = regexp.MustCompile(`^#+\s+(.*)$`)
-	anchorRegex = regexp.MustCompile(`<a\s+.*(?:id|name)="([^"]+)".*>`)
-	listRegex   = regexp.MustCompile(`^(\s*)([*+\-]|\d+\.)\s+(.*)$`)
-	tableRegex  = regexp.MustCompile(`^\s*\|`)
+	headerRegex      = regexp.MustCompile(`^#+\s+(.*)$`)
+	anchorRegex      = regexp.MustCompile(`<a\s+.*(?:id|name)="([^"]+)".*>`)
+	listRegex        = regexp.MustCompile(`^(\s*)([*+\-]|\d+\.)\s+(.*)$`)
+	tableRegex       = regexp.MustCompile(`^\s*\|`)
+	tableSepRegex    = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	frontMatterRegex = regexp.MustCompile(`^([A-Za-z_][\w-]*)\s*:\s*(.*)$`)
 )
 
 func parseMarkdown(r io.Reader) ([]tableData, error) {
@@ -235,10 +213,30 @@ func parseMarkdown(r io.Reader) ([]tableData, error) {
 	}
 
 	i := 0
+
+	// YAML front matter at the top of the file becomes a metadata table
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		if t, consumed, ok := parseFrontMatter(lines); ok {
+			tables = append(tables, t)
+			i = consumed
+		}
+	}
+
 	for i < len(lines) {
 		line := lines[i]
 		trimLine := strings.TrimSpace(line)
 
+		// Skip fenced code blocks so pipes inside code aren't misparsed
+		if strings.HasPrefix(trimLine, "```") || strings.HasPrefix(trimLine, "~~~") {
+			fence := trimLine[:3]
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				i++
+			}
+			i++ // Closing fence
+			continue
+		}
+
 		// Check for Name (Header or Anchor)
 		if match := headerRegex.FindStringSubmatch(trimLine); match != nil {
 			currentName = strings.TrimSpace(match[1])
@@ -253,8 +251,9 @@ func parseMarkdown(r io.Reader) ([]tableData, error) {
 
 		// Check for Table Start
 		if tableRegex.MatchString(trimLine) {
-			// Validate it's a table by checking next line for separator
-			if i+1 < len(lines) && strings.Contains(lines[i+1], "---") {
+			// Validate it's a table by checking next line for a separator
+			// (alignment colons like |:---:| included)
+			if i+1 < len(lines) && tableSepRegex.MatchString(lines[i+1]) {
 				table, consumed := parseTable(lines[i:], currentName)
 				tables = append(tables, table)
 				i += consumed
@@ -278,23 +277,91 @@ func parseMarkdown(r io.Reader) ([]tableData, error) {
 	return tables, nil
 }
 
-func parseTable(lines []string, name string) (tableData, int) {
-	var rows [][]string
-	consumed := 0
+// parseFrontMatter reads a YAML front-matter block (--- ... --- or ...) into
+// a key/value metadata table. Only top-level scalar keys are captured.
+func parseFrontMatter(lines []string) (tableData, int, bool) {
+	end := -1
+	for j := 1; j < len(lines); j++ {
+		trimmed := strings.TrimSpace(lines[j])
+		if trimmed == "---" || trimmed == "..." {
+			end = j
+			break
+		}
+	}
+	if end == -1 {
+		return tableData{}, 0, false
+	}
 
-	// Helper to split pipe row
-	splitRow := func(l string) []string {
-		// remove leading/trailing pipes if present
-		l = strings.TrimSpace(l)
-		l = strings.TrimPrefix(l, "|")
-		l = strings.TrimSuffix(l, "|")
-		parts := strings.Split(l, "|")
-		for k, v := range parts {
-			parts[k] = strings.TrimSpace(v)
+	rows := common.NewSpillBuffer()
+	for _, line := range lines[1:end] {
+		match := frontMatterRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue // Nested structures and list items are skipped
 		}
-		return parts
+		value := strings.TrimSpace(match[2])
+		value = strings.Trim(value, `"'`)
+		rows.Append([]string{match[1], value})
+	}
+	if rows.Len() == 0 {
+		return tableData{}, 0, false
 	}
 
+	return tableData{
+		rawName: "front_matter",
+		headers: []string{"key", "value"},
+		rows:    rows,
+	}, end + 1, true
+}
+
+// splitTableRow splits a pipe-delimited row on unescaped pipes, honoring
+// GitHub-style \| escapes, and drops the empty cells produced by leading
+// and trailing pipes.
+func splitTableRow(l string) []string {
+	l = strings.TrimSpace(l)
+
+	var parts []string
+	var sb strings.Builder
+	escaped := false
+	for _, r := range l {
+		if escaped {
+			if r != '|' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		if r == '|' {
+			parts = append(parts, strings.TrimSpace(sb.String()))
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	if escaped {
+		sb.WriteByte('\\')
+	}
+	parts = append(parts, strings.TrimSpace(sb.String()))
+
+	if len(parts) > 0 && parts[0] == "" {
+		parts = parts[1:]
+	}
+	if len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return parts
+}
+
+func parseTable(lines []string, name string) (tableData, int) {
+	rows := common.NewSpillBuffer()
+	consumed := 0
+
+	splitRow := splitTableRow
+
 	// First line is headers
 	headers := splitRow(lines[0])
 	consumed++
@@ -310,7 +377,7 @@ func parseTable(lines []string, name string) (tableData, int) {
 		if !strings.Contains(line, "|") {
 			break
 		}
-		rows = append(rows, splitRow(line))
+		rows.Append(splitRow(line))
 		consumed++
 	}
 
@@ -322,7 +389,7 @@ func parseTable(lines []string, name string) (tableData, int) {
 }
 
 func parseList(lines []string, name string) (tableData, int) {
-	var rows [][]string
+	rows := common.NewSpillBuffer()
 	consumed := 0
 
 	type listItem struct {
@@ -422,7 +489,7 @@ func parseList(lines []string, name string) (tableData, int) {
 	// Convert items to rows
 	for _, item := range items {
 		val := strings.TrimSpace(item.value.String())
-		rows = append(rows, []string{item.key, val})
+		rows.Append([]string{item.key, val})
 	}
 
 	return tableData{
@@ -5,6 +5,9 @@ import (
 	"context"
 	"strings"
 	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 )
 
 func TestMarkdownConverter(t *testing.T) {
@@ -242,3 +245,126 @@ func TestConvertToSQL(t *testing.T) {
 		t.Errorf("SQL missing value: %s", sql)
 	}
 }
+
+func TestMarkdownDriverRegistration(t *testing.T) {
+	// .md must resolve through the extension registry so the CLI can
+	// open markdown files without an explicit driver name
+	name, ok := converters.ForExtension(".md")
+	if !ok || name != "markdown" {
+		t.Fatalf("Expected .md to resolve to markdown, got %q (ok=%v)", name, ok)
+	}
+
+	input := "| a | b |\n|---|---|\n| 1 | 2 |\n"
+	provider, err := converters.Open("markdown", strings.NewReader(input), &common.ConversionConfig{})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	tables := provider.GetTableNames()
+	if len(tables) != 1 {
+		t.Fatalf("Expected 1 table, got %v", tables)
+	}
+
+	var rows [][]interface{}
+	err = provider.ScanRows(context.Background(), tables[0], func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != "1" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestMarkdownFrontMatter(t *testing.T) {
+	input := `---
+title: My Notes
+author: alice
+tags: [a, b]
+---
+
+| a | b |
+|---|---|
+| 1 | 2 |
+`
+	conv, err := NewMarkdownConverter(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 2 {
+		t.Fatalf("Expected front_matter + data table, got %v", tables)
+	}
+	if tables[0] != "front_matter" {
+		t.Errorf("Expected front_matter first, got %v", tables)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "front_matter", func(row []interface{}, rowErr error) error {
+		copied := make([]interface{}, len(row))
+		copy(copied, row)
+		rows = append(rows, copied)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 metadata rows, got %v", rows)
+	}
+	if rows[0][0] != "title" || rows[0][1] != "My Notes" {
+		t.Errorf("Unexpected first metadata row: %v", rows[0])
+	}
+}
+
+func TestMarkdownCodeFenceSkipped(t *testing.T) {
+	input := "```\n| not | a | table |\n|---|---|---|\n```\n\n| a | b |\n|---|---|\n| 1 | 2 |\n"
+
+	conv, err := NewMarkdownConverter(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 1 {
+		t.Fatalf("Expected only the real table, got %v", tables)
+	}
+	headers := conv.GetHeaders(tables[0])
+	if len(headers) != 2 || headers[0] != "a" {
+		t.Errorf("Unexpected headers: %v", headers)
+	}
+}
+
+func TestMarkdownAlignmentAndEscapedPipes(t *testing.T) {
+	input := `| name | expr |
+|:-----|:----:|
+| or   | a \| b |
+`
+	conv, err := NewMarkdownConverter(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 1 {
+		t.Fatalf("Expected 1 table, got %v", tables)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), tables[0], func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 || len(rows[0]) != 2 {
+		t.Fatalf("Expected 1 row with 2 cells, got %v", rows)
+	}
+	if rows[0][1] != "a | b" {
+		t.Errorf("Expected escaped pipe to stay in the cell, got %v", rows[0][1])
+	}
+}
@@ -0,0 +1,127 @@
+package converters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// Column names the melted layout appends after the id columns.
+const (
+	meltVariableColumn = "variable"
+	meltValueColumn    = "value"
+)
+
+// wrapMeltProvider unpivots every table when opts.Melt names id columns:
+// the id columns stay as-is and each remaining column becomes one
+// (variable, value) row per source row. Tables missing one of the id
+// columns are an error rather than being silently passed through.
+func wrapMeltProvider(provider common.RowProvider, opts *ImportOptions) (common.RowProvider, error) {
+	if opts == nil || len(opts.Melt) == 0 {
+		return provider, nil
+	}
+	tables := make(map[string]meltTable)
+	for _, tableName := range provider.GetTableNames() {
+		headers := provider.GetHeaders(tableName)
+		position := make(map[string]int, len(headers))
+		for i, header := range headers {
+			position[header] = i
+		}
+		var idIdx []int
+		isID := make(map[int]bool, len(opts.Melt))
+		for _, id := range opts.Melt {
+			i, ok := position[strings.TrimSpace(id)]
+			if !ok {
+				return nil, fmt.Errorf("melt id column %s not found in table %s", id, tableName)
+			}
+			idIdx = append(idIdx, i)
+			isID[i] = true
+		}
+		tables[tableName] = meltTable{headers: headers, idIdx: idIdx, isID: isID}
+	}
+	return &meltProvider{inner: provider, tables: tables}, nil
+}
+
+// meltTable records which source columns of one table are id columns.
+type meltTable struct {
+	headers []string
+	idIdx   []int // id column positions, in the order opts.Melt named them
+	isID    map[int]bool
+}
+
+// meltProvider unpivots each table into a long layout: the id columns
+// repeat on every output row and the remaining columns become (variable,
+// value) pairs, one row per non-NULL cell. That turns "region, 2021,
+// 2022, 2023, ..." spreadsheets into the (region, variable, value) shape
+// analysts otherwise build afterwards with SQL.
+type meltProvider struct {
+	inner  common.RowProvider
+	tables map[string]meltTable
+}
+
+func (p *meltProvider) GetTableNames() []string {
+	return p.inner.GetTableNames()
+}
+
+func (p *meltProvider) GetHeaders(tableName string) []string {
+	table := p.tables[tableName]
+	headers := make([]string, 0, len(table.idIdx)+2)
+	for _, i := range table.idIdx {
+		headers = append(headers, table.headers[i])
+	}
+	return append(headers, meltVariableColumn, meltValueColumn)
+}
+
+func (p *meltProvider) GetColumnTypes(tableName string) []string {
+	table := p.tables[tableName]
+	srcTypes := p.inner.GetColumnTypes(tableName)
+	types := make([]string, 0, len(table.idIdx)+2)
+	for _, i := range table.idIdx {
+		if i < len(srcTypes) {
+			types = append(types, srcTypes[i])
+		} else {
+			types = append(types, "TEXT")
+		}
+	}
+	return append(types, "TEXT", "TEXT")
+}
+
+func (p *meltProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	table := p.tables[tableName]
+	return p.inner.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return yield(nil, rowErr)
+		}
+		ids := make([]interface{}, len(table.idIdx))
+		for j, i := range table.idIdx {
+			if i < len(row) {
+				ids[j] = row[i]
+			}
+		}
+		for i, value := range row {
+			if table.isID[i] || value == nil {
+				continue
+			}
+			name := fmt.Sprintf("%s%d", common.CLPRE, i)
+			if i < len(table.headers) {
+				name = table.headers[i]
+			}
+			out := make([]interface{}, 0, len(ids)+2)
+			out = append(out, ids...)
+			if err := yield(append(out, name, value), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (p *meltProvider) Close() error {
+	if closer, ok := p.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
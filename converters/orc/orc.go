@@ -0,0 +1,214 @@
+package orc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	scritchley "github.com/scritchley/orc"
+	orcproto "github.com/scritchley/orc/proto"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	ORCTB = "tb0"
+)
+
+func init() {
+	converters.Register("orc", &orcDriver{}, converters.Capabilities{Extensions: []string{".orc"}, MIMETypes: []string{"application/x-orc"}})
+}
+
+type orcDriver struct{}
+
+func (d *orcDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewORCConverterWithConfig(source, config)
+}
+
+// sizedFile adapts an *os.File to the orc SizedReaderAt interface.
+type sizedFile struct {
+	*os.File
+	size int64
+}
+
+func (s sizedFile) Size() int64 {
+	return s.size
+}
+
+// ORCConverter converts Apache ORC files to a SQLite table, deriving column
+// names and types from the embedded schema and streaming the records.
+type ORCConverter struct {
+	reader   *scritchley.Reader
+	tempFile *os.File // To be cleaned up if a temp file was used
+	headers  []string
+	colTypes []string
+
+	Config common.ConversionConfig
+}
+
+// Ensure ORCConverter implements RowProvider
+var _ common.RowProvider = (*ORCConverter)(nil)
+
+// Ensure ORCConverter implements io.Closer
+var _ io.Closer = (*ORCConverter)(nil)
+
+// NewORCConverter creates a new ORCConverter from an io.Reader.
+func NewORCConverter(r io.Reader) (*ORCConverter, error) {
+	return NewORCConverterWithConfig(r, nil)
+}
+
+// NewORCConverterWithConfig creates a new ORCConverter from an io.Reader with
+// optional config. ORC requires random access, so a non-file source is copied
+// to a temporary file first.
+func NewORCConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*ORCConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{
+			TableName: ORCTB,
+		}
+	}
+	if config.TableName == "" {
+		config.TableName = ORCTB
+	}
+
+	c := &ORCConverter{Config: *config}
+
+	var file *os.File
+	if f, ok := r.(*os.File); ok {
+		file = f
+	} else {
+		tempFile, err := os.CreateTemp("", "mksqlite-orc-*.orc")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		if _, err := io.Copy(tempFile, r); err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+			return nil, fmt.Errorf("failed to copy stream to temp file: %w", err)
+		}
+		c.tempFile = tempFile
+		file = tempFile
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to stat orc file: %w", err)
+	}
+
+	reader, err := scritchley.NewReader(sizedFile{file, info.Size()})
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to open orc file: %w", err)
+	}
+	c.reader = reader
+
+	schema := reader.Schema()
+	c.headers = schema.Columns()
+	c.colTypes = make([]string, len(c.headers))
+	for i, name := range c.headers {
+		field, err := schema.GetField(name)
+		if err != nil {
+			c.colTypes[i] = "TEXT"
+			continue
+		}
+		c.colTypes[i] = sqliteTypeFor(field.Type().GetKind())
+	}
+
+	return c, nil
+}
+
+// Close closes and removes the temporary file if one was used.
+func (c *ORCConverter) Close() error {
+	if c.tempFile != nil {
+		c.tempFile.Close()
+		return os.Remove(c.tempFile.Name())
+	}
+	return nil
+}
+
+// sqliteTypeFor maps an ORC type kind to a SQLite column type.
+func sqliteTypeFor(kind orcproto.Type_Kind) string {
+	switch kind {
+	case orcproto.Type_BOOLEAN, orcproto.Type_BYTE, orcproto.Type_SHORT,
+		orcproto.Type_INT, orcproto.Type_LONG, orcproto.Type_DATE:
+		return "INTEGER"
+	case orcproto.Type_FLOAT, orcproto.Type_DOUBLE, orcproto.Type_DECIMAL:
+		return "REAL"
+	case orcproto.Type_BINARY:
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+// convertValue flattens one decoded ORC value for SQLite insertion.
+func convertValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil, string, int, int32, int64, float32, float64, bool, []byte:
+		return val
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// GetTableNames implements RowProvider
+func (c *ORCConverter) GetTableNames() []string {
+	return []string{c.Config.TableName}
+}
+
+// GetHeaders implements RowProvider
+func (c *ORCConverter) GetHeaders(tableName string) []string {
+	if tableName == c.Config.TableName {
+		return common.GenColumnNames(c.headers)
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *ORCConverter) GetColumnTypes(tableName string) []string {
+	if tableName == c.Config.TableName {
+		return c.colTypes
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider by iterating stripes with a cursor.
+func (c *ORCConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	if c.reader == nil {
+		return fmt.Errorf("orc reader is not initialized")
+	}
+
+	cursor := c.reader.Select(c.headers...)
+	defer cursor.Close()
+
+	for cursor.Stripes() {
+		for cursor.Next() {
+			raw := cursor.Row()
+			values := make([]interface{}, len(raw))
+			for i, v := range raw {
+				values[i] = convertValue(v)
+			}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,81 @@
+package orc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	scritchley "github.com/scritchley/orc"
+)
+
+func TestORCConverter(t *testing.T) {
+	orcPath := filepath.Join(t.TempDir(), "test.orc")
+	f, err := os.Create(orcPath)
+	if err != nil {
+		t.Fatalf("failed to create orc file: %v", err)
+	}
+
+	schema, err := scritchley.ParseSchema("struct<name:string,count:int,score:double>")
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	writer, err := scritchley.NewWriter(f, scritchley.SetSchema(schema))
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+	if err := writer.Write("alice", int64(42), 1.5); err != nil {
+		t.Fatalf("failed to write row: %v", err)
+	}
+	if err := writer.Write("bob", int64(7), 2.5); err != nil {
+		t.Fatalf("failed to write row: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+	f.Close()
+
+	file, err := os.Open(orcPath)
+	if err != nil {
+		t.Fatalf("failed to open orc file: %v", err)
+	}
+	defer file.Close()
+
+	conv, err := NewORCConverter(file)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	defer conv.Close()
+
+	headers := conv.GetHeaders(ORCTB)
+	if len(headers) != 3 || headers[0] != "name" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	colTypes := conv.GetColumnTypes(ORCTB)
+	expected := []string{"TEXT", "INTEGER", "REAL"}
+	for i, want := range expected {
+		if colTypes[i] != want {
+			t.Errorf("column %d type = %s, want %s", i, colTypes[i], want)
+		}
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), ORCTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "alice" {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+}
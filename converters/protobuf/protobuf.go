@@ -0,0 +1,286 @@
+package protobuf
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	ProtoTB = "tb0"
+)
+
+func init() {
+	converters.Register("protobuf", &protobufDriver{}, converters.Capabilities{Extensions: []string{".pb", ".bin"}, MIMETypes: []string{"application/protobuf"}})
+}
+
+type protobufDriver struct{}
+
+func (d *protobufDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewProtobufConverterWithConfig(source, config)
+}
+
+// ProtobufConverter decodes a stream of varint length-delimited protobuf
+// messages using a compiled FileDescriptorSet, producing one column per
+// top-level field. Nested messages, lists and maps are JSON-encoded.
+type ProtobufConverter struct {
+	reader     *bufio.Reader
+	descriptor protoreflect.MessageDescriptor
+	fields     []protoreflect.FieldDescriptor
+
+	Config common.ConversionConfig
+}
+
+// Ensure ProtobufConverter implements RowProvider
+var _ common.RowProvider = (*ProtobufConverter)(nil)
+
+// NewProtobufConverterWithConfig creates a new ProtobufConverter. The config
+// must carry ProtoDescriptor (path to a compiled FileDescriptorSet); the
+// message type is taken from ProtoMessage or defaults to the first message in
+// the set.
+func NewProtobufConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*ProtobufConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+	if config.TableName == "" {
+		config.TableName = ProtoTB
+	}
+	if config.ProtoDescriptor == "" {
+		return nil, fmt.Errorf("protobuf converter requires a descriptor set (--proto-desc)")
+	}
+
+	descBytes, err := os.ReadFile(config.ProtoDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(descBytes, &fds); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set: %w", err)
+	}
+
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build file registry: %w", err)
+	}
+
+	var md protoreflect.MessageDescriptor
+	if config.ProtoMessage != "" {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(config.ProtoMessage))
+		if err != nil {
+			return nil, fmt.Errorf("message %q not found in descriptor set: %w", config.ProtoMessage, err)
+		}
+		var ok bool
+		md, ok = desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a message type", config.ProtoMessage)
+		}
+	} else {
+		// Default: first message of the first file that declares one
+		files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+			if fd.Messages().Len() > 0 && md == nil {
+				md = fd.Messages().Get(0)
+				return false
+			}
+			return true
+		})
+		if md == nil {
+			return nil, fmt.Errorf("descriptor set contains no message types")
+		}
+	}
+
+	fields := make([]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := 0; i < md.Fields().Len(); i++ {
+		fields[i] = md.Fields().Get(i)
+	}
+
+	return &ProtobufConverter{
+		reader:     bufio.NewReaderSize(r, 65536),
+		descriptor: md,
+		fields:     fields,
+		Config:     *config,
+	}, nil
+}
+
+// GetTableNames implements RowProvider
+func (c *ProtobufConverter) GetTableNames() []string {
+	return []string{c.Config.TableName}
+}
+
+// GetHeaders implements RowProvider
+func (c *ProtobufConverter) GetHeaders(tableName string) []string {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	raw := make([]string, len(c.fields))
+	for i, fd := range c.fields {
+		raw[i] = string(fd.Name())
+	}
+	return common.GenColumnNames(raw)
+}
+
+// GetColumnTypes implements RowProvider
+func (c *ProtobufConverter) GetColumnTypes(tableName string) []string {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	colTypes := make([]string, len(c.fields))
+	for i, fd := range c.fields {
+		colTypes[i] = sqliteTypeFor(fd)
+	}
+	return colTypes
+}
+
+// sqliteTypeFor maps a protobuf field to a SQLite column type.
+func sqliteTypeFor(fd protoreflect.FieldDescriptor) string {
+	if fd.IsList() || fd.IsMap() {
+		return "TEXT"
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "INTEGER"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "REAL"
+	case protoreflect.BytesKind:
+		return "BLOB"
+	default: // string, enum, message, group
+		return "TEXT"
+	}
+}
+
+// readMessage reads one varint length-delimited message payload.
+func (c *ProtobufConverter) readMessage() ([]byte, error) {
+	length, err := binary.ReadUvarint(c.reader)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, fmt.Errorf("truncated message (wanted %d bytes): %w", length, err)
+	}
+	return payload, nil
+}
+
+// rowFor converts one decoded message into a row of column values.
+func (c *ProtobufConverter) rowFor(msg *dynamicpb.Message) ([]interface{}, error) {
+	// JSON-encode the whole message once; complex fields are picked from it.
+	jsonBytes, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to JSON-encode message: %w", err)
+	}
+	// protojson output has deliberately unstable whitespace; compact it so
+	// cell values are deterministic.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, jsonBytes); err != nil {
+		return nil, fmt.Errorf("failed to compact message JSON: %w", err)
+	}
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(compacted.Bytes(), &jsonMap); err != nil {
+		return nil, fmt.Errorf("failed to decode message JSON: %w", err)
+	}
+
+	values := make([]interface{}, len(c.fields))
+	for i, fd := range c.fields {
+		if fd.IsList() || fd.IsMap() || fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+			if raw, ok := jsonMap[string(fd.Name())]; ok {
+				values[i] = string(raw)
+			}
+			continue
+		}
+		if !msg.Has(fd) && fd.HasPresence() {
+			continue // NULL
+		}
+		v := msg.Get(fd)
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			if v.Bool() {
+				values[i] = int64(1)
+			} else {
+				values[i] = int64(0)
+			}
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			values[i] = v.Int()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+			protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			values[i] = int64(v.Uint())
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			values[i] = v.Float()
+		case protoreflect.BytesKind:
+			values[i] = v.Bytes()
+		case protoreflect.EnumKind:
+			if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+				values[i] = string(ev.Name())
+			} else {
+				values[i] = int64(v.Enum())
+			}
+		default:
+			values[i] = v.String()
+		}
+	}
+	return values, nil
+}
+
+// ScanRows implements RowProvider by streaming length-delimited messages.
+func (c *ProtobufConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != c.Config.TableName {
+		return nil
+	}
+	if c.reader == nil {
+		return fmt.Errorf("protobuf reader is not initialized")
+	}
+
+	for {
+		payload, err := c.readMessage()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+
+		msg := dynamicpb.NewMessage(c.descriptor)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			if yieldErr := yield(nil, fmt.Errorf("failed to decode message: %w", err)); yieldErr != nil {
+				return yieldErr
+			}
+			continue
+		}
+
+		row, err := c.rowFor(msg)
+		if err != nil {
+			if yieldErr := yield(nil, err); yieldErr != nil {
+				return yieldErr
+			}
+			continue
+		}
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+}
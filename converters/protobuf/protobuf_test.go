@@ -0,0 +1,149 @@
+package protobuf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// buildDescriptorSet compiles a small test schema to a FileDescriptorSet file.
+func buildDescriptorSet(t *testing.T) (string, protoreflect.MessageDescriptor) {
+	t.Helper()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("testpkg"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Person"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:   proto.String("name"),
+						Number: proto.Int32(1),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+					{
+						Name:   proto.String("age"),
+						Number: proto.Int32(2),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+					{
+						Name:   proto.String("tags"),
+						Number: proto.Int32(3),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					},
+				},
+			},
+		},
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}}
+	data, err := proto.Marshal(fds)
+	if err != nil {
+		t.Fatalf("failed to marshal descriptor set: %v", err)
+	}
+
+	descPath := filepath.Join(t.TempDir(), "test.desc")
+	if err := os.WriteFile(descPath, data, 0644); err != nil {
+		t.Fatalf("failed to write descriptor set: %v", err)
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("failed to build file descriptor: %v", err)
+	}
+	return descPath, fd.Messages().Get(0)
+}
+
+func TestProtobufConverter(t *testing.T) {
+	descPath, md := buildDescriptorSet(t)
+
+	// Encode two length-delimited messages
+	var payload bytes.Buffer
+	for _, person := range []struct {
+		name string
+		age  int64
+		tags []string
+	}{
+		{"alice", 30, []string{"a", "b"}},
+		{"bob", 40, nil},
+	} {
+		msg := dynamicpb.NewMessage(md)
+		msg.Set(md.Fields().ByName("name"), protoreflect.ValueOfString(person.name))
+		msg.Set(md.Fields().ByName("age"), protoreflect.ValueOfInt64(person.age))
+		if len(person.tags) > 0 {
+			list := msg.Mutable(md.Fields().ByName("tags")).List()
+			for _, tag := range person.tags {
+				list.Append(protoreflect.ValueOfString(tag))
+			}
+		}
+		encoded, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("failed to marshal message: %v", err)
+		}
+		payload.Write(protowire.AppendVarint(nil, uint64(len(encoded))))
+		payload.Write(encoded)
+	}
+
+	conv, err := NewProtobufConverterWithConfig(&payload, &common.ConversionConfig{
+		ProtoDescriptor: descPath,
+		ProtoMessage:    "testpkg.Person",
+	})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	headers := conv.GetHeaders(ProtoTB)
+	if len(headers) != 3 || headers[0] != "name" || headers[2] != "tags" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	colTypes := conv.GetColumnTypes(ProtoTB)
+	if colTypes[0] != "TEXT" || colTypes[1] != "INTEGER" || colTypes[2] != "TEXT" {
+		t.Errorf("unexpected column types: %v", colTypes)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), ProtoTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "alice" || rows[0][1] != int64(30) {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+	if rows[0][2] != `["a","b"]` {
+		t.Errorf("repeated field should be JSON-encoded: %v", rows[0][2])
+	}
+}
+
+func TestProtobufConverter_MissingDescriptor(t *testing.T) {
+	_, err := NewProtobufConverterWithConfig(bytes.NewReader(nil), &common.ConversionConfig{})
+	if err == nil {
+		t.Fatal("expected error without descriptor set")
+	}
+}
@@ -0,0 +1,298 @@
+package rar
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// Native RAR header parsing for listing. Both the legacy RAR4 and the RAR5
+// container formats store per-file metadata in plain block headers, so the
+// file list can be read without a decompressor. Archives created with
+// "encrypt file names" have encrypted headers and are rejected.
+
+var (
+	rar4Sig = []byte{0x52, 0x61, 0x72, 0x21, 0x1A, 0x07, 0x00}
+	rar5Sig = []byte{0x52, 0x61, 0x72, 0x21, 0x1A, 0x07, 0x01, 0x00}
+)
+
+func parseRarEntries(r io.Reader) ([]common.ArchiveEntry, error) {
+	br := bufio.NewReaderSize(r, 65536)
+
+	sig, err := br.Peek(8)
+	if err != nil && len(sig) < 7 {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	switch {
+	case len(sig) >= 8 && string(sig) == string(rar5Sig):
+		br.Discard(8)
+		return parseRar5(br)
+	case string(sig[:7]) == string(rar4Sig):
+		br.Discard(7)
+		return parseRar4(br)
+	default:
+		return nil, fmt.Errorf("not a RAR archive")
+	}
+}
+
+// parseRar4 walks the RAR 1.5-4.x block chain.
+func parseRar4(br *bufio.Reader) ([]common.ArchiveEntry, error) {
+	var entries []common.ArchiveEntry
+
+	for {
+		base := make([]byte, 7)
+		if _, err := io.ReadFull(br, base); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		headType := base[2]
+		headFlags := binary.LittleEndian.Uint16(base[3:5])
+		headSize := binary.LittleEndian.Uint16(base[5:7])
+		if headSize < 7 {
+			return nil, fmt.Errorf("invalid block size %d", headSize)
+		}
+
+		rest := make([]byte, headSize-7)
+		if _, err := io.ReadFull(br, rest); err != nil {
+			return nil, fmt.Errorf("truncated block header: %w", err)
+		}
+
+		// Blocks with the long-block flag carry a data area after the header
+		var dataSize uint64
+		if headFlags&0x8000 != 0 {
+			if len(rest) < 4 {
+				return nil, fmt.Errorf("long block header too short")
+			}
+			dataSize = uint64(binary.LittleEndian.Uint32(rest[0:4]))
+		}
+
+		switch headType {
+		case 0x73: // main archive header
+			if headFlags&0x0080 != 0 {
+				return nil, fmt.Errorf("archive headers are encrypted; listing requires the password")
+			}
+		case 0x74: // file header
+			if len(rest) < 25 {
+				return nil, fmt.Errorf("file header too short")
+			}
+			packSize := uint64(binary.LittleEndian.Uint32(rest[0:4]))
+			unpSize := uint64(binary.LittleEndian.Uint32(rest[4:8]))
+			fileCRC := binary.LittleEndian.Uint32(rest[9:13])
+			fileTime := binary.LittleEndian.Uint32(rest[13:17])
+			nameSize := int(binary.LittleEndian.Uint16(rest[19:21]))
+
+			nameStart := 25
+			if headFlags&0x0100 != 0 { // large file: 64-bit sizes
+				if len(rest) < 33 {
+					return nil, fmt.Errorf("large file header too short")
+				}
+				packSize |= uint64(binary.LittleEndian.Uint32(rest[25:29])) << 32
+				unpSize |= uint64(binary.LittleEndian.Uint32(rest[29:33])) << 32
+				nameStart = 33
+			}
+			if nameStart+nameSize > len(rest) {
+				return nil, fmt.Errorf("file name out of bounds")
+			}
+			name := string(rest[nameStart : nameStart+nameSize])
+
+			entries = append(entries, common.ArchiveEntry{
+				Name:             name,
+				Modified:         msdosTime(uint16(fileTime>>16), uint16(fileTime&0xFFFF)),
+				UncompressedSize: unpSize,
+				CompressedSize:   packSize,
+				CRC32:            fileCRC,
+				IsDir:            headFlags&0xE0 == 0xE0,
+			})
+			dataSize = packSize
+		case 0x7B: // archive end
+			return entries, nil
+		}
+
+		if dataSize > 0 {
+			if _, err := io.CopyN(io.Discard, br, int64(dataSize)); err != nil {
+				return nil, fmt.Errorf("truncated data area: %w", err)
+			}
+		}
+	}
+}
+
+// parseRar5 walks the RAR 5.0 block chain (vint-encoded headers).
+func parseRar5(br *bufio.Reader) ([]common.ArchiveEntry, error) {
+	var entries []common.ArchiveEntry
+
+	for {
+		// CRC32 of the header
+		if _, err := io.CopyN(io.Discard, br, 4); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		headerSize, err := readVint(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header size: %w", err)
+		}
+		header := make([]byte, headerSize)
+		if _, err := io.ReadFull(br, header); err != nil {
+			return nil, fmt.Errorf("truncated block header: %w", err)
+		}
+
+		pos := 0
+		headType, n := parseVint(header[pos:])
+		if n == 0 {
+			return nil, fmt.Errorf("invalid header type")
+		}
+		pos += n
+		headFlags, n := parseVint(header[pos:])
+		if n == 0 {
+			return nil, fmt.Errorf("invalid header flags")
+		}
+		pos += n
+
+		if headFlags&0x1 != 0 { // extra area size
+			if _, n = parseVint(header[pos:]); n == 0 {
+				return nil, fmt.Errorf("invalid extra size")
+			}
+			pos += n
+		}
+		var dataSize uint64
+		if headFlags&0x2 != 0 { // data area size
+			if dataSize, n = parseVint(header[pos:]); n == 0 {
+				return nil, fmt.Errorf("invalid data size")
+			}
+			pos += n
+		}
+
+		switch headType {
+		case 2: // file header
+			entry, err := parseRar5FileHeader(header[pos:], dataSize)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case 4: // archive encryption header
+			return nil, fmt.Errorf("archive headers are encrypted; listing requires the password")
+		case 5: // end of archive
+			return entries, nil
+		}
+
+		if dataSize > 0 {
+			if _, err := io.CopyN(io.Discard, br, int64(dataSize)); err != nil {
+				return nil, fmt.Errorf("truncated data area: %w", err)
+			}
+		}
+	}
+}
+
+func parseRar5FileHeader(b []byte, dataSize uint64) (common.ArchiveEntry, error) {
+	var entry common.ArchiveEntry
+	pos := 0
+
+	fileFlags, n := parseVint(b[pos:])
+	if n == 0 {
+		return entry, fmt.Errorf("invalid file flags")
+	}
+	pos += n
+	unpSize, n := parseVint(b[pos:])
+	if n == 0 {
+		return entry, fmt.Errorf("invalid unpacked size")
+	}
+	pos += n
+	if _, n = parseVint(b[pos:]); n == 0 { // attributes
+		return entry, fmt.Errorf("invalid attributes")
+	}
+	pos += n
+
+	if fileFlags&0x2 != 0 { // mtime (unix)
+		if pos+4 > len(b) {
+			return entry, fmt.Errorf("file header too short")
+		}
+		entry.Modified = time.Unix(int64(binary.LittleEndian.Uint32(b[pos:pos+4])), 0).UTC()
+		pos += 4
+	}
+	if fileFlags&0x4 != 0 { // data CRC32
+		if pos+4 > len(b) {
+			return entry, fmt.Errorf("file header too short")
+		}
+		entry.CRC32 = binary.LittleEndian.Uint32(b[pos : pos+4])
+		pos += 4
+	}
+
+	if _, n = parseVint(b[pos:]); n == 0 { // compression info
+		return entry, fmt.Errorf("invalid compression info")
+	}
+	pos += n
+	if _, n = parseVint(b[pos:]); n == 0 { // host OS
+		return entry, fmt.Errorf("invalid host OS")
+	}
+	pos += n
+	nameLen, n := parseVint(b[pos:])
+	if n == 0 {
+		return entry, fmt.Errorf("invalid name length")
+	}
+	pos += n
+	if pos+int(nameLen) > len(b) {
+		return entry, fmt.Errorf("file name out of bounds")
+	}
+
+	entry.Name = string(b[pos : pos+int(nameLen)])
+	entry.IsDir = fileFlags&0x1 != 0
+	if fileFlags&0x8 == 0 { // unpacked size is known
+		entry.UncompressedSize = unpSize
+	}
+	entry.CompressedSize = dataSize
+	return entry, nil
+}
+
+// readVint reads a RAR5 variable-length integer (7 bits per byte,
+// little-endian, high bit set on continuation bytes).
+func readVint(br *bufio.Reader) (uint64, error) {
+	var v uint64
+	for shift := 0; shift < 70; shift += 7 {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("vint too long")
+}
+
+// parseVint decodes a RAR5 variable-length integer from a buffer, returning
+// the value and the number of bytes consumed (0 on a malformed vint).
+func parseVint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		v |= uint64(b[i]&0x7F) << (7 * i)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// msdosTime converts an MS-DOS date/time pair to time.Time (RAR4 file time).
+func msdosTime(dd, dt uint16) time.Time {
+	return time.Date(
+		int(dd>>9)+1980,
+		time.Month((dd>>5)&0x0F),
+		int(dd&0x1F),
+		int(dt>>11),
+		int((dt>>5)&0x3F),
+		int((dt&0x1F)*2),
+		0,
+		time.UTC,
+	)
+}
@@ -0,0 +1,146 @@
+package rar
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+// buildRar5 assembles a minimal RAR5 archive with one stored file.
+func buildRar5(t *testing.T, name string, data []byte, mtime uint32, crc uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write([]byte{0x52, 0x61, 0x72, 0x21, 0x1A, 0x07, 0x01, 0x00})
+
+	writeBlock := func(header []byte) {
+		buf.Write([]byte{0, 0, 0, 0}) // header CRC (not validated by the lister)
+		buf.WriteByte(byte(len(header)))
+		buf.Write(header)
+	}
+
+	// Main archive header: type 1, no flags, archive flags 0
+	writeBlock([]byte{0x01, 0x00, 0x00})
+
+	// File header: type 2, flags 0x2 (data area)
+	var fh bytes.Buffer
+	fh.WriteByte(0x02)            // type
+	fh.WriteByte(0x02)            // header flags: data area present
+	fh.WriteByte(byte(len(data))) // data size
+	fh.WriteByte(0x06)            // file flags: mtime + crc
+	fh.WriteByte(byte(len(data))) // unpacked size
+	fh.WriteByte(0x00)            // attributes
+	binary.Write(&fh, binary.LittleEndian, mtime)
+	binary.Write(&fh, binary.LittleEndian, crc)
+	fh.WriteByte(0x00) // compression info
+	fh.WriteByte(0x01) // host OS
+	fh.WriteByte(byte(len(name)))
+	fh.WriteString(name)
+	writeBlock(fh.Bytes())
+	buf.Write(data)
+
+	// End of archive: type 5
+	writeBlock([]byte{0x05, 0x00})
+	return buf.Bytes()
+}
+
+// buildRar4 assembles a minimal RAR4 archive with one stored file.
+func buildRar4(t *testing.T, name string, data []byte, crc uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write([]byte{0x52, 0x61, 0x72, 0x21, 0x1A, 0x07, 0x00})
+
+	// Main header: 13 bytes (base 7 + reserved 6)
+	main := []byte{0, 0, 0x73, 0, 0, 13, 0, 0, 0, 0, 0, 0, 0}
+	buf.Write(main)
+
+	// File header
+	headSize := 7 + 25 + len(name)
+	var fh bytes.Buffer
+	binary.Write(&fh, binary.LittleEndian, uint16(0))      // CRC
+	fh.WriteByte(0x74)                                     // type
+	binary.Write(&fh, binary.LittleEndian, uint16(0x8000)) // flags: long block
+	binary.Write(&fh, binary.LittleEndian, uint16(headSize))
+	binary.Write(&fh, binary.LittleEndian, uint32(len(data))) // pack size
+	binary.Write(&fh, binary.LittleEndian, uint32(len(data))) // unpacked size
+	fh.WriteByte(0)                                           // host OS
+	binary.Write(&fh, binary.LittleEndian, crc)
+	binary.Write(&fh, binary.LittleEndian, uint32(0x58210000)) // DOS time
+	fh.WriteByte(29)                                           // unpack version
+	fh.WriteByte(0x30)                                         // method: store
+	binary.Write(&fh, binary.LittleEndian, uint16(len(name)))
+	binary.Write(&fh, binary.LittleEndian, uint32(0)) // attributes
+	fh.WriteString(name)
+	buf.Write(fh.Bytes())
+	buf.Write(data)
+
+	// End block
+	buf.Write([]byte{0, 0, 0x7B, 0, 0x40, 7, 0})
+	return buf.Bytes()
+}
+
+func scanFileList(t *testing.T, conv *RarConverter) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := conv.ScanRows(context.Background(), "file_list", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestRar5Listing(t *testing.T) {
+	archive := buildRar5(t, "docs/readme.txt", []byte("hello"), 1700000000, 0x3610a686)
+
+	conv, err := NewRarConverter(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows := scanFileList(t, conv)
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row[0] != "docs/readme.txt" {
+		t.Errorf("Unexpected name: %v", row[0])
+	}
+	if row[3] != uint64(5) || row[4] != uint64(5) {
+		t.Errorf("Unexpected sizes: %v / %v", row[3], row[4])
+	}
+	if row[5] != uint32(0x3610a686) {
+		t.Errorf("Unexpected CRC: %v", row[5])
+	}
+	if row[6] != "false" {
+		t.Errorf("Expected is_dir false, got %v", row[6])
+	}
+}
+
+func TestRar4Listing(t *testing.T) {
+	archive := buildRar4(t, "old.txt", []byte("hello"), 0x3610a686)
+
+	conv, err := NewRarConverter(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	rows := scanFileList(t, conv)
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	if rows[0][0] != "old.txt" {
+		t.Errorf("Unexpected name: %v", rows[0][0])
+	}
+	if rows[0][3] != uint64(5) {
+		t.Errorf("Unexpected uncompressed size: %v", rows[0][3])
+	}
+}
+
+func TestRarNotAnArchive(t *testing.T) {
+	if _, err := NewRarConverter(bytes.NewReader([]byte("plain text file"))); err == nil {
+		t.Error("Expected an error for a non-RAR input")
+	}
+}
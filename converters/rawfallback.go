@@ -0,0 +1,156 @@
+package converters
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// rawFallbackSuffix names the companion column holding original strings.
+const rawFallbackSuffix = "_raw"
+
+// wrapRawFallbackProvider gives every numeric column a companion
+// <col>_raw TEXT column when opts.RawFallback is set: values that parse
+// cleanly land in the numeric column with a NULL companion, and values
+// that don't land verbatim in the companion with a NULL numeric value, so
+// a stray "n/a" in a number column is preserved instead of polluting the
+// column with text.
+func wrapRawFallbackProvider(provider common.RowProvider, opts *ImportOptions, loc *common.Locale) common.RowProvider {
+	if opts == nil || !opts.RawFallback {
+		return provider
+	}
+	tables := make(map[string][]int)
+	for _, tableName := range provider.GetTableNames() {
+		var numeric []int
+		for i, colType := range provider.GetColumnTypes(tableName) {
+			switch strings.ToUpper(colType) {
+			case "INTEGER", "REAL":
+				numeric = append(numeric, i)
+			}
+		}
+		if len(numeric) > 0 {
+			tables[tableName] = numeric
+		}
+	}
+	if len(tables) == 0 {
+		return provider
+	}
+	return &rawFallbackProvider{inner: provider, tables: tables, loc: loc}
+}
+
+// rawFallbackProvider interleaves a TEXT companion after each numeric
+// column and routes every value to exactly one of the pair at scan time.
+type rawFallbackProvider struct {
+	inner  common.RowProvider
+	tables map[string][]int // table -> numeric column positions in the source
+	loc    *common.Locale
+}
+
+func (p *rawFallbackProvider) GetTableNames() []string {
+	return p.inner.GetTableNames()
+}
+
+// expand interleaves companion entries after the numeric positions of one
+// table, filling them with fill.
+func expandWithCompanions[T any](values []T, numeric []int, fill func(i int) T) []T {
+	isNumeric := make(map[int]bool, len(numeric))
+	for _, i := range numeric {
+		isNumeric[i] = true
+	}
+	out := make([]T, 0, len(values)+len(numeric))
+	for i, v := range values {
+		out = append(out, v)
+		if isNumeric[i] {
+			out = append(out, fill(i))
+		}
+	}
+	return out
+}
+
+func (p *rawFallbackProvider) GetHeaders(tableName string) []string {
+	headers := p.inner.GetHeaders(tableName)
+	numeric, ok := p.tables[tableName]
+	if !ok {
+		return headers
+	}
+	return expandWithCompanions(headers, numeric, func(i int) string {
+		return headers[i] + rawFallbackSuffix
+	})
+}
+
+func (p *rawFallbackProvider) GetColumnTypes(tableName string) []string {
+	types := p.inner.GetColumnTypes(tableName)
+	numeric, ok := p.tables[tableName]
+	if !ok {
+		return types
+	}
+	return expandWithCompanions(types, numeric, func(int) string { return "TEXT" })
+}
+
+func (p *rawFallbackProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	numeric, ok := p.tables[tableName]
+	if !ok {
+		return p.inner.ScanRows(ctx, tableName, yield)
+	}
+	types := p.inner.GetColumnTypes(tableName)
+	isNumeric := make(map[int]bool, len(numeric))
+	for _, i := range numeric {
+		isNumeric[i] = true
+	}
+	return p.inner.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return yield(nil, rowErr)
+		}
+		out := make([]interface{}, 0, len(row)+len(numeric))
+		for i, value := range row {
+			if !isNumeric[i] {
+				out = append(out, value)
+				continue
+			}
+			if s, isString := value.(string); isString {
+				trimmed := strings.TrimSpace(s)
+				if trimmed == "" {
+					out = append(out, value, nil)
+					continue
+				}
+				if coerced, ok := coerceNumeric(trimmed, types[i], p.loc); ok {
+					out = append(out, coerced, nil)
+					continue
+				}
+				// Coercion failed: preserve the text in the companion
+				out = append(out, nil, s)
+				continue
+			}
+			out = append(out, value, nil)
+		}
+		// Companions for numeric columns beyond the row's own length are
+		// padded by the engine like any other missing column
+		return yield(out, nil)
+	})
+}
+
+// coerceNumeric parses a trimmed string into its numeric column type,
+// honouring the locale's conventions when one is set (a nil locale means
+// plain strconv parsing).
+func coerceNumeric(trimmed, colType string, loc *common.Locale) (interface{}, bool) {
+	switch strings.ToUpper(colType) {
+	case "INTEGER":
+		if n, err := loc.ParseInt(trimmed); err == nil {
+			return n, true
+		}
+	case "REAL":
+		if f, err := loc.ParseFloat(trimmed); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func (p *rawFallbackProvider) Close() error {
+	if closer, ok := p.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -1,21 +1,53 @@
+// Package converters hosts the import engine and the driver registry.
+//
+// Every format lives in its own registered subpackage (converters/csv,
+// converters/html, ...); there are no parallel implementations in this
+// package. Embedders import converters/all (or the individual subpackages
+// they need) for registration side effects and use Open as the single
+// entry point.
 package converters
 
 import (
 	"fmt"
 	"io"
-	"github.com/darianmavgo/mksqlite/converters/common"
+	"io/fs"
 	"sort"
+	"strings"
 	"sync"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
 )
 
+// Capabilities describes what inputs a driver can handle, so callers can
+// dispatch by extension or MIME type instead of hardcoding a switch.
+type Capabilities struct {
+	Extensions []string // File extensions handled, with leading dot (".csv")
+	MIMETypes  []string // MIME types handled ("text/csv")
+	Streaming  bool     // Driver supports streaming SQL export
+}
+
+// DriverInfo pairs a registered driver name with its capabilities.
+type DriverInfo struct {
+	Name         string
+	Capabilities Capabilities
+}
+
+type registeredDriver struct {
+	driver common.Driver
+	caps   Capabilities
+}
+
 var (
 	driversMu sync.RWMutex
-	drivers   = make(map[string]common.Driver)
+	drivers   = make(map[string]registeredDriver)
+	byExt     = make(map[string]string) // ".csv" -> "csv"
+	byMIME    = make(map[string]string) // "text/csv" -> "csv"
 )
 
-// Register makes a converter driver available by the provided name.
-// If Register is called twice with the same name or if driver is nil, it panics.
-func Register(name string, driver common.Driver) {
+// Register makes a converter driver available by the provided name and
+// records its capabilities for dispatch. If Register is called twice with
+// the same name, extension or MIME type, or if driver is nil, it panics.
+func Register(name string, driver common.Driver, caps Capabilities) {
 	driversMu.Lock()
 	defer driversMu.Unlock()
 	if driver == nil {
@@ -24,18 +56,85 @@ func Register(name string, driver common.Driver) {
 	if _, dup := drivers[name]; dup {
 		panic("converters: Register called twice for driver " + name)
 	}
-	drivers[name] = driver
+	for _, ext := range caps.Extensions {
+		ext = strings.ToLower(ext)
+		if owner, dup := byExt[ext]; dup {
+			panic("converters: extension " + ext + " already registered by driver " + owner)
+		}
+		byExt[ext] = name
+	}
+	for _, mime := range caps.MIMETypes {
+		mime = strings.ToLower(mime)
+		if owner, dup := byMIME[mime]; dup {
+			panic("converters: MIME type " + mime + " already registered by driver " + owner)
+		}
+		byMIME[mime] = name
+	}
+	drivers[name] = registeredDriver{driver: driver, caps: caps}
 }
 
 // Open opens a converter by driver name and source reader.
 func Open(driverName string, source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
 	driversMu.RLock()
-	driver, ok := drivers[driverName]
+	reg, ok := drivers[driverName]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("converters: unknown driver %q (forgotten import?)", driverName)
+	}
+	return reg.driver.Open(source, config)
+}
+
+// OpenFS opens a converter by driver name over an fs.FS tree, so embedded
+// or in-memory file trees (embed.FS, fstest.MapFS, a zip archive) can be
+// converted without touching the real filesystem. Drivers advertise FS
+// support by implementing common.FSDriver; for all others an error is
+// returned.
+func OpenFS(driverName string, fsys fs.FS, config *common.ConversionConfig) (common.RowProvider, error) {
+	driversMu.RLock()
+	reg, ok := drivers[driverName]
 	driversMu.RUnlock()
 	if !ok {
 		return nil, fmt.Errorf("converters: unknown driver %q (forgotten import?)", driverName)
 	}
-	return driver.Open(source, config)
+	fsDriver, ok := reg.driver.(common.FSDriver)
+	if !ok {
+		return nil, fmt.Errorf("converters: driver %q does not support fs.FS input", driverName)
+	}
+	return fsDriver.OpenFS(fsys, config)
+}
+
+// List returns the registered drivers and their capabilities, sorted by name.
+func List() []DriverInfo {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	list := make([]DriverInfo, 0, len(drivers))
+	for name, reg := range drivers {
+		list = append(list, DriverInfo{Name: name, Capabilities: reg.caps})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ForExtension returns the driver registered for a file extension
+// (with leading dot, case-insensitive).
+func ForExtension(ext string) (string, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	name, ok := byExt[strings.ToLower(ext)]
+	return name, ok
+}
+
+// ForMIMEType returns the driver registered for a MIME type; any
+// parameters ("; charset=utf-8") are ignored.
+func ForMIMEType(mimeType string) (string, bool) {
+	if idx := strings.IndexByte(mimeType, ';'); idx != -1 {
+		mimeType = mimeType[:idx]
+	}
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	name, ok := byMIME[mimeType]
+	return name, ok
 }
 
 // Drivers returns a sorted list of the names of the registered drivers.
@@ -0,0 +1,55 @@
+package converters
+
+import (
+	"io"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+type fakeDriver struct{}
+
+func (d *fakeDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return nil, nil
+}
+
+func TestRegistryMetadata(t *testing.T) {
+	Register("regtest", &fakeDriver{}, Capabilities{
+		Extensions: []string{".regtest"},
+		MIMETypes:  []string{"application/x-regtest"},
+		Streaming:  true,
+	})
+
+	if name, ok := ForExtension(".REGTEST"); !ok || name != "regtest" {
+		t.Errorf("ForExtension lookup failed: %s, %v", name, ok)
+	}
+	if _, ok := ForExtension(".nosuch"); ok {
+		t.Error("ForExtension should miss for unknown extension")
+	}
+
+	if name, ok := ForMIMEType("application/x-regtest; charset=utf-8"); !ok || name != "regtest" {
+		t.Errorf("ForMIMEType lookup failed: %s, %v", name, ok)
+	}
+
+	found := false
+	for _, info := range List() {
+		if info.Name == "regtest" {
+			found = true
+			if !info.Capabilities.Streaming || len(info.Capabilities.Extensions) != 1 {
+				t.Errorf("unexpected capabilities: %+v", info.Capabilities)
+			}
+		}
+	}
+	if !found {
+		t.Error("registered driver missing from List()")
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate extension")
+		}
+	}()
+	Register("regtest2", &fakeDriver{}, Capabilities{Extensions: []string{".regtest"}})
+}
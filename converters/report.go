@@ -0,0 +1,29 @@
+package converters
+
+// ColumnReport records one column of an imported table as it was created.
+type ColumnReport struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TableReport summarizes one table of an import.
+type TableReport struct {
+	Name    string         `json:"name"`
+	Rows    int            `json:"rows"`
+	Errors  int            `json:"errors"`
+	Columns []ColumnReport `json:"columns"`
+}
+
+// ImportReport is the machine-readable conversion summary filled in when
+// ImportOptions.Report is set, so CI jobs can assert a conversion succeeded
+// without opening the produced database.
+type ImportReport struct {
+	Source          string        `json:"source,omitempty"`
+	Driver          string        `json:"driver,omitempty"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	BytesRead       int64         `json:"bytes_read,omitempty"`
+	Rows            int           `json:"rows"`
+	Errors          int           `json:"errors"`
+	RowsPerSecond   float64       `json:"rows_per_second"`
+	Tables          []TableReport `json:"tables"`
+}
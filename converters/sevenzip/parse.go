@@ -0,0 +1,586 @@
+package sevenzip
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf16"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// Native 7z header parsing for listing. The metadata block at the end of the
+// archive (names, sizes, timestamps, CRCs) is parsed without a decompressor,
+// which covers archives written without header compression. Archives with
+// LZMA-compressed or encrypted headers are rejected with a clear error.
+
+var sevenZipSig = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+
+// Property IDs from the 7z format specification
+const (
+	idEnd               = 0x00
+	idHeader            = 0x01
+	idArchiveProperties = 0x02
+	idMainStreamsInfo   = 0x04
+	idFilesInfo         = 0x05
+	idPackInfo          = 0x06
+	idUnpackInfo        = 0x07
+	idSubStreamsInfo    = 0x08
+	idSize              = 0x09
+	idCRC               = 0x0A
+	idFolder            = 0x0B
+	idCodersUnpackSize  = 0x0C
+	idNumUnpackStream   = 0x0D
+	idEmptyStream       = 0x0E
+	idEmptyFile         = 0x0F
+	idName              = 0x11
+	idMTime             = 0x14
+	idEncodedHeader     = 0x17
+)
+
+func parseSevenZipEntries(r io.Reader) ([]common.ArchiveEntry, error) {
+	br := bufio.NewReaderSize(r, 65536)
+
+	sig := make([]byte, 32)
+	if _, err := io.ReadFull(br, sig); err != nil {
+		return nil, fmt.Errorf("failed to read signature header: %w", err)
+	}
+	if string(sig[:6]) != string(sevenZipSig) {
+		return nil, fmt.Errorf("not a 7z archive")
+	}
+
+	nextHeaderOffset := binary.LittleEndian.Uint64(sig[12:20])
+	nextHeaderSize := binary.LittleEndian.Uint64(sig[20:28])
+	if nextHeaderSize == 0 {
+		return nil, nil // empty archive
+	}
+
+	// The metadata header sits after the packed streams
+	if _, err := io.CopyN(io.Discard, br, int64(nextHeaderOffset)); err != nil {
+		return nil, fmt.Errorf("truncated archive: %w", err)
+	}
+	header := make([]byte, nextHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("truncated header: %w", err)
+	}
+
+	hr := &hdrReader{data: header}
+	switch hr.byte() {
+	case idHeader:
+		return parseHeader(hr)
+	case idEncodedHeader:
+		return nil, fmt.Errorf("7z header is compressed or encrypted; recreate the archive without header compression (-mhc=off) to list it")
+	default:
+		return nil, fmt.Errorf("unrecognized 7z header")
+	}
+}
+
+// hdrReader is a cursor over the header bytes with a sticky error, so the
+// parsing code reads fields without checking bounds at every step.
+type hdrReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *hdrReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos >= len(r.data) {
+		r.err = fmt.Errorf("truncated 7z header")
+		return 0
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *hdrReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.pos+n > len(r.data) {
+		r.err = fmt.Errorf("truncated 7z header")
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *hdrReader) skip(n int) {
+	r.bytes(n)
+}
+
+// number reads the 7z variable-length integer encoding: the high bits of the
+// first byte say how many extra little-endian bytes follow.
+func (r *hdrReader) number() uint64 {
+	first := r.byte()
+	mask := byte(0x80)
+	var value uint64
+	for i := 0; i < 8; i++ {
+		if first&mask == 0 {
+			value |= uint64(first&(mask-1)) << (8 * i)
+			return value
+		}
+		value |= uint64(r.byte()) << (8 * i)
+		mask >>= 1
+	}
+	return value
+}
+
+// bitVector reads n bits packed MSB-first.
+func (r *hdrReader) bitVector(n int) []bool {
+	bits := make([]bool, n)
+	var b byte
+	var mask byte
+	for i := 0; i < n; i++ {
+		if mask == 0 {
+			b = r.byte()
+			mask = 0x80
+		}
+		bits[i] = b&mask != 0
+		mask >>= 1
+	}
+	return bits
+}
+
+// boolVector reads an all-defined byte followed by an optional bit vector.
+func (r *hdrReader) boolVector(n int) []bool {
+	if r.byte() != 0 {
+		bits := make([]bool, n)
+		for i := range bits {
+			bits[i] = true
+		}
+		return bits
+	}
+	return r.bitVector(n)
+}
+
+// digests reads a kCRC structure for n streams.
+func (r *hdrReader) digests(n int) (defined []bool, crcs []uint32) {
+	defined = r.boolVector(n)
+	crcs = make([]uint32, n)
+	for i := 0; i < n; i++ {
+		if defined[i] {
+			crcs[i] = binary.LittleEndian.Uint32(r.bytes(4))
+		}
+	}
+	return defined, crcs
+}
+
+// streamsInfo carries the per-substream metadata needed to size files.
+type streamsInfo struct {
+	packSizes    []uint64
+	folderSizes  []uint64 // main output size per folder
+	folderCRCDef []bool
+	folderCRCs   []uint32
+	numSubs      []uint64 // substreams per folder (default 1)
+	subSizes     []uint64
+	subCRCDef    []bool
+	subCRCs      []uint32
+}
+
+func parseHeader(r *hdrReader) ([]common.ArchiveEntry, error) {
+	var streams streamsInfo
+	var entries []common.ArchiveEntry
+
+	for {
+		id := r.number()
+		if r.err != nil {
+			return nil, r.err
+		}
+		switch id {
+		case idEnd:
+			return entries, r.err
+		case idArchiveProperties:
+			for {
+				propID := r.number()
+				if propID == idEnd {
+					break
+				}
+				r.skip(int(r.number()))
+				if r.err != nil {
+					return nil, r.err
+				}
+			}
+		case idMainStreamsInfo:
+			if err := parseStreamsInfo(r, &streams); err != nil {
+				return nil, err
+			}
+		case idFilesInfo:
+			var err error
+			entries, err = parseFilesInfo(r, &streams)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected 7z property 0x%02x", id)
+		}
+	}
+}
+
+func parseStreamsInfo(r *hdrReader, streams *streamsInfo) error {
+	for {
+		switch id := r.number(); id {
+		case idEnd:
+			streams.finalize()
+			return r.err
+		case idPackInfo:
+			r.number() // pack position
+			numPack := int(r.number())
+			for {
+				sub := r.number()
+				if sub == idEnd {
+					break
+				}
+				switch sub {
+				case idSize:
+					streams.packSizes = make([]uint64, numPack)
+					for i := range streams.packSizes {
+						streams.packSizes[i] = r.number()
+					}
+				case idCRC:
+					r.digests(numPack)
+				default:
+					return fmt.Errorf("unexpected pack info property 0x%02x", sub)
+				}
+				if r.err != nil {
+					return r.err
+				}
+			}
+		case idUnpackInfo:
+			if err := parseUnpackInfo(r, streams); err != nil {
+				return err
+			}
+		case idSubStreamsInfo:
+			if err := parseSubStreamsInfo(r, streams); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected streams info property 0x%02x", id)
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+}
+
+func parseUnpackInfo(r *hdrReader, streams *streamsInfo) error {
+	if r.number() != idFolder {
+		return fmt.Errorf("expected folder property")
+	}
+	numFolders := int(r.number())
+	if r.byte() != 0 {
+		return fmt.Errorf("external 7z folder metadata is not supported")
+	}
+
+	// For each folder, find how many output streams its coders produce and
+	// which one is the final (unbound) output.
+	numOuts := make([]int, numFolders)
+	mainOut := make([]int, numFolders)
+	for f := 0; f < numFolders; f++ {
+		numCoders := int(r.number())
+		totalIn, totalOut := 0, 0
+		for c := 0; c < numCoders; c++ {
+			flag := r.byte()
+			r.skip(int(flag & 0x0F)) // codec ID
+			numIn, numOut := 1, 1
+			if flag&0x10 != 0 {
+				numIn = int(r.number())
+				numOut = int(r.number())
+			}
+			if flag&0x20 != 0 {
+				r.skip(int(r.number())) // codec properties
+			}
+			totalIn += numIn
+			totalOut += numOut
+		}
+
+		bound := make([]bool, totalOut)
+		numBindPairs := totalOut - 1
+		for i := 0; i < numBindPairs; i++ {
+			r.number() // in index
+			out := int(r.number())
+			if out >= 0 && out < totalOut {
+				bound[out] = true
+			}
+		}
+		if numPacked := totalIn - numBindPairs; numPacked > 1 {
+			for i := 0; i < numPacked; i++ {
+				r.number() // pack stream index
+			}
+		}
+
+		numOuts[f] = totalOut
+		mainOut[f] = totalOut - 1
+		for i, b := range bound {
+			if !b {
+				mainOut[f] = i
+				break
+			}
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+
+	if r.number() != idCodersUnpackSize {
+		return fmt.Errorf("expected coders unpack size property")
+	}
+	streams.folderSizes = make([]uint64, numFolders)
+	for f := 0; f < numFolders; f++ {
+		for i := 0; i < numOuts[f]; i++ {
+			size := r.number()
+			if i == mainOut[f] {
+				streams.folderSizes[f] = size
+			}
+		}
+	}
+
+	for {
+		switch sub := r.number(); sub {
+		case idEnd:
+			return r.err
+		case idCRC:
+			streams.folderCRCDef, streams.folderCRCs = r.digests(numFolders)
+		default:
+			return fmt.Errorf("unexpected unpack info property 0x%02x", sub)
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+}
+
+func parseSubStreamsInfo(r *hdrReader, streams *streamsInfo) error {
+	numFolders := len(streams.folderSizes)
+	for {
+		switch sub := r.number(); sub {
+		case idEnd:
+			return r.err
+		case idNumUnpackStream:
+			streams.numSubs = make([]uint64, numFolders)
+			for f := range streams.numSubs {
+				streams.numSubs[f] = r.number()
+			}
+		case idSize:
+			// Sizes for all but the last substream of each folder; the last
+			// one is whatever remains of the folder's unpacked size.
+			streams.ensureNumSubs()
+			streams.subSizes = nil
+			for f := 0; f < numFolders; f++ {
+				var sum uint64
+				for i := uint64(1); i < streams.numSubs[f]; i++ {
+					size := r.number()
+					streams.subSizes = append(streams.subSizes, size)
+					sum += size
+				}
+				if streams.numSubs[f] > 0 {
+					streams.subSizes = append(streams.subSizes, streams.folderSizes[f]-sum)
+				}
+			}
+		case idCRC:
+			// Digests are only stored for substreams whose CRC isn't already
+			// known from the folder digest (single-substream folders).
+			streams.ensureNumSubs()
+			total := 0
+			unknown := 0
+			for f, n := range streams.numSubs {
+				total += int(n)
+				if n == 1 && f < len(streams.folderCRCDef) && streams.folderCRCDef[f] {
+					continue
+				}
+				unknown += int(n)
+			}
+			defined, crcs := r.digests(unknown)
+			streams.subCRCDef = make([]bool, total)
+			streams.subCRCs = make([]uint32, total)
+			idx, uIdx := 0, 0
+			for f, n := range streams.numSubs {
+				if n == 1 && f < len(streams.folderCRCDef) && streams.folderCRCDef[f] {
+					streams.subCRCDef[idx] = true
+					streams.subCRCs[idx] = streams.folderCRCs[f]
+					idx++
+					continue
+				}
+				for i := uint64(0); i < n; i++ {
+					streams.subCRCDef[idx] = defined[uIdx]
+					streams.subCRCs[idx] = crcs[uIdx]
+					idx++
+					uIdx++
+				}
+			}
+		default:
+			return fmt.Errorf("unexpected substreams property 0x%02x", sub)
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+}
+
+// ensureNumSubs defaults to one substream per folder.
+func (s *streamsInfo) ensureNumSubs() {
+	if s.numSubs == nil {
+		s.numSubs = make([]uint64, len(s.folderSizes))
+		for i := range s.numSubs {
+			s.numSubs[i] = 1
+		}
+	}
+}
+
+// finalize fills in defaults once all streams info has been read.
+func (s *streamsInfo) finalize() {
+	s.ensureNumSubs()
+	if s.subSizes == nil {
+		for f, n := range s.numSubs {
+			if n == 1 {
+				s.subSizes = append(s.subSizes, s.folderSizes[f])
+			}
+		}
+	}
+	if s.subCRCDef == nil && s.folderCRCDef != nil {
+		s.subCRCDef = make([]bool, len(s.subSizes))
+		s.subCRCs = make([]uint32, len(s.subSizes))
+		idx := 0
+		for f, n := range s.numSubs {
+			if n == 1 && idx < len(s.subCRCDef) {
+				s.subCRCDef[idx] = s.folderCRCDef[f]
+				s.subCRCs[idx] = s.folderCRCs[f]
+			}
+			idx += int(n)
+		}
+	}
+}
+
+// subCompressedSizes attributes pack sizes to substreams. Per-file compressed
+// size is only meaningful when a folder holds a single file and maps to a
+// single pack stream (i.e. a non-solid archive); solid folders report 0.
+func (s *streamsInfo) subCompressedSizes() []uint64 {
+	sizes := make([]uint64, len(s.subSizes))
+	if len(s.packSizes) != len(s.folderSizes) {
+		return sizes
+	}
+	idx := 0
+	for f, n := range s.numSubs {
+		if n == 1 && idx < len(sizes) {
+			sizes[idx] = s.packSizes[f]
+		}
+		idx += int(n)
+	}
+	return sizes
+}
+
+func parseFilesInfo(r *hdrReader, streams *streamsInfo) ([]common.ArchiveEntry, error) {
+	numFiles := int(r.number())
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var emptyStream, emptyFile []bool
+	names := make([]string, 0, numFiles)
+	mtimes := make([]time.Time, numFiles)
+
+	for {
+		propID := r.number()
+		if propID == idEnd {
+			break
+		}
+		size := int(r.number())
+		if r.err != nil {
+			return nil, r.err
+		}
+		end := r.pos + size
+
+		switch propID {
+		case idEmptyStream:
+			emptyStream = r.bitVector(numFiles)
+		case idEmptyFile:
+			numEmpty := 0
+			for _, e := range emptyStream {
+				if e {
+					numEmpty++
+				}
+			}
+			emptyFile = r.bitVector(numEmpty)
+		case idName:
+			if r.byte() != 0 {
+				return nil, fmt.Errorf("external 7z file names are not supported")
+			}
+			names = decodeNames(r.bytes(end - r.pos))
+		case idMTime:
+			defined := r.boolVector(numFiles)
+			if r.byte() != 0 {
+				return nil, fmt.Errorf("external 7z timestamps are not supported")
+			}
+			for i := 0; i < numFiles; i++ {
+				if defined[i] {
+					mtimes[i] = filetimeToTime(binary.LittleEndian.Uint64(r.bytes(8)))
+				}
+			}
+		}
+
+		// Properties are size-prefixed, so unknown (or partially read) ones
+		// are skipped wholesale.
+		if r.err == nil && r.pos < end {
+			r.skip(end - r.pos)
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+	}
+
+	if len(names) != numFiles {
+		return nil, fmt.Errorf("7z header names %d files, expected %d", len(names), numFiles)
+	}
+
+	subCompressed := streams.subCompressedSizes()
+	entries := make([]common.ArchiveEntry, 0, numFiles)
+	subIdx := 0
+	emptyIdx := 0
+	for i := 0; i < numFiles; i++ {
+		entry := common.ArchiveEntry{Name: names[i], Modified: mtimes[i]}
+		if len(emptyStream) == numFiles && emptyStream[i] {
+			// No content stream: a directory, unless flagged as an empty file
+			entry.IsDir = emptyIdx >= len(emptyFile) || !emptyFile[emptyIdx]
+			emptyIdx++
+		} else {
+			if subIdx < len(streams.subSizes) {
+				entry.UncompressedSize = streams.subSizes[subIdx]
+				entry.CompressedSize = subCompressed[subIdx]
+			}
+			if subIdx < len(streams.subCRCDef) && streams.subCRCDef[subIdx] {
+				entry.CRC32 = streams.subCRCs[subIdx]
+			}
+			subIdx++
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// decodeNames splits a blob of NUL-terminated UTF-16LE strings.
+func decodeNames(b []byte) []string {
+	var names []string
+	var units []uint16
+	for i := 0; i+1 < len(b); i += 2 {
+		u := binary.LittleEndian.Uint16(b[i : i+2])
+		if u == 0 {
+			names = append(names, string(utf16.Decode(units)))
+			units = units[:0]
+			continue
+		}
+		units = append(units, u)
+	}
+	return names
+}
+
+// filetimeToTime converts a Windows FILETIME (100ns ticks since 1601) to UTC.
+func filetimeToTime(ft uint64) time.Time {
+	const epochDelta = 116444736000000000 // 1601 -> 1970 in 100ns ticks
+	return time.Unix(0, (int64(ft)-epochDelta)*100).UTC()
+}
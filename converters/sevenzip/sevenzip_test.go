@@ -0,0 +1,136 @@
+package sevenzip
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// buildSevenZip assembles a minimal 7z archive with one stored ("copy"
+// codec) file and an uncompressed metadata header.
+func buildSevenZip(t *testing.T, name string, data []byte, mtime time.Time, crc uint32) []byte {
+	t.Helper()
+
+	var hdr bytes.Buffer
+	hdr.WriteByte(idHeader)
+
+	// MainStreamsInfo
+	hdr.WriteByte(idMainStreamsInfo)
+	hdr.WriteByte(idPackInfo)
+	hdr.WriteByte(0x00) // pack position
+	hdr.WriteByte(0x01) // one pack stream
+	hdr.WriteByte(idSize)
+	hdr.WriteByte(byte(len(data))) // pack size
+	hdr.WriteByte(idEnd)
+	hdr.WriteByte(idUnpackInfo)
+	hdr.WriteByte(idFolder)
+	hdr.WriteByte(0x01) // one folder
+	hdr.WriteByte(0x00) // not external
+	hdr.WriteByte(0x01) // one coder
+	hdr.WriteByte(0x01) // coder flag: 1-byte codec ID
+	hdr.WriteByte(0x00) // codec: copy
+	hdr.WriteByte(idCodersUnpackSize)
+	hdr.WriteByte(byte(len(data))) // folder unpack size
+	hdr.WriteByte(idCRC)
+	hdr.WriteByte(0x01) // all defined
+	binary.Write(&hdr, binary.LittleEndian, crc)
+	hdr.WriteByte(idEnd)
+	hdr.WriteByte(idEnd)
+
+	// FilesInfo
+	hdr.WriteByte(idFilesInfo)
+	hdr.WriteByte(0x01) // one file
+
+	nameUnits := utf16.Encode([]rune(name))
+	hdr.WriteByte(idName)
+	hdr.WriteByte(byte(1 + len(nameUnits)*2 + 2)) // property size
+	hdr.WriteByte(0x00)                           // not external
+	for _, u := range nameUnits {
+		binary.Write(&hdr, binary.LittleEndian, u)
+	}
+	hdr.Write([]byte{0x00, 0x00}) // terminator
+
+	filetime := uint64(mtime.UnixNano()/100) + 116444736000000000
+	hdr.WriteByte(idMTime)
+	hdr.WriteByte(0x0A) // property size: defined + external + 8 bytes
+	hdr.WriteByte(0x01) // all defined
+	hdr.WriteByte(0x00) // not external
+	binary.Write(&hdr, binary.LittleEndian, filetime)
+
+	hdr.WriteByte(idEnd)
+	hdr.WriteByte(idEnd)
+
+	var buf bytes.Buffer
+	buf.Write(sevenZipSig)
+	buf.Write([]byte{0x00, 0x04})                              // version
+	buf.Write([]byte{0, 0, 0, 0})                              // start header CRC (not validated)
+	binary.Write(&buf, binary.LittleEndian, uint64(len(data))) // next header offset
+	binary.Write(&buf, binary.LittleEndian, uint64(hdr.Len())) // next header size
+	buf.Write([]byte{0, 0, 0, 0})                              // next header CRC
+	buf.Write(data)
+	buf.Write(hdr.Bytes())
+	return buf.Bytes()
+}
+
+func TestSevenZipListing(t *testing.T) {
+	mtime := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	archive := buildSevenZip(t, "notes/hello.txt", []byte("hello"), mtime, 0x3610a686)
+
+	conv, err := NewSevenZipConverter(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "file_list", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+
+	row := rows[0]
+	if row[0] != "notes/hello.txt" {
+		t.Errorf("Unexpected name: %v", row[0])
+	}
+	if row[2] != mtime.Format(time.RFC3339) {
+		t.Errorf("Unexpected mtime: %v", row[2])
+	}
+	if row[3] != uint64(5) || row[4] != uint64(5) {
+		t.Errorf("Unexpected sizes: %v / %v", row[3], row[4])
+	}
+	if row[5] != uint32(0x3610a686) {
+		t.Errorf("Unexpected CRC: %v", row[5])
+	}
+	if row[6] != "false" {
+		t.Errorf("Expected is_dir false, got %v", row[6])
+	}
+}
+
+func TestSevenZipEncodedHeaderRejected(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(sevenZipSig)
+	buf.Write([]byte{0x00, 0x04})
+	buf.Write([]byte{0, 0, 0, 0})
+	binary.Write(&buf, binary.LittleEndian, uint64(0)) // next header offset
+	binary.Write(&buf, binary.LittleEndian, uint64(1)) // next header size
+	buf.Write([]byte{0, 0, 0, 0})
+	buf.WriteByte(idEncodedHeader)
+
+	if _, err := NewSevenZipConverter(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Error("Expected an error for a compressed header")
+	}
+}
+
+func TestSevenZipNotAnArchive(t *testing.T) {
+	if _, err := NewSevenZipConverter(bytes.NewReader(make([]byte, 64))); err == nil {
+		t.Error("Expected an error for a non-7z input")
+	}
+}
@@ -0,0 +1,362 @@
+package converters
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// checkpointTable records the last committed source position per table, in
+// the same transaction as the rows it covers, so a consumer that crashes
+// and restarts can seek to exactly where the data ends.
+const checkpointTable = "_mksqlite_checkpoint"
+
+// DefaultFlushInterval is how often an idle-ish Sink commits pending rows
+// when SinkOptions.FlushInterval is zero.
+const DefaultFlushInterval = 5 * time.Second
+
+// SinkOptions configures OpenSink.
+type SinkOptions struct {
+	TableName     string        // Target table, default "tb0"
+	Headers       []string      // Column names, required
+	ColumnTypes   []string      // SQLite types parallel to Headers; empty means all TEXT
+	BatchSize     int           // Rows per commit; 0 uses the package BatchSize
+	FlushInterval time.Duration // Commit pending rows at least this often; 0 uses DefaultFlushInterval
+}
+
+// Sink appends rows to a SQLite table as they arrive from a message
+// stream, committing in batches and checkpointing the source position
+// (a Kafka "partition:offset", an SQS receipt, ...) atomically with the
+// data. mksqlite deliberately bundles no queue client — the consuming
+// application brings its own Kafka/NATS/SQS consumer, calls Append (or
+// AppendJSON) per message, and seeks to Checkpoint() on restart:
+//
+//	sink, _ := converters.OpenSink("events.db", &converters.SinkOptions{Headers: ...})
+//	pos, _ := sink.Checkpoint() // resume point, "" on first run
+//	for msg := range consume(pos) {
+//		sink.AppendJSON(msg.Value, msg.Offset)
+//	}
+//	sink.Close()
+//
+// Unlike the bulk importer, the sink runs with WAL journaling and normal
+// synchronous mode: a long-running consumer must survive crashes, so the
+// speed-over-safety pragmas of one-shot conversion don't apply.
+type Sink struct {
+	db        *sql.DB
+	tableName string
+	headers   []string
+	stmt      *sql.Stmt // Prepared on db, rebound into each transaction
+	batchSize int
+
+	mu         sync.Mutex
+	tx         *sql.Tx
+	txStmt     *sql.Stmt
+	pending    int
+	checkpoint string // Latest appended position, written on commit
+	closed     bool
+
+	stopFlusher chan struct{}
+}
+
+// OpenSink opens (or creates) outputPath and prepares the target table
+// for streaming appends. An existing table is appended to, so restarted
+// consumers keep accumulating into the same database.
+func OpenSink(outputPath string, opts *SinkOptions) (*Sink, error) {
+	if opts == nil || len(opts.Headers) == 0 {
+		return nil, fmt.Errorf("converters: OpenSink: Headers are required")
+	}
+	tableName := opts.TableName
+	if tableName == "" {
+		tableName = "tb0"
+	}
+
+	headers := common.GenColumnNames(opts.Headers)
+	colTypes := opts.ColumnTypes
+	if len(colTypes) == 0 {
+		colTypes = make([]string, len(headers))
+		for i := range colTypes {
+			colTypes[i] = "TEXT"
+		}
+	}
+	if len(colTypes) != len(headers) {
+		return nil, fmt.Errorf("converters: OpenSink: %d column types for %d headers", len(colTypes), len(headers))
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`PRAGMA journal_mode = WAL; PRAGMA synchronous = NORMAL;`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set pragmas: %w", err)
+	}
+
+	createSQL := strings.Replace(common.GenCreateTableSQLWithTypes(tableName, headers, colTypes),
+		"CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+	if _, err := db.Exec(createSQL); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create table %s: %w", tableName, err)
+	}
+	_, err = db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		table_name TEXT PRIMARY KEY,
+		position TEXT,
+		updated_at TEXT
+	)`, checkpointTable))
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create checkpoint table: %w", err)
+	}
+
+	insertSQL, err := common.GenPreparedStmt(tableName, headers, common.InsertStmt)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	// Prepared on db before any transaction starts; rebound with tx.Stmt
+	stmt, err := db.Prepare(insertSQL)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = BatchSize
+	}
+	flushInterval := opts.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	s := &Sink{
+		db:          db,
+		tableName:   tableName,
+		headers:     headers,
+		stmt:        stmt,
+		batchSize:   batchSize,
+		stopFlusher: make(chan struct{}),
+	}
+
+	// Background flusher so pending rows of a quiet stream still land
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Flush()
+			case <-s.stopFlusher:
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// Append stages one row for the next commit. checkpoint is the source
+// position of the message the row came from; pass "" when the stream has
+// no positions. Every BatchSize rows the batch commits together with the
+// latest checkpoint.
+func (s *Sink) Append(row []interface{}, checkpoint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("converters: sink is closed")
+	}
+
+	if s.tx == nil {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		s.tx = tx
+		s.txStmt = tx.Stmt(s.stmt)
+	}
+
+	// Pad or truncate to the declared width, like the bulk importer
+	if len(row) < len(s.headers) {
+		padded := make([]interface{}, len(s.headers))
+		copy(padded, row)
+		row = padded
+	} else if len(row) > len(s.headers) {
+		row = row[:len(s.headers)]
+	}
+
+	if _, err := s.txStmt.Exec(row...); err != nil {
+		return fmt.Errorf("failed to insert row: %w", err)
+	}
+	s.pending++
+	if checkpoint != "" {
+		s.checkpoint = checkpoint
+	}
+	if s.pending >= s.batchSize {
+		return s.commitLocked()
+	}
+	return nil
+}
+
+// sinkNumber converts a json.Number to int64 when the literal has no
+// fraction or exponent, float64 otherwise, falling back to the raw text
+// for numbers neither can hold.
+func sinkNumber(n json.Number) interface{} {
+	if !strings.ContainsAny(n.String(), ".eE") {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// AppendJSON appends one JSON object message, aligning its top-level keys
+// to the sink's headers; missing keys become NULL, unknown keys are
+// dropped and nested values are stored as JSON text. Numbers decode via
+// UseNumber, like the JSON converter, so integers keep their exact value
+// instead of passing through float64.
+func (s *Sink) AppendJSON(msg []byte, checkpoint string) error {
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.UseNumber()
+	var rec map[string]interface{}
+	if err := dec.Decode(&rec); err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+	row := make([]interface{}, len(s.headers))
+	for i, h := range s.headers {
+		v, ok := rec[h]
+		if !ok || v == nil {
+			continue
+		}
+		switch t := v.(type) {
+		case string, bool:
+			row[i] = t
+		case json.Number:
+			row[i] = sinkNumber(t)
+		default:
+			if data, err := json.Marshal(t); err == nil {
+				row[i] = string(data)
+			}
+		}
+	}
+	return s.Append(row, checkpoint)
+}
+
+// commitLocked writes the checkpoint and commits the open transaction.
+// Callers must hold s.mu.
+func (s *Sink) commitLocked() error {
+	if s.tx == nil {
+		return nil
+	}
+	if s.checkpoint != "" {
+		_, err := s.tx.Exec(fmt.Sprintf(`INSERT INTO %s (table_name, position, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT(table_name) DO UPDATE SET position = excluded.position, updated_at = excluded.updated_at`,
+			checkpointTable), s.tableName, s.checkpoint, time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return fmt.Errorf("failed to write checkpoint: %w", err)
+		}
+	}
+	s.txStmt.Close()
+	s.txStmt = nil
+	if err := s.tx.Commit(); err != nil {
+		s.tx = nil
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	s.tx = nil
+	s.pending = 0
+	return nil
+}
+
+// Flush commits any pending rows and their checkpoint immediately.
+func (s *Sink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	return s.commitLocked()
+}
+
+// Checkpoint returns the last committed source position for this sink's
+// table, or "" when nothing has been checkpointed yet. Consumers call it
+// after OpenSink to know where to resume.
+func (s *Sink) Checkpoint() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	querySQL := fmt.Sprintf(`SELECT position FROM %s WHERE table_name = ?`, checkpointTable)
+	var position string
+	var err error
+	if s.tx != nil {
+		// The single connection is inside the open transaction; the
+		// checkpoint row is only written at commit, so this still reads
+		// the last committed position.
+		err = s.tx.QueryRow(querySQL, s.tableName).Scan(&position)
+	} else {
+		err = s.db.QueryRow(querySQL, s.tableName).Scan(&position)
+	}
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return position, nil
+}
+
+// SinkCheckpoint reads the last committed source position for tableName
+// (default "tb0") in an existing sink database without opening a sink, so
+// wrapper scripts can seek the upstream consumer before any rows flow. A
+// missing database or checkpoint yields "".
+func SinkCheckpoint(outputPath, tableName string) (string, error) {
+	if tableName == "" {
+		tableName = "tb0"
+	}
+	if _, err := os.Stat(outputPath); err != nil {
+		return "", nil
+	}
+
+	db, err := sql.Open("sqlite", outputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	var position string
+	err = db.QueryRow(fmt.Sprintf(`SELECT position FROM %s WHERE table_name = ?`, checkpointTable), tableName).Scan(&position)
+	if err == sql.ErrNoRows || (err != nil && strings.Contains(err.Error(), "no such table")) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return position, nil
+}
+
+// Close flushes pending rows and releases the database.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	err := s.commitLocked()
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.stopFlusher)
+	s.stmt.Close()
+	if cerr := s.db.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package converters
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestSinkAppendAndCheckpoint(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sink.db")
+	sink, err := OpenSink(dbPath, &SinkOptions{
+		Headers:     []string{"id", "body"},
+		ColumnTypes: []string{"INTEGER", "TEXT"},
+	})
+	if err != nil {
+		t.Fatalf("OpenSink failed: %v", err)
+	}
+
+	if pos, err := sink.Checkpoint(); err != nil || pos != "" {
+		t.Fatalf("expected empty initial checkpoint, got %q (%v)", pos, err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := sink.Append([]interface{}{i, fmt.Sprintf("msg %d", i)}, fmt.Sprintf("0:%d", i)); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	pos, err := sink.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if pos != "0:9" {
+		t.Errorf("expected checkpoint 0:9, got %q", pos)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM tb0`).Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 10 {
+		t.Errorf("expected 10 rows, got %d", count)
+	}
+}
+
+func TestSinkBatchCommit(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sink.db")
+	sink, err := OpenSink(dbPath, &SinkOptions{
+		Headers:   []string{"n"},
+		BatchSize: 5,
+	})
+	if err != nil {
+		t.Fatalf("OpenSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	// Four rows stay pending; the checkpoint must not move yet
+	for i := 0; i < 4; i++ {
+		if err := sink.Append([]interface{}{i}, fmt.Sprintf("0:%d", i)); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if pos, _ := sink.Checkpoint(); pos != "" {
+		t.Errorf("expected no checkpoint before the batch commits, got %q", pos)
+	}
+
+	// The fifth row completes the batch and commits it with the checkpoint
+	if err := sink.Append([]interface{}{4}, "0:4"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if pos, _ := sink.Checkpoint(); pos != "0:4" {
+		t.Errorf("expected checkpoint 0:4 after batch commit, got %q", pos)
+	}
+}
+
+func TestSinkResume(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sink.db")
+	opts := &SinkOptions{Headers: []string{"n"}}
+
+	sink, err := OpenSink(dbPath, opts)
+	if err != nil {
+		t.Fatalf("OpenSink failed: %v", err)
+	}
+	if err := sink.Append([]interface{}{1}, "0:1"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// A restarted consumer reopens the sink and resumes from the checkpoint
+	sink, err = OpenSink(dbPath, opts)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer sink.Close()
+	pos, err := sink.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if pos != "0:1" {
+		t.Errorf("expected checkpoint 0:1 after reopen, got %q", pos)
+	}
+	if err := sink.Append([]interface{}{2}, "0:2"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+}
+
+func TestSinkAppendJSON(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sink.db")
+	sink, err := OpenSink(dbPath, &SinkOptions{
+		Headers:     []string{"id", "name", "tags"},
+		ColumnTypes: []string{"INTEGER", "TEXT", "TEXT"},
+	})
+	if err != nil {
+		t.Fatalf("OpenSink failed: %v", err)
+	}
+
+	err = sink.AppendJSON([]byte(`{"id": 7, "name": "ada", "tags": ["x"], "extra": true}`), "0:0")
+	if err != nil {
+		t.Fatalf("AppendJSON failed: %v", err)
+	}
+	if err := sink.AppendJSON([]byte(`not json`), "0:1"); err == nil {
+		t.Error("expected error for malformed message")
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	var id int64
+	var name, tags string
+	if err := db.QueryRow(`SELECT id, name, tags FROM tb0`).Scan(&id, &name, &tags); err != nil {
+		t.Fatalf("Failed to read row back: %v", err)
+	}
+	if id != 7 || name != "ada" || tags != `["x"]` {
+		t.Errorf("unexpected row: %d %s %s", id, name, tags)
+	}
+}
+
+func TestSinkAppendJSONNumbers(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sink.db")
+	sink, err := OpenSink(dbPath, &SinkOptions{
+		Headers:     []string{"count", "big", "ratio"},
+		ColumnTypes: []string{"INTEGER", "INTEGER", "REAL"},
+	})
+	if err != nil {
+		t.Fatalf("OpenSink failed: %v", err)
+	}
+
+	// An int64 above 2^53 would lose precision through float64
+	err = sink.AppendJSON([]byte(`{"count": 1, "big": 9007199254740993, "ratio": 0.5}`), "")
+	if err != nil {
+		t.Fatalf("AppendJSON failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	var count, big int64
+	var ratio float64
+	var countType, bigType string
+	err = db.QueryRow(`SELECT count, typeof(count), big, typeof(big), ratio FROM tb0`).
+		Scan(&count, &countType, &big, &bigType, &ratio)
+	if err != nil {
+		t.Fatalf("Failed to read row back: %v", err)
+	}
+	if count != 1 || countType != "integer" {
+		t.Errorf("expected integer 1, got %s %d", countType, count)
+	}
+	if big != 9007199254740993 || bigType != "integer" {
+		t.Errorf("expected exact integer 9007199254740993, got %s %d", bigType, big)
+	}
+	if ratio != 0.5 {
+		t.Errorf("expected 0.5, got %v", ratio)
+	}
+}
@@ -0,0 +1,209 @@
+package sitemap
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// URLsTB is the name of the table holding the sitemap entries.
+const URLsTB = "urls"
+
+func init() {
+	converters.Register("sitemap", &sitemapDriver{}, converters.Capabilities{Extensions: []string{".xml"}, MIMETypes: []string{"application/xml", "text/xml"}})
+}
+
+type sitemapDriver struct{}
+
+func (d *sitemapDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewSitemapConverterWithConfig(source, config)
+}
+
+// sitemapEntry is one <url> of a urlset or one <sitemap> of a sitemapindex;
+// both carry loc and lastmod, only urlset entries have the rest.
+type sitemapEntry struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod"`
+	ChangeFreq string `xml:"changefreq"`
+	Priority   string `xml:"priority"`
+}
+
+// sitemapDoc matches either document shape; XMLName tells them apart.
+type sitemapDoc struct {
+	XMLName  xml.Name
+	URLs     []sitemapEntry `xml:"url"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapURL struct {
+	loc, lastmod, changefreq string
+	priority                 interface{} // REAL, nil when absent
+}
+
+// SitemapConverter turns sitemap.xml and sitemap-index files into a "urls"
+// table (loc, lastmod, changefreq, priority). Index files list child
+// sitemaps; with FetchSitemaps set those are fetched and their entries
+// imported, otherwise the child sitemap locations themselves become rows.
+type SitemapConverter struct {
+	urls   []sitemapURL
+	client *http.Client    // nil unless FetchSitemaps is set
+	seen   map[string]bool // child sitemaps already fetched, guards cycles
+}
+
+// Ensure SitemapConverter implements RowProvider
+var _ common.RowProvider = (*SitemapConverter)(nil)
+
+// NewSitemapConverter creates a new SitemapConverter from an io.Reader.
+func NewSitemapConverter(r io.Reader) (*SitemapConverter, error) {
+	return NewSitemapConverterWithConfig(r, nil)
+}
+
+// NewSitemapConverterWithConfig creates a new SitemapConverter from an
+// io.Reader with optional config.
+func NewSitemapConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*SitemapConverter, error) {
+	c := &SitemapConverter{seen: make(map[string]bool)}
+	if config != nil && config.FetchSitemaps {
+		c.client = &http.Client{Timeout: 30 * time.Second}
+		if config.ScanTimeout != "" {
+			if d, err := time.ParseDuration(config.ScanTimeout); err == nil {
+				c.client.Timeout = d
+			}
+		}
+	}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parse decodes one sitemap document, recursing through fetchChild when it
+// is an index and fetching is enabled.
+func (c *SitemapConverter) parse(r io.Reader) error {
+	var doc sitemapDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to parse sitemap: %w", err)
+	}
+	switch doc.XMLName.Local {
+	case "urlset":
+		for _, entry := range doc.URLs {
+			c.addURL(entry)
+		}
+	case "sitemapindex":
+		for _, entry := range doc.Sitemaps {
+			if c.client == nil {
+				c.addURL(entry)
+				continue
+			}
+			if err := c.fetchChild(strings.TrimSpace(entry.Loc)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("not a sitemap document (root element %q)", doc.XMLName.Local)
+	}
+	return nil
+}
+
+// fetchChild retrieves a child sitemap of an index and parses it; the child
+// may itself be an index. Gzipped sitemaps (.xml.gz) are decompressed.
+func (c *SitemapConverter) fetchChild(loc string) error {
+	if loc == "" || c.seen[loc] {
+		return nil
+	}
+	c.seen[loc] = true
+
+	resp, err := c.client.Get(loc)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sitemap %s: %w", loc, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch sitemap %s: status %d", loc, resp.StatusCode)
+	}
+
+	body := io.Reader(resp.Body)
+	if u, err := url.Parse(loc); err == nil && strings.HasSuffix(u.Path, ".gz") {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return fmt.Errorf("failed to decompress sitemap %s: %w", loc, err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+	if err := c.parse(body); err != nil {
+		return fmt.Errorf("sitemap %s: %w", loc, err)
+	}
+	return nil
+}
+
+// addURL appends one entry, trimming whitespace-heavy XML text and parsing
+// the priority into a number.
+func (c *SitemapConverter) addURL(entry sitemapEntry) {
+	u := sitemapURL{
+		loc:        strings.TrimSpace(entry.Loc),
+		lastmod:    strings.TrimSpace(entry.LastMod),
+		changefreq: strings.TrimSpace(entry.ChangeFreq),
+	}
+	if p := strings.TrimSpace(entry.Priority); p != "" {
+		if val, err := strconv.ParseFloat(p, 64); err == nil {
+			u.priority = val
+		}
+	}
+	c.urls = append(c.urls, u)
+}
+
+// GetTableNames implements RowProvider
+func (c *SitemapConverter) GetTableNames() []string {
+	return []string{URLsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *SitemapConverter) GetHeaders(tableName string) []string {
+	if tableName != URLsTB {
+		return nil
+	}
+	return []string{"loc", "lastmod", "changefreq", "priority"}
+}
+
+// GetColumnTypes implements RowProvider
+func (c *SitemapConverter) GetColumnTypes(tableName string) []string {
+	if tableName != URLsTB {
+		return nil
+	}
+	return []string{"TEXT", "TEXT", "TEXT", "REAL"}
+}
+
+// ScanRows implements RowProvider
+func (c *SitemapConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != URLsTB {
+		return nil
+	}
+	for _, u := range c.urls {
+		var lastmod, changefreq interface{}
+		if u.lastmod != "" {
+			lastmod = u.lastmod
+		}
+		if u.changefreq != "" {
+			changefreq = u.changefreq
+		}
+		if err := yield([]interface{}{u.loc, lastmod, changefreq, u.priority}, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
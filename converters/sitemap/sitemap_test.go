@@ -0,0 +1,131 @@
+package sitemap
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const sampleSitemap = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/</loc>
+    <lastmod>2024-05-01</lastmod>
+    <changefreq>daily</changefreq>
+    <priority>1.0</priority>
+  </url>
+  <url>
+    <loc>https://example.com/about</loc>
+    <changefreq>monthly</changefreq>
+  </url>
+</urlset>`
+
+func scan(t *testing.T, conv *SitemapConverter) [][]interface{} {
+	t.Helper()
+	var rows [][]interface{}
+	err := conv.ScanRows(context.Background(), URLsTB, func(r []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	return rows
+}
+
+func TestSitemapConverter(t *testing.T) {
+	conv, err := NewSitemapConverter(strings.NewReader(sampleSitemap))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	if tables := conv.GetTableNames(); len(tables) != 1 || tables[0] != URLsTB {
+		t.Fatalf("expected a urls table, got %v", tables)
+	}
+	headers := conv.GetHeaders(URLsTB)
+	if len(headers) != 4 || headers[0] != "loc" || headers[3] != "priority" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+
+	rows := scan(t, conv)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "https://example.com/" || rows[0][1] != "2024-05-01" || rows[0][2] != "daily" || rows[0][3] != 1.0 {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+	// Absent fields come through as NULL
+	if rows[1][1] != nil || rows[1][3] != nil {
+		t.Errorf("expected NULL lastmod and priority, got %v", rows[1])
+	}
+}
+
+func TestSitemapNotASitemap(t *testing.T) {
+	_, err := NewSitemapConverter(strings.NewReader(`<?xml version="1.0"?><rss version="2.0"></rss>`))
+	if err == nil || !strings.Contains(err.Error(), "not a sitemap document") {
+		t.Fatalf("expected a not-a-sitemap error, got %v", err)
+	}
+}
+
+func TestSitemapIndex(t *testing.T) {
+	child := func(locs ...string) string {
+		var sb strings.Builder
+		sb.WriteString(`<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+		for _, loc := range locs {
+			sb.WriteString("<url><loc>" + loc + "</loc></url>")
+		}
+		sb.WriteString(`</urlset>`)
+		return sb.String()
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pages.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(child("https://example.com/a", "https://example.com/b")))
+	})
+	mux.HandleFunc("/posts.xml.gz", func(w http.ResponseWriter, r *http.Request) {
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(child("https://example.com/c")))
+		gz.Close()
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	index := `<?xml version="1.0"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>` + srv.URL + `/pages.xml</loc><lastmod>2024-05-01</lastmod></sitemap>
+  <sitemap><loc>` + srv.URL + `/posts.xml.gz</loc></sitemap>
+</sitemapindex>`
+
+	// Without the fetch option the child sitemaps themselves become rows
+	conv, err := NewSitemapConverter(strings.NewReader(index))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	rows := scan(t, conv)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 child sitemap rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != srv.URL+"/pages.xml" || rows[0][1] != "2024-05-01" {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+
+	// With it, the children are fetched (decompressing .gz) and their URLs imported
+	conv, err = NewSitemapConverterWithConfig(strings.NewReader(index), &common.ConversionConfig{FetchSitemaps: true})
+	if err != nil {
+		t.Fatalf("failed to create fetching converter: %v", err)
+	}
+	rows = scan(t, conv)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows across child sitemaps, got %d: %v", len(rows), rows)
+	}
+	if rows[2][0] != "https://example.com/c" {
+		t.Errorf("unexpected last row: %v", rows[2])
+	}
+}
@@ -0,0 +1,113 @@
+package converters
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SniffLen is how many leading bytes Sniff needs to classify an input.
+const SniffLen = 4096
+
+// Sniff inspects the first bytes of an input and returns the name of the
+// driver that should handle it, for files whose extension is missing or
+// wrong (downloads named "data", "export.tmp"). It recognises binary
+// magics first, then falls back to text heuristics.
+func Sniff(header []byte) (string, bool) {
+	if len(header) == 0 {
+		return "", false
+	}
+
+	// Binary magics
+	switch {
+	case bytes.HasPrefix(header, []byte("SQLite format 3\x00")):
+		return "sqlite", true
+	case bytes.HasPrefix(header, []byte("Obj\x01")):
+		return "avro", true
+	case bytes.HasPrefix(header, []byte("ORC")):
+		return "orc", true
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		// Office documents are zip containers; the first entry names
+		// usually appear within the sniff window.
+		switch {
+		case bytes.Contains(header, []byte("word/")):
+			return "docx", true
+		case bytes.Contains(header, []byte("xl/")):
+			return "excel", true
+		}
+		return "zip", true
+	case bytes.HasPrefix(header, []byte("7z\xBC\xAF\x27\x1C")):
+		return "7z", true
+	case bytes.HasPrefix(header, []byte("Rar!\x1A\x07")):
+		return "rar", true
+	}
+
+	// Strip a UTF-8 BOM before text heuristics
+	text := bytes.TrimPrefix(header, []byte{0xEF, 0xBB, 0xBF})
+	trimmed := bytes.TrimLeft(text, " \t\r\n")
+	if len(trimmed) == 0 {
+		return "", false
+	}
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("BEGIN:VCARD")):
+		return "vcard", true
+	case bytes.HasPrefix(trimmed, []byte("BEGIN:VCALENDAR")):
+		return "ics", true
+	case bytes.HasPrefix(trimmed, []byte("From ")):
+		return "email", true
+	}
+
+	switch trimmed[0] {
+	case '{', '[':
+		if bytes.Contains(trimmed, []byte(`"FeatureCollection"`)) || bytes.Contains(trimmed, []byte(`"Feature"`)) {
+			return "geojson", true
+		}
+		return "json", true
+	case '<':
+		lower := bytes.ToLower(trimmed)
+		switch {
+		case bytes.Contains(lower, []byte("<kml")):
+			return "kml", true
+		case bytes.Contains(lower, []byte("<gpx")):
+			return "gpx", true
+		case bytes.Contains(lower, []byte("<urlset")), bytes.Contains(lower, []byte("<sitemapindex")):
+			return "sitemap", true
+		case bytes.Contains(lower, []byte("<html")), bytes.HasPrefix(lower, []byte("<!doctype html")):
+			return "html", true
+		case bytes.Contains(lower, []byte("<table")):
+			return "html", true
+		}
+		return "", false
+	}
+
+	if !isMostlyText(text) {
+		return "", false
+	}
+
+	// Delimited text: if the first line splits into multiple fields on a
+	// common delimiter, treat it as CSV; otherwise plain text.
+	firstLine := string(text)
+	if idx := strings.IndexByte(firstLine, '\n'); idx != -1 {
+		firstLine = firstLine[:idx]
+	}
+	for _, delim := range []string{",", "\t", ";", "|"} {
+		if strings.Count(firstLine, delim) >= 1 {
+			return "csv", true
+		}
+	}
+	return "txt", true
+}
+
+// isMostlyText reports whether the sample looks like printable text.
+func isMostlyText(sample []byte) bool {
+	if len(sample) == 0 {
+		return false
+	}
+	binary := 0
+	for _, b := range sample {
+		if b == 0 || (b < 0x09 && b != 0) {
+			binary++
+		}
+	}
+	return binary*100/len(sample) < 5
+}
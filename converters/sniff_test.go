@@ -0,0 +1,41 @@
+package converters
+
+import "testing"
+
+func TestSniff(t *testing.T) {
+	cases := []struct {
+		name   string
+		header []byte
+		want   string
+		wantOK bool
+	}{
+		{"sqlite", []byte("SQLite format 3\x00rest"), "sqlite", true},
+		{"avro", []byte("Obj\x01..."), "avro", true},
+		{"zip", []byte("PK\x03\x04\x14\x00somefile.txt"), "zip", true},
+		{"xlsx", []byte("PK\x03\x04\x14\x00xl/workbook.xml"), "excel", true},
+		{"docx", []byte("PK\x03\x04\x14\x00word/document.xml"), "docx", true},
+		{"json", []byte(`{"a": 1}`), "json", true},
+		{"json bom", append([]byte{0xEF, 0xBB, 0xBF}, []byte(`[1,2]`)...), "json", true},
+		{"geojson", []byte(`{"type": "FeatureCollection", "features": []}`), "geojson", true},
+		{"html", []byte("<!DOCTYPE html>\n<html><body>"), "html", true},
+		{"kml", []byte(`<?xml version="1.0"?><kml xmlns="http://www.opengis.net/kml/2.2">`), "kml", true},
+		{"gpx", []byte(`<?xml version="1.0"?><gpx version="1.1">`), "gpx", true},
+		{"sitemap", []byte(`<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`), "sitemap", true},
+		{"sitemap index", []byte(`<?xml version="1.0"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`), "sitemap", true},
+		{"vcard", []byte("BEGIN:VCARD\nVERSION:3.0\n"), "vcard", true},
+		{"ics", []byte("BEGIN:VCALENDAR\nVERSION:2.0\n"), "ics", true},
+		{"mbox", []byte("From alice@example.com Mon Jan  2 15:04:05 2006\n"), "email", true},
+		{"csv", []byte("name,age,city\nalice,30,paris\n"), "csv", true},
+		{"tsv", []byte("name\tage\nalice\t30\n"), "csv", true},
+		{"txt", []byte("just some prose without delimiters\nmore prose\n"), "txt", true},
+		{"binary", []byte{0x00, 0x01, 0x02, 0x03, 0x00, 0x00}, "", false},
+		{"empty", nil, "", false},
+	}
+
+	for _, tc := range cases {
+		got, ok := Sniff(tc.header)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: Sniff() = %q, %v; want %q, %v", tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
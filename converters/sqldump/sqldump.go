@@ -0,0 +1,447 @@
+package sqldump
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+func init() {
+	converters.Register("sqldump", &sqldumpDriver{}, converters.Capabilities{Extensions: []string{".sql"}, MIMETypes: []string{"application/sql"}})
+}
+
+type sqldumpDriver struct{}
+
+func (d *sqldumpDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewSQLDumpConverterWithConfig(source, config)
+}
+
+// dumpTable holds the translated schema and rows for one table in the dump.
+type dumpTable struct {
+	headers  []string
+	colTypes []string
+	rows     [][]interface{}
+}
+
+// SQLDumpConverter parses mysqldump / pg_dump (plain format) .sql files,
+// translating dialect-specific DDL into SQLite-compatible schema and
+// collecting the INSERT/COPY data for import through the common pipeline.
+type SQLDumpConverter struct {
+	order  []string
+	tables map[string]*dumpTable
+
+	Config common.ConversionConfig
+}
+
+// Ensure SQLDumpConverter implements RowProvider
+var _ common.RowProvider = (*SQLDumpConverter)(nil)
+
+// NewSQLDumpConverter creates a new SQLDumpConverter from an io.Reader.
+func NewSQLDumpConverter(r io.Reader) (*SQLDumpConverter, error) {
+	return NewSQLDumpConverterWithConfig(r, nil)
+}
+
+// NewSQLDumpConverterWithConfig creates a new SQLDumpConverter from an io.Reader with optional config.
+func NewSQLDumpConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*SQLDumpConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	c := &SQLDumpConverter{
+		tables: make(map[string]*dumpTable),
+		Config: *config,
+	}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+	if len(c.tables) == 0 {
+		return nil, fmt.Errorf("no tables found in SQL dump")
+	}
+	return c, nil
+}
+
+// mapDumpType reduces a MySQL/PostgreSQL column type to a SQLite type.
+func mapDumpType(declared string) string {
+	upper := strings.ToUpper(declared)
+	// Strip length/precision, e.g. VARCHAR(255)
+	if idx := strings.Index(upper, "("); idx != -1 {
+		upper = upper[:idx]
+	}
+	upper = strings.TrimSpace(upper)
+	switch {
+	case strings.Contains(upper, "INT"), upper == "SERIAL", upper == "BIGSERIAL",
+		upper == "SMALLSERIAL", upper == "BOOLEAN", upper == "BOOL", upper == "BIT":
+		return "INTEGER"
+	case strings.Contains(upper, "FLOAT"), strings.Contains(upper, "DOUBLE"),
+		strings.Contains(upper, "DECIMAL"), strings.Contains(upper, "NUMERIC"),
+		upper == "REAL", upper == "MONEY":
+		return "REAL"
+	case strings.Contains(upper, "BLOB"), upper == "BYTEA", strings.Contains(upper, "BINARY"):
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+// unquoteIdent strips MySQL backticks, standard double quotes, and schema prefixes.
+func unquoteIdent(name string) string {
+	name = strings.TrimSpace(name)
+	// Drop schema qualifier (public.users -> users)
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		name = name[idx+1:]
+	}
+	name = strings.Trim(name, "`\"")
+	return name
+}
+
+// parse reads statements from the dump, handling CREATE TABLE, INSERT INTO and
+// PostgreSQL COPY ... FROM stdin blocks. Everything else is ignored.
+func (c *SQLDumpConverter) parse(r io.Reader) error {
+	br := bufio.NewReaderSize(r, 65536)
+
+	for {
+		stmt, err := readStatement(br)
+		if stmt != "" {
+			trimmed := strings.TrimSpace(stmt)
+			upper := strings.ToUpper(trimmed)
+			switch {
+			case strings.HasPrefix(upper, "CREATE TABLE"):
+				if parseErr := c.parseCreateTable(trimmed); parseErr != nil {
+					return parseErr
+				}
+			case strings.HasPrefix(upper, "INSERT INTO"):
+				if parseErr := c.parseInsert(trimmed); parseErr != nil {
+					return parseErr
+				}
+			case strings.HasPrefix(upper, "COPY "):
+				if parseErr := c.parseCopy(trimmed, br); parseErr != nil {
+					return parseErr
+				}
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read SQL dump: %w", err)
+		}
+	}
+}
+
+// readStatement reads one semicolon-terminated statement, respecting quoted
+// strings and stripping line comments.
+func readStatement(br *bufio.Reader) (string, error) {
+	var sb strings.Builder
+	var quote byte // 0, '\'', '"' or '`'
+
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return sb.String(), err
+		}
+
+		if quote == 0 {
+			// Line comments: -- ... and # ...
+			if b == '-' {
+				if peek, _ := br.Peek(1); len(peek) == 1 && peek[0] == '-' {
+					br.ReadString('\n')
+					continue
+				}
+			}
+			if b == '/' {
+				if peek, _ := br.Peek(1); len(peek) == 1 && peek[0] == '*' {
+					// Block comment (mysqldump conditional comments included)
+					br.ReadByte()
+					for {
+						chunk, err := br.ReadString('*')
+						if err != nil {
+							return sb.String(), err
+						}
+						if strings.HasSuffix(chunk, "*") {
+							if next, _ := br.Peek(1); len(next) == 1 && next[0] == '/' {
+								br.ReadByte()
+								break
+							}
+						}
+					}
+					continue
+				}
+			}
+			if b == ';' {
+				return sb.String(), nil
+			}
+			if b == '\'' || b == '"' || b == '`' {
+				quote = b
+			}
+		} else {
+			if b == '\\' && quote == '\'' {
+				// MySQL backslash escape: keep both bytes
+				sb.WriteByte(b)
+				next, err := br.ReadByte()
+				if err != nil {
+					return sb.String(), err
+				}
+				sb.WriteByte(next)
+				continue
+			}
+			if b == quote {
+				quote = 0
+			}
+		}
+		sb.WriteByte(b)
+	}
+}
+
+// parseCreateTable translates a CREATE TABLE statement into a table schema.
+func (c *SQLDumpConverter) parseCreateTable(stmt string) error {
+	open := strings.Index(stmt, "(")
+	closeIdx := strings.LastIndex(stmt, ")")
+	if open == -1 || closeIdx == -1 || closeIdx < open {
+		return nil // Not a column list we can use
+	}
+
+	header := stmt[:open]
+	header = strings.TrimSpace(header)
+	// CREATE TABLE [IF NOT EXISTS] name
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return nil
+	}
+	rawName := fields[len(fields)-1]
+	tableName := unquoteIdent(rawName)
+
+	body := stmt[open+1 : closeIdx]
+	table := &dumpTable{}
+
+	for _, col := range splitTopLevel(body, ',') {
+		col = strings.TrimSpace(col)
+		if col == "" {
+			continue
+		}
+		upper := strings.ToUpper(col)
+		// Skip table-level constraints
+		if strings.HasPrefix(upper, "PRIMARY KEY") || strings.HasPrefix(upper, "KEY") ||
+			strings.HasPrefix(upper, "UNIQUE") || strings.HasPrefix(upper, "CONSTRAINT") ||
+			strings.HasPrefix(upper, "FOREIGN KEY") || strings.HasPrefix(upper, "INDEX") ||
+			strings.HasPrefix(upper, "FULLTEXT") || strings.HasPrefix(upper, "CHECK") {
+			continue
+		}
+		parts := strings.Fields(col)
+		if len(parts) < 2 {
+			continue
+		}
+		table.headers = append(table.headers, unquoteIdent(parts[0]))
+		table.colTypes = append(table.colTypes, mapDumpType(parts[1]))
+	}
+
+	if len(table.headers) == 0 {
+		return nil
+	}
+	if _, exists := c.tables[tableName]; !exists {
+		c.order = append(c.order, tableName)
+	}
+	c.tables[tableName] = table
+	return nil
+}
+
+// splitTopLevel splits s on sep outside of parentheses and quotes.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	last := 0
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if quote != 0 {
+			if ch == '\\' && quote == '\'' {
+				i++
+				continue
+			}
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"', '`':
+			quote = ch
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[last:])
+	return parts
+}
+
+// parseInsert parses INSERT INTO name [(cols)] VALUES (...),(...) statements.
+func (c *SQLDumpConverter) parseInsert(stmt string) error {
+	upper := strings.ToUpper(stmt)
+	valuesIdx := strings.Index(upper, "VALUES")
+	if valuesIdx == -1 {
+		return nil
+	}
+
+	head := strings.TrimSpace(stmt[len("INSERT INTO"):valuesIdx])
+	// Strip optional column list
+	if idx := strings.Index(head, "("); idx != -1 {
+		head = head[:idx]
+	}
+	tableName := unquoteIdent(head)
+
+	table, ok := c.tables[tableName]
+	if !ok {
+		// Data without preceding DDL: synthesize a table lazily from the first tuple width.
+		table = &dumpTable{}
+		c.tables[tableName] = table
+		c.order = append(c.order, tableName)
+	}
+
+	for _, tuple := range splitTopLevel(stmt[valuesIdx+len("VALUES"):], ',') {
+		tuple = strings.TrimSpace(tuple)
+		if !strings.HasPrefix(tuple, "(") || !strings.HasSuffix(tuple, ")") {
+			continue
+		}
+		rawValues := splitTopLevel(tuple[1:len(tuple)-1], ',')
+		row := make([]interface{}, len(rawValues))
+		for i, raw := range rawValues {
+			row[i] = parseLiteral(strings.TrimSpace(raw))
+		}
+		if len(table.headers) == 0 {
+			// Synthesized table: generate default columns
+			names := make([]string, len(row))
+			table.headers = common.GenColumnNames(names)
+			table.colTypes = make([]string, len(row))
+			for i := range table.colTypes {
+				table.colTypes[i] = "TEXT"
+			}
+		}
+		table.rows = append(table.rows, row)
+	}
+	return nil
+}
+
+// parseLiteral converts one SQL literal to a Go value.
+func parseLiteral(raw string) interface{} {
+	if strings.EqualFold(raw, "NULL") {
+		return nil
+	}
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' {
+		inner := raw[1 : len(raw)-1]
+		inner = strings.ReplaceAll(inner, "''", "'")
+		// MySQL backslash escapes
+		replacer := strings.NewReplacer(`\'`, "'", `\"`, `"`, `\\`, `\`, `\n`, "\n", `\r`, "\r", `\t`, "\t", `\0`, "\x00")
+		return replacer.Replace(inner)
+	}
+	return raw
+}
+
+// parseCopy handles pg_dump "COPY table (cols) FROM stdin;" blocks where rows
+// follow as tab-separated lines terminated by "\.".
+func (c *SQLDumpConverter) parseCopy(stmt string, br *bufio.Reader) error {
+	upper := strings.ToUpper(stmt)
+	if !strings.Contains(upper, "FROM STDIN") {
+		return nil
+	}
+
+	head := strings.TrimSpace(stmt[len("COPY"):])
+	if idx := strings.Index(head, "("); idx != -1 {
+		head = head[:idx]
+	} else if idx := strings.Index(strings.ToUpper(head), "FROM"); idx != -1 {
+		head = head[:idx]
+	}
+	tableName := unquoteIdent(head)
+
+	table, ok := c.tables[tableName]
+	if !ok {
+		table = &dumpTable{}
+		c.tables[tableName] = table
+		c.order = append(c.order, tableName)
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+		trimmed := strings.TrimRight(line, "\n")
+		if trimmed == `\.` {
+			return nil
+		}
+		if trimmed != "" {
+			rawValues := strings.Split(trimmed, "\t")
+			row := make([]interface{}, len(rawValues))
+			for i, raw := range rawValues {
+				if raw == `\N` {
+					row[i] = nil
+				} else {
+					replacer := strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\r`, "\r", `\\`, `\`)
+					row[i] = replacer.Replace(raw)
+				}
+			}
+			if len(table.headers) == 0 {
+				names := make([]string, len(row))
+				table.headers = common.GenColumnNames(names)
+				table.colTypes = make([]string, len(row))
+				for i := range table.colTypes {
+					table.colTypes[i] = "TEXT"
+				}
+			}
+			table.rows = append(table.rows, row)
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read COPY data: %w", err)
+		}
+	}
+}
+
+// GetTableNames implements RowProvider
+func (c *SQLDumpConverter) GetTableNames() []string {
+	return c.order
+}
+
+// GetHeaders implements RowProvider
+func (c *SQLDumpConverter) GetHeaders(tableName string) []string {
+	if table, ok := c.tables[tableName]; ok {
+		return common.GenColumnNames(table.headers)
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *SQLDumpConverter) GetColumnTypes(tableName string) []string {
+	if table, ok := c.tables[tableName]; ok {
+		return table.colTypes
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *SQLDumpConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	table, ok := c.tables[tableName]
+	if !ok {
+		return nil
+	}
+	for _, row := range table.rows {
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
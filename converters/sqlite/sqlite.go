@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	converters.Register("sqlite", &sqliteDriver{}, converters.Capabilities{Extensions: []string{".db", ".sqlite", ".sqlite3"}, MIMETypes: []string{"application/vnd.sqlite3"}})
+}
+
+type sqliteDriver struct{}
+
+func (d *sqliteDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewSQLiteConverterWithConfig(source, config)
+}
+
+// tableInfo caches the schema of one table in the source database.
+type tableInfo struct {
+	headers  []string
+	colTypes []string
+}
+
+// SQLiteConverter reads an existing SQLite database as a RowProvider so it can
+// be merged into another output database or dumped through the common pipeline.
+type SQLiteConverter struct {
+	db       *sql.DB
+	dbPath   string
+	tempFile string // Set when the input stream was copied to a temp file
+	order    []string
+	tables   map[string]tableInfo
+
+	Config common.ConversionConfig
+}
+
+// Ensure SQLiteConverter implements RowProvider
+var _ common.RowProvider = (*SQLiteConverter)(nil)
+
+// Ensure SQLiteConverter implements io.Closer
+var _ io.Closer = (*SQLiteConverter)(nil)
+
+// NewSQLiteConverter creates a new SQLiteConverter from an io.Reader.
+func NewSQLiteConverter(r io.Reader) (*SQLiteConverter, error) {
+	return NewSQLiteConverterWithConfig(r, nil)
+}
+
+// NewSQLiteConverterWithConfig creates a new SQLiteConverter from an io.Reader
+// with optional config. SQLite needs random access, so a non-file source is
+// copied to a temporary file first.
+func NewSQLiteConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*SQLiteConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	c := &SQLiteConverter{
+		tables: make(map[string]tableInfo),
+		Config: *config,
+	}
+
+	if f, ok := r.(*os.File); ok {
+		c.dbPath = f.Name()
+	} else {
+		tmpFile, err := os.CreateTemp("", "mksqlite-src-*.db")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		if _, err := io.Copy(tmpFile, r); err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFile.Name())
+			return nil, fmt.Errorf("failed to copy database to temp file: %w", err)
+		}
+		tmpFile.Close()
+		c.dbPath = tmpFile.Name()
+		c.tempFile = tmpFile.Name()
+	}
+
+	db, err := sql.Open("sqlite", "file:"+c.dbPath+"?mode=ro")
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to open source database: %w", err)
+	}
+	c.db = db
+
+	if err := c.loadSchema(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close closes the database and removes the temp copy if one was made.
+func (c *SQLiteConverter) Close() error {
+	if c.db != nil {
+		c.db.Close()
+	}
+	if c.tempFile != "" {
+		return os.Remove(c.tempFile)
+	}
+	return nil
+}
+
+// mapDeclaredType reduces a declared SQLite column type to the TEXT/INTEGER/REAL
+// set the rest of the pipeline uses, following SQLite's own affinity rules.
+func mapDeclaredType(declared string) string {
+	upper := strings.ToUpper(declared)
+	switch {
+	case strings.Contains(upper, "INT"):
+		return "INTEGER"
+	case strings.Contains(upper, "REAL"), strings.Contains(upper, "FLOA"), strings.Contains(upper, "DOUB"):
+		return "REAL"
+	case strings.Contains(upper, "BLOB"):
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+// loadSchema enumerates user tables and caches their columns and types.
+func (c *SQLiteConverter) loadSchema() error {
+	rows, err := c.db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("failed to scan table name: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate tables: %w", err)
+	}
+
+	for _, name := range names {
+		info, err := c.loadTableInfo(name)
+		if err != nil {
+			return err
+		}
+		c.tables[name] = info
+		c.order = append(c.order, name)
+	}
+	return nil
+}
+
+// loadTableInfo reads PRAGMA table_info for one table.
+func (c *SQLiteConverter) loadTableInfo(tableName string) (tableInfo, error) {
+	rows, err := c.db.Query(fmt.Sprintf(`PRAGMA table_info(%q)`, tableName))
+	if err != nil {
+		return tableInfo{}, fmt.Errorf("failed to read table_info for %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	var info tableInfo
+	for rows.Next() {
+		var cid int
+		var name, declared string
+		var notNull, pk int
+		var dfltValue interface{}
+		if err := rows.Scan(&cid, &name, &declared, &notNull, &dfltValue, &pk); err != nil {
+			return tableInfo{}, fmt.Errorf("failed to scan table_info row: %w", err)
+		}
+		info.headers = append(info.headers, name)
+		info.colTypes = append(info.colTypes, mapDeclaredType(declared))
+	}
+	return info, rows.Err()
+}
+
+// GetTableNames implements RowProvider
+func (c *SQLiteConverter) GetTableNames() []string {
+	return c.order
+}
+
+// GetHeaders implements RowProvider
+func (c *SQLiteConverter) GetHeaders(tableName string) []string {
+	if info, ok := c.tables[tableName]; ok {
+		return info.headers
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *SQLiteConverter) GetColumnTypes(tableName string) []string {
+	if info, ok := c.tables[tableName]; ok {
+		return info.colTypes
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider by streaming SELECT * over the source table.
+func (c *SQLiteConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	info, ok := c.tables[tableName]
+	if !ok {
+		return nil
+	}
+
+	cols := make([]string, len(info.headers))
+	for i, h := range info.headers {
+		cols[i] = fmt.Sprintf("%q", h)
+	}
+	query := fmt.Sprintf(`SELECT %s FROM %q`, strings.Join(cols, ", "), tableName)
+
+	rows, err := c.db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query table %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(info.headers))
+	scanArgs := make([]interface{}, len(info.headers))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			if yieldErr := yield(nil, fmt.Errorf("failed to scan row in %s: %w", tableName, err)); yieldErr != nil {
+				return yieldErr
+			}
+			continue
+		}
+		row := make([]interface{}, len(values))
+		copy(row, values)
+		if err := yield(row, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters"
+
+	_ "modernc.org/sqlite"
+)
+
+// makeSourceDB creates a small database on disk and returns its path.
+func makeSourceDB(t *testing.T) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "source.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	stmts := []string{
+		`CREATE TABLE people (name TEXT, age INTEGER, score REAL)`,
+		`INSERT INTO people VALUES ('alice', 30, 1.5)`,
+		`INSERT INTO people VALUES ('bob', 40, 2.5)`,
+		`CREATE TABLE notes (body TEXT)`,
+		`INSERT INTO notes VALUES ('hello')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("failed to exec %q: %v", stmt, err)
+		}
+	}
+	return dbPath
+}
+
+func TestSQLiteConverter_ReadTables(t *testing.T) {
+	dbPath := makeSourceDB(t)
+
+	file, err := os.Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db file: %v", err)
+	}
+	defer file.Close()
+
+	conv, err := NewSQLiteConverter(file)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	defer conv.Close()
+
+	tables := conv.GetTableNames()
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %v", tables)
+	}
+
+	headers := conv.GetHeaders("people")
+	if len(headers) != 3 || headers[0] != "name" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+	colTypes := conv.GetColumnTypes("people")
+	if colTypes[1] != "INTEGER" || colTypes[2] != "REAL" {
+		t.Errorf("unexpected column types: %v", colTypes)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), "people", func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "alice" {
+		t.Errorf("unexpected first row: %v", rows[0])
+	}
+}
+
+func TestSQLiteConverter_ReImport(t *testing.T) {
+	dbPath := makeSourceDB(t)
+
+	file, err := os.Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db file: %v", err)
+	}
+	defer file.Close()
+
+	conv, err := NewSQLiteConverter(file)
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	defer conv.Close()
+
+	outPath := filepath.Join(t.TempDir(), "copy.db")
+	outFile, err := os.Create(outPath)
+	if err != nil {
+		t.Fatalf("failed to create output file: %v", err)
+	}
+	defer outFile.Close()
+
+	if err := converters.ImportToSQLite(conv, outFile, nil); err != nil {
+		t.Fatalf("ImportToSQLite failed: %v", err)
+	}
+
+	out, err := sql.Open("sqlite", outPath)
+	if err != nil {
+		t.Fatalf("failed to open output db: %v", err)
+	}
+	defer out.Close()
+
+	var count int
+	if err := out.QueryRow("SELECT COUNT(*) FROM people").Scan(&count); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 rows in copied db, got %d", count)
+	}
+}
@@ -0,0 +1,361 @@
+package takeout
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	ManifestTB = "takeout_manifest"
+)
+
+func init() {
+	converters.Register("takeout", &takeoutDriver{}, converters.Capabilities{})
+}
+
+type takeoutDriver struct{}
+
+func (d *takeoutDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewTakeoutConverterWithConfig(source, config)
+}
+
+// driverForExt maps Takeout file extensions to converter driver names.
+// Filesystem and zip are intentionally excluded: the archive itself is
+// walked here, not handed to another container converter.
+func driverForExt(ext string) string {
+	switch ext {
+	case ".csv":
+		return "csv"
+	case ".json":
+		return "json"
+	case ".mbox", ".eml":
+		return "email"
+	case ".vcf":
+		return "vcard"
+	case ".ics":
+		return "ics"
+	case ".html", ".htm":
+		return "html"
+	case ".xlsx", ".xls":
+		return "excel"
+	}
+	return ""
+}
+
+// plannedTable maps an output table to a file and the table name the
+// underlying converter uses for it.
+type plannedTable struct {
+	path        string // Absolute path of the source file
+	driverName  string
+	sourceTable string // Table name inside the sub-converter
+	headers     []string
+	colTypes    []string
+}
+
+// manifestRow records one file encountered during planning.
+type manifestRow struct {
+	Path    string
+	Product string
+	Driver  string
+	Table   string
+	Status  string
+}
+
+// TakeoutConverter understands the Google Takeout directory/zip layout.
+// It dispatches every supported file to its registered converter, namespaces
+// the resulting tables by product, and records a manifest of what was imported.
+type TakeoutConverter struct {
+	root     string // Directory being walked
+	tempDir  string // Extraction dir when input was a zip (cleaned up on Close)
+	tables   map[string]plannedTable
+	order    []string // Table names in walk order
+	manifest []manifestRow
+
+	Config common.ConversionConfig
+}
+
+// Ensure TakeoutConverter implements RowProvider
+var _ common.RowProvider = (*TakeoutConverter)(nil)
+
+// Ensure TakeoutConverter implements io.Closer
+var _ io.Closer = (*TakeoutConverter)(nil)
+
+// NewTakeoutConverterWithConfig creates a TakeoutConverter for the directory or
+// zip archive named by config.InputPath. The source reader is unused; the
+// archive is walked through the filesystem.
+func NewTakeoutConverterWithConfig(source io.Reader, config *common.ConversionConfig) (*TakeoutConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+	if config.InputPath == "" {
+		return nil, fmt.Errorf("takeout converter requires InputPath to be set")
+	}
+
+	c := &TakeoutConverter{
+		tables: make(map[string]plannedTable),
+		Config: *config,
+	}
+
+	info, err := os.Stat(config.InputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat input path: %w", err)
+	}
+
+	if info.IsDir() {
+		c.root = config.InputPath
+	} else if strings.EqualFold(filepath.Ext(config.InputPath), ".zip") {
+		tempDir, err := extractZip(config.InputPath)
+		if err != nil {
+			return nil, err
+		}
+		c.tempDir = tempDir
+		c.root = tempDir
+	} else {
+		return nil, fmt.Errorf("takeout input must be a directory or zip archive: %s", config.InputPath)
+	}
+
+	if err := c.plan(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close removes the temporary extraction directory if one was created.
+func (c *TakeoutConverter) Close() error {
+	if c.tempDir != "" {
+		return os.RemoveAll(c.tempDir)
+	}
+	return nil
+}
+
+// extractZip extracts the archive to a fresh temp directory and returns its path.
+func extractZip(path string) (string, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open takeout zip: %w", err)
+	}
+	defer reader.Close()
+
+	tempDir, err := os.MkdirTemp("", "mksqlite-takeout-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	for _, f := range reader.File {
+		dest := filepath.Join(tempDir, filepath.Clean(f.Name))
+		if !strings.HasPrefix(dest, tempDir+string(os.PathSeparator)) {
+			continue // Skip entries escaping the temp dir
+		}
+		if f.FileInfo().IsDir() {
+			os.MkdirAll(dest, 0755)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			os.RemoveAll(tempDir)
+			return "", fmt.Errorf("failed to create dir for %s: %w", f.Name, err)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			os.RemoveAll(tempDir)
+			return "", fmt.Errorf("failed to open zip entry %s: %w", f.Name, err)
+		}
+		out, err := os.Create(dest)
+		if err != nil {
+			rc.Close()
+			os.RemoveAll(tempDir)
+			return "", fmt.Errorf("failed to create %s: %w", dest, err)
+		}
+		_, err = io.Copy(out, rc)
+		out.Close()
+		rc.Close()
+		if err != nil {
+			os.RemoveAll(tempDir)
+			return "", fmt.Errorf("failed to extract %s: %w", f.Name, err)
+		}
+	}
+	return tempDir, nil
+}
+
+// productFor derives the Takeout product name from a path relative to the root.
+// Takeout archives nest products one level below a "Takeout" directory.
+func productFor(relPath string) string {
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	for i, part := range parts {
+		if strings.EqualFold(part, "Takeout") && i+1 < len(parts)-1 {
+			return parts[i+1]
+		}
+	}
+	if len(parts) > 1 {
+		return parts[0]
+	}
+	return "takeout"
+}
+
+// plan walks the archive, opens each supported file with its converter to
+// capture schema, and records manifest entries.
+func (c *TakeoutConverter) plan() error {
+	return filepath.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		relPath, relErr := filepath.Rel(c.root, path)
+		if relErr != nil {
+			relPath = path
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		driverName := driverForExt(ext)
+		if driverName == "" {
+			c.manifest = append(c.manifest, manifestRow{
+				Path: relPath, Product: productFor(relPath), Status: "skipped: unsupported type",
+			})
+			return nil
+		}
+
+		product := productFor(relPath)
+		base := strings.TrimSuffix(filepath.Base(path), ext)
+
+		file, err := os.Open(path)
+		if err != nil {
+			c.manifest = append(c.manifest, manifestRow{
+				Path: relPath, Product: product, Driver: driverName,
+				Status: fmt.Sprintf("error: %v", err),
+			})
+			return nil
+		}
+
+		subConfig := &common.ConversionConfig{InputPath: path}
+		provider, err := converters.Open(driverName, file, subConfig)
+		if err != nil {
+			file.Close()
+			c.manifest = append(c.manifest, manifestRow{
+				Path: relPath, Product: product, Driver: driverName,
+				Status: fmt.Sprintf("error: %v", err),
+			})
+			return nil
+		}
+
+		for _, sourceTable := range provider.GetTableNames() {
+			headers := provider.GetHeaders(sourceTable)
+			if len(headers) == 0 {
+				continue
+			}
+			rawName := product + "_" + base
+			if len(provider.GetTableNames()) > 1 {
+				rawName += "_" + sourceTable
+			}
+			tableName := common.GenTableNames([]string{rawName})[0]
+			// Avoid collisions across files with the same name
+			for i := 2; ; i++ {
+				if _, exists := c.tables[tableName]; !exists {
+					break
+				}
+				tableName = fmt.Sprintf("%s%d", tableName, i)
+			}
+
+			c.tables[tableName] = plannedTable{
+				path:        path,
+				driverName:  driverName,
+				sourceTable: sourceTable,
+				headers:     headers,
+				colTypes:    provider.GetColumnTypes(sourceTable),
+			}
+			c.order = append(c.order, tableName)
+			c.manifest = append(c.manifest, manifestRow{
+				Path: relPath, Product: product, Driver: driverName,
+				Table: tableName, Status: "imported",
+			})
+		}
+
+		if closer, ok := provider.(io.Closer); ok {
+			closer.Close()
+		}
+		file.Close()
+		return nil
+	})
+}
+
+// GetTableNames implements RowProvider
+func (c *TakeoutConverter) GetTableNames() []string {
+	names := make([]string, 0, len(c.order)+1)
+	names = append(names, c.order...)
+	names = append(names, ManifestTB)
+	return names
+}
+
+// GetHeaders implements RowProvider
+func (c *TakeoutConverter) GetHeaders(tableName string) []string {
+	if tableName == ManifestTB {
+		return []string{"path", "product", "driver", "table_name", "status"}
+	}
+	if planned, ok := c.tables[tableName]; ok {
+		return planned.headers
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *TakeoutConverter) GetColumnTypes(tableName string) []string {
+	if tableName == ManifestTB {
+		return []string{"TEXT", "TEXT", "TEXT", "TEXT", "TEXT"}
+	}
+	if planned, ok := c.tables[tableName]; ok {
+		return planned.colTypes
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider. Each planned table re-opens its source file
+// and delegates to the dispatched converter.
+func (c *TakeoutConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName == ManifestTB {
+		for _, m := range c.manifest {
+			values := []interface{}{m.Path, m.Product, m.Driver, m.Table, m.Status}
+			if err := yield(values, nil); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		return nil
+	}
+
+	planned, ok := c.tables[tableName]
+	if !ok {
+		return nil
+	}
+
+	file, err := os.Open(planned.path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen %s: %w", planned.path, err)
+	}
+	defer file.Close()
+
+	subConfig := &common.ConversionConfig{InputPath: planned.path}
+	provider, err := converters.Open(planned.driverName, file, subConfig)
+	if err != nil {
+		return fmt.Errorf("failed to reopen converter for %s: %w", planned.path, err)
+	}
+	if closer, ok := provider.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	return provider.ScanRows(ctx, planned.sourceTable, yield)
+}
@@ -0,0 +1,107 @@
+package takeout
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+
+	_ "github.com/darianmavgo/mksqlite/converters/csv"
+	_ "github.com/darianmavgo/mksqlite/converters/email"
+)
+
+func TestTakeoutConverter_Directory(t *testing.T) {
+	root := t.TempDir()
+	chromeDir := filepath.Join(root, "Takeout", "Chrome")
+	mailDir := filepath.Join(root, "Takeout", "Mail")
+	if err := os.MkdirAll(chromeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(mailDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	csvContent := "name,value\na,1\nb,2\n"
+	if err := os.WriteFile(filepath.Join(chromeDir, "Devices.csv"), []byte(csvContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mbox := `From alice@example.com Mon Jan  2 15:04:05 2006
+From: Alice <alice@example.com>
+Subject: Hello
+Message-ID: <msg-1@example.com>
+Date: Mon, 02 Jan 2006 15:04:05 -0700
+
+Body text.
+`
+	if err := os.WriteFile(filepath.Join(mailDir, "All mail.mbox"), []byte(mbox), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unsupported file should be recorded as skipped
+	if err := os.WriteFile(filepath.Join(chromeDir, "image.png"), []byte{0x89}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	conv, err := NewTakeoutConverterWithConfig(nil, &common.ConversionConfig{InputPath: root})
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+	defer conv.Close()
+
+	tables := conv.GetTableNames()
+	hasTable := func(name string) bool {
+		for _, tb := range tables {
+			if tb == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasTable(ManifestTB) {
+		t.Errorf("missing manifest table, got %v", tables)
+	}
+	if !hasTable("chrome_devices") {
+		t.Errorf("missing chrome_devices table, got %v", tables)
+	}
+	if !hasTable("mail_all_mail_messages") {
+		t.Errorf("missing mail messages table, got %v", tables)
+	}
+
+	// Dispatched CSV rows should come through
+	var csvRows int
+	err = conv.ScanRows(context.Background(), "chrome_devices", func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		csvRows++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if csvRows != 2 {
+		t.Errorf("expected 2 csv rows, got %d", csvRows)
+	}
+
+	// Manifest should include the skipped png
+	foundSkipped := false
+	err = conv.ScanRows(context.Background(), ManifestTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		if row[4] == "skipped: unsupported type" {
+			foundSkipped = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows manifest failed: %v", err)
+	}
+	if !foundSkipped {
+		t.Error("manifest missing skipped entry for unsupported file")
+	}
+}
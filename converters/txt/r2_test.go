@@ -2,9 +2,9 @@ package txt_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/txt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"time"
 
@@ -17,7 +18,7 @@ const (
 )
 
 func init() {
-	converters.Register("txt", &txtDriver{})
+	converters.Register("txt", &txtDriver{}, converters.Capabilities{Extensions: []string{".txt"}, MIMETypes: []string{"text/plain"}, Streaming: true})
 }
 
 type txtDriver struct{}
@@ -32,6 +33,11 @@ type TxtConverter struct {
 
 	Config  common.ConversionConfig
 	timeout time.Duration
+
+	// LinePattern extraction mode
+	pattern    *regexp.Regexp
+	groupNames []string
+	unparsed   []string // Non-matching lines buffered for the "unparsed" table
 }
 
 // Ensure TxtConverter implements RowProvider
@@ -64,21 +70,51 @@ func NewTxtConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*T
 		}
 	}
 
-	return &TxtConverter{
+	c := &TxtConverter{
 		scanner: bufio.NewScanner(bufio.NewReaderSize(r, 65536)),
 		Config:  *config,
 		timeout: timeout,
-	}, nil
+	}
+
+	if config.LinePattern != "" {
+		pattern, err := regexp.Compile(config.LinePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid line pattern: %w", err)
+		}
+		if pattern.NumSubexp() == 0 {
+			return nil, fmt.Errorf("line pattern must contain at least one capture group")
+		}
+		names := pattern.SubexpNames()[1:]
+		for i, name := range names {
+			if name == "" {
+				names[i] = fmt.Sprintf("field%d", i)
+			}
+		}
+		c.pattern = pattern
+		c.groupNames = names
+	}
+
+	return c, nil
 }
 
 // GetTableNames implements RowProvider
 func (c *TxtConverter) GetTableNames() []string {
-	return []string{c.Config.TableName}
+	names := []string{c.Config.TableName}
+	if c.pattern != nil && c.Config.KeepUnparsed {
+		names = append(names, "unparsed")
+	}
+	return names
 }
 
 // GetHeaders implements RowProvider
 func (c *TxtConverter) GetHeaders(tableName string) []string {
 	if tableName == c.Config.TableName {
+		if c.pattern != nil {
+			return common.GenColumnNames(c.groupNames)
+		}
+		return []string{"content"}
+	}
+	if tableName == "unparsed" && c.pattern != nil && c.Config.KeepUnparsed {
 		return []string{"content"}
 	}
 	return nil
@@ -86,14 +122,39 @@ func (c *TxtConverter) GetHeaders(tableName string) []string {
 
 // GetColumnTypes implements RowProvider
 func (c *TxtConverter) GetColumnTypes(tableName string) []string {
-	if tableName == c.Config.TableName {
-		return []string{"TEXT"}
+	headers := c.GetHeaders(tableName)
+	if headers == nil {
+		return nil
 	}
-	return nil
+	colTypes := make([]string, len(headers))
+	for i := range colTypes {
+		colTypes[i] = "TEXT"
+	}
+	return colTypes
+}
+
+// recordSeparatorLine reports whether line ends the current record. The
+// special separator "blank" splits on empty lines.
+func recordSeparatorLine(line, sep string) bool {
+	line = strings.TrimRight(line, "\r")
+	if sep == "blank" {
+		return strings.TrimSpace(line) == ""
+	}
+	return line == sep
 }
 
 // ScanRows implements RowProvider using a worker pattern (pipelining) to improve streaming performance.
 func (c *TxtConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	// The unparsed table replays lines buffered during the main scan
+	if tableName == "unparsed" && c.pattern != nil && c.Config.KeepUnparsed {
+		for _, line := range c.unparsed {
+			if err := yield([]interface{}{line}, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	if tableName != c.Config.TableName {
 		return nil
 	}
@@ -111,6 +172,45 @@ func (c *TxtConverter) ScanRows(ctx context.Context, tableName string, yield fun
 	go func() {
 		defer close(rowsCh)
 
+		// process turns one record (a line, or a joined multi-line block)
+		// into a row; it returns false if the consumer went away.
+		process := func(text string) bool {
+			var row []interface{}
+			if c.pattern != nil {
+				match := c.pattern.FindStringSubmatch(text)
+				if match == nil {
+					if c.Config.KeepUnparsed {
+						c.unparsed = append(c.unparsed, text)
+					}
+					return true
+				}
+				row = make([]interface{}, len(match)-1)
+				for i, val := range match[1:] {
+					row[i] = val
+				}
+			} else {
+				row = []interface{}{text}
+			}
+
+			select {
+			case rowsCh <- row:
+				return true
+			case <-cancelCh:
+				return false
+			}
+		}
+
+		sep := c.Config.RecordSeparator
+		var record []string
+		flush := func() bool {
+			if len(record) == 0 {
+				return true
+			}
+			text := strings.Join(record, "\n")
+			record = record[:0]
+			return process(text)
+		}
+
 		for c.scanner.Scan() {
 			// Check cancel
 			select {
@@ -121,12 +221,24 @@ func (c *TxtConverter) ScanRows(ctx context.Context, tableName string, yield fun
 
 			line := c.scanner.Text()
 
-			select {
-			case rowsCh <- []interface{}{line}:
-			case <-cancelCh:
+			if sep != "" {
+				if recordSeparatorLine(line, sep) {
+					if !flush() {
+						return
+					}
+					continue
+				}
+				record = append(record, line)
+				continue
+			}
+
+			if !process(line) {
 				return
 			}
 		}
+		if sep != "" && !flush() {
+			return
+		}
 
 		if err := c.scanner.Err(); err != nil {
 			select {
@@ -170,16 +282,48 @@ func (c *TxtConverter) ScanRows(ctx context.Context, tableName string, yield fun
 }
 
 // ConvertToSQL implements StreamConverter for Txt files (outputs SQL to writer).
+// PipelinesScans implements common.PipelinedProvider: ScanRows already
+// feeds rows from a reader goroutine through a bounded channel.
+func (c *TxtConverter) PipelinesScans() bool {
+	return true
+}
+
 func (c *TxtConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
 	if c.scanner == nil {
 		return fmt.Errorf("Txt scanner is not initialized")
 	}
 
-	// Write CREATE TABLE statement
-	// Write CREATE TABLE statement
-	createTableSQL := common.GenCreateTableSQLWithTypes(c.Config.TableName, []string{"content"}, []string{"TEXT"})
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+	headers := c.GetHeaders(c.Config.TableName)
+	colTypes := c.GetColumnTypes(c.Config.TableName)
+
+	sqlWriter := common.NewSQLWriter(writer, c.Config.RowsPerInsert)
+	if err := sqlWriter.BeginTable(c.Config.TableName, headers, colTypes); err != nil {
+		return err
+	}
+
+	writeRecord := func(text string) error {
+		if c.pattern != nil {
+			match := c.pattern.FindStringSubmatch(text)
+			if match == nil {
+				if c.Config.KeepUnparsed {
+					c.unparsed = append(c.unparsed, text)
+				}
+				return nil
+			}
+			return sqlWriter.WriteStringRow(match[1:])
+		}
+		return sqlWriter.WriteStringRow([]string{text})
+	}
+
+	sep := c.Config.RecordSeparator
+	var record []string
+	flush := func() error {
+		if len(record) == 0 {
+			return nil
+		}
+		text := strings.Join(record, "\n")
+		record = record[:0]
+		return writeRecord(text)
 	}
 
 	for c.scanner.Scan() {
@@ -189,20 +333,27 @@ func (c *TxtConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 			return ctx.Err()
 		default:
 		}
+
 		line := c.scanner.Text()
 
-		if _, err := fmt.Fprintf(writer, "INSERT INTO %s (content) VALUES (", c.Config.TableName); err != nil {
-			return fmt.Errorf("failed to write INSERT start: %w", err)
+		if sep != "" {
+			if recordSeparatorLine(line, sep) {
+				if err := flush(); err != nil {
+					return err
+				}
+				continue
+			}
+			record = append(record, line)
+			continue
 		}
 
-		// Escape single quotes by doubling them
-		escapedVal := strings.ReplaceAll(line, "'", "''")
-		if _, err := fmt.Fprintf(writer, "'%s'", escapedVal); err != nil {
-			return fmt.Errorf("failed to write value: %w", err)
+		if err := writeRecord(line); err != nil {
+			return err
 		}
-
-		if _, err := writer.Write([]byte(");\n")); err != nil {
-			return fmt.Errorf("failed to write statement end: %w", err)
+	}
+	if sep != "" {
+		if err := flush(); err != nil {
+			return err
 		}
 	}
 
@@ -210,5 +361,21 @@ func (c *TxtConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 		return fmt.Errorf("failed to read txt line: %w", err)
 	}
 
+	if err := sqlWriter.EndTable(); err != nil {
+		return err
+	}
+
+	if c.pattern != nil && c.Config.KeepUnparsed && len(c.unparsed) > 0 {
+		if err := sqlWriter.BeginTable("unparsed", []string{"content"}, []string{"TEXT"}); err != nil {
+			return err
+		}
+		for _, line := range c.unparsed {
+			if err := sqlWriter.WriteStringRow([]string{line}); err != nil {
+				return err
+			}
+		}
+		return sqlWriter.EndTable()
+	}
+
 	return nil
 }
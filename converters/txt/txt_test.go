@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 
 	_ "modernc.org/sqlite"
 )
@@ -106,9 +107,12 @@ Line 3`
 	output := builder.String()
 	expectedContains := []string{
 		"CREATE TABLE tb0 (content TEXT);",
-		"INSERT INTO tb0 (content) VALUES ('Line 1');",
-		"INSERT INTO tb0 (content) VALUES ('Line ''2''');",
-		"INSERT INTO tb0 (content) VALUES ('Line 3');",
+		"BEGIN TRANSACTION;",
+		"INSERT INTO tb0 (content) VALUES",
+		"('Line 1')",
+		"('Line ''2''')",
+		"('Line 3')",
+		"COMMIT;",
 	}
 
 	for _, exp := range expectedContains {
@@ -117,3 +121,120 @@ Line 3`
 		}
 	}
 }
+
+func TestTxtLinePattern(t *testing.T) {
+	input := "2024-01-01 INFO started\nnot a log line\n2024-01-02 ERROR failed\n"
+
+	conv, err := NewTxtConverterWithConfig(strings.NewReader(input), &common.ConversionConfig{
+		LinePattern:  `^(?P<date>\d{4}-\d{2}-\d{2}) (?P<level>\S+) (?P<message>.*)$`,
+		KeepUnparsed: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tables := conv.GetTableNames()
+	if len(tables) != 2 || tables[1] != "unparsed" {
+		t.Fatalf("Expected main + unparsed tables, got %v", tables)
+	}
+
+	headers := conv.GetHeaders(TXTTB)
+	if len(headers) != 3 || headers[0] != "date" || headers[2] != "message" {
+		t.Fatalf("Expected date/level/message headers, got %v", headers)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), TXTTB, func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 matching rows, got %v", rows)
+	}
+	if rows[1][1] != "ERROR" || rows[1][2] != "failed" {
+		t.Errorf("Unexpected second row: %v", rows[1])
+	}
+
+	var unparsed [][]interface{}
+	err = conv.ScanRows(context.Background(), "unparsed", func(row []interface{}, rowErr error) error {
+		unparsed = append(unparsed, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows unparsed failed: %v", err)
+	}
+	if len(unparsed) != 1 || unparsed[0][0] != "not a log line" {
+		t.Errorf("Unexpected unparsed rows: %v", unparsed)
+	}
+}
+
+func TestTxtLinePatternErrors(t *testing.T) {
+	if _, err := NewTxtConverterWithConfig(strings.NewReader("x"), &common.ConversionConfig{
+		LinePattern: `[`,
+	}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if _, err := NewTxtConverterWithConfig(strings.NewReader("x"), &common.ConversionConfig{
+		LinePattern: `no groups`,
+	}); err == nil {
+		t.Error("Expected an error for a pattern without capture groups")
+	}
+}
+
+func TestTxtBlankLineRecords(t *testing.T) {
+	input := "GET /a HTTP/1.1\nHost: example.com\n\nGET /b HTTP/1.1\nHost: example.org\n"
+
+	conv, err := NewTxtConverterWithConfig(strings.NewReader(input), &common.ConversionConfig{
+		RecordSeparator: "blank",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), TXTTB, func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "GET /a HTTP/1.1\nHost: example.com" {
+		t.Errorf("Unexpected first record: %q", rows[0][0])
+	}
+}
+
+func TestTxtCustomRecordSeparator(t *testing.T) {
+	input := "first block\nline two\n---\nsecond block\n---\n---\nthird\n"
+
+	conv, err := NewTxtConverterWithConfig(strings.NewReader(input), &common.ConversionConfig{
+		RecordSeparator: "---",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), TXTTB, func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	// Consecutive separators don't produce empty records
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 records, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "first block\nline two" || rows[2][0] != "third" {
+		t.Errorf("Unexpected records: %v", rows)
+	}
+}
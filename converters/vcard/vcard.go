@@ -0,0 +1,190 @@
+package vcard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+const (
+	ContactsTB = "contacts"
+)
+
+func init() {
+	converters.Register("vcard", &vcardDriver{}, converters.Capabilities{Extensions: []string{".vcf"}, MIMETypes: []string{"text/vcard"}, Streaming: true})
+}
+
+type vcardDriver struct{}
+
+func (d *vcardDriver) Open(source io.Reader, config *common.ConversionConfig) (common.RowProvider, error) {
+	return NewVCardConverterWithConfig(source, config)
+}
+
+// contactRow holds one parsed vCard.
+type contactRow struct {
+	FullName string
+	Email    string
+	Phone    string
+	Org      string
+	Title    string
+}
+
+// VCardConverter converts .vcf contact exports to a SQLite "contacts" table.
+type VCardConverter struct {
+	contacts []contactRow
+
+	Config common.ConversionConfig
+}
+
+// Ensure VCardConverter implements RowProvider
+var _ common.RowProvider = (*VCardConverter)(nil)
+
+// Ensure VCardConverter implements StreamConverter
+var _ common.StreamConverter = (*VCardConverter)(nil)
+
+// NewVCardConverter creates a new VCardConverter from an io.Reader.
+func NewVCardConverter(r io.Reader) (*VCardConverter, error) {
+	return NewVCardConverterWithConfig(r, nil)
+}
+
+// NewVCardConverterWithConfig creates a new VCardConverter from an io.Reader with optional config.
+func NewVCardConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*VCardConverter, error) {
+	if config == nil {
+		config = &common.ConversionConfig{}
+	}
+
+	c := &VCardConverter{Config: *config}
+	if err := c.parse(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parse reads the vCard stream. Multiple cards per file are supported;
+// additional EMAIL/TEL values beyond the first are joined with "; ".
+func (c *VCardConverter) parse(r io.Reader) error {
+	var current *contactRow
+	var emails, phones []string
+
+	err := common.ScanUnfoldedLines(r, func(name, params, value string) error {
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VCARD") {
+				current = &contactRow{}
+				emails, phones = nil, nil
+			}
+		case "END":
+			if strings.EqualFold(value, "VCARD") && current != nil {
+				current.Email = strings.Join(emails, "; ")
+				current.Phone = strings.Join(phones, "; ")
+				c.contacts = append(c.contacts, *current)
+				current = nil
+			}
+		case "FN":
+			if current != nil {
+				current.FullName = value
+			}
+		case "N":
+			// Prefer FN; fall back to reassembled N parts.
+			if current != nil && current.FullName == "" {
+				parts := strings.Split(value, ";")
+				// N is family;given;additional;prefix;suffix
+				var nameParts []string
+				if len(parts) > 1 && parts[1] != "" {
+					nameParts = append(nameParts, parts[1])
+				}
+				if parts[0] != "" {
+					nameParts = append(nameParts, parts[0])
+				}
+				current.FullName = strings.Join(nameParts, " ")
+			}
+		case "EMAIL":
+			if current != nil && value != "" {
+				emails = append(emails, value)
+			}
+		case "TEL":
+			if current != nil && value != "" {
+				phones = append(phones, value)
+			}
+		case "ORG":
+			if current != nil {
+				current.Org = strings.ReplaceAll(value, ";", ", ")
+			}
+		case "TITLE":
+			if current != nil {
+				current.Title = value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse vCard input: %w", err)
+	}
+	return nil
+}
+
+// GetTableNames implements RowProvider
+func (c *VCardConverter) GetTableNames() []string {
+	return []string{ContactsTB}
+}
+
+// GetHeaders implements RowProvider
+func (c *VCardConverter) GetHeaders(tableName string) []string {
+	if tableName == ContactsTB {
+		return []string{"full_name", "email", "phone", "org", "title"}
+	}
+	return nil
+}
+
+// GetColumnTypes implements RowProvider
+func (c *VCardConverter) GetColumnTypes(tableName string) []string {
+	if tableName == ContactsTB {
+		return []string{"TEXT", "TEXT", "TEXT", "TEXT", "TEXT"}
+	}
+	return nil
+}
+
+// ScanRows implements RowProvider
+func (c *VCardConverter) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	if tableName != ContactsTB {
+		return nil
+	}
+	for _, contact := range c.contacts {
+		values := []interface{}{contact.FullName, contact.Email, contact.Phone, contact.Org, contact.Title}
+		if err := yield(values, nil); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+// ConvertToSQL implements StreamConverter for vCard files (outputs SQL to writer).
+func (c *VCardConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error {
+	headers := c.GetHeaders(ContactsTB)
+	colTypes := c.GetColumnTypes(ContactsTB)
+
+	sqlWriter := common.NewSQLWriter(writer, c.Config.RowsPerInsert)
+	if err := sqlWriter.BeginTable(ContactsTB, headers, colTypes); err != nil {
+		return err
+	}
+
+	err := c.ScanRows(ctx, ContactsTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		return sqlWriter.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return sqlWriter.EndTable()
+}
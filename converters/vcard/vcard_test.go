@@ -0,0 +1,62 @@
+package vcard
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const sampleVCF = `BEGIN:VCARD
+VERSION:3.0
+FN:Alice Example
+N:Example;Alice;;;
+EMAIL;TYPE=INTERNET:alice@example.com
+EMAIL:alice@work.example.com
+TEL;TYPE=CELL:+1-555-0100
+ORG:Example Corp;Engineering
+TITLE:Engineer
+END:VCARD
+BEGIN:VCARD
+VERSION:3.0
+N:Doe;Bob;;;
+TEL:+1-555-0199
+END:VCARD
+`
+
+func TestVCardConverter(t *testing.T) {
+	conv, err := NewVCardConverter(strings.NewReader(sampleVCF))
+	if err != nil {
+		t.Fatalf("failed to create converter: %v", err)
+	}
+
+	var rows [][]interface{}
+	err = conv.ScanRows(context.Background(), ContactsTB, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			t.Fatalf("row error: %v", rowErr)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 contacts, got %d", len(rows))
+	}
+
+	if rows[0][0] != "Alice Example" {
+		t.Errorf("unexpected full_name: %v", rows[0][0])
+	}
+	if rows[0][1] != "alice@example.com; alice@work.example.com" {
+		t.Errorf("unexpected email: %v", rows[0][1])
+	}
+	if rows[0][3] != "Example Corp, Engineering" {
+		t.Errorf("unexpected org: %v", rows[0][3])
+	}
+
+	// Second card has no FN; name should be rebuilt from N.
+	if rows[1][0] != "Bob Doe" {
+		t.Errorf("unexpected fallback name: %v", rows[1][0])
+	}
+}
@@ -0,0 +1,423 @@
+package converters
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/darianmavgo/mksqlite/converters/common"
+)
+
+// MaxTableColumns is the widest table populateDB will create directly. It
+// mirrors SQLite's default SQLITE_MAX_COLUMN limit of 2000: wider CREATE
+// TABLE statements fail inside SQLite with an unhelpful "too many columns"
+// error, so the engine checks up front and offers the WideStrategy layouts
+// instead. Tests lower it to exercise the wide paths with small inputs.
+var MaxTableColumns = 2000
+
+// WideStrategy values accepted by ImportOptions.
+const (
+	// WidePartition splits an over-wide table into vertically partitioned
+	// tables <table>_part1, <table>_part2, ... of at most MaxTableColumns
+	// columns each. Every part receives its slice of each row in the same
+	// order, so the original rows are reassembled by joining the parts on
+	// rowid.
+	WidePartition = "partition"
+
+	// WideEAV pivots an over-wide table into a tall entity-attribute-value
+	// table with row_id, column, and value columns, one output row per
+	// non-NULL cell.
+	WideEAV = "eav"
+)
+
+// wrapWideProvider checks every table's effective width against
+// MaxTableColumns and, when a table is too wide, replaces the provider
+// with one exposing the layout selected by opts.WideStrategy. Providers
+// without over-wide tables pass through untouched; over-wide tables with
+// no strategy configured are an error rather than a guaranteed SQLite
+// failure later.
+func wrapWideProvider(provider common.RowProvider, opts *ImportOptions) (common.RowProvider, error) {
+	limit := MaxTableColumns
+	if opts != nil && opts.Lineage {
+		limit -= len(lineageColumns)
+	}
+	wide := make(map[string][]string)
+	var firstWide string
+	for _, tableName := range provider.GetTableNames() {
+		headers := provider.GetHeaders(tableName)
+		width := len(headers)
+		// A column projection can bring a wide input back under the limit
+		if keep, err := projectionIndexes(opts, tableName, headers); err == nil && keep != nil {
+			width = len(keep)
+		}
+		if width > limit {
+			if firstWide == "" {
+				firstWide = tableName
+			}
+			wide[tableName] = headers
+		}
+	}
+	if len(wide) == 0 {
+		return provider, nil
+	}
+
+	strategy := ""
+	if opts != nil {
+		strategy = opts.WideStrategy
+	}
+	switch strategy {
+	case WideEAV:
+		return &eavProvider{inner: provider, wide: wide}, nil
+	case WidePartition:
+		if opts != nil && (opts.Where != nil || opts.Dedupe || len(opts.DedupeColumns) > 0 || opts.SampleRate != 0 || opts.Limit != 0) {
+			return nil, errors.New("the partition wide-table strategy cannot be combined with filtering, sampling, limits, or dedupe: dropped rows would leave the parts misaligned")
+		}
+		if opts != nil && opts.WithoutRowid {
+			return nil, errors.New("the partition wide-table strategy cannot be combined with WITHOUT ROWID tables: parts are joined by rowid")
+		}
+		return newPartitionProvider(provider, wide, limit), nil
+	case "":
+		return nil, fmt.Errorf("table %s has %d columns, more than SQLite's limit of %d; rerun with --wide-strategy partition or eav",
+			firstWide, len(wide[firstWide]), limit)
+	default:
+		return nil, fmt.Errorf("unknown wide-table strategy %q (want %q or %q)", strategy, WidePartition, WideEAV)
+	}
+}
+
+// EAV layout columns shared by every pivoted table.
+var (
+	eavHeaders = []string{"row_id", "column", "value"}
+	eavTypes   = []string{"INTEGER", "TEXT", "TEXT"}
+)
+
+// eavProvider pivots over-wide tables into a tall (row_id, column, value)
+// layout while passing narrow tables through untouched. row_id numbers the
+// source rows from 1 and column carries the source header name, so a
+// source row is reassembled with GROUP BY row_id or a pivot query. NULL
+// cells are skipped: sparse wide inputs stay sparse instead of ballooning
+// into rows of NULL values.
+type eavProvider struct {
+	inner common.RowProvider
+	wide  map[string][]string // over-wide table -> source headers
+}
+
+func (p *eavProvider) GetTableNames() []string {
+	return p.inner.GetTableNames()
+}
+
+func (p *eavProvider) GetHeaders(tableName string) []string {
+	if _, ok := p.wide[tableName]; ok {
+		return eavHeaders
+	}
+	return p.inner.GetHeaders(tableName)
+}
+
+func (p *eavProvider) GetColumnTypes(tableName string) []string {
+	if _, ok := p.wide[tableName]; ok {
+		return eavTypes
+	}
+	return p.inner.GetColumnTypes(tableName)
+}
+
+func (p *eavProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	headers, ok := p.wide[tableName]
+	if !ok {
+		return p.inner.ScanRows(ctx, tableName, yield)
+	}
+	rowID := int64(0)
+	return p.inner.ScanRows(ctx, tableName, func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return yield(nil, rowErr)
+		}
+		rowID++
+		for i, value := range row {
+			if value == nil {
+				continue
+			}
+			name := fmt.Sprintf("%s%d", common.CLPRE, i)
+			if i < len(headers) {
+				name = headers[i]
+			}
+			if err := yield([]interface{}{rowID, name, value}, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (p *eavProvider) Close() error {
+	if closer, ok := p.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// newPartitionProvider expands every over-wide table into parts of at most
+// width columns, keeping narrow tables and the overall table order as-is.
+func newPartitionProvider(inner common.RowProvider, wide map[string][]string, width int) *partitionProvider {
+	p := &partitionProvider{
+		inner:  inner,
+		parts:  make(map[string]widePart),
+		spills: make(map[string]*wideSpill),
+	}
+	for _, tableName := range inner.GetTableNames() {
+		headers, ok := wide[tableName]
+		if !ok {
+			p.tables = append(p.tables, tableName)
+			continue
+		}
+		count := (len(headers) + width - 1) / width
+		for i := 0; i < count; i++ {
+			hi := (i + 1) * width
+			if hi > len(headers) {
+				hi = len(headers)
+			}
+			name := fmt.Sprintf("%s_part%d", tableName, i+1)
+			p.tables = append(p.tables, name)
+			p.parts[name] = widePart{source: tableName, index: i, lo: i * width, hi: hi, last: i == count-1}
+		}
+	}
+	return p
+}
+
+// partitionProvider splits over-wide tables into vertical partitions of
+// bounded width, named <table>_part1..N. Streaming sources can only be
+// scanned once, so the part1 scan spills every full row and later parts
+// replay the spill; sequential inserts into the freshly created parts keep
+// rowids aligned, which is why wrapWideProvider refuses to combine
+// partitioning with options that drop rows.
+type partitionProvider struct {
+	inner  common.RowProvider
+	tables []string              // expanded table list, parts in source order
+	parts  map[string]widePart   // part table -> source table and column range
+	spills map[string]*wideSpill // source table -> rows captured during part1
+}
+
+// widePart locates one partition inside its source table's headers.
+type widePart struct {
+	source string
+	index  int // 0-based part number
+	lo, hi int // column range [lo, hi) of the source headers
+	last   bool
+}
+
+func (p *partitionProvider) GetTableNames() []string {
+	return p.tables
+}
+
+func (p *partitionProvider) GetHeaders(tableName string) []string {
+	part, ok := p.parts[tableName]
+	if !ok {
+		return p.inner.GetHeaders(tableName)
+	}
+	return p.inner.GetHeaders(part.source)[part.lo:part.hi]
+}
+
+func (p *partitionProvider) GetColumnTypes(tableName string) []string {
+	part, ok := p.parts[tableName]
+	if !ok {
+		return p.inner.GetColumnTypes(tableName)
+	}
+	types := p.inner.GetColumnTypes(part.source)
+	out := make([]string, part.hi-part.lo)
+	for i := range out {
+		out[i] = "TEXT"
+		if part.lo+i < len(types) {
+			out[i] = types[part.lo+i]
+		}
+	}
+	return out
+}
+
+// sliceRow cuts one part's columns out of a full source row, padding with
+// NULL when the row is shorter than the headers.
+func (part widePart) sliceRow(row []interface{}) []interface{} {
+	out := make([]interface{}, part.hi-part.lo)
+	for i := range out {
+		if part.lo+i < len(row) {
+			out[i] = row[part.lo+i]
+		}
+	}
+	return out
+}
+
+func (p *partitionProvider) ScanRows(ctx context.Context, tableName string, yield func([]interface{}, error) error) error {
+	part, ok := p.parts[tableName]
+	if !ok {
+		return p.inner.ScanRows(ctx, tableName, yield)
+	}
+	if part.index == 0 {
+		spill := &wideSpill{}
+		p.spills[part.source] = spill
+		err := p.inner.ScanRows(ctx, part.source, func(row []interface{}, rowErr error) error {
+			if rowErr != nil {
+				// Bad rows are reported once, against part1, and spill
+				// nothing, so they are inserted into no part at all
+				return yield(nil, rowErr)
+			}
+			spill.append(row)
+			return yield(part.sliceRow(row), nil)
+		})
+		if err != nil {
+			return err
+		}
+		return spill.err
+	}
+	spill := p.spills[part.source]
+	if spill == nil {
+		return fmt.Errorf("partition %s scanned before %s_part1", tableName, part.source)
+	}
+	err := spill.scan(func(row []interface{}) error {
+		return yield(part.sliceRow(row), nil)
+	})
+	if err == nil && part.last {
+		err = spill.close()
+		delete(p.spills, part.source)
+	}
+	return err
+}
+
+func (p *partitionProvider) Close() error {
+	var firstErr error
+	for source, spill := range p.spills {
+		if err := spill.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(p.spills, source)
+	}
+	if closer, ok := p.inner.(io.Closer); ok {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// wideRowNull stands in for NULL cells in a spilled row; gob refuses to
+// encode nil interface values.
+type wideRowNull struct{}
+
+func init() {
+	// Concrete cell types converters yield, so spilled rows round-trip
+	// through the gob temp file
+	gob.Register(wideRowNull{})
+	gob.Register(int64(0))
+	gob.Register(uint64(0))
+	gob.Register(float64(0))
+	gob.Register(time.Time{})
+}
+
+// wideSpill buffers full rows during a part1 scan so later parts can
+// replay them. It is common.SpillBuffer's shape adapted to []interface{}
+// rows, which need NULL cells swapped for a sentinel before gob encoding.
+// The first common.SpillThreshold rows stay in memory; the rest go to a
+// temp file.
+type wideSpill struct {
+	mem   [][]interface{}
+	path  string
+	file  *os.File
+	w     *bufio.Writer
+	enc   *gob.Encoder
+	count int
+	err   error
+}
+
+// append adds a row to the spill; temp-file errors are sticky.
+func (b *wideSpill) append(row []interface{}) {
+	if b.err != nil {
+		return
+	}
+	if b.count < common.SpillThreshold {
+		// Providers may reuse their row slices between yields (the CSV
+		// converter pools them), so buffer a copy, not the live slice
+		kept := make([]interface{}, len(row))
+		copy(kept, row)
+		b.mem = append(b.mem, kept)
+		b.count++
+		return
+	}
+	if b.file == nil {
+		f, err := os.CreateTemp("", "mksqlite_wide_*.rows")
+		if err != nil {
+			b.err = err
+			return
+		}
+		b.file = f
+		b.path = f.Name()
+		b.w = bufio.NewWriterSize(f, 65536)
+		b.enc = gob.NewEncoder(b.w)
+	}
+	encoded := make([]interface{}, len(row))
+	for i, value := range row {
+		if value == nil {
+			encoded[i] = wideRowNull{}
+		} else {
+			encoded[i] = value
+		}
+	}
+	if err := b.enc.Encode(encoded); err != nil {
+		b.err = err
+		return
+	}
+	b.count++
+}
+
+// scan replays every spilled row in append order; it may be called once
+// per remaining part.
+func (b *wideSpill) scan(yield func(row []interface{}) error) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, row := range b.mem {
+		if err := yield(row); err != nil {
+			return err
+		}
+	}
+	if b.file == nil {
+		return nil
+	}
+	if err := b.w.Flush(); err != nil {
+		return err
+	}
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := gob.NewDecoder(bufio.NewReaderSize(f, 65536))
+	for {
+		var row []interface{}
+		if err := dec.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		for i, value := range row {
+			if _, isNull := value.(wideRowNull); isNull {
+				row[i] = nil
+			}
+		}
+		if err := yield(row); err != nil {
+			return err
+		}
+	}
+}
+
+// close drops the in-memory rows and removes the temp file, if any.
+func (b *wideSpill) close() error {
+	b.mem = nil
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	err := os.Remove(b.path)
+	b.file = nil
+	return err
+}
@@ -17,32 +17,35 @@ type FastZipEntry struct {
 	CompressedSize   uint64
 	CRC32            uint32
 	IsDir            bool
+	IsEncrypted      bool   // General-purpose flag bit 0
+	Extra            []byte // Raw extra field bytes from the central directory
 }
 
 // ParseCentralDirectoryFast reads the Central Directory from a ReaderAt without downloading the whole file.
-func ParseCentralDirectoryFast(r io.ReaderAt, fileSize int64) ([]FastZipEntry, int64, error) {
+// It also returns the archive-level comment from the EOCD record.
+func ParseCentralDirectoryFast(r io.ReaderAt, fileSize int64) ([]FastZipEntry, string, int64, error) {
 	// 1. Find and parse EOCD
-	cdOffset, cdSize, numEntries, err := locateCentralDirectory(r, fileSize)
+	cdOffset, cdSize, numEntries, comment, err := locateCentralDirectory(r, fileSize)
 	if err != nil {
-		return nil, 0, err
+		return nil, "", 0, err
 	}
 
 	// 2. Read entire central directory
 	cdData := make([]byte, cdSize)
 	if _, err := r.ReadAt(cdData, cdOffset); err != nil {
-		return nil, 0, fmt.Errorf("failed to read central directory: %w", err)
+		return nil, "", 0, fmt.Errorf("failed to read central directory: %w", err)
 	}
 
 	// 3. Parse entries
 	entries, err := parseCDEntries(cdData, numEntries)
 	if err != nil {
-		return nil, 0, err
+		return nil, "", 0, err
 	}
 
-	return entries, int64(cdSize), nil
+	return entries, comment, int64(cdSize), nil
 }
 
-func locateCentralDirectory(r io.ReaderAt, fileSize int64) (cdOffset int64, cdSize uint64, numEntries uint64, err error) {
+func locateCentralDirectory(r io.ReaderAt, fileSize int64) (cdOffset int64, cdSize uint64, numEntries uint64, comment string, err error) {
 	const classicEOCDSig = 0x06054b50
 	const zip64EOCLocatorSig = 0x07064b50
 	const zip64EOCDSig = 0x06064b50
@@ -55,7 +58,7 @@ func locateCentralDirectory(r io.ReaderAt, fileSize int64) (cdOffset int64, cdSi
 
 	buf := make([]byte, searchSize)
 	if _, err := r.ReadAt(buf, fileSize-searchSize); err != nil {
-		return 0, 0, 0, err
+		return 0, 0, 0, "", err
 	}
 
 	// Find classic EOCD
@@ -67,45 +70,54 @@ func locateCentralDirectory(r io.ReaderAt, fileSize int64) (cdOffset int64, cdSi
 		}
 	}
 	if classicEOCDOffset == -1 {
-		return 0, 0, 0, fmt.Errorf("classic EOCD not found")
+		return 0, 0, 0, "", fmt.Errorf("classic EOCD not found")
 	}
 
 	// Read classic EOCD
 	classicEOCD := make([]byte, 22)
 	if _, err := r.ReadAt(classicEOCD, classicEOCDOffset); err != nil {
-		return 0, 0, 0, err
+		return 0, 0, 0, "", err
 	}
 
 	cdOffset32 := binary.LittleEndian.Uint32(classicEOCD[16:20])
 	cdSize32 := binary.LittleEndian.Uint32(classicEOCD[12:16])
 	numEntries16 := binary.LittleEndian.Uint16(classicEOCD[10:12])
 
+	// Archive comment trails the EOCD record
+	commentLen := binary.LittleEndian.Uint16(classicEOCD[20:22])
+	if commentLen > 0 && classicEOCDOffset+22+int64(commentLen) <= fileSize {
+		commentBytes := make([]byte, commentLen)
+		if _, err := r.ReadAt(commentBytes, classicEOCDOffset+22); err == nil {
+			comment = string(commentBytes)
+		}
+	}
+
 	// Check for ZIP64
 	if cdOffset32 == 0xFFFFFFFF || cdSize32 == 0xFFFFFFFF || numEntries16 == 0xFFFF {
 		locatorOffset := classicEOCDOffset - 20
 		if locatorOffset < 0 {
-			return 0, 0, 0, fmt.Errorf("no space for ZIP64 locator")
+			return 0, 0, 0, "", fmt.Errorf("no space for ZIP64 locator")
 		}
 
 		locator := make([]byte, 20)
 		if _, err := r.ReadAt(locator, locatorOffset); err != nil {
-			return 0, 0, 0, err
+			return 0, 0, 0, "", err
 		}
 		if binary.LittleEndian.Uint32(locator[0:4]) != zip64EOCLocatorSig {
-			return 0, 0, 0, fmt.Errorf("ZIP64 locator signature missing")
+			return 0, 0, 0, "", fmt.Errorf("ZIP64 locator signature missing")
 		}
 
 		zip64EOCDOffset := int64(binary.LittleEndian.Uint64(locator[8:16]))
 		if zip64EOCDOffset < 0 || zip64EOCDOffset >= fileSize {
-			return 0, 0, 0, fmt.Errorf("invalid ZIP64 EOCD offset")
+			return 0, 0, 0, "", fmt.Errorf("invalid ZIP64 EOCD offset")
 		}
 
 		zip64EOCDHeader := make([]byte, 56)
 		if _, err := r.ReadAt(zip64EOCDHeader, zip64EOCDOffset); err != nil {
-			return 0, 0, 0, err
+			return 0, 0, 0, "", err
 		}
 		if binary.LittleEndian.Uint32(zip64EOCDHeader[0:4]) != zip64EOCDSig {
-			return 0, 0, 0, fmt.Errorf("ZIP64 EOCD signature missing")
+			return 0, 0, 0, "", fmt.Errorf("ZIP64 EOCD signature missing")
 		}
 
 		numEntries = binary.LittleEndian.Uint64(zip64EOCDHeader[32:40])
@@ -118,10 +130,10 @@ func locateCentralDirectory(r io.ReaderAt, fileSize int64) (cdOffset int64, cdSi
 	}
 
 	if cdOffset < 0 || cdOffset+int64(cdSize) > fileSize {
-		return 0, 0, 0, fmt.Errorf("invalid central directory location")
+		return 0, 0, 0, "", fmt.Errorf("invalid central directory location")
 	}
 
-	return cdOffset, cdSize, numEntries, nil
+	return cdOffset, cdSize, numEntries, comment, nil
 }
 
 func parseCDEntries(cdData []byte, numEntries uint64) ([]FastZipEntry, error) {
@@ -139,6 +151,7 @@ func parseCDEntries(cdData []byte, numEntries uint64) ([]FastZipEntry, error) {
 			return nil, fmt.Errorf("invalid CD header at entry %d", i)
 		}
 
+		flags := binary.LittleEndian.Uint16(header[8:10])
 		// method := binary.LittleEndian.Uint16(header[10:12])
 		modTime := binary.LittleEndian.Uint16(header[12:14])
 		modDate := binary.LittleEndian.Uint16(header[14:16])
@@ -195,6 +208,14 @@ func parseCDEntries(cdData []byte, numEntries uint64) ([]FastZipEntry, error) {
 		}
 		name := string(cdData[nameStart:nameEnd])
 
+		// Extra field (kept raw; surfaced as JSON in the file list)
+		var extraCopy []byte
+		extraStart := pos + 46 + int(fileNameLen)
+		extraEnd := extraStart + int(extraLen)
+		if extraLen > 0 && extraEnd <= len(cdData) {
+			extraCopy = append([]byte(nil), cdData[extraStart:extraEnd]...)
+		}
+
 		// Comment
 		commentStart := pos + 46 + int(fileNameLen) + int(extraLen)
 		commentEnd := commentStart + int(commentLen)
@@ -222,6 +243,8 @@ func parseCDEntries(cdData []byte, numEntries uint64) ([]FastZipEntry, error) {
 			CompressedSize:   actualCompressedSize,
 			CRC32:            crc32,
 			IsDir:            isDir,
+			IsEncrypted:      flags&0x1 != 0,
+			Extra:            extraCopy,
 		})
 
 		pos += 46 + int(fileNameLen) + int(extraLen) + int(commentLen)
@@ -2,9 +2,9 @@ package zip_test
 
 import (
 	"fmt"
-	"io"
 	"github.com/darianmavgo/mksqlite/converters"
 	"github.com/darianmavgo/mksqlite/converters/zip"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
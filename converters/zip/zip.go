@@ -3,11 +3,13 @@ package zip
 import (
 	"archive/zip"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/darianmavgo/mksqlite/converters"
@@ -15,7 +17,7 @@ import (
 )
 
 func init() {
-	converters.Register("zip", &zipDriver{})
+	converters.Register("zip", &zipDriver{}, converters.Capabilities{Extensions: []string{".zip"}, MIMETypes: []string{"application/zip"}, Streaming: true})
 }
 
 type zipDriver struct{}
@@ -32,8 +34,14 @@ type SizableReaderAt interface {
 
 // ZipConverter converts ZIP archive file lists to SQLite tables
 type ZipConverter struct {
-	files    []FastZipEntry
-	tempFile *os.File // To be cleaned up if a temp file was used
+	files          []FastZipEntry
+	tempFile       *os.File // To be cleaned up if a temp file was used
+	rowsPerInsert  int
+	archiveComment string
+	password       string      // Threaded to content extraction; listing never needs it
+	readerAt       io.ReaderAt // Archive bytes for member extraction; nil when unavailable
+	size           int64
+	inlineContent  int64 // Members up to this size get a BLOB content column; 0 disables
 }
 
 // Ensure ZipConverter implements RowProvider
@@ -77,6 +85,9 @@ func (p *progressReader) Read(b []byte) (int, error) {
 func NewZipConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*ZipConverter, error) {
 	var files []FastZipEntry
 	var tempFile *os.File
+	var archiveComment string
+	var readerAt io.ReaderAt
+	var size int64
 	var err error
 
 	if config == nil {
@@ -98,18 +109,20 @@ func NewZipConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*Z
 			return nil, fmt.Errorf("failed to stat file: %w", err)
 		}
 		log.Printf("FastZip: Using fast path for file %s (size %d)", f.Name(), info.Size())
-		files, _, err = ParseCentralDirectoryFast(f, info.Size())
+		readerAt, size = f, info.Size()
+		files, archiveComment, _, err = ParseCentralDirectoryFast(f, info.Size())
 		if err != nil {
 			return nil, fmt.Errorf("fast parsing failed: %w", err)
 		}
 	} else if sa, ok := r.(SizableReaderAt); ok {
 		// 2. Custom SizableReaderAt (e.g. HTTP Range Reader)
-		size, err := sa.Size()
+		size, err = sa.Size()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get size from reader: %w", err)
 		}
 		log.Printf("FastZip: Using fast path for SizableReaderAt (size %d)", size)
-		files, _, err = ParseCentralDirectoryFast(sa, size)
+		readerAt = sa
+		files, archiveComment, _, err = ParseCentralDirectoryFast(sa, size)
 		if err != nil {
 			return nil, fmt.Errorf("fast parsing failed: %w", err)
 		}
@@ -162,6 +175,8 @@ func NewZipConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*Z
 			return nil, fmt.Errorf("failed to stat temp file: %w", err)
 		}
 
+		readerAt, size = tempFile, info.Size()
+
 		// Use standard library for temp file (robustness), then convert to FastZipEntry
 		zReader, err := zip.NewReader(tempFile, info.Size())
 		if err != nil {
@@ -169,6 +184,7 @@ func NewZipConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*Z
 			return nil, fmt.Errorf("failed to create zip reader: %w", err)
 		}
 
+		archiveComment = zReader.Comment
 		for _, f := range zReader.File {
 			isDir := false
 			if f.FileInfo().IsDir() {
@@ -182,11 +198,79 @@ func NewZipConverterWithConfig(r io.Reader, config *common.ConversionConfig) (*Z
 				CompressedSize:   f.CompressedSize64,
 				CRC32:            f.CRC32,
 				IsDir:            isDir,
+				IsEncrypted:      f.Flags&0x1 != 0,
+				Extra:            f.Extra,
 			})
 		}
 	}
 
-	return &ZipConverter{files: files, tempFile: tempFile}, nil
+	return &ZipConverter{
+		files:          files,
+		tempFile:       tempFile,
+		rowsPerInsert:  config.RowsPerInsert,
+		archiveComment: archiveComment,
+		password:       config.ZipPassword,
+		readerAt:       readerAt,
+		size:           size,
+		inlineContent:  config.InlineContent,
+	}, nil
+}
+
+// memberContents opens the archive through the retained ReaderAt and reads
+// the bytes of every member at or under the inline limit, keyed by name.
+// Directories and encrypted members are skipped.
+func (z *ZipConverter) memberContents() map[string][]byte {
+	if z.readerAt == nil {
+		return nil
+	}
+	zReader, err := zip.NewReader(z.readerAt, z.size)
+	if err != nil {
+		return nil
+	}
+	contents := make(map[string][]byte)
+	for _, f := range zReader.File {
+		if f.FileInfo().IsDir() || f.Flags&0x1 != 0 || int64(f.UncompressedSize64) > z.inlineContent {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			continue
+		}
+		contents[f.Name] = data
+	}
+	return contents
+}
+
+// extraJSON renders a raw extra-field blob as a JSON object keyed by the
+// hex tag id, with hex-encoded payloads. Malformed blobs fall back to a
+// single raw entry.
+func extraJSON(extra []byte) string {
+	if len(extra) == 0 {
+		return ""
+	}
+	fields := make(map[string]string)
+	pos := 0
+	for pos+4 <= len(extra) {
+		tag := binary.LittleEndian.Uint16(extra[pos : pos+2])
+		size := binary.LittleEndian.Uint16(extra[pos+2 : pos+4])
+		pos += 4
+		if pos+int(size) > len(extra) {
+			fields["raw"] = hex.EncodeToString(extra)
+			break
+		}
+		fields[fmt.Sprintf("0x%04x", tag)] = hex.EncodeToString(extra[pos : pos+int(size)])
+		pos += int(size)
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return ""
+	}
+	return string(b)
 }
 
 // GetTableNames implements RowProvider
@@ -205,6 +289,12 @@ func (z *ZipConverter) GetHeaders(tableName string) []string {
 			"compressed_size",
 			"crc32",
 			"is_dir",
+			"is_encrypted",
+			"extra",
+			"archive_comment",
+		}
+		if z.inlineContent > 0 {
+			rawHeaders = append(rawHeaders, "content")
 		}
 		return common.GenColumnNames(rawHeaders)
 	}
@@ -216,8 +306,13 @@ func (z *ZipConverter) GetColumnTypes(tableName string) []string {
 	if tableName == "file_list" {
 		// name: TEXT, comment: TEXT, modified: TEXT
 		// uncompressed_size: INTEGER, compressed_size: INTEGER
-		// crc32: INTEGER, is_dir: INTEGER
-		return []string{"TEXT", "TEXT", "TEXT", "INTEGER", "INTEGER", "INTEGER", "INTEGER"}
+		// crc32: INTEGER, is_dir: INTEGER, is_encrypted: INTEGER
+		// extra: TEXT, archive_comment: TEXT
+		colTypes := []string{"TEXT", "TEXT", "TEXT", "INTEGER", "INTEGER", "INTEGER", "INTEGER", "INTEGER", "TEXT", "TEXT"}
+		if z.inlineContent > 0 {
+			colTypes = append(colTypes, "BLOB")
+		}
+		return colTypes
 	}
 	return nil
 }
@@ -228,6 +323,11 @@ func (z *ZipConverter) ScanRows(ctx context.Context, tableName string, yield fun
 		return nil
 	}
 
+	var contents map[string][]byte
+	if z.inlineContent > 0 {
+		contents = z.memberContents()
+	}
+
 	// Iterate through files
 	for _, f := range z.files {
 		// Prepare values
@@ -235,6 +335,10 @@ func (z *ZipConverter) ScanRows(ctx context.Context, tableName string, yield fun
 		if f.IsDir {
 			isDir = "true"
 		}
+		isEncrypted := "false"
+		if f.IsEncrypted {
+			isEncrypted = "true"
+		}
 
 		values := []interface{}{
 			f.Name,
@@ -244,6 +348,16 @@ func (z *ZipConverter) ScanRows(ctx context.Context, tableName string, yield fun
 			f.CompressedSize,
 			f.CRC32,
 			isDir,
+			isEncrypted,
+			extraJSON(f.Extra),
+			z.archiveComment,
+		}
+		if z.inlineContent > 0 {
+			if data, ok := contents[f.Name]; ok {
+				values = append(values, data)
+			} else {
+				values = append(values, nil)
+			}
 		}
 
 		if err := yield(values, nil); err != nil {
@@ -266,9 +380,14 @@ func (z *ZipConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 	headers := z.GetHeaders(tableName)
 	colTypes := z.GetColumnTypes(tableName)
 
-	createTableSQL := common.GenCreateTableSQLWithTypes(tableName, headers, colTypes)
-	if _, err := fmt.Fprintf(writer, "%s;\n\n", createTableSQL); err != nil {
-		return fmt.Errorf("failed to write CREATE TABLE: %w", err)
+	sqlWriter := common.NewSQLWriter(writer, z.rowsPerInsert)
+	if err := sqlWriter.BeginTable(tableName, headers, colTypes); err != nil {
+		return err
+	}
+
+	var contents map[string][]byte
+	if z.inlineContent > 0 {
+		contents = z.memberContents()
 	}
 
 	for _, f := range z.files {
@@ -276,6 +395,10 @@ func (z *ZipConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 		if f.IsDir {
 			isDir = "true"
 		}
+		isEncrypted := "false"
+		if f.IsEncrypted {
+			isEncrypted = "true"
+		}
 
 		// Values as strings for SQL
 		row := []string{
@@ -286,43 +409,27 @@ func (z *ZipConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 			fmt.Sprintf("%d", f.CompressedSize),
 			fmt.Sprintf("%d", f.CRC32),
 			isDir,
+			isEncrypted,
+			extraJSON(f.Extra),
+			z.archiveComment,
 		}
 
-		if _, err := fmt.Fprintf(writer, "INSERT INTO %s (", tableName); err != nil {
-			return fmt.Errorf("failed to write INSERT start: %w", err)
-		}
-
-		// Write column names
-		for i, header := range headers {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write column separator: %w", err)
-				}
-			}
-			if _, err := fmt.Fprintf(writer, "%s", header); err != nil {
-				return fmt.Errorf("failed to write column name: %w", err)
+		if z.inlineContent > 0 {
+			// The content column is binary, so the row switches to the
+			// value-based writer for X'..' encoding
+			values := make([]interface{}, len(row), len(row)+1)
+			for i, v := range row {
+				values[i] = v
 			}
-		}
-
-		if _, err := writer.Write([]byte(") VALUES (")); err != nil {
-			return fmt.Errorf("failed to write VALUES start: %w", err)
-		}
-
-		// Write values
-		for i, val := range row {
-			if i > 0 {
-				if _, err := writer.Write([]byte(", ")); err != nil {
-					return fmt.Errorf("failed to write value separator: %w", err)
-				}
+			var content interface{}
+			if data, ok := contents[f.Name]; ok {
+				content = data
 			}
-			escapedVal := strings.ReplaceAll(val, "'", "''")
-			if _, err := fmt.Fprintf(writer, "'%s'", escapedVal); err != nil {
-				return fmt.Errorf("failed to write value: %w", err)
+			if err := sqlWriter.WriteRow(append(values, content)); err != nil {
+				return err
 			}
-		}
-
-		if _, err := writer.Write([]byte(");\n")); err != nil {
-			return fmt.Errorf("failed to write statement end: %w", err)
+		} else if err := sqlWriter.WriteStringRow(row); err != nil {
+			return err
 		}
 		// Check cancel
 		select {
@@ -332,5 +439,5 @@ func (z *ZipConverter) ConvertToSQL(ctx context.Context, writer io.Writer) error
 		}
 	}
 
-	return nil
+	return sqlWriter.EndTable()
 }
@@ -1,12 +1,16 @@
 package zip
 
 import (
+	"archive/zip"
+	"context"
 	"database/sql"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/darianmavgo/mksqlite/converters"
+	"github.com/darianmavgo/mksqlite/converters/common"
 
 	_ "modernc.org/sqlite"
 )
@@ -89,3 +93,165 @@ func TestZipConvertFile(t *testing.T) {
 		}
 	}
 }
+
+func TestZipCommentAndExtraFields(t *testing.T) {
+	// Build a small archive with an archive comment, a member comment, and
+	// a custom extra field, then list it via the fast path.
+	archivePath := filepath.Join(t.TempDir(), "meta.zip")
+	out, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	w := zip.NewWriter(out)
+	if err := w.SetComment("archive level comment"); err != nil {
+		t.Fatalf("Failed to set archive comment: %v", err)
+	}
+	header := &zip.FileHeader{
+		Name:    "notes.txt",
+		Comment: "member comment",
+		// One extra field: tag 0x6375, 4 data bytes
+		Extra: []byte{0x75, 0x63, 0x04, 0x00, 0xde, 0xad, 0xbe, 0xef},
+	}
+	fw, err := w.CreateHeader(header)
+	if err != nil {
+		t.Fatalf("Failed to create member: %v", err)
+	}
+	if _, err := fw.Write([]byte("hello")); err != nil {
+		t.Fatalf("Failed to write member: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	out.Close()
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer file.Close()
+
+	converter, err := NewZipConverter(file)
+	if err != nil {
+		t.Fatalf("Failed to create Zip converter: %v", err)
+	}
+
+	headers := converter.GetHeaders("file_list")
+	want := map[string]int{}
+	for i, h := range headers {
+		want[h] = i
+	}
+	for _, h := range []string{"is_encrypted", "extra", "archive_comment"} {
+		if _, ok := want[h]; !ok {
+			t.Fatalf("Expected header %q, got %v", h, headers)
+		}
+	}
+
+	var rows [][]interface{}
+	err = converter.ScanRows(context.Background(), "file_list", func(row []interface{}, rowErr error) error {
+		rows = append(rows, row)
+		return rowErr
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+
+	row := rows[0]
+	if row[want["comment"]] != "member comment" {
+		t.Errorf("Unexpected member comment: %v", row[want["comment"]])
+	}
+	if row[want["archive_comment"]] != "archive level comment" {
+		t.Errorf("Unexpected archive comment: %v", row[want["archive_comment"]])
+	}
+	// archive/zip cannot write encrypted members, so the flag must be off
+	if row[want["is_encrypted"]] != "false" {
+		t.Errorf("Expected is_encrypted false, got %v", row[want["is_encrypted"]])
+	}
+	extra, _ := row[want["extra"]].(string)
+	if !strings.Contains(extra, "0x6375") || !strings.Contains(extra, "deadbeef") {
+		t.Errorf("Expected extra field JSON with tag 0x6375, got %q", extra)
+	}
+}
+
+func TestZipExtraJSON(t *testing.T) {
+	if got := extraJSON(nil); got != "" {
+		t.Errorf("Expected empty string for nil extra, got %q", got)
+	}
+	// Truncated TLV falls back to a raw hex entry
+	got := extraJSON([]byte{0x01, 0x00, 0xff, 0x00, 0xaa})
+	if !strings.Contains(got, `"raw"`) {
+		t.Errorf("Expected raw fallback for truncated extra, got %q", got)
+	}
+}
+
+func TestZipInlineContent(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "content.zip")
+	out, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	w := zip.NewWriter(out)
+	fw, err := w.Create("small.txt")
+	if err != nil {
+		t.Fatalf("Failed to create member: %v", err)
+	}
+	if _, err := fw.Write([]byte("hello blob")); err != nil {
+		t.Fatalf("Failed to write member: %v", err)
+	}
+	fw, err = w.Create("big.bin")
+	if err != nil {
+		t.Fatalf("Failed to create member: %v", err)
+	}
+	if _, err := fw.Write(make([]byte, 1024)); err != nil {
+		t.Fatalf("Failed to write member: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	out.Close()
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer file.Close()
+
+	converter, err := NewZipConverterWithConfig(file, &common.ConversionConfig{InlineContent: 64})
+	if err != nil {
+		t.Fatalf("Failed to create Zip converter: %v", err)
+	}
+	defer converter.Close()
+
+	headers := converter.GetHeaders("file_list")
+	if headers[len(headers)-1] != "content" {
+		t.Fatalf("Expected content column, got %v", headers)
+	}
+	colTypes := converter.GetColumnTypes("file_list")
+	if colTypes[len(colTypes)-1] != "BLOB" {
+		t.Fatalf("Expected BLOB type for content, got %v", colTypes)
+	}
+
+	contents := make(map[string]interface{})
+	err = converter.ScanRows(context.Background(), "file_list", func(row []interface{}, rowErr error) error {
+		if rowErr != nil {
+			return rowErr
+		}
+		contents[row[0].(string)] = row[len(row)-1]
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanRows failed: %v", err)
+	}
+
+	data, ok := contents["small.txt"].([]byte)
+	if !ok || string(data) != "hello blob" {
+		t.Errorf("Expected member bytes inline, got %v", contents["small.txt"])
+	}
+	if contents["big.bin"] != nil {
+		t.Errorf("Expected NULL content for member over the limit, got %v", contents["big.bin"])
+	}
+}